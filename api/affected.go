@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// affectedObjects records the objects which have been created, updated,
+// patched or deleted through the client.
+type affectedObjects struct {
+	mu    sync.Mutex
+	seen  map[string]bool
+	names []string
+}
+
+func (a *affectedObjects) add(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.seen == nil {
+		a.seen = map[string]bool{}
+	}
+	if a.seen[name] {
+		return
+	}
+	a.seen[name] = true
+	a.names = append(a.names, name)
+}
+
+func (a *affectedObjects) all() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.names...)
+}
+
+// Affected returns the objects which have been changed by the client so far
+// in the form kind/name, e.g. "application/myapp", in the order they were
+// first changed. Objects of server side dry runs are included.
+func (c *Client) Affected() []string {
+	return c.affected.all()
+}
+
+func (c *Client) recordAffected(obj runtimeclient.Object) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" && c.WithWatch != nil {
+		if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+			kind = gvk.Kind
+		}
+	}
+	c.affected.add(strings.ToLower(kind) + "/" + obj.GetName())
+}
+
+// Create creates the object and records it as affected.
+func (c *Client) Create(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+	if err := c.WithWatch.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.recordAffected(obj)
+	return nil
+}
+
+// Update updates the object and records it as affected.
+func (c *Client) Update(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
+	if err := c.WithWatch.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.recordAffected(obj)
+	return nil
+}
+
+// Patch patches the object and records it as affected.
+func (c *Client) Patch(ctx context.Context, obj runtimeclient.Object, patch runtimeclient.Patch, opts ...runtimeclient.PatchOption) error {
+	if err := c.WithWatch.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.recordAffected(obj)
+	return nil
+}
+
+// Delete deletes the object and records it as affected.
+func (c *Client) Delete(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.DeleteOption) error {
+	if err := c.WithWatch.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.recordAffected(obj)
+	return nil
+}
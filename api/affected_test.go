@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAffected(t *testing.T) {
+	ctx := context.Background()
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	c := &Client{WithWatch: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	app := &apps.Application{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	require.NoError(t, c.Create(ctx, app))
+	require.NoError(t, c.Get(ctx, ObjectName(app), app))
+	app.Labels = map[string]string{"team": "a"}
+	require.NoError(t, c.Update(ctx, app))
+	// failed requests are not recorded
+	assert.Error(t, c.Create(ctx, &apps.Application{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}))
+	assert.Error(t, c.Delete(ctx, &apps.Release{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}}))
+
+	before := app.DeepCopy()
+	app.Labels["team"] = "b"
+	require.NoError(t, c.Patch(ctx, app, runtimeclient.MergeFrom(before)))
+	build := &apps.Build{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "default"}}
+	require.NoError(t, c.Create(ctx, build))
+	require.NoError(t, c.Delete(ctx, build))
+
+	assert.Equal(t, []string{"application/foo", "build/bar"}, c.Affected())
+	assert.Empty(t, (&Client{}).Affected())
+}
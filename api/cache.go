@@ -0,0 +1,73 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// discoveryCacheTTL is the duration after which the cached discovery data
+// is refreshed. Unknown kinds trigger a refresh in any case, so new API
+// types can be used right away.
+const discoveryCacheTTL = 6 * time.Hour
+
+// unsafeHostChars matches characters which are replaced in the cache
+// directory name of an API host.
+var unsafeHostChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]`)
+
+// discoveryCacheDir returns the directory of the discovery cache of the
+// API host below the user cache directory.
+func discoveryCacheDir(host string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "nctl", "discovery", unsafeHostChars.ReplaceAllString(host, "_")), nil
+}
+
+// newCachedRESTMapper returns a RESTMapper which caches the discovery data
+// of the API on disk between invocations. The cache is dropped if the
+// kubeconfig has been modified after the cache was written, e.g. after
+// logging in again.
+func newCachedRESTMapper(cfg *rest.Config, kubeconfigPath string) (meta.RESTMapper, error) {
+	dir, err := discoveryCacheDir(cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+	if kubeconfigModifiedSince(kubeconfigPath, dir) {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	discovery, err := disk.NewCachedDiscoveryClientForConfig(
+		rest.CopyConfig(cfg),
+		filepath.Join(dir, "discovery"),
+		filepath.Join(dir, "http"),
+		discoveryCacheTTL,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(discovery), nil
+}
+
+// kubeconfigModifiedSince reports if the kubeconfig has been modified after
+// the cache directory. A missing cache is never outdated.
+func kubeconfigModifiedSince(kubeconfigPath, cacheDir string) bool {
+	cache, err := os.Stat(cacheDir)
+	if err != nil {
+		return false
+	}
+	kubeconfig, err := os.Stat(kubeconfigPath)
+	if err != nil {
+		return false
+	}
+	return kubeconfig.ModTime().After(cache.ModTime())
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestCachedRESTMapper(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := &rest.Config{Host: "https://nineapis.ch:443"}
+	dir, err := discoveryCacheDir(cfg.Host)
+	require.NoError(t, err)
+	assert.Equal(t, "https___nineapis.ch_443", filepath.Base(dir))
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("apiVersion: v1\nkind: Config\n"), 0o600))
+	stale := filepath.Join(dir, "discovery", "stale.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stale), 0o700))
+	require.NoError(t, os.WriteFile(stale, []byte("{}"), 0o600))
+
+	// the cache is newer than the kubeconfig and is kept
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(kubeconfig, past, past))
+	_, err = newCachedRESTMapper(cfg, kubeconfig)
+	require.NoError(t, err)
+	assert.FileExists(t, stale)
+
+	// the kubeconfig changed after the cache was written, e.g. by a new
+	// login, so the cache is dropped
+	require.NoError(t, os.Chtimes(dir, past.Add(-time.Hour), past.Add(-time.Hour)))
+	_, err = newCachedRESTMapper(cfg, kubeconfig)
+	require.NoError(t, err)
+	assert.NoFileExists(t, stale)
+}
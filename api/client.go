@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -15,7 +16,9 @@ import (
 	"github.com/ninech/nctl/api/config"
 	"github.com/ninech/nctl/api/log"
 	"github.com/ninech/nctl/internal/format"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -31,6 +34,19 @@ type Client struct {
 	Project           string
 	Log               *log.Client
 	KubeconfigContext string
+	// Debug is set if the client logs its requests, see the Debug option.
+	Debug bool
+	// mapper is kept when the client is recreated by an option, so that
+	// the discovery is only done once.
+	mapper apimeta.RESTMapper
+	// priceSource is the file or URL of the price table.
+	priceSource PriceSource
+	// warnings collects the warnings returned by the API.
+	warnings *warningCollector
+	// affected records the objects changed through the client.
+	affected affectedObjects
+	// readOnly is set if the client rejects all changes, see ReadOnly.
+	readOnly bool
 }
 
 type ClientOpt func(c *Client) error
@@ -47,14 +63,25 @@ func New(ctx context.Context, apiClusterContext, project string, opts ...ClientO
 	if err := client.loadConfig(apiClusterContext); err != nil {
 		return nil, err
 	}
+	client.warnings = &warningCollector{}
+	client.Config.WarningHandler = client.warnings
 
 	scheme, err := NewScheme()
 	if err != nil {
 		return nil, err
 	}
 
+	// failing to set up the cache is not fatal, the client then just does
+	// the discovery on its own.
+	if mapper, err := newCachedRESTMapper(client.Config, client.KubeconfigPath); err == nil {
+		client.mapper = mapper
+	}
+
 	c, err := runtimeclient.NewWithWatch(client.Config, runtimeclient.Options{
 		Scheme: scheme,
+		Mapper: client.mapper,
+		// warnings are collected by our own handler instead.
+		WarningHandler: runtimeclient.WarningHandlerOptions{SuppressWarnings: true},
 	})
 	if err != nil {
 		return nil, err
@@ -71,9 +98,9 @@ func New(ctx context.Context, apiClusterContext, project string, opts ...ClientO
 }
 
 // LogClient sets up a log client connected to the provided address.
-func LogClient(ctx context.Context, address string, insecure bool) ClientOpt {
+func LogClient(ctx context.Context, address string, t log.TransportConfig) ClientOpt {
 	return func(c *Client) error {
-		logClient, err := log.NewClient(address, func(ctx context.Context) string { return c.Token(ctx) }, c.Project, insecure)
+		logClient, err := log.NewClient(address, func(ctx context.Context) string { return c.Token(ctx) }, c.Project, t)
 		if err != nil {
 			return fmt.Errorf("unable to create log client: %w", err)
 		}
@@ -90,6 +117,7 @@ func StaticToken(ctx context.Context) ClientOpt {
 		c.Config.BearerToken = c.Token(ctx)
 		tokenClient, err := runtimeclient.NewWithWatch(c.Config, runtimeclient.Options{
 			Scheme: c.Scheme(),
+			Mapper: c.mapper,
 		})
 		if err != nil {
 			return err
@@ -100,6 +128,41 @@ func StaticToken(ctx context.Context) ClientOpt {
 	}
 }
 
+// Tracing configures the client to create a span for every request to the
+// API and to propagate the trace context, see the telemetry package.
+func Tracing() ClientOpt {
+	return func(c *Client) error {
+		return c.wrapTransport(func(rt http.RoundTripper) http.RoundTripper {
+			return otelhttp.NewTransport(rt)
+		})
+	}
+}
+
+// wrapTransport wraps the transport of the client config and recreates the
+// client with it.
+func (c *Client) wrapTransport(fn func(http.RoundTripper) http.RoundTripper) error {
+	c.Config.Wrap(fn)
+	return c.recreate()
+}
+
+// recreate creates the client again from the current config, e.g. after it
+// has been changed by an option.
+func (c *Client) recreate() error {
+	recreated, err := runtimeclient.NewWithWatch(c.Config, runtimeclient.Options{
+		Scheme:         c.Scheme(),
+		Mapper:         c.mapper,
+		WarningHandler: runtimeclient.WarningHandlerOptions{SuppressWarnings: true},
+	})
+	if err != nil {
+		return err
+	}
+	c.WithWatch = recreated
+	if c.readOnly {
+		c.WithWatch = readOnlyClient{WithWatch: recreated}
+	}
+	return nil
+}
+
 // NewScheme returns a *runtime.Scheme with all the relevant types registered.
 func NewScheme() (*runtime.Scheme, error) {
 	scheme := runtime.NewScheme()
@@ -171,7 +234,15 @@ func (c *Client) DeploioRuntimeClient(ctx context.Context, scheme *runtime.Schem
 	if err != nil {
 		return nil, err
 	}
-	return runtimeclient.New(cfg, runtimeclient.Options{Scheme: scheme})
+	runtimeClient, err := runtimeclient.NewWithWatch(cfg, runtimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	if c.readOnly {
+		// read-only mode also applies to the replicas on the deplo.io cluster.
+		return readOnlyClient{WithWatch: runtimeClient}, nil
+	}
+	return runtimeClient, nil
 }
 
 func (c *Client) DeploioRuntimeConfig(ctx context.Context) (*rest.Config, error) {
@@ -242,9 +313,12 @@ func loadConfigWithContext(apiServerURL string, loader clientcmd.ClientConfigLoa
 	}
 
 	cfg, err := clientConfig.ClientConfig()
-	cfg.QPS = 25
-	cfg.Burst = 50
-	return cfg, ns, err
+	if err != nil {
+		return nil, "", err
+	}
+	cfg.QPS = DefaultQPS
+	cfg.Burst = DefaultBurst
+	return cfg, ns, nil
 }
 
 func ObjectName(obj runtimeclient.Object) types.NamespacedName {
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"text/template"
 
 	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -193,3 +195,46 @@ func RemoveClusterFromKubeConfig(kubeconfigPath, clusterContext string) error {
 func ContextName(cluster *infrastructure.KubernetesCluster) string {
 	return fmt.Sprintf("%s/%s", cluster.Name, cluster.Namespace)
 }
+
+// ContextNameData are the fields which can be used in a context name
+// template, e.g. "{{.Org}}-{{.Project}}-{{.Name}}".
+type ContextNameData struct {
+	// Org is the organization the context belongs to.
+	Org string
+	// Project is the project of the cluster or the default project of the
+	// organization for the API context.
+	Project string
+	// Name is the name of the cluster or the host of the API.
+	Name string
+}
+
+// ClusterContextName returns the kubeconfig context name of the cluster,
+// rendered from the template if it is set.
+func ClusterContextName(tmpl, org string, cluster *infrastructure.KubernetesCluster) (string, error) {
+	return ContextNameFromTemplate(
+		tmpl,
+		ContextNameData{Org: org, Project: cluster.Namespace, Name: cluster.Name},
+		ContextName(cluster),
+	)
+}
+
+// ContextNameFromTemplate returns the context name rendered from the
+// template. If the template is empty, defaultName is returned.
+func ContextNameFromTemplate(tmpl string, data ContextNameData, defaultName string) (string, error) {
+	if tmpl == "" {
+		return defaultName, nil
+	}
+	t, err := template.New("context-name").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid context name template %q: %w", tmpl, err)
+	}
+	b := &strings.Builder{}
+	if err := t.Execute(b, data); err != nil {
+		return "", fmt.Errorf("invalid context name template %q: %w", tmpl, err)
+	}
+	name := strings.TrimSpace(b.String())
+	if name == "" {
+		return "", fmt.Errorf("context name template %q results in an empty name", tmpl)
+	}
+	return name, nil
+}
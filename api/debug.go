@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ninech/nctl/internal/format"
+)
+
+// Debug configures the client to log every request to the API to out,
+// including its status and latency. Request bodies and headers are never
+// logged and sensitive query parameters are redacted.
+func Debug(out io.Writer) ClientOpt {
+	return func(c *Client) error {
+		c.Debug = true
+		return c.wrapTransport(func(rt http.RoundTripper) http.RoundTripper {
+			return &debugTransport{next: rt, out: out}
+		})
+	}
+}
+
+type debugTransport struct {
+	next http.RoundTripper
+	out  io.Writer
+}
+
+// RoundTrip logs the request after it has been sent. Every attempt of a
+// retried request shows up as its own line, responses which cause a retry
+// are marked as such.
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	target := redactURL(req.URL)
+	if err != nil {
+		fmt.Fprintf(t.out, "DEBUG %s %s error=%q latency=%s\n", req.Method, target, err, latency)
+		return resp, err
+	}
+
+	line := fmt.Sprintf("DEBUG %s %s status=%d latency=%s", req.Method, target, resp.StatusCode, latency)
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" &&
+		(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError) {
+		line += fmt.Sprintf(" retry-after=%ss", retryAfter)
+	}
+	fmt.Fprintln(t.out, line)
+	return resp, nil
+}
+
+// redactURL returns the URL with the values of sensitive query parameters
+// and user info replaced.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+	query := redacted.Query()
+	for k := range query {
+		if format.SensitiveName(k) {
+			query[k] = []string{"REDACTED"}
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Endpoints overrides the endpoints configured in the kubeconfig, e.g. to
+// test against a staging environment or in split-horizon DNS setups.
+type Endpoints struct {
+	// API is the URL of the API server.
+	API string
+	// Issuer is the OIDC issuer URL which is used to get a token.
+	Issuer string
+}
+
+// OverrideEndpoints configures the client to use the given endpoints
+// instead of the ones from the kubeconfig. Empty endpoints are not changed.
+func OverrideEndpoints(e Endpoints) ClientOpt {
+	return func(c *Client) error {
+		if e.API == "" && e.Issuer == "" {
+			return nil
+		}
+		if e.API != "" {
+			if _, err := url.ParseRequestURI(e.API); err != nil {
+				return fmt.Errorf("invalid API URL %q: %w", e.API, err)
+			}
+			c.Config.Host = e.API
+		}
+		if e.Issuer != "" {
+			if _, err := url.ParseRequestURI(e.Issuer); err != nil {
+				return fmt.Errorf("invalid issuer URL %q: %w", e.Issuer, err)
+			}
+			if c.Config.ExecProvider == nil {
+				return fmt.Errorf("the issuer URL can only be overridden when logged in with OIDC")
+			}
+			args := make([]string, 0, len(c.Config.ExecProvider.Args))
+			for _, arg := range c.Config.ExecProvider.Args {
+				if !strings.HasPrefix(arg, IssuerURLArg) {
+					args = append(args, arg)
+				}
+			}
+			c.Config.ExecProvider.Args = append(args, IssuerURLArg+e.Issuer)
+		}
+		// the mapper needs to do its discovery against the new endpoint.
+		if mapper, err := newCachedRESTMapper(c.Config, c.KubeconfigPath); err == nil {
+			c.mapper = mapper
+		}
+		return c.recreate()
+	}
+}
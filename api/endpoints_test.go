@@ -0,0 +1,79 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOverrideEndpoints(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	newClient := func(t *testing.T, exec *clientcmdapi.ExecConfig) *Client {
+		scheme, err := NewScheme()
+		require.NoError(t, err)
+		return &Client{
+			WithWatch: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Config:    &rest.Config{Host: "https://nineapis.ch", ExecProvider: exec},
+		}
+	}
+	exec := func() *clientcmdapi.ExecConfig {
+		return &clientcmdapi.ExecConfig{
+			APIVersion: "client.authentication.k8s.io/v1beta1",
+			Command:    "nctl",
+			Args:       []string{"auth", "oidc", IssuerURLArg + "https://auth.nine.ch", ClientIDArg + "nctl", UsePKCEArg},
+		}
+	}
+
+	tests := map[string]struct {
+		endpoints Endpoints
+		exec      *clientcmdapi.ExecConfig
+		wantHost  string
+		wantArgs  []string
+		wantErr   bool
+	}{
+		"no overrides": {
+			exec:     exec(),
+			wantHost: "https://nineapis.ch",
+			wantArgs: exec().Args,
+		},
+		"api": {
+			endpoints: Endpoints{API: "https://[2001:db8::1]:6443"},
+			exec:      exec(),
+			wantHost:  "https://[2001:db8::1]:6443",
+			wantArgs:  exec().Args,
+		},
+		"issuer": {
+			endpoints: Endpoints{Issuer: "https://auth.staging.nine.ch"},
+			exec:      exec(),
+			wantHost:  "https://nineapis.ch",
+			wantArgs:  []string{"auth", "oidc", ClientIDArg + "nctl", UsePKCEArg, IssuerURLArg + "https://auth.staging.nine.ch"},
+		},
+		"issuer with static token": {
+			endpoints: Endpoints{Issuer: "https://auth.staging.nine.ch"},
+			wantErr:   true,
+		},
+		"invalid api": {
+			endpoints: Endpoints{API: "nineapis"},
+			exec:      exec(),
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newClient(t, tc.exec)
+			err := OverrideEndpoints(tc.endpoints)(c)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantHost, c.Config.Host)
+			assert.Equal(t, tc.wantArgs, c.Config.ExecProvider.Args)
+		})
+	}
+}
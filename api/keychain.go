@@ -0,0 +1,107 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/int128/kubelogin/pkg/oidc"
+	"github.com/int128/kubelogin/pkg/tokencache"
+	"github.com/int128/kubelogin/pkg/tokencache/repository"
+	"github.com/zalando/go-keyring"
+)
+
+// keychainService is the service the refresh tokens are stored with in the
+// keychain.
+const keychainService = "nctl"
+
+// Keychain configures if refresh tokens are stored in the keychain of the OS
+// (macOS Keychain, Windows Credential Manager or Secret Service). If it is
+// disabled or no keychain is available, they are stored in plain text in the
+// token cache directory.
+var Keychain = true
+
+// TokenCacheRepository returns the repository the tokens are cached in.
+func TokenCacheRepository() repository.Interface {
+	if !Keychain {
+		return &repository.Repository{}
+	}
+	return &keychainRepository{file: &repository.Repository{}}
+}
+
+// ClearTokenCache removes the cached tokens of the issuer and client.
+func ClearTokenCache(dir, issuerURL, clientID string) error {
+	filename, err := TokenCacheFilename(tokencache.Key{IssuerURL: issuerURL, ClientID: clientID})
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, filename)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if Keychain {
+		// the keychain might not be available at all, in which case
+		// there is nothing to remove.
+		_ = keyring.Delete(keychainService, filename)
+	}
+	return nil
+}
+
+// TokenCacheFilename returns the filename of the cached token of the key. It
+// has been copied from kubelogin, see
+// github.com/int128/kubelogin/pkg/tokencache/repository.
+func TokenCacheFilename(key tokencache.Key) (string, error) {
+	s := sha256.New()
+	e := gob.NewEncoder(s)
+	if err := e.Encode(&key); err != nil {
+		return "", fmt.Errorf("could not encode the key: %w", err)
+	}
+	return hex.EncodeToString(s.Sum(nil)), nil
+}
+
+// keychainRepository stores the refresh token in the keychain and the short
+// lived ID token in the token cache directory, as keychains limit the size
+// of entries. If the keychain is not available, the refresh token is stored
+// in the directory as well.
+type keychainRepository struct {
+	file repository.Interface
+}
+
+func (r *keychainRepository) FindByKey(dir string, key tokencache.Key) (*oidc.TokenSet, error) {
+	tokenSet, err := r.file.FindByKey(dir, key)
+	if err != nil {
+		return nil, err
+	}
+	name, err := TokenCacheFilename(key)
+	if err != nil {
+		return nil, err
+	}
+	// a refresh token in the file is newer than the one in the keychain,
+	// e.g. if it has been written without the keychain or the keychain was
+	// unavailable. Tokens which have been cached before the keychain was
+	// used still contain it as well, they are moved on the next save.
+	if tokenSet.RefreshToken != "" {
+		return tokenSet, nil
+	}
+	if refreshToken, err := keyring.Get(keychainService, name); err == nil {
+		tokenSet.RefreshToken = refreshToken
+	}
+	return tokenSet, nil
+}
+
+func (r *keychainRepository) Save(dir string, key tokencache.Key, tokenSet oidc.TokenSet) error {
+	name, err := TokenCacheFilename(key)
+	if err != nil {
+		return err
+	}
+	if tokenSet.RefreshToken == "" {
+		_ = keyring.Delete(keychainService, name)
+	} else if err := keyring.Set(keychainService, name, tokenSet.RefreshToken); err == nil {
+		tokenSet.RefreshToken = ""
+	}
+	return r.file.Save(dir, key, tokenSet)
+}
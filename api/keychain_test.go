@@ -0,0 +1,69 @@
+package api
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/int128/kubelogin/pkg/oidc"
+	"github.com/int128/kubelogin/pkg/tokencache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestKeychainRepository(t *testing.T) {
+	keyring.MockInit()
+	dir := t.TempDir()
+	key := tokencache.Key{IssuerURL: "https://issuer.example.org", ClientID: "nctl"}
+	filename, err := TokenCacheFilename(key)
+	require.NoError(t, err)
+
+	repo := TokenCacheRepository()
+	require.NoError(t, repo.Save(dir, key, oidc.TokenSet{IDToken: "id", RefreshToken: "refresh"}))
+
+	content, err := os.ReadFile(path.Join(dir, filename))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "refresh")
+	stored, err := keyring.Get(keychainService, filename)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", stored)
+
+	tokenSet, err := repo.FindByKey(dir, key)
+	require.NoError(t, err)
+	assert.Equal(t, &oidc.TokenSet{IDToken: "id", RefreshToken: "refresh"}, tokenSet)
+
+	require.NoError(t, ClearTokenCache(dir, key.IssuerURL, key.ClientID))
+	assert.NoFileExists(t, path.Join(dir, filename))
+	_, err = keyring.Get(keychainService, filename)
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+
+	// without the keychain the refresh token is stored in the file
+	Keychain = false
+	defer func() { Keychain = true }()
+	require.NoError(t, TokenCacheRepository().Save(dir, key, oidc.TokenSet{IDToken: "id", RefreshToken: "refresh"}))
+	content, err = os.ReadFile(path.Join(dir, filename))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "refresh")
+
+	// the newer token in the file wins over an old one in the keychain
+	require.NoError(t, keyring.Set(keychainService, filename, "old-refresh"))
+	require.NoError(t, TokenCacheRepository().Save(dir, key, oidc.TokenSet{IDToken: "id", RefreshToken: "new-refresh"}))
+	Keychain = true
+	tokenSet, err = TokenCacheRepository().FindByKey(dir, key)
+	require.NoError(t, err)
+	assert.Equal(t, "new-refresh", tokenSet.RefreshToken)
+}
+
+func TestKeychainRepositoryUnavailable(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrUnsupportedPlatform)
+	defer keyring.MockInit()
+	dir := t.TempDir()
+	key := tokencache.Key{IssuerURL: "https://issuer.example.org", ClientID: "nctl"}
+
+	repo := TokenCacheRepository()
+	require.NoError(t, repo.Save(dir, key, oidc.TokenSet{IDToken: "id", RefreshToken: "refresh"}))
+	tokenSet, err := repo.FindByKey(dir, key)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", tokenSet.RefreshToken)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	management "github.com/ninech/apis/management/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// kindAliases are short names which can be used in place of a kind. They
+// match the aliases of the resource commands like get, create and delete,
+// see "nctl api-resources".
+var kindAliases = map[string]string{
+	"app":      "application",
+	"asa":      "apiserviceaccount",
+	"kvs":      "keyvaluestore",
+	"pg":       "postgres",
+	"cloudvm":  "cloudvirtualmachine",
+	"vm":       "cloudvirtualmachine",
+	"argo":     "argocd",
+	"vcluster": "kubernetescluster",
+	"cluster":  "kubernetescluster",
+	"proj":     "project",
+	"config":   "projectconfig",
+}
+
+// LookupKind resolves a user supplied kind like "postgres", "app",
+// "Application" or "application.apps.nine.ch" to a kind of the Nine API
+// which is registered in the scheme. Plurals are accepted as well.
+func LookupKind(scheme *runtime.Scheme, name string) (schema.GroupVersionKind, error) {
+	kind, group, _ := strings.Cut(strings.ToLower(name), ".")
+
+	candidates := []string{kind}
+	if alias, ok := kindAliases[kind]; ok {
+		candidates = append(candidates, alias)
+	}
+	if singular, ok := strings.CutSuffix(kind, "s"); ok {
+		candidates = append(candidates, singular)
+		if alias, ok := kindAliases[singular]; ok {
+			candidates = append(candidates, alias)
+		}
+	}
+
+	matches := map[schema.GroupVersionKind]struct{}{}
+	for gvk := range scheme.AllKnownTypes() {
+		if !isNineGroup(gvk.Group) || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		if len(group) != 0 && gvk.Group != group {
+			continue
+		}
+		for _, c := range candidates {
+			if strings.ToLower(gvk.Kind) == c {
+				matches[gvk] = struct{}{}
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return schema.GroupVersionKind{}, fmt.Errorf("unknown kind %q", name)
+	case 1:
+		for gvk := range matches {
+			return gvk, nil
+		}
+	}
+
+	names := []string{}
+	for gvk := range matches {
+		names = append(names, strings.ToLower(gvk.Kind)+"."+gvk.Group)
+	}
+	sort.Strings(names)
+	return schema.GroupVersionKind{}, fmt.Errorf("kind %q is ambiguous, use one of: %s", name, strings.Join(names, ", "))
+}
+
+// NamespacedNameFor returns the namespaced name of a resource of the given
+// kind. Projects are always located in the organization namespace, all other
+// resources in the current project.
+func (c *Client) NamespacedNameFor(gvk schema.GroupVersionKind, name string) (types.NamespacedName, error) {
+	if gvk.GroupKind() == management.SchemeGroupVersion.WithKind(management.ProjectKind).GroupKind() {
+		org, err := c.Organization()
+		if err != nil {
+			return types.NamespacedName{}, err
+		}
+		return NamespacedName(name, org), nil
+	}
+
+	return c.Name(name), nil
+}
+
+func isNineGroup(group string) bool {
+	return strings.HasSuffix(group, ".nine.ch")
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupKind(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		wantKind string
+		wantErr  bool
+	}{
+		{name: "postgres", wantKind: storage.PostgresKind},
+		{name: "Application", wantKind: apps.ApplicationKind},
+		{name: "app", wantKind: apps.ApplicationKind},
+		{name: "apps", wantKind: apps.ApplicationKind},
+		{name: "builds", wantKind: apps.BuildKind},
+		{name: "kvs", wantKind: storage.KeyValueStoreKind},
+		{name: "cloudvm", wantKind: infrastructure.CloudVirtualMachineKind},
+		{name: "application.apps.nine.ch", wantKind: apps.ApplicationKind},
+		{name: "application.storage.nine.ch", wantErr: true},
+		{name: "secret", wantErr: true},
+		{name: "doesnotexist", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gvk, err := LookupKind(scheme, tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKind, gvk.Kind)
+		})
+	}
+}
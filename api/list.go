@@ -22,6 +22,11 @@ type ListOpts struct {
 	searchForName     string
 	allProjects       bool `help:"apply the get over all projects." short:"A"`
 	allNamespaces     bool `help:"apply the get over all namespaces." hidden:""`
+
+	// pageOptions limit and continue a list. They only apply to a list
+	// in a single namespace or across all namespaces, as a continue
+	// token belongs to one list call.
+	pageOptions []runtimeclient.ListOption
 }
 
 type ListOpt func(opts *ListOpts)
@@ -43,6 +48,25 @@ func MatchLabel(k, v string) ListOpt {
 	}
 }
 
+// Limit limits the amount of returned objects. If there are more objects,
+// the continue token of the list is set.
+func Limit(limit int64) ListOpt {
+	return func(cmd *ListOpts) {
+		if limit > 0 {
+			cmd.pageOptions = append(cmd.pageOptions, runtimeclient.Limit(limit))
+		}
+	}
+}
+
+// Continue continues a limited list from the given continue token.
+func Continue(token string) ListOpt {
+	return func(cmd *ListOpts) {
+		if len(token) != 0 {
+			cmd.pageOptions = append(cmd.pageOptions, runtimeclient.Continue(token))
+		}
+	}
+}
+
 func AllProjects() ListOpt {
 	return func(cmd *ListOpts) {
 		cmd.allProjects = true
@@ -80,7 +104,7 @@ func (c *Client) ListObjects(ctx context.Context, list runtimeclient.ObjectList,
 	}
 
 	if opts.allNamespaces {
-		if err := c.List(ctx, list, opts.clientListOptions...); err != nil {
+		if err := c.List(ctx, list, append(opts.clientListOptions, opts.pageOptions...)...); err != nil {
 			return fmt.Errorf("error when listing across all namespaces: %w", err)
 		}
 		return nil
@@ -110,8 +134,8 @@ func (c *Client) ListObjects(ctx context.Context, list runtimeclient.ObjectList,
 		// another project, we return an error saying that we found the
 		// named object somewhere else.
 
-		opts.clientListOptions = append(opts.clientListOptions, runtimeclient.InNamespace(c.Project))
-		if err := c.List(ctx, list, opts.clientListOptions...); err != nil {
+		listOptions := append(slices.Clone(opts.clientListOptions), runtimeclient.InNamespace(c.Project))
+		if err := c.List(ctx, list, append(listOptions, opts.pageOptions...)...); err != nil {
 			return err
 		}
 		// if we did not search for a specific named object or we
@@ -122,56 +146,48 @@ func (c *Client) ListObjects(ctx context.Context, list runtimeclient.ObjectList,
 			return nil
 		}
 	}
+	// the projects are listed one by one, so a limited list could not be
+	// continued. When falling back to search a named object in all
+	// projects, the limit and continue token of the current project
+	// don't matter.
+	if opts.allProjects && len(opts.pageOptions) > 0 {
+		return errors.New("a limited list can not be done across all projects")
+	}
+	list.SetContinue("")
+
 	// we want to search in all projects, so we need to get them first...
 	projects, err := c.Projects(ctx, "")
 	if err != nil {
 		return fmt.Errorf("error when searching for projects: %w", err)
 	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
 
-	type ProjectItems struct {
-		projectName string
-		items       reflect.Value
-	}
-
-	projectsSize := len(projects)
-	var wg sync.WaitGroup
-	wg.Add(projectsSize)
-	ch := make(chan ProjectItems, projectsSize)
-
-	for _, proj := range projects {
+	// every project gets its own slot so that the items can be collected
+	// in the order of the projects without further sorting.
+	collected := make([]reflect.Value, len(projects))
+	parallel(len(projects), func(i int) {
+		proj := projects[i]
 		tempOpts := slices.Clone(opts.clientListOptions)
-		go func() {
-			defer wg.Done()
-			// we ensured the list is a pointer type and that is has an
-			// 'Items' field which is a slice above, so we don't need to do
-			// this again here and instead use the reflect functions directly.
-			tempList := reflect.New(reflect.TypeOf(list).Elem()).Interface().(runtimeclient.ObjectList)
-			tempList.GetObjectKind().SetGroupVersionKind(list.GetObjectKind().GroupVersionKind())
-			if err := c.List(ctx, tempList, append(tempOpts, runtimeclient.InNamespace(proj.Name))...); err != nil {
-				format.PrintWarningf("error when searching in project %s: %s", proj.Name, err)
-				return
-			}
-			tempListItems := reflect.ValueOf(tempList).Elem().FieldByName("Items")
-			ch <- ProjectItems{projectName: proj.Name, items: tempListItems}
-		}()
-	}
-
-	wg.Wait()
-	close(ch)
-
-	// Collect and sort by project name
-	collected := make([]ProjectItems, 0, projectsSize)
-	for pi := range ch {
-		collected = append(collected, pi)
-	}
-
-	sort.Slice(collected, func(i, j int) bool {
-		return collected[i].projectName < collected[j].projectName
+		// we ensured the list is a pointer type and that is has an
+		// 'Items' field which is a slice above, so we don't need to do
+		// this again here and instead use the reflect functions directly.
+		tempList := reflect.New(reflect.TypeOf(list).Elem()).Interface().(runtimeclient.ObjectList)
+		tempList.GetObjectKind().SetGroupVersionKind(list.GetObjectKind().GroupVersionKind())
+		if err := c.List(ctx, tempList, append(tempOpts, runtimeclient.InNamespace(proj.Name))...); err != nil {
+			format.PrintWarningf("error when searching in project %s: %s", proj.Name, err)
+			return
+		}
+		collected[i] = reflect.ValueOf(tempList).Elem().FieldByName("Items")
 	})
 
-	for _, pi := range collected {
-		for i := range pi.items.Len() {
-			items.Set(reflect.Append(items, pi.items.Index(i)))
+	for _, projectItems := range collected {
+		if !projectItems.IsValid() {
+			continue
+		}
+		for i := range projectItems.Len() {
+			items.Set(reflect.Append(items, projectItems.Index(i)))
 		}
 	}
 
@@ -248,3 +264,26 @@ func (c *Client) Projects(ctx context.Context, onlyName string) ([]management.Pr
 	}
 	return projectList.Items, nil
 }
+
+// maxParallelLists limits the amount of concurrent list calls when listing
+// across projects or kinds, so that the API is not flooded with requests.
+var maxParallelLists = 10
+
+// parallel calls fn for every index in [0, n) with at most
+// maxParallelLists calls running concurrently and waits for all of them.
+func parallel(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxParallelLists)
+	for i := range n {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallel(t *testing.T) {
+	maxParallelLists = 3
+	defer func() { maxParallelLists = 10 }()
+
+	var running, maxRunning atomic.Int32
+	done := make([]bool, 20)
+	parallel(len(done), func(i int) {
+		n := running.Add(1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		done[i] = true
+		running.Add(-1)
+	})
+
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+	assert.Greater(t, maxRunning.Load(), int32(1))
+	for i, d := range done {
+		assert.True(t, d, "index %d not processed", i)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"sort"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logqlmodel"
 	"github.com/grafana/loki/pkg/util/unmarshal"
+	"github.com/ninech/nctl/internal/format"
 	"github.com/prometheus/common/config"
 )
 
@@ -38,16 +41,56 @@ type Query struct {
 	Direction   logproto.Direction
 }
 
+// TransportConfig configures how the client connects to the log API.
+type TransportConfig struct {
+	// Proxy is the URL of the proxy to use. If empty, the proxy is taken
+	// from the HTTPS_PROXY and NO_PROXY environment variables.
+	Proxy string
+	// CAFile is the path of a CA bundle which is trusted instead of the
+	// system CAs.
+	CAFile string
+	// Insecure disables the verification of the server certificate.
+	Insecure bool
+}
+
+// HTTPClient returns an HTTP client which connects with the same settings as
+// the log client, for requests to other endpoints than the log API.
+func (t TransportConfig) HTTPClient() (*http.Client, error) {
+	tlsConfig, err := config.NewTLSConfig(&config.TLSConfig{
+		CAFile:             t.CAFile,
+		InsecureSkipVerify: t.Insecure,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if t.Proxy != "" {
+		proxy, err := url.Parse(t.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", t.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
 // NewClient returns a new log API client.
-func NewClient(address string, tokenFunc tokenFunc, orgID string, insecure bool) (*Client, error) {
+func NewClient(address string, tokenFunc tokenFunc, orgID string, t TransportConfig) (*Client, error) {
 	out, err := StdOut("default")
 	if err != nil {
 		return nil, err
 	}
 
-	tls := config.TLSConfig{}
-	if insecure {
-		tls.InsecureSkipVerify = true
+	tls := config.TLSConfig{
+		CAFile:             t.CAFile,
+		InsecureSkipVerify: t.Insecure,
+	}
+
+	proxy, err := proxyURL(address, t.Proxy)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Client{
@@ -57,10 +100,29 @@ func NewClient(address string, tokenFunc tokenFunc, orgID string, insecure bool)
 			Address:   address,
 			OrgID:     orgID,
 			TLSConfig: tls,
+			ProxyURL:  proxy,
 		},
 	}, nil
 }
 
+// proxyURL returns the proxy to connect to address with. The loki client
+// does not respect the proxy environment variables, so we need to look it
+// up ourselves if no proxy is configured.
+func proxyURL(address, proxy string) (string, error) {
+	if proxy != "" {
+		return proxy, nil
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid log API address %q: %w", address, err)
+	}
+	envProxy, err := http.ProxyFromEnvironment(&http.Request{URL: u})
+	if err != nil || envProxy == nil {
+		return "", err
+	}
+	return envProxy.String(), nil
+}
+
 // Mode translates the mode to lokis terminology.
 func Mode(m string) string {
 	// the json output mode is called "jsonl" for some reason
@@ -74,7 +136,7 @@ func Mode(m string) string {
 // StdOut sets up an stdout log output with the specified mode.
 func StdOut(mode string) (output.LogOutput, error) {
 	out, err := output.NewLogOutput(os.Stdout, mode, &output.LogOutputOptions{
-		NoLabels: true, ColoredOutput: true, Timezone: time.Local,
+		NoLabels: true, ColoredOutput: true, Timezone: format.Location(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create log output: %s", err)
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	logclient "github.com/grafana/loki/pkg/logcli/client"
 	"github.com/grafana/loki/pkg/logcli/output"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,3 +41,26 @@ func TestClient(t *testing.T) {
 	}
 	assert.Equal(t, fmt.Sprintf("%s %s\n", expectedTime.Local().Format(time.RFC3339), expectedLine), buf.String())
 }
+
+func TestNewClientTransport(t *testing.T) {
+	c, err := NewClient("https://logs.example.org", nil, "default", TransportConfig{
+		Proxy:    "http://proxy.example.org:3128",
+		CAFile:   "/etc/ssl/corp.pem",
+		Insecure: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, ok := c.Client.(*logclient.DefaultClient)
+	if !ok {
+		t.Fatalf("expected a *logclient.DefaultClient, got %T", c.Client)
+	}
+	assert.Equal(t, "http://proxy.example.org:3128", client.ProxyURL)
+	assert.Equal(t, "/etc/ssl/corp.pem", client.TLSConfig.CAFile)
+	assert.True(t, client.TLSConfig.InsecureSkipVerify)
+
+	if _, err := NewClient("://invalid", nil, "default", TransportConfig{}); err == nil {
+		t.Fatal("expected an error for an invalid address")
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/grafana/loki/pkg/logcli/output"
 	"github.com/grafana/loki/pkg/loghttp"
+	"github.com/ninech/nctl/internal/format"
 )
 
 type filteredOutput struct {
@@ -44,9 +45,11 @@ func NewStdOut(mode string, noLabels bool, labels ...string) (Output, error) {
 	return NewOutput(os.Stdout, mode, noLabels, labels...)
 }
 
+// NewOutput returns an output writing to w. Only output to stdout is
+// colored, so files don't end up with escape codes.
 func NewOutput(w io.Writer, mode string, noLabels bool, labels ...string) (Output, error) {
 	out, err := output.NewLogOutput(w, mode, &output.LogOutputOptions{
-		NoLabels: noLabels, ColoredOutput: true, Timezone: time.Local,
+		NoLabels: noLabels, ColoredOutput: w == io.Writer(os.Stdout), Timezone: format.Location(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create log output: %s", err)
@@ -0,0 +1,89 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/grafana/loki/pkg/loghttp"
+)
+
+// Redacted replaces text in log lines which should not be shared.
+const Redacted = "<redacted>"
+
+// BuiltinRedactions are the patterns which are redacted in addition to the
+// ones given by the user: bearer tokens and email addresses.
+var BuiltinRedactions = []string{
+	`(?i)bearer\s+([a-z0-9._~+/=-]+)`,
+	`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`,
+}
+
+// Redactor replaces all matches of its patterns in log lines. If a pattern
+// contains capture groups, only the text matched by the groups is replaced,
+// so that e.g. `password=(\S+)` keeps the key.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor compiles the patterns into a Redactor.
+func NewRedactor(patterns ...string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns the line with all matches replaced.
+func (r *Redactor) Redact(line string) string {
+	for _, re := range r.patterns {
+		if re.NumSubexp() == 0 {
+			line = re.ReplaceAllLiteralString(line, Redacted)
+			continue
+		}
+		line = redactGroups(re, line)
+	}
+	return line
+}
+
+// redactGroups replaces the text matched by the capture groups of re and
+// keeps the rest of each match.
+func redactGroups(re *regexp.Regexp, line string) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range re.FindAllStringSubmatchIndex(line, -1) {
+		for i := 2; i < len(match); i += 2 {
+			start, end := match[i], match[i+1]
+			// skip groups which did not participate or are nested in
+			// an already redacted group.
+			if start < last || start == -1 {
+				continue
+			}
+			b.WriteString(line[last:start])
+			b.WriteString(Redacted)
+			last = end
+		}
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+type redactedOutput struct {
+	Output
+	redactor *Redactor
+}
+
+func (o *redactedOutput) FormatAndPrintln(ts time.Time, lbls loghttp.LabelSet, maxLabelsLen int, line string) {
+	o.Output.FormatAndPrintln(ts, lbls, maxLabelsLen, o.redactor.Redact(line))
+}
+
+// NewRedactedOutput returns an output which redacts all lines before passing
+// them on to out.
+func NewRedactedOutput(out Output, r *Redactor) Output {
+	return &redactedOutput{Output: out, redactor: r}
+}
@@ -0,0 +1,55 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		line     string
+		want     string
+	}{
+		{
+			name:     "without groups",
+			patterns: []string{`password=\S+`},
+			line:     "login password=hunter2 ok",
+			want:     "login <redacted> ok",
+		},
+		{
+			name:     "password value group",
+			patterns: []string{`password=(\S+)`},
+			line:     "login password=hunter2 ok password=again",
+			want:     "login password=<redacted> ok password=<redacted>",
+		},
+		{
+			name:     "several groups",
+			patterns: []string{`user=(\S+) password=(\S+)`},
+			line:     "user=admin password=hunter2",
+			want:     "user=<redacted> password=<redacted>",
+		},
+		{
+			name:     "optional group",
+			patterns: []string{`token(?:=(\S+))?`},
+			line:     "token token=abc",
+			want:     "token token=<redacted>",
+		},
+		{
+			name:     "builtin",
+			patterns: BuiltinRedactions,
+			line:     "Authorization: Bearer abc.def from user@example.org",
+			want:     "Authorization: Bearer <redacted> from <redacted>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRedactor(tt.patterns...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.Redact(tt.line))
+		})
+	}
+}
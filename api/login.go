@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -21,7 +21,6 @@ import (
 	"github.com/int128/kubelogin/pkg/oidc"
 	"github.com/int128/kubelogin/pkg/oidc/client"
 	"github.com/int128/kubelogin/pkg/tlsclientconfig/loader"
-	"github.com/int128/kubelogin/pkg/tokencache/repository"
 	"github.com/int128/kubelogin/pkg/usecases/authentication"
 	"github.com/int128/kubelogin/pkg/usecases/authentication/authcode"
 	"github.com/int128/kubelogin/pkg/usecases/authentication/ropc"
@@ -40,8 +39,15 @@ const (
 	CustomersPrefix       = "/Customers/"
 )
 
+// TokenCacheDir returns the directory the OIDC tokens are cached in. The
+// path is built with the separators of the OS, as mixed separators are not
+// handled by all tools on Windows.
+func TokenCacheDir() string {
+	return filepath.Join(homedir.HomeDir(), filepath.FromSlash(DefaultTokenCachePath))
+}
+
 var (
-	defaultBindAddresses = []string{"127.0.0.1:8000", "127.0.0.1:18000"}
+	defaultBindAddresses = []string{"127.0.0.1:8000", "127.0.0.1:18000", "[::1]:8000", "[::1]:18000"}
 	defaultAuthTimeout   = 180 * time.Second
 )
 
@@ -117,7 +123,7 @@ func GetToken(ctx context.Context, issuerURL, clientID string, usePKCE bool, out
 			ClientID:  clientID,
 			UsePKCE:   usePKCE,
 		},
-		TokenCacheDir: path.Join(homedir.HomeDir(), DefaultTokenCachePath),
+		TokenCacheDir: TokenCacheDir(),
 		GrantOptionSet: authentication.GrantOptionSet{
 			AuthCodeBrowserOption: &authcode.BrowserOption{
 				BindAddress:           defaultBindAddresses,
@@ -157,7 +163,7 @@ func GetToken(ctx context.Context, issuerURL, clientID string, usePKCE bool, out
 			},
 		},
 		Logger:               logger,
-		TokenCacheRepository: &repository.Repository{},
+		TokenCacheRepository: TokenCacheRepository(),
 		Writer: &writer.Writer{
 			Stdout: out,
 		},
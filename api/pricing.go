@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ninech/nctl/api/log"
+	"sigs.k8s.io/yaml"
+)
+
+// Kinds of resources in the price table.
+const (
+	PriceApplication = "application"
+	PricePostgres    = "postgres"
+	PriceMySQL       = "mysql"
+)
+
+// PriceTable contains the monthly prices of resources, e.g.
+//
+//	currency: CHF
+//	prices:
+//	  application:
+//	    micro: 8
+//	    standard-2: 60
+//	  postgres:
+//	    nine-db-s: 90
+type PriceTable struct {
+	Currency string `json:"currency"`
+	// Prices maps the kind of a resource to the monthly price of each of
+	// its sizes or machine types.
+	Prices map[string]map[string]float64 `json:"prices"`
+}
+
+// Estimate is the estimated monthly cost of count resources of a size.
+type Estimate struct {
+	Kind     string
+	Size     string
+	Count    int
+	Unit     float64
+	Currency string
+}
+
+// Total returns the monthly cost of all resources.
+func (e Estimate) Total() float64 {
+	return e.Unit * float64(e.Count)
+}
+
+func (e Estimate) String() string {
+	return fmt.Sprintf("%s %.2f per month (%d × %s %s at %s %.2f)",
+		e.Currency, e.Total(), e.Count, e.Kind, e.Size, e.Currency, e.Unit)
+}
+
+// PriceSource is the file or URL a price table is loaded from.
+type PriceSource struct {
+	Source string
+	// Transport configures how a price table is fetched from a URL.
+	Transport log.TransportConfig
+}
+
+// PriceTable loads the price table from the source.
+func (s PriceSource) PriceTable(ctx context.Context) (*PriceTable, error) {
+	if s.Source == "" {
+		return nil, fmt.Errorf("no price table configured, set --price-table or NCTL_PRICE_TABLE to a file or URL containing the prices of your contract")
+	}
+	httpClient, err := s.Transport.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return LoadPriceTable(ctx, httpClient, s.Source)
+}
+
+// PriceTableSource configures the file or URL the price table is loaded
+// from, see PriceTable.
+func PriceTableSource(source PriceSource) ClientOpt {
+	return func(c *Client) error {
+		c.priceSource = source
+		return nil
+	}
+}
+
+// PriceTable loads the price table configured with PriceTableSource.
+func (c *Client) PriceTable(ctx context.Context) (*PriceTable, error) {
+	return c.priceSource.PriceTable(ctx)
+}
+
+// LoadPriceTable reads a price table in YAML or JSON from a file or an
+// HTTP(S) URL, which is fetched with the given client.
+func LoadPriceTable(ctx context.Context, httpClient *http.Client, source string) (*PriceTable, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		data, err = fetch(ctx, httpClient, source)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to load price table: %w", err)
+	}
+
+	table := &PriceTable{}
+	if err := yaml.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("invalid price table %s: %w", source, err)
+	}
+	return table, nil
+}
+
+func fetch(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Estimate returns the monthly cost of count resources of the kind and size.
+func (t *PriceTable) Estimate(kind, size string, count int) (Estimate, error) {
+	price, ok := t.Prices[kind][size]
+	if !ok {
+		return Estimate{}, fmt.Errorf("the price table has no price for %s %q", kind, size)
+	}
+	return Estimate{Kind: kind, Size: size, Count: count, Unit: price, Currency: t.Currency}, nil
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ninech/nctl/api/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPriceTable = `currency: CHF
+prices:
+  application:
+    micro: 8
+    standard-2: 60.5
+`
+
+func TestLoadPriceTable(t *testing.T) {
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "prices.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testPriceTable), 0600))
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prices.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testPriceTable))
+	}))
+	defer srv.Close()
+
+	// the TLS settings of the global flags are used to fetch the table.
+	transport := log.TransportConfig{Insecure: true}
+	for _, source := range []string{path, srv.URL + "/prices.yaml"} {
+		table, err := PriceSource{Source: source, Transport: transport}.PriceTable(ctx)
+		require.NoError(t, err, source)
+
+		estimate, err := table.Estimate(PriceApplication, "standard-2", 3)
+		require.NoError(t, err)
+		assert.Equal(t, 181.5, estimate.Total())
+		assert.Equal(t, "CHF 181.50 per month (3 × application standard-2 at CHF 60.50)", estimate.String())
+
+		_, err = table.Estimate(PricePostgres, "nine-db-s", 1)
+		assert.ErrorContains(t, err, `no price for postgres "nine-db-s"`)
+	}
+
+	_, err := PriceSource{}.PriceTable(ctx)
+	assert.ErrorContains(t, err, "no price table configured")
+	_, err = PriceSource{Source: srv.URL + "/missing", Transport: transport}.PriceTable(ctx)
+	assert.ErrorContains(t, err, "404")
+	_, err = PriceSource{Source: srv.URL + "/prices.yaml"}.PriceTable(ctx)
+	assert.ErrorContains(t, err, "certificate")
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrReadOnly is returned for all requests which would change resources if
+// the client is read-only.
+var ErrReadOnly = errors.New("nctl is in read-only mode, changing resources is not allowed (unset --read-only or NCTL_READONLY)")
+
+// ReadOnly configures the client to reject all requests which change
+// resources, e.g. for shared automation accounts or demos. Server side dry
+// runs are still allowed as they don't persist anything.
+func ReadOnly() ClientOpt {
+	return func(c *Client) error {
+		c.readOnly = true
+		c.WithWatch = readOnlyClient{WithWatch: c.WithWatch}
+		return nil
+	}
+}
+
+// readOnlyClient wraps a client and rejects Create, Update, Patch and Delete
+// requests, including the ones to sub resources like the status.
+type readOnlyClient struct {
+	runtimeclient.WithWatch
+}
+
+func isDryRun(dryRun []string) bool {
+	return slices.Contains(dryRun, metav1.DryRunAll)
+}
+
+func (c readOnlyClient) Create(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+	if !isDryRun((&runtimeclient.CreateOptions{}).ApplyOptions(opts).DryRun) {
+		return ErrReadOnly
+	}
+	return c.WithWatch.Create(ctx, obj, opts...)
+}
+
+func (c readOnlyClient) Update(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
+	if !isDryRun((&runtimeclient.UpdateOptions{}).ApplyOptions(opts).DryRun) {
+		return ErrReadOnly
+	}
+	return c.WithWatch.Update(ctx, obj, opts...)
+}
+
+func (c readOnlyClient) Patch(ctx context.Context, obj runtimeclient.Object, patch runtimeclient.Patch, opts ...runtimeclient.PatchOption) error {
+	if !isDryRun((&runtimeclient.PatchOptions{}).ApplyOptions(opts).DryRun) {
+		return ErrReadOnly
+	}
+	return c.WithWatch.Patch(ctx, obj, patch, opts...)
+}
+
+func (c readOnlyClient) Delete(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.DeleteOption) error {
+	if !isDryRun((&runtimeclient.DeleteOptions{}).ApplyOptions(opts).DryRun) {
+		return ErrReadOnly
+	}
+	return c.WithWatch.Delete(ctx, obj, opts...)
+}
+
+func (c readOnlyClient) DeleteAllOf(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.DeleteAllOfOption) error {
+	if !isDryRun((&runtimeclient.DeleteAllOfOptions{}).ApplyOptions(opts).DryRun) {
+		return ErrReadOnly
+	}
+	return c.WithWatch.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c readOnlyClient) Status() runtimeclient.SubResourceWriter {
+	return readOnlySubResourceWriter{}
+}
+
+func (c readOnlyClient) SubResource(subResource string) runtimeclient.SubResourceClient {
+	return readOnlySubResourceClient{SubResourceClient: c.WithWatch.SubResource(subResource)}
+}
+
+// readOnlySubResourceWriter rejects all writes to sub resources. Dry runs
+// are rejected as well, nctl does not use them for sub resources.
+type readOnlySubResourceWriter struct{}
+
+func (readOnlySubResourceWriter) Create(context.Context, runtimeclient.Object, runtimeclient.Object, ...runtimeclient.SubResourceCreateOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlySubResourceWriter) Update(context.Context, runtimeclient.Object, ...runtimeclient.SubResourceUpdateOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlySubResourceWriter) Patch(context.Context, runtimeclient.Object, runtimeclient.Patch, ...runtimeclient.SubResourcePatchOption) error {
+	return ErrReadOnly
+}
+
+// readOnlySubResourceClient allows to get sub resources, but not to change
+// them.
+type readOnlySubResourceClient struct {
+	runtimeclient.SubResourceClient
+}
+
+func (c readOnlySubResourceClient) Create(ctx context.Context, obj, subResource runtimeclient.Object, opts ...runtimeclient.SubResourceCreateOption) error {
+	return ErrReadOnly
+}
+
+func (c readOnlySubResourceClient) Update(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.SubResourceUpdateOption) error {
+	return ErrReadOnly
+}
+
+func (c readOnlySubResourceClient) Patch(ctx context.Context, obj runtimeclient.Object, patch runtimeclient.Patch, opts ...runtimeclient.SubResourcePatchOption) error {
+	return ErrReadOnly
+}
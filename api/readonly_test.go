@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	existing := &apps.Application{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"}}
+	c := &Client{WithWatch: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()}
+	require.NoError(t, ReadOnly()(c))
+
+	app := &apps.Application{}
+	require.NoError(t, c.Get(ctx, ObjectName(existing), app))
+	list := &apps.ApplicationList{}
+	require.NoError(t, c.List(ctx, list))
+	assert.Len(t, list.Items, 1)
+
+	newApp := &apps.Application{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"}}
+	assert.ErrorIs(t, c.Create(ctx, newApp), ErrReadOnly)
+	assert.NoError(t, c.Create(ctx, newApp, runtimeclient.DryRunAll))
+
+	before := app.DeepCopy()
+	app.Labels = map[string]string{"team": "a"}
+	assert.ErrorIs(t, c.Update(ctx, app), ErrReadOnly)
+	assert.ErrorIs(t, c.Patch(ctx, app, runtimeclient.MergeFrom(before)), ErrReadOnly)
+	assert.ErrorIs(t, c.Status().Update(ctx, app), ErrReadOnly)
+	assert.ErrorIs(t, c.SubResource("status").Patch(ctx, app, runtimeclient.MergeFrom(before)), ErrReadOnly)
+	assert.ErrorIs(t, c.Delete(ctx, app), ErrReadOnly)
+	assert.ErrorIs(t, c.DeleteAllOf(ctx, &apps.Application{}, runtimeclient.InNamespace("default")), ErrReadOnly)
+
+	require.NoError(t, c.Get(ctx, ObjectName(existing), app))
+	assert.Empty(t, app.Labels)
+	assert.Equal(t, []string{"application/new"}, c.Affected())
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	logclient "github.com/grafana/loki/pkg/logcli/client"
+	"k8s.io/client-go/rest"
+)
+
+// interaction is a request to the API and its response as recorded to a
+// file. Request headers and bodies are never recorded.
+type interaction struct {
+	Method      string `json:"method"`
+	URI         string `json:"uri"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body"`
+}
+
+func (i interaction) key() string {
+	return i.Method + " " + i.URI
+}
+
+func requestKey(req *http.Request) string {
+	return req.Method + " " + req.URL.RequestURI()
+}
+
+// Record configures the client to append all requests to the API and the
+// log API with their responses to the file at path, so they can be replayed
+// later with Replay. Following log output is not recorded.
+func Record(path string) ClientOpt {
+	return func(c *Client) error {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("unable to open recording: %w", err)
+		}
+		wrap := func(rt http.RoundTripper) http.RoundTripper {
+			return &recordTransport{next: rt, out: f}
+		}
+		c.setLogTransport(wrap)
+		// the discovery needs to be recorded as well, so the cached
+		// mapper can't be used.
+		c.mapper = nil
+		return c.wrapTransport(wrap)
+	}
+}
+
+// Replay configures the client to answer all requests with the responses
+// recorded with Record instead of connecting to the API. Requests are
+// matched by their method and URI, if the same request has been recorded
+// multiple times, the responses are returned in order. No login is needed
+// for replaying.
+func Replay(path string) ClientOpt {
+	return func(c *Client) error {
+		rt, err := newReplayTransport(path)
+		if err != nil {
+			return err
+		}
+		c.setLogTransport(func(http.RoundTripper) http.RoundTripper { return rt })
+
+		c.Config.ExecProvider = nil
+		c.Config.AuthProvider = nil
+		c.Config.BearerToken = "replay"
+		c.Config.Proxy = nil
+		// a custom transport can't be used together with TLS options.
+		c.Config.TLSClientConfig = rest.TLSClientConfig{}
+		c.Config.Transport = rt
+		c.mapper = nil
+		return c.recreate()
+	}
+}
+
+// setLogTransport wraps the transport of the log client if there is one.
+func (c *Client) setLogTransport(fn func(http.RoundTripper) http.RoundTripper) {
+	if c.Log == nil {
+		return
+	}
+	if dc, ok := c.Log.Client.(*logclient.DefaultClient); ok {
+		dc.Tripperware = fn
+	}
+}
+
+type recordTransport struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	out  io.Writer
+}
+
+// RoundTrip records the response once its body has been read and closed, so
+// streamed responses like watches contain everything the client has seen.
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	i := interaction{
+		Method:      req.Method,
+		URI:         req.URL.RequestURI(),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	resp.Body = &recordingBody{ReadCloser: resp.Body, done: func(body []byte) {
+		i.Body = string(body)
+		t.write(i)
+	}}
+	return resp, nil
+}
+
+func (t *recordTransport) write(i interaction) {
+	b, err := json.Marshal(i)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.out.Write(append(b, '\n'))
+}
+
+// recordingBody keeps a copy of everything read from the body and passes it
+// to done when the body is closed.
+type recordingBody struct {
+	io.ReadCloser
+	buf  bytes.Buffer
+	once sync.Once
+	done func([]byte)
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.done(b.buf.Bytes()) })
+	return err
+}
+
+type replayTransport struct {
+	mu           sync.Mutex
+	interactions map[string][]interaction
+}
+
+func newReplayTransport(path string) (*replayTransport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open recording: %w", err)
+	}
+	defer f.Close()
+
+	t := &replayTransport{interactions: map[string][]interaction{}}
+	scanner := bufio.NewScanner(f)
+	// responses can easily exceed the default token size.
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var i interaction
+		if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			return nil, fmt.Errorf("invalid recording %s on line %d: %w", path, line, err)
+		}
+		t.interactions[i.key()] = append(t.interactions[i.key()], i)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read recording: %w", err)
+	}
+	return t, nil
+}
+
+// RoundTrip returns the next recorded response of the request. The last
+// response is returned again once all of them have been used.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	t.mu.Lock()
+	recorded := t.interactions[requestKey(req)]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.RequestURI())
+	}
+	i := recorded[0]
+	if len(recorded) > 1 {
+		t.interactions[requestKey(req)] = recorded[1:]
+	}
+	t.mu.Unlock()
+
+	header := http.Header{}
+	if i.ContentType != "" {
+		header.Set("Content-Type", i.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(i.Body)),
+		ContentLength: int64(len(i.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRecordReplay(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		fmt.Fprintf(w, `{"call":%d}`, calls)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	c := &Client{
+		WithWatch: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Config:    &rest.Config{Host: srv.URL},
+	}
+	require.NoError(t, Record(path)(c))
+	rt := c.Config.WrapTransport(http.DefaultTransport)
+
+	get := func(rt http.RoundTripper, uri string) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+uri, nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	for _, uri := range []string{"/apis?limit=1", "/apis?limit=1", "/missing"} {
+		get(rt, uri)
+	}
+
+	replay, err := newReplayTransport(path)
+	require.NoError(t, err)
+	srv.Close()
+
+	status, body := get(replay, "/apis?limit=1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"call":1}`, body)
+	_, body = get(replay, "/apis?limit=1")
+	assert.Equal(t, `{"call":2}`, body)
+	// the last response is repeated
+	_, body = get(replay, "/apis?limit=1")
+	assert.Equal(t, `{"call":2}`, body)
+
+	status, body = get(replay, "/missing")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, `{"call":3}`, body)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/apis", nil)
+	require.NoError(t, err)
+	_, err = replay.RoundTrip(req)
+	assert.ErrorContains(t, err, "no recorded response for GET /apis")
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ProjectResources returns all resources of the Nine API in the given
+// projects, sorted by project, kind and name. If kinds is not empty, only
+// resources of these kinds are returned. Resources owned by Nine are skipped
+// unless includeNineResources is set. Errors while listing single kinds are
+// returned as warnings so that as many resources as possible are returned.
+func (c *Client) ProjectResources(ctx context.Context, projects, kinds []string, includeNineResources bool) ([]*unstructured.Unstructured, []string, error) {
+	var warnings []string
+	var result []*unstructured.Unstructured
+	listTypes, err := ListTypes(c.Scheme(), kinds)
+	if err != nil {
+		return nil, nil, err
+	}
+	// every project and kind combination is listed concurrently and gets
+	// its own slot for the results, so that no locking is needed.
+	type listResult struct {
+		items   []*unstructured.Unstructured
+		warning string
+	}
+	results := make([]listResult, len(projects)*len(listTypes))
+	parallel(len(results), func(i int) {
+		project, listType := projects[i/len(listTypes)], listTypes[i%len(listTypes)]
+		u := &unstructured.UnstructuredList{}
+		u.SetGroupVersionKind(listType)
+		if err := c.List(ctx, u, runtimeclient.InNamespace(project)); err != nil {
+			if !kerrors.IsForbidden(err) {
+				results[i].warning = err.Error()
+			}
+			return
+		}
+		// we convert to a list of pointers so that we can
+		// directly call DeepCopyObject() on them and also
+		// filter nine owned resources if needed
+		for _, item := range u.Items {
+			item := item
+			if !includeNineResources {
+				if value, exists := item.GetLabels()[meta.NineOwnedLabelKey]; exists && value == meta.NineOwnedLabelValue {
+					continue
+				}
+			}
+			results[i].items = append(results[i].items, &item)
+		}
+	})
+	for _, r := range results {
+		if r.warning != "" {
+			warnings = append(warnings, r.warning)
+		}
+		result = append(result, r.items...)
+	}
+	// we sort the items of the project to always have the same stable
+	// output. We sort first by project, then by Kind and then by Name.
+	sort.Slice(
+		result,
+		func(i, j int) bool {
+			if result[i].GetNamespace() != result[j].GetNamespace() {
+				return result[i].GetNamespace() < result[j].GetNamespace()
+			}
+			if result[i].GetKind() != result[j].GetKind() {
+				return result[i].GetKind() < result[j].GetKind()
+			}
+			return result[i].GetName() < result[j].GetName()
+		},
+	)
+
+	return result, warnings, nil
+}
+
+// Generated reports if the resource is created by the platform, like builds
+// and releases of an application, and not by the user.
+func Generated(obj runtimeclient.Object) bool {
+	if len(obj.GetOwnerReferences()) != 0 {
+		return true
+	}
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	return gk == apps.SchemeGroupVersion.WithKind(apps.ReleaseKind).GroupKind() ||
+		gk == apps.SchemeGroupVersion.WithKind(apps.BuildKind).GroupKind()
+}
+
+// ListTypes returns the list kinds of all Nine API resources in the scheme.
+// If kinds is not empty, only the list kinds of these kinds are returned.
+func ListTypes(s *runtime.Scheme, kinds []string) ([]schema.GroupVersionKind, error) {
+	result := []schema.GroupVersionKind{}
+	lists := nineListTypes(s)
+	if len(kinds) == 0 {
+		return lists, nil
+	}
+OUTER:
+	for _, kind := range kinds {
+		for _, list := range lists {
+			if !strings.EqualFold(kind+"list", list.GroupKind().Kind) {
+				continue
+			}
+			result = append(result, list)
+			continue OUTER
+		}
+		return []schema.GroupVersionKind{}, fmt.Errorf("kind %s does not seem to be part of any nine.ch API", kind)
+	}
+	return result, nil
+}
+
+func excludeListType(gvk schema.GroupVersionKind) bool {
+	// ClusterData is a non-namespaced resource and used to allow
+	// connecting to deplo.io application replicas.
+	if strings.EqualFold(gvk.Kind, infrastructure.ClusterDataKind+"list") &&
+		strings.EqualFold(gvk.Group, infrastructure.Group) {
+		return true
+	}
+	return false
+}
+
+func nineListTypes(s *runtime.Scheme) []schema.GroupVersionKind {
+	var lists []schema.GroupVersionKind
+	for gvk := range s.AllKnownTypes() {
+		if !strings.HasSuffix(strings.ToLower(gvk.Kind), "list") {
+			continue
+		}
+		if excludeListType(gvk) {
+			continue
+		}
+		if strings.HasSuffix(strings.ToLower(gvk.Group), "nine.ch") {
+			lists = append(lists, gvk)
+		}
+	}
+	// we sort the items to have a predicatable order of types in the output
+	sort.Slice(
+		lists,
+		func(i, j int) bool {
+			return lists[i].Kind < lists[j].Kind
+		},
+	)
+
+	return lists
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// DefaultQPS and DefaultBurst limit the requests to the API if not
+	// configured otherwise.
+	DefaultQPS   = 25
+	DefaultBurst = 50
+)
+
+// TransportConfig configures how the client connects to the API.
+type TransportConfig struct {
+	// QPS and Burst limit the requests to the API. They are only changed
+	// if set.
+	QPS   float32
+	Burst int
+	// Proxy is the URL of the proxy to use. If empty, the proxy is taken
+	// from the HTTPS_PROXY and NO_PROXY environment variables.
+	Proxy string
+	// CAFile is the path of a CA bundle which is trusted instead of the CA
+	// in the kubeconfig, e.g. the CA of a TLS intercepting proxy.
+	CAFile string
+	// Insecure disables the verification of the API server certificate.
+	Insecure bool
+}
+
+// Transport configures how the client connects to the API.
+func Transport(t TransportConfig) ClientOpt {
+	return func(c *Client) error {
+		if t.QPS > 0 {
+			c.Config.QPS = t.QPS
+		}
+		if t.Burst > 0 {
+			c.Config.Burst = t.Burst
+		}
+		if t.Proxy != "" {
+			proxy, err := url.Parse(t.Proxy)
+			if err != nil {
+				return fmt.Errorf("invalid proxy URL %q: %w", t.Proxy, err)
+			}
+			c.Config.Proxy = http.ProxyURL(proxy)
+		}
+		if t.CAFile != "" {
+			// the CA data takes precedence over the file, so it needs
+			// to be removed.
+			c.Config.TLSClientConfig.CAData = nil
+			c.Config.TLSClientConfig.CAFile = t.CAFile
+		}
+		if t.Insecure {
+			// a CA can't be set together with insecure.
+			c.Config.TLSClientConfig.CAData = nil
+			c.Config.TLSClientConfig.CAFile = ""
+			c.Config.TLSClientConfig.Insecure = true
+		}
+		// the discovery of the mapper needs to use the same settings.
+		if mapper, err := newCachedRESTMapper(c.Config, c.KubeconfigPath); err == nil {
+			c.mapper = mapper
+		}
+		return c.recreate()
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTransport(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, caData, 0600))
+
+	newClient := func(t *testing.T) *Client {
+		scheme, err := NewScheme()
+		require.NoError(t, err)
+		return &Client{
+			WithWatch: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Config: &rest.Config{
+				Host:            srv.URL,
+				QPS:             DefaultQPS,
+				Burst:           DefaultBurst,
+				TLSClientConfig: rest.TLSClientConfig{CAData: caData},
+			},
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		c := newClient(t)
+		require.NoError(t, Transport(TransportConfig{})(c))
+		assert.Equal(t, float32(DefaultQPS), c.Config.QPS)
+		assert.Equal(t, DefaultBurst, c.Config.Burst)
+		assert.Nil(t, c.Config.Proxy)
+		assert.Equal(t, caData, c.Config.CAData)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		c := newClient(t)
+		require.NoError(t, Transport(TransportConfig{
+			QPS:    100,
+			Burst:  200,
+			Proxy:  "http://proxy.example.org:3128",
+			CAFile: caFile,
+		})(c))
+		assert.Equal(t, float32(100), c.Config.QPS)
+		assert.Equal(t, 200, c.Config.Burst)
+		assert.Nil(t, c.Config.CAData)
+		assert.Equal(t, caFile, c.Config.CAFile)
+		proxy, err := c.Config.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "nineapis.ch"}})
+		require.NoError(t, err)
+		assert.Equal(t, "proxy.example.org:3128", proxy.Host)
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		c := newClient(t)
+		require.NoError(t, Transport(TransportConfig{CAFile: caFile, Insecure: true})(c))
+		assert.True(t, c.Config.Insecure)
+		assert.Empty(t, c.Config.CAFile)
+		assert.Nil(t, c.Config.CAData)
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		assert.Error(t, Transport(TransportConfig{Proxy: "://invalid"})(newClient(t)))
+	})
+}
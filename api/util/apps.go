@@ -19,11 +19,14 @@ import (
 const (
 	ApplicationNameLabel = "application.apps.nine.ch/name"
 	ManagedByAnnotation  = "app.kubernetes.io/managed-by"
-	NctlName             = "nctl"
-	PrivateKeySecretKey  = "privatekey"
-	UsernameSecretKey    = "username"
-	PasswordSecretKey    = "password"
-	dnsNotSetText        = "<not set yet>"
+	// ReleaseMessageAnnotation holds the message of the deploy which
+	// created a release.
+	ReleaseMessageAnnotation = "nctl.nine.ch/message"
+	NctlName                 = "nctl"
+	PrivateKeySecretKey      = "privatekey"
+	UsernameSecretKey        = "username"
+	PasswordSecretKey        = "password"
+	dnsNotSetText            = "<not set yet>"
 	// DNSSetupURL redirects to the proper deplo.io docs entry about
 	// how to setup custom hosts
 	DNSSetupURL = "https://docs.nine.ch/a/myshbw3EY1"
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// AppEnv are the runtime and build environment variables of an
+// application. It is printed by "nctl get app --env" and read by
+// "nctl update app --env-from-file", so the env can be exported and
+// imported again.
+type AppEnv struct {
+	Application string            `json:"application,omitempty"`
+	Project     string            `json:"project,omitempty"`
+	Env         map[string]string `json:"env"`
+	BuildEnv    map[string]string `json:"buildEnv"`
+}
+
+// NewAppEnv returns the env of the application.
+func NewAppEnv(app *apps.Application) AppEnv {
+	return AppEnv{
+		Application: app.Name,
+		Project:     app.Namespace,
+		Env:         envVarsToMap(app.Spec.ForProvider.Config.Env),
+		BuildEnv:    envVarsToMap(app.Spec.ForProvider.BuildEnv),
+	}
+}
+
+func envVarsToMap(vars apps.EnvVars) map[string]string {
+	env := make(map[string]string, len(vars))
+	for _, v := range vars {
+		env[v.Name] = v.Value
+	}
+	return env
+}
+
+// ReadAppEnv reads the env of the application from a YAML or JSON file. The
+// file contains a single env, multiple YAML documents or a list as printed
+// by "nctl get app --env", in which case the env of the application is taken
+// from it. An env without an application is only used if it is the only one
+// in the file.
+func ReadAppEnv(path, application string) (*AppEnv, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	envs := []AppEnv{}
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse env file %s: %w", path, err)
+		}
+		docEnvs, err := parseAppEnvs(doc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse env file %s: %w", path, err)
+		}
+		envs = append(envs, docEnvs...)
+	}
+
+	for i := range envs {
+		if envs[i].Application == application {
+			return &envs[i], nil
+		}
+	}
+	if len(envs) == 1 && envs[0].Application == "" {
+		return &envs[0], nil
+	}
+	return nil, fmt.Errorf("env file %s contains no env of application %q", path, application)
+}
+
+// parseAppEnvs parses a single YAML document, which is either an env or a
+// list of envs. Empty documents contain no env.
+func parseAppEnvs(doc []byte) ([]AppEnv, error) {
+	list := struct {
+		Kind  string   `json:"kind"`
+		Items []AppEnv `json:"items"`
+	}{}
+	obj := map[string]any{}
+	if err := yaml.Unmarshal(doc, &obj); err != nil {
+		return nil, err
+	}
+	if len(obj) == 0 {
+		return nil, nil
+	}
+	if err := yaml.Unmarshal(doc, &list); err != nil {
+		return nil, err
+	}
+	if list.Kind == "List" {
+		return list.Items, nil
+	}
+
+	env := AppEnv{}
+	if err := yaml.UnmarshalStrict(doc, &env); err != nil {
+		return nil, err
+	}
+	return []AppEnv{env}, nil
+}
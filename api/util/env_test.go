@@ -0,0 +1,60 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAppEnv(t *testing.T) {
+	for name, tc := range map[string]struct {
+		content string
+		want    map[string]string
+		wantErr string
+	}{
+		"single env": {
+			content: "application: shop\nenv:\n  A: shop\n",
+			want:    map[string]string{"A": "shop"},
+		},
+		"single env without application": {
+			content: "env:\n  A: any\n",
+			want:    map[string]string{"A": "any"},
+		},
+		"single env of another application": {
+			content: "application: blog\nenv:\n  A: blog\n",
+			wantErr: `no env of application "shop"`,
+		},
+		"multiple documents": {
+			content: "---\napplication: blog\nenv:\n  A: blog\n---\napplication: shop\nenv:\n  A: shop\n---\n",
+			want:    map[string]string{"A": "shop"},
+		},
+		"multiple documents of other applications": {
+			content: "application: blog\nenv:\n  A: blog\n---\napplication: wiki\nenv:\n  A: wiki\n",
+			wantErr: `no env of application "shop"`,
+		},
+		"list": {
+			content: `{"kind": "List", "items": [{"application": "blog", "env": {"A": "blog"}}, {"application": "shop", "env": {"A": "shop"}}]}`,
+			want:    map[string]string{"A": "shop"},
+		},
+		"unknown field": {
+			content: "application: shop\nenvironment:\n  A: shop\n",
+			wantErr: "unable to parse",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "env.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			env, err := ReadAppEnv(path, "shop")
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, env.Env)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	ProcessTypeWeb       = "web"
+	ProcessTypeWorker    = "worker"
+	ProcessTypeScheduled = "scheduled"
+)
+
+// Process is a process of an application, either the web process serving
+// the requests, a worker job or a scheduled job. They all run the image of
+// the application, but with different commands.
+type Process struct {
+	Name    string
+	Type    string
+	Command string
+	Size    apps.ApplicationSize
+	// Replicas is only set for the web process, worker jobs always run
+	// once.
+	Replicas *int32
+	Schedule string
+}
+
+// ApplicationProcesses returns the processes of the application as
+// configured in its spec, starting with the web process. The sizes of jobs
+// default to the size of the app.
+func ApplicationProcesses(app *apps.Application) []Process {
+	cfg := app.Spec.ForProvider.Config
+	size := cfg.Size
+	if size == "" {
+		size = apps.DefaultConfig.Size
+	}
+	replicas := cfg.Replicas
+	if replicas == nil {
+		replicas = apps.DefaultConfig.Replicas
+	}
+
+	processes := []Process{{Name: ProcessTypeWeb, Type: ProcessTypeWeb, Size: size, Replicas: replicas}}
+	for _, wj := range cfg.WorkerJobs {
+		processes = append(processes, Process{
+			Name:     wj.Name,
+			Type:     ProcessTypeWorker,
+			Command:  wj.Command,
+			Size:     ptr.Deref(wj.Size, size),
+			Replicas: ptr.To(int32(1)),
+		})
+	}
+	for _, sj := range cfg.ScheduledJobs {
+		processes = append(processes, Process{
+			Name:     sj.Name,
+			Type:     ProcessTypeScheduled,
+			Command:  sj.Command,
+			Size:     ptr.Deref(sj.Size, size),
+			Schedule: sj.Schedule,
+		})
+	}
+	return processes
+}
+
+// ParseWorkerJob parses a worker job given as comma separated key=value
+// pairs, e.g. name=queue,command="bundle exec sidekiq",size=mini. Values
+// containing commas can be put in double quotes. Fields which are not given
+// are left empty.
+func ParseWorkerJob(s string) (apps.WorkerJob, error) {
+	job := apps.WorkerJob{}
+	for _, pair := range splitUnquoted(s, ',') {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return job, fmt.Errorf("invalid worker %q: %q is not in the format key=value", s, pair)
+		}
+		value = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), `"`), `"`)
+		switch strings.TrimSpace(key) {
+		case "name":
+			job.Name = value
+		case "command":
+			job.Command = value
+		case "size":
+			job.Size = ptr.To(apps.ApplicationSize(value))
+		case "replicas":
+			return job, fmt.Errorf("invalid worker %q: the replicas of worker jobs can not be set, they always run once", s)
+		default:
+			return job, fmt.Errorf("invalid worker %q: unknown key %q, supported are name, command and size", s, key)
+		}
+	}
+	if job.Name == "" {
+		return job, fmt.Errorf("invalid worker %q: the name is missing", s)
+	}
+	return job, nil
+}
+
+// splitUnquoted splits s at sep, except within double quotes.
+func splitUnquoted(s string, sep rune) []string {
+	parts := []string{}
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// SetWorkerJob adds the worker job to the config or updates the existing
+// job with the same name. Only the command and size which are set on job
+// are changed on an existing job, a new job needs a command.
+func SetWorkerJob(cfg *apps.Config, job apps.WorkerJob) error {
+	for i := range cfg.WorkerJobs {
+		if cfg.WorkerJobs[i].Name != job.Name {
+			continue
+		}
+		if job.Command != "" {
+			cfg.WorkerJobs[i].Command = job.Command
+		}
+		if job.Size != nil {
+			cfg.WorkerJobs[i].Size = job.Size
+		}
+		return nil
+	}
+	if job.Command == "" {
+		return fmt.Errorf("worker %q does not exist, a command is needed to add it", job.Name)
+	}
+	cfg.WorkerJobs = append(cfg.WorkerJobs, job)
+	return nil
+}
+
+// Replica is a running instance of a process of an application.
+type Replica struct {
+	// Process is the name of the process, see Process.
+	Process string
+	apps.ReplicaObservation
+}
+
+// ReleaseReplicas returns the replicas of the release as observed in its
+// status, starting with the replicas of the web process. Scheduled jobs are
+// only included if includeScheduled is set, as their replicas only run
+// until the job is done.
+func ReleaseReplicas(release *apps.Release, includeScheduled bool) []Replica {
+	replicas := []Replica{}
+	for _, obs := range release.Status.AtProvider.ReplicaObservation {
+		replicas = append(replicas, Replica{Process: ProcessTypeWeb, ReplicaObservation: obs})
+	}
+	for _, wjs := range release.Status.AtProvider.WorkerJobStatus {
+		for _, obs := range wjs.ReplicaObservation {
+			replicas = append(replicas, Replica{Process: wjs.Name, ReplicaObservation: obs})
+		}
+	}
+	if !includeScheduled {
+		return replicas
+	}
+	for _, sjs := range release.Status.AtProvider.ScheduledJobStatus {
+		for _, obs := range sjs.ReplicaObservation {
+			replicas = append(replicas, Replica{Process: sjs.Name, ReplicaObservation: obs})
+		}
+	}
+	return replicas
+}
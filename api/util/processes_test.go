@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseWorkerJob(t *testing.T) {
+	job, err := ParseWorkerJob(`name=queue, command="bundle exec sidekiq -q a,b",size=mini`)
+	require.NoError(t, err)
+	assert.Equal(t, apps.WorkerJob{
+		Job:  apps.Job{Name: "queue", Command: "bundle exec sidekiq -q a,b"},
+		Size: ptr.To(apps.ApplicationSize("mini")),
+	}, job)
+
+	// the quotes are usually already removed by the shell
+	job, err = ParseWorkerJob("name=queue,command=bundle exec sidekiq")
+	require.NoError(t, err)
+	assert.Equal(t, "bundle exec sidekiq", job.Command)
+
+	_, err = ParseWorkerJob("command=bin/queue")
+	assert.ErrorContains(t, err, "name is missing")
+	_, err = ParseWorkerJob("name=queue,replicas=2")
+	assert.ErrorContains(t, err, "replicas")
+	_, err = ParseWorkerJob("queue")
+	assert.ErrorContains(t, err, "key=value")
+}
+
+func TestSetWorkerJob(t *testing.T) {
+	cfg := &apps.Config{WorkerJobs: []apps.WorkerJob{{Job: apps.Job{Name: "queue", Command: "sidekiq"}}}}
+	require.NoError(t, SetWorkerJob(cfg, apps.WorkerJob{Job: apps.Job{Name: "queue"}, Size: ptr.To(apps.ApplicationSize("mini"))}))
+	require.NoError(t, SetWorkerJob(cfg, apps.WorkerJob{Job: apps.Job{Name: "mailer", Command: "bin/mailer"}}))
+	assert.Equal(t, []apps.WorkerJob{
+		{Job: apps.Job{Name: "queue", Command: "sidekiq"}, Size: ptr.To(apps.ApplicationSize("mini"))},
+		{Job: apps.Job{Name: "mailer", Command: "bin/mailer"}},
+	}, cfg.WorkerJobs)
+
+	assert.ErrorContains(t, SetWorkerJob(cfg, apps.WorkerJob{Job: apps.Job{Name: "newname"}}), "command is needed")
+	assert.Len(t, cfg.WorkerJobs, 2)
+}
+
+func TestApplicationProcesses(t *testing.T) {
+	app := &apps.Application{}
+	app.Spec.ForProvider.Config = apps.Config{
+		Size:          "mini",
+		Replicas:      ptr.To(int32(3)),
+		WorkerJobs:    []apps.WorkerJob{{Job: apps.Job{Name: "queue", Command: "sidekiq"}, Size: ptr.To(apps.ApplicationSize("micro"))}},
+		ScheduledJobs: []apps.ScheduledJob{{Job: apps.Job{Name: "cleanup", Command: "rake cleanup"}, Schedule: "0 * * * *"}},
+	}
+	assert.Equal(t, []Process{
+		{Name: "web", Type: ProcessTypeWeb, Size: "mini", Replicas: ptr.To(int32(3))},
+		{Name: "queue", Type: ProcessTypeWorker, Command: "sidekiq", Size: "micro", Replicas: ptr.To(int32(1))},
+		{Name: "cleanup", Type: ProcessTypeScheduled, Command: "rake cleanup", Size: "mini", Schedule: "0 * * * *"},
+	}, ApplicationProcesses(app))
+}
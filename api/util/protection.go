@@ -0,0 +1,31 @@
+package util
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProtectedAnnotation marks a resource as protected. nctl refuses to delete
+// protected resources unless explicitly forced to. This is only a client-side
+// guardrail, the API itself does not know about it.
+const ProtectedAnnotation = "nctl.nine.ch/protected"
+
+// IsProtected returns true if the resource has been protected against
+// deletion.
+func IsProtected(obj metav1.Object) bool {
+	return obj.GetAnnotations()[ProtectedAnnotation] == "true"
+}
+
+// SetProtected adds or removes the protection of a resource.
+func SetProtected(obj metav1.Object, protected bool) {
+	annotations := obj.GetAnnotations()
+	if !protected {
+		delete(annotations, ProtectedAnnotation)
+		obj.SetAnnotations(annotations)
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ProtectedAnnotation] = "true"
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,16 @@
+package util
+
+import (
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHKeyFingerprint returns the SHA256 fingerprint of a public key in
+// authorized_keys format, as printed by "ssh-keygen -l". It returns an empty
+// string if the key can't be parsed.
+func SSHKeyFingerprint(publicKey string) string {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return ""
+	}
+	return ssh.FingerprintSHA256(key)
+}
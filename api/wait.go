@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Watched selects resources whose changes are watched by WaitUntil.
+type Watched struct {
+	// either list or obj is set, the list of obj is looked up in the
+	// scheme of the client.
+	list runtimeclient.ObjectList
+	obj  runtimeclient.Object
+	opts []runtimeclient.ListOption
+}
+
+// WatchObject watches the object with the name and namespace of obj.
+func WatchObject(obj runtimeclient.Object) Watched {
+	return Watched{
+		obj: obj,
+		opts: []runtimeclient.ListOption{
+			runtimeclient.InNamespace(obj.GetNamespace()),
+			runtimeclient.MatchingFields{"metadata.name": obj.GetName()},
+		},
+	}
+}
+
+// WatchList watches the resources of the list which match the opts.
+func WatchList(list runtimeclient.ObjectList, opts ...runtimeclient.ListOption) Watched {
+	return Watched{list: list, opts: opts}
+}
+
+// WaitUntil calls check right away and again whenever one of the watched
+// resources changes, until check reports done, returns an error or ctx is
+// done, in which case the error of ctx is returned. If the resources can't
+// be watched or a watch ends, check is called again after interval and the
+// watch is started again, so no change is missed.
+func (c *Client) WaitUntil(ctx context.Context, interval time.Duration, check func() (bool, error), watched ...Watched) error {
+	for {
+		changes, stop := c.watchChanges(ctx, watched)
+		done, err := check()
+		if err != nil || done {
+			stop()
+			return err
+		}
+
+	watching:
+		for {
+			select {
+			case _, ok := <-changes:
+				if !ok {
+					break watching
+				}
+				done, err := check()
+				if err != nil || done {
+					stop()
+					return err
+				}
+			case <-ctx.Done():
+				stop()
+				return ctx.Err()
+			}
+		}
+		stop()
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// watchChanges watches the resources and sends on the returned channel if
+// one of them changes. Multiple changes are merged while they are not
+// received. The channel is closed once any of the watches ends or if they
+// can't be started. The returned func stops all watches.
+func (c *Client) watchChanges(ctx context.Context, watched []Watched) (<-chan struct{}, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	changes := make(chan struct{}, 1)
+	wg := sync.WaitGroup{}
+	for _, w := range watched {
+		list := w.list
+		if list == nil {
+			var err error
+			if list, err = c.listFor(w.obj); err != nil {
+				cancel()
+				break
+			}
+		}
+		wi, err := c.Watch(ctx, list, w.opts...)
+		if err != nil {
+			cancel()
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer wi.Stop()
+			// the watches are started again together.
+			defer cancel()
+			for {
+				select {
+				case event, ok := <-wi.ResultChan():
+					if !ok || event.Type == watch.Error {
+						return
+					}
+					select {
+					case changes <- struct{}{}:
+					default:
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		wg.Wait()
+		close(changes)
+	}()
+	return changes, cancel
+}
+
+// listFor returns an empty list for the kind of obj.
+func (c *Client) listFor(obj runtimeclient.Object) (runtimeclient.ObjectList, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	gvk.Kind += "List"
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		return list, nil
+	}
+	o, err := c.Scheme().New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := o.(runtimeclient.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", gvk.Kind)
+	}
+	return list, nil
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestWaitUntil(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		funcs    interceptor.Funcs
+		interval time.Duration
+		watched  func(build *apps.Build) Watched
+	}{
+		// the interval is long, so only a watch event can end the wait in time
+		"watch object": {
+			interval: time.Hour,
+			watched:  func(build *apps.Build) Watched { return WatchObject(build) },
+		},
+		"watch unstructured object": {
+			interval: time.Hour,
+			watched: func(build *apps.Build) Watched {
+				obj := &unstructured.Unstructured{}
+				obj.SetGroupVersionKind(apps.BuildGroupVersionKind)
+				obj.SetName(build.Name)
+				obj.SetNamespace(build.Namespace)
+				return WatchObject(obj)
+			},
+		},
+		"watch list": {
+			interval: time.Hour,
+			watched: func(build *apps.Build) Watched {
+				return WatchList(&apps.BuildList{}, runtimeclient.InNamespace(build.Namespace))
+			},
+		},
+		"fall back to polling": {
+			funcs: interceptor.Funcs{
+				Watch: func(context.Context, runtimeclient.WithWatch, runtimeclient.ObjectList, ...runtimeclient.ListOption) (watch.Interface, error) {
+					return nil, errors.New("watch not supported")
+				},
+			},
+			interval: 10 * time.Millisecond,
+			watched:  func(build *apps.Build) Watched { return WatchObject(build) },
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			build := &apps.Build{ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "default"}}
+			c := &Client{WithWatch: fake.NewClientBuilder().WithScheme(scheme).WithObjects(build).WithInterceptorFuncs(tc.funcs).Build()}
+
+			time.AfterFunc(50*time.Millisecond, func() {
+				updated := build.DeepCopy()
+				updated.Status.AtProvider.BuildStatus = apps.BuildProcessStatusSuccess
+				_ = c.Update(ctx, updated)
+			})
+
+			checks := 0
+			err := c.WaitUntil(ctx, tc.interval, func() (bool, error) {
+				checks++
+				current := &apps.Build{}
+				if err := c.Get(ctx, ObjectName(build), current); err != nil {
+					return false, err
+				}
+				return current.Status.AtProvider.BuildStatus == apps.BuildProcessStatusSuccess, nil
+			}, tc.watched(build))
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, checks, 2)
+		})
+	}
+
+	// the error of the context is returned
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c := &Client{WithWatch: fake.NewClientBuilder().WithScheme(scheme).Build()}
+	err = c.WaitUntil(ctx, time.Hour, func() (bool, error) { return false, nil }, WatchList(&apps.BuildList{}))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// errors of the check end the wait
+	err = c.WaitUntil(context.Background(), time.Hour, func() (bool, error) { return false, errors.New("boom") }, WatchList(&apps.BuildList{}))
+	assert.EqualError(t, err, "boom")
+}
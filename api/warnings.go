@@ -0,0 +1,48 @@
+package api
+
+import (
+	"sync"
+)
+
+// warningCode is the code of warnings which are returned by the API, see
+// RFC 7234.
+const warningCode = 299
+
+// warningCollector collects the warnings returned by the API in the warning
+// header, e.g. about deprecated API versions or fields.
+type warningCollector struct {
+	mu       sync.Mutex
+	seen     map[string]bool
+	messages []string
+}
+
+// HandleWarningHeader implements rest.WarningHandler.
+func (w *warningCollector) HandleWarningHeader(code int, agent, message string) {
+	if code != warningCode || message == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seen == nil {
+		w.seen = map[string]bool{}
+	}
+	if w.seen[message] {
+		return
+	}
+	w.seen[message] = true
+	w.messages = append(w.messages, message)
+}
+
+func (w *warningCollector) all() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.messages...)
+}
+
+// Warnings returns all distinct warnings the API has returned so far.
+func (c *Client) Warnings() []string {
+	if c.warnings == nil {
+		return nil
+	}
+	return c.warnings.all()
+}
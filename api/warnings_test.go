@@ -0,0 +1,20 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarningCollector(t *testing.T) {
+	w := &warningCollector{}
+	w.HandleWarningHeader(299, "", "v1alpha1 Foo is deprecated")
+	w.HandleWarningHeader(299, "", "v1alpha1 Foo is deprecated")
+	w.HandleWarningHeader(199, "", "miscellaneous warning")
+	w.HandleWarningHeader(299, "", "")
+	w.HandleWarningHeader(299, "", "field bar is deprecated")
+
+	c := &Client{warnings: w}
+	assert.Equal(t, []string{"v1alpha1 Foo is deprecated", "field bar is deprecated"}, c.Warnings())
+	assert.Nil(t, (&Client{}).Warnings())
+}
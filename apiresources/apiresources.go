@@ -0,0 +1,117 @@
+// Package apiresources lists the resource kinds which nctl supports together
+// with their short names and the commands which can be used with them.
+package apiresources
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type Cmd struct {
+	out io.Writer
+}
+
+func (cmd *Cmd) Help() string {
+	return "Lists the resource kinds with their short names and the commands which have a\n" +
+		"subcommand for them, e.g. \"nctl get pg\". Short names can also be used as the\n" +
+		"kind of commands like describe, edit, patch, label or events."
+}
+
+// resource is a kind of the API with the names and verbs of the commands for
+// it.
+type resource struct {
+	gvk   schema.GroupVersionKind
+	names []string
+	verbs []string
+}
+
+func (cmd *Cmd) Run(app *kong.Application) error {
+	out := cmd.out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	resources, err := listResources(app)
+	if err != nil {
+		return err
+	}
+
+	w := format.NewTable(out)
+	fmt.Fprintln(w, "NAME\tSHORTNAMES\tAPIGROUP\tKIND\tVERBS")
+	for _, r := range resources {
+		name := strings.ToLower(r.gvk.Kind)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, strings.Join(r.shortNames(), ","), r.gvk.Group, r.gvk.Kind, strings.Join(r.verbs, ","))
+	}
+	return w.Flush()
+}
+
+// listResources returns the resources which have a command in the grammar of the
+// app, sorted by kind. A resource command is a subcommand of a verb which
+// belongs to an API group, like "nctl get application".
+func listResources(app *kong.Application) ([]resource, error) {
+	scheme, err := api.NewScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	byKind := map[schema.GroupVersionKind]*resource{}
+	for _, verb := range app.Children {
+		if verb.Hidden {
+			continue
+		}
+		for _, n := range verb.Children {
+			if n.Group == nil || n.Hidden {
+				continue
+			}
+			gvk, err := api.LookupKind(scheme, n.Name)
+			if err != nil {
+				return nil, fmt.Errorf("unable to find the kind of command %q: %w", verb.Name+" "+n.Name, err)
+			}
+			r, ok := byKind[gvk]
+			if !ok {
+				r = &resource{gvk: gvk}
+				byKind[gvk] = r
+			}
+			for _, name := range append([]string{n.Name}, n.Aliases...) {
+				if !slices.Contains(r.names, name) {
+					r.names = append(r.names, name)
+				}
+			}
+			if !slices.Contains(r.verbs, verb.Name) {
+				r.verbs = append(r.verbs, verb.Name)
+			}
+		}
+	}
+
+	resources := make([]resource, 0, len(byKind))
+	for _, r := range byKind {
+		resources = append(resources, *r)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].gvk.Kind < resources[j].gvk.Kind
+	})
+	return resources, nil
+}
+
+// shortNames returns the names of the commands for the resource apart from
+// its kind and the plural of it.
+func (r resource) shortNames() []string {
+	kind := strings.ToLower(r.gvk.Kind)
+	names := []string{}
+	for _, name := range r.names {
+		if name != kind && name != kind+"s" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
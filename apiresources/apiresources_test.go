@@ -0,0 +1,39 @@
+package apiresources
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type appCmd struct {
+	Name string `arg:""`
+}
+
+type grammar struct {
+	Get struct {
+		Applications appCmd   `cmd:"" group:"deplo.io" name:"applications" aliases:"app,apps"`
+		Postgres     appCmd   `cmd:"" group:"storage.nine.ch" name:"postgres" aliases:"pg"`
+		Quota        struct{} `cmd:""`
+	} `cmd:"" aliases:"ls"`
+	Logs struct {
+		Application appCmd `cmd:"" group:"deplo.io" name:"application" aliases:"app"`
+	} `cmd:""`
+}
+
+func TestAPIResources(t *testing.T) {
+	parser, err := kong.New(&grammar{})
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := Cmd{out: out}
+	require.NoError(t, cmd.Run(parser.Model))
+	assert.Regexp(t, `NAME\s+SHORTNAMES\s+APIGROUP\s+KIND\s+VERBS`, out.String())
+	assert.Regexp(t, `application\s+app,apps\s+apps.nine.ch\s+Application\s+get,logs\n`, out.String())
+	assert.Regexp(t, `postgres\s+pg\s+storage.nine.ch\s+Postgres\s+get\n`, out.String())
+	// commands without an API group like quota are no resources.
+	assert.NotContains(t, out.String(), "quota")
+}
@@ -2,5 +2,6 @@ package apply
 
 type Cmd struct {
 	Filename string   `short:"f" predictor:"file"`
+	DryRun   string   `help:"Must be \"none\" or \"server\". If server, the resource is validated by the API (including webhooks) without being persisted. ${enum}" enum:"none,server" default:"none"`
 	FromFile fromFile `cmd:"" default:"1" name:"-f <file>" help:"Apply any resource from a yaml or json file."`
 }
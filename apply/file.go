@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/format"
 	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -18,7 +19,7 @@ type fromFile struct {
 }
 
 func (cmd *Cmd) Run(ctx context.Context, client *api.Client, apply *Cmd) error {
-	return File(ctx, client, apply.Filename, UpdateOnExists())
+	return File(ctx, client, apply.Filename, UpdateOnExists(), DryRun(apply.DryRun == DryRunServer))
 }
 
 type Option func(*config)
@@ -26,8 +27,14 @@ type Option func(*config)
 type config struct {
 	updateOnExists bool
 	delete         bool
+	dryRun         bool
+	forceProtected bool
 }
 
+// DryRunServer is the dry-run mode which submits the request to the API
+// without persisting it.
+const DryRunServer = "server"
+
 func UpdateOnExists() Option {
 	return func(c *config) {
 		c.updateOnExists = true
@@ -40,6 +47,21 @@ func Delete() Option {
 	}
 }
 
+// ForceProtected configures File to delete resources even if they have been
+// protected against deletion. It is only relevant together with Delete.
+func ForceProtected(enabled bool) Option {
+	return func(c *config) {
+		c.forceProtected = enabled
+	}
+}
+
+// DryRun configures File to only submit server-side dry-run requests.
+func DryRun(enabled bool) Option {
+	return func(c *config) {
+		c.dryRun = enabled
+	}
+}
+
 func File(ctx context.Context, client *api.Client, filename string, opts ...Option) error {
 	if len(filename) == 0 {
 		return fmt.Errorf("missing flag -f, --filename=STRING")
@@ -61,26 +83,42 @@ func File(ctx context.Context, client *api.Client, filename string, opts ...Opti
 	}
 
 	if cfg.delete {
-		if err := client.Delete(ctx, obj); err != nil {
-			return err
-		}
-		format.PrintSuccessf("🗑", "deleted %s", formatObj(obj))
-
-		return nil
+		return deleteObject(ctx, client, obj, cfg)
 	}
 
-	if err := client.Create(ctx, obj); err != nil {
+	if err := client.Create(ctx, obj, cfg.createOptions()...); err != nil {
 		if errors.IsAlreadyExists(err) && cfg.updateOnExists {
-			return update(ctx, client, obj)
+			return update(ctx, client, obj, cfg)
 		}
 		return err
 	}
 
-	format.PrintSuccessf("🏗", "created %s", formatObj(obj))
+	format.PrintSuccessf("🏗", "created %s%s", formatObj(obj), cfg.dryRunSuffix())
 	return nil
 }
 
-func update(ctx context.Context, client *api.Client, obj *unstructured.Unstructured) error {
+func deleteObject(ctx context.Context, client *api.Client, obj *unstructured.Unstructured, cfg *config) error {
+	if !cfg.forceProtected {
+		// the protection is only set on the resource in the API, the
+		// file does not need to contain it.
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := client.Get(ctx, api.ObjectName(obj), current); err != nil {
+			return err
+		}
+		if util.IsProtected(current) {
+			return fmt.Errorf("%s %q is protected against deletion, use --force-protected to delete it anyway", obj.GetKind(), obj.GetName())
+		}
+	}
+
+	if err := client.Delete(ctx, obj, cfg.deleteOptions()...); err != nil {
+		return err
+	}
+	format.PrintSuccessf("🗑", "deleted %s%s", formatObj(obj), cfg.dryRunSuffix())
+	return nil
+}
+
+func update(ctx context.Context, client *api.Client, obj *unstructured.Unstructured, cfg *config) error {
 	oldObj := &unstructured.Unstructured{}
 	oldObj.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
 	if err := client.Get(ctx, api.ObjectName(obj), oldObj); err != nil {
@@ -99,14 +137,42 @@ func update(ctx context.Context, client *api.Client, obj *unstructured.Unstructu
 	// ensure resource version is up to date
 	obj.SetResourceVersion(oldObj.GetResourceVersion())
 
-	if err := client.Update(ctx, obj); err != nil {
+	if err := client.Update(ctx, obj, cfg.updateOptions()...); err != nil {
 		return err
 	}
 
-	format.PrintSuccessf("🏗", "applied %s", formatObj(obj))
+	format.PrintSuccessf("🏗", "applied %s%s", formatObj(obj), cfg.dryRunSuffix())
+	return nil
+}
+
+func (c *config) createOptions() []client.CreateOption {
+	if c.dryRun {
+		return []client.CreateOption{client.DryRunAll}
+	}
+	return nil
+}
+
+func (c *config) updateOptions() []client.UpdateOption {
+	if c.dryRun {
+		return []client.UpdateOption{client.DryRunAll}
+	}
+	return nil
+}
+
+func (c *config) deleteOptions() []client.DeleteOption {
+	if c.dryRun {
+		return []client.DeleteOption{client.DryRunAll}
+	}
 	return nil
 }
 
+func (c *config) dryRunSuffix() string {
+	if c.dryRun {
+		return " (server dry run)"
+	}
+	return ""
+}
+
 func formatObj(obj client.Object) string {
 	return fmt.Sprintf("%s %s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), obj.GetNamespace())
 }
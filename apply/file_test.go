@@ -8,9 +8,12 @@ import (
 
 	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	iam "github.com/ninech/apis/iam/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/test"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -182,3 +185,23 @@ func TestFile(t *testing.T) {
 		})
 	}
 }
+
+func TestFileDeleteProtected(t *testing.T) {
+	ctx := context.Background()
+	asa := &iam.APIServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "protected", Namespace: test.DefaultProject}}
+	util.SetProtected(asa, true)
+	apiClient, err := test.SetupClient(test.WithObjects(asa))
+	require.NoError(t, err)
+
+	// the file does not need to contain the protection
+	f, err := os.CreateTemp(t.TempDir(), "nctl-filetest*")
+	require.NoError(t, err)
+	_, err = f.WriteString(fmt.Sprintf(apiServiceAccountYAML, asa.Name, "value", runtimev1.DeletionOrphan))
+	require.NoError(t, err)
+
+	require.ErrorContains(t, File(ctx, apiClient, f.Name(), Delete()), "protected against deletion")
+	require.NoError(t, apiClient.Get(ctx, api.ObjectName(asa), asa))
+
+	require.NoError(t, File(ctx, apiClient, f.Name(), Delete(), ForceProtected(true)))
+	require.True(t, errors.IsNotFound(apiClient.Get(ctx, api.ObjectName(asa), asa)))
+}
@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	devtools "github.com/ninech/apis/devtools/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/log"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// argoCDUsernameKey and argoCDPasswordKey are the keys of the admin
+	// credentials in the connection secret of an Argo CD instance.
+	argoCDUsernameKey = "username"
+	argoCDPasswordKey = "password"
+)
+
+type ArgoCDCmd struct {
+	Name  string `arg:"" predictor:"resource_name" help:"Name of the Argo CD instance."`
+	Login bool   `help:"Log in the argocd CLI with the admin credentials instead of printing them."`
+	out   io.Writer
+}
+
+func (cmd *ArgoCDCmd) Run(ctx context.Context, client *api.Client, transport log.TransportConfig) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	argoCD := &devtools.ArgoCD{}
+	if err := client.Get(ctx, client.Name(cmd.Name), argoCD); err != nil {
+		return err
+	}
+	if argoCD.Status.AtProvider.URL == "" {
+		return fmt.Errorf("argocd %q is not ready yet, it has no URL", cmd.Name)
+	}
+
+	secret, err := client.GetConnectionSecret(ctx, argoCD)
+	if err != nil {
+		return fmt.Errorf("unable to get credentials of argocd %q: %w", cmd.Name, err)
+	}
+	username, password := string(secret.Data[argoCDUsernameKey]), string(secret.Data[argoCDPasswordKey])
+	if username == "" || password == "" {
+		return fmt.Errorf("connection secret of argocd %q contains no admin credentials", cmd.Name)
+	}
+
+	if !cmd.Login {
+		fmt.Fprintf(cmd.out, "URL:      %s\n", argoCD.Status.AtProvider.URL)
+		fmt.Fprintf(cmd.out, "Username: %s\n", username)
+		fmt.Fprintf(cmd.out, "Password: %s\n", password)
+		return nil
+	}
+
+	server, err := argoCDServer(argoCD)
+	if err != nil {
+		return err
+	}
+	httpClient, err := transport.HTTPClient()
+	if err != nil {
+		return err
+	}
+	token, err := argoCDSession(ctx, httpClient, argoCD.Status.AtProvider.URL, username, password)
+	if err != nil {
+		return fmt.Errorf("unable to log in to argocd %q: %w", cmd.Name, err)
+	}
+	path, err := argoCDConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := writeArgoCDConfig(path, server, token); err != nil {
+		return fmt.Errorf("unable to write argocd config %s: %w", path, err)
+	}
+
+	format.PrintSuccessf("🐙", "logged in to argocd %s, the argocd CLI now uses the context %s", cmd.Name, server)
+	return nil
+}
+
+// argoCDSession creates a session with the admin credentials and returns its
+// token. This is what "argocd login" does, but running it would require to
+// pass the password on the command line, where it can be read by other
+// users of the machine.
+func argoCDSession(ctx context.Context, httpClient *http.Client, argoCDURL, username, password string) (string, error) {
+	body, err := json.Marshal(map[string]string{"username": username, "password": password})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(argoCDURL, "/")+"/api/v1/session", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	session := struct {
+		Token string `json:"token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return "", fmt.Errorf("unable to decode session: %w", err)
+	}
+	if session.Token == "" {
+		return "", errors.New("session contains no token")
+	}
+	return session.Token, nil
+}
+
+// argoCDConfigPath returns the path of the argocd CLI config, it is looked up
+// the same way as the argocd CLI does.
+func argoCDConfigPath() (string, error) {
+	if dir := os.Getenv("ARGOCD_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "config"), nil
+	}
+	home := homedir.HomeDir()
+	if home == "" {
+		return "", errors.New("unable to find the home directory for the argocd config")
+	}
+	// the legacy directory is used as long as it exists.
+	if _, err := os.Stat(filepath.Join(home, ".argocd")); err == nil {
+		return filepath.Join(home, ".argocd", "config"), nil
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "argocd", "config"), nil
+}
+
+// writeArgoCDConfig adds the server, user and context of the server with the
+// session token to the argocd CLI config and makes it the current context.
+// All other entries and fields of the config are kept.
+func writeArgoCDConfig(path, server, token string) error {
+	cfg := map[string]any{}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return err
+	}
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+
+	upsertArgoCDEntry(cfg, "servers", "server", map[string]any{"server": server, "grpc-web": true})
+	upsertArgoCDEntry(cfg, "users", "name", map[string]any{"name": server, "auth-token": token})
+	upsertArgoCDEntry(cfg, "contexts", "name", map[string]any{"name": server, "server": server, "user": server})
+	cfg["current-context"] = server
+
+	content, err = yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
+
+// upsertArgoCDEntry replaces the entry in the list of the config whose key
+// matches the one of the entry, e.g. to drop the refresh token of a previous
+// login. If there is none, the entry is appended.
+func upsertArgoCDEntry(cfg map[string]any, list, key string, entry map[string]any) {
+	items, _ := cfg[list].([]any)
+	for i, item := range items {
+		if existing, ok := item.(map[string]any); ok && existing[key] == entry[key] {
+			items[i] = entry
+			return
+		}
+	}
+	cfg[list] = append(items, entry)
+}
+
+// argoCDServer returns the host to pass to "argocd login", which does not
+// accept a URL.
+func argoCDServer(argoCD *devtools.ArgoCD) (string, error) {
+	raw := argoCD.Status.AtProvider.CLIURL
+	if raw == "" {
+		raw = argoCD.Status.AtProvider.URL
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid argocd URL %q: %w", raw, err)
+	}
+	if u.Host == "" {
+		return raw, nil
+	}
+	return u.Host, nil
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	devtools "github.com/ninech/apis/devtools/v1alpha1"
+	"github.com/ninech/nctl/api/log"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestArgoCD(t *testing.T) {
+	ctx := context.Background()
+	argoCD := &devtools.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argo", Namespace: test.DefaultProject},
+		Spec: devtools.ArgoCDSpec{ResourceSpec: runtimev1.ResourceSpec{
+			WriteConnectionSecretToReference: &runtimev1.SecretReference{Name: "argocd-argo", Namespace: test.DefaultProject},
+		}},
+		Status: devtools.ArgoCDStatus{AtProvider: devtools.ArgoCDObservation{
+			URL:    "https://argo.example.org",
+			CLIURL: "https://argo-cli.example.org",
+		}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-argo", Namespace: test.DefaultProject},
+		Data: map[string][]byte{
+			argoCDUsernameKey: []byte("admin"),
+			argoCDPasswordKey: []byte("topsecret"),
+		},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(argoCD, secret))
+	require.NoError(t, err)
+	transport := log.TransportConfig{}
+
+	out := &bytes.Buffer{}
+	cmd := &ArgoCDCmd{Name: "argo", out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, transport))
+	assert.Contains(t, out.String(), "https://argo.example.org")
+	assert.Contains(t, out.String(), "admin")
+	assert.Contains(t, out.String(), "topsecret")
+
+	server, err := argoCDServer(argoCD)
+	require.NoError(t, err)
+	assert.Equal(t, "argo-cli.example.org", server)
+
+	cmd = &ArgoCDCmd{Name: "missing", out: &bytes.Buffer{}}
+	assert.Error(t, cmd.Run(ctx, apiClient, transport))
+}
+
+func TestArgoCDLogin(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credentials := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil || r.URL.Path != "/api/v1/session" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if credentials["username"] != "admin" || credentials["password"] != "topsecret" {
+			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token": "session-token"}`))
+	}))
+	defer srv.Close()
+
+	argoCD := &devtools.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argo", Namespace: test.DefaultProject},
+		Spec: devtools.ArgoCDSpec{ResourceSpec: runtimev1.ResourceSpec{
+			WriteConnectionSecretToReference: &runtimev1.SecretReference{Name: "argocd-argo", Namespace: test.DefaultProject},
+		}},
+		Status: devtools.ArgoCDStatus{AtProvider: devtools.ArgoCDObservation{
+			URL:    srv.URL,
+			CLIURL: "https://argo-cli.example.org",
+		}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-argo", Namespace: test.DefaultProject},
+		Data: map[string][]byte{
+			argoCDUsernameKey: []byte("admin"),
+			argoCDPasswordKey: []byte("topsecret"),
+		},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(argoCD, secret))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	t.Setenv("ARGOCD_CONFIG_DIR", dir)
+	path := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(path, []byte(`current-context: other
+prompts-enabled: true
+contexts:
+- name: other
+  server: other.example.org
+  user: other
+users:
+- name: argo-cli.example.org
+  auth-token: expired
+  refresh-token: refresh
+`), 0o600))
+
+	cmd := &ArgoCDCmd{Name: "argo", Login: true, out: &bytes.Buffer{}}
+	// the server certificate is only trusted with the TLS settings of the
+	// global flags.
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient, log.TransportConfig{}), "certificate")
+	require.NoError(t, cmd.Run(ctx, apiClient, log.TransportConfig{Insecure: true}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `contexts:
+- name: other
+  server: other.example.org
+  user: other
+- name: argo-cli.example.org
+  server: argo-cli.example.org
+  user: argo-cli.example.org
+current-context: argo-cli.example.org
+prompts-enabled: true
+servers:
+- grpc-web: true
+  server: argo-cli.example.org
+users:
+- auth-token: session-token
+  name: argo-cli.example.org
+`, string(content))
+
+	secret.Data[argoCDPasswordKey] = []byte("wrong")
+	require.NoError(t, apiClient.Update(ctx, secret))
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient, log.TransportConfig{Insecure: true}), "401")
+}
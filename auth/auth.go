@@ -4,9 +4,12 @@ type Cmd struct {
 	Login            LoginCmd            `cmd:"" help:"Login to nineapis.ch."`
 	Logout           LogoutCmd           `cmd:"" help:"Logout from nineapis.ch."`
 	Cluster          ClusterCmd          `cmd:"" help:"Authenticate with Kubernetes Cluster."`
+	ArgoCD           ArgoCDCmd           `cmd:"" name:"argocd" help:"Print the admin credentials of an Argo CD instance or log in the argocd CLI."`
+	Registry         RegistryCmd         `cmd:"" help:"Log in to a container registry with docker or print its credentials."`
 	OIDC             OIDCCmd             `cmd:"" help:"Perform interactive OIDC login." hidden:""`
 	SetProject       SetProjectCmd       `cmd:"" help:"Set the default project to be used."`
 	SetOrg           SetOrgCmd           `cmd:"" help:"Set the organization to be used."`
 	Whoami           WhoAmICmd           `cmd:"" help:"Show who you are logged in as, your active organization and all your available organizations."`
+	Status           StatusCmd           `cmd:"" help:"Show the issuer, audience, scopes and expiry of the current token and the kubeconfig contexts created by nctl."`
 	PrintAccessToken PrintAccessTokenCmd `cmd:"" help:"Print short-lived access token to authenticate against the API to stdout and exit."`
 }
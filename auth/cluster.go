@@ -18,6 +18,9 @@ import (
 type ClusterCmd struct {
 	Name       string `arg:"" help:"Name of the cluster to authenticate with. Also accepts 'name/project' format."`
 	ExecPlugin bool   `help:"Automatically run exec plugin after writing the kubeconfig."`
+	// ContextNameTemplate is also set on the login command, see LoginCmd,
+	// and on delete vcluster to remove the context again.
+	ContextNameTemplate string `help:"Go template for the name of the kubeconfig context, e.g. '{{.Org}}-{{.Project}}-{{.Name}}'. Defaults to name/project." env:"NCTL_CONTEXT_NAME_TEMPLATE" placeholder:"TEMPLATE"`
 }
 
 func (a *ClusterCmd) Run(ctx context.Context, client *api.Client) error {
@@ -56,12 +59,17 @@ func (a *ClusterCmd) Run(ctx context.Context, client *api.Client) error {
 		return fmt.Errorf("can not identify executable path of %s: %w", util.NctlName, err)
 	}
 
+	contextName, err := a.contextName(client, cluster)
+	if err != nil {
+		return err
+	}
+
 	cfg, err := newAPIConfig(
 		apiEndpoint,
 		issuerURL,
 		command,
 		cluster.Status.AtProvider.OIDCClientID,
-		overrideName(config.ContextName(cluster)),
+		overrideName(contextName),
 		setCACert(caCert),
 	)
 	if err != nil {
@@ -98,6 +106,18 @@ func (a *ClusterCmd) Run(ctx context.Context, client *api.Client) error {
 	return nil
 }
 
+// contextName returns the name of the kubeconfig context of the cluster.
+func (a *ClusterCmd) contextName(client *api.Client, cluster *infrastructure.KubernetesCluster) (string, error) {
+	if a.ContextNameTemplate == "" {
+		return config.ContextName(cluster), nil
+	}
+	org, err := client.Organization()
+	if err != nil {
+		return "", err
+	}
+	return config.ClusterContextName(a.ContextNameTemplate, org, cluster)
+}
+
 func clusterName(name, project string) (types.NamespacedName, error) {
 	parts := strings.Split(name, "/")
 	if len(parts) == 2 {
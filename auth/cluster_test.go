@@ -69,6 +69,27 @@ func TestClusterCmd(t *testing.T) {
 	checkConfig(t, merged, 2, config.ContextName(cluster))
 }
 
+func TestClusterCmdContextNameTemplate(t *testing.T) {
+	cluster := newCluster()
+	apiClient, err := test.SetupClient(
+		test.WithObjects(cluster),
+		test.WithOrganization("evilcorp"),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	cmd := &ClusterCmd{Name: config.ContextName(cluster), ContextNameTemplate: "{{.Org}}-{{.Project}}-{{.Name}}"}
+	require.NoError(t, cmd.Run(context.TODO(), apiClient))
+
+	kubeconfig, err := clientcmd.LoadFromFile(apiClient.KubeconfigPath)
+	require.NoError(t, err)
+	require.Contains(t, kubeconfig.Contexts, "evilcorp-test-test")
+	require.Equal(t, "evilcorp-test-test", kubeconfig.CurrentContext)
+
+	cmd.ContextNameTemplate = "{{.Cluster}}"
+	require.ErrorContains(t, cmd.Run(context.TODO(), apiClient), "invalid context name template")
+}
+
 func newCluster() *infrastructure.KubernetesCluster {
 	return &infrastructure.KubernetesCluster{
 		ObjectMeta: metav1.ObjectMeta{
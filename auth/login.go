@@ -21,8 +21,9 @@ type LoginCmd struct {
 	APIURL                      string `help:"The URL of the Nine API" default:"https://nineapis.ch" env:"NCTL_API_URL" name:"api-url"`
 	APIToken                    string `help:"Use a static API token instead of using an OIDC login. You need to specify the --organization parameter as well." env:"NCTL_API_TOKEN"`
 	Organization                string `help:"The name of your organization to use when providing an API token. This parameter is only used when providing a API token. This parameter needs to be set if you use --api-token." env:"NCTL_ORGANIZATION"`
-	IssuerURL                   string `help:"Issuer URL is the OIDC issuer URL of the API." default:"https://auth.nine.ch/auth/realms/pub"`
+	IssuerURL                   string `help:"Issuer URL is the OIDC issuer URL of the API." default:"https://auth.nine.ch/auth/realms/pub" env:"NCTL_ISSUER_URL"`
 	ClientID                    string `help:"Client ID is the OIDC client ID of the API." default:"nineapis.ch-f178254"`
+	ContextNameTemplate         string `help:"Go template for the name of the kubeconfig context of the API, e.g. '{{.Org}}-{{.Name}}'. The project is the default project of the organization and the name the host of the API. Other contexts than the default need to be selected with --api-context." env:"NCTL_CONTEXT_NAME_TEMPLATE" placeholder:"TEMPLATE"`
 	ForceInteractiveEnvOverride bool   `help:"Used for internal purposes only. Set to true to force interactive environment explicit override. Set to false to fall back to automatic interactivity detection." default:"false" hidden:""`
 }
 
@@ -54,7 +55,12 @@ func (l *LoginCmd) Run(ctx context.Context, command string, tk api.TokenGetter)
 			return err
 		}
 
-		cfg, err := newAPIConfig(apiURL, issuerURL, command, l.ClientID, useStaticToken(l.APIToken), withOrganization(l.Organization))
+		name, err := l.contextName(apiURL, l.Organization)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := newAPIConfig(apiURL, issuerURL, command, l.ClientID, useStaticToken(l.APIToken), withOrganization(l.Organization), overrideName(name))
 		if err != nil {
 			return err
 		}
@@ -89,7 +95,12 @@ func (l *LoginCmd) Run(ctx context.Context, command string, tk api.TokenGetter)
 		printAvailableOrgsString(org, userInfo.Orgs)
 	}
 
-	cfg, err := newAPIConfig(apiURL, issuerURL, command, l.ClientID, withOrganization(org))
+	name, err := l.contextName(apiURL, org)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := newAPIConfig(apiURL, issuerURL, command, l.ClientID, withOrganization(org), overrideName(name))
 	if err != nil {
 		return err
 	}
@@ -97,6 +108,23 @@ func (l *LoginCmd) Run(ctx context.Context, command string, tk api.TokenGetter)
 	return login(ctx, cfg, loadingRules.GetDefaultFilename(), userInfo.User, "", project(org))
 }
 
+// contextName returns the name of the kubeconfig context of the API. If it
+// differs from the default, a hint on how to use it is printed.
+func (l *LoginCmd) contextName(apiURL *url.URL, org string) (string, error) {
+	name, err := config.ContextNameFromTemplate(
+		l.ContextNameTemplate,
+		config.ContextNameData{Org: org, Project: org, Name: apiURL.Host},
+		apiURL.Host,
+	)
+	if err != nil {
+		return "", err
+	}
+	if name != apiURL.Host {
+		fmt.Printf("use the context %q with: nctl --api-context %s <command>\n", name, name)
+	}
+	return name, nil
+}
+
 type apiConfig struct {
 	name         string
 	token        string
@@ -2,27 +2,22 @@ package auth
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/gob"
-	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/int128/kubelogin/pkg/tokencache"
-	"github.com/int128/kubelogin/pkg/tokencache/repository"
 
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/internal/format"
-	"k8s.io/client-go/util/homedir"
 )
 
 type LogoutCmd struct {
 	APIURL    string `help:"The URL of the Nine API" default:"https://nineapis.ch" env:"NCTL_API_URL" name:"api-url"`
-	IssuerURL string `help:"Issuer URL is the OIDC issuer URL of the API." default:"https://auth.nine.ch/auth/realms/pub"`
+	IssuerURL string `help:"Issuer URL is the OIDC issuer URL of the API." default:"https://auth.nine.ch/auth/realms/pub" env:"NCTL_ISSUER_URL"`
 	ClientID  string `help:"Client ID is the OIDC client ID of the API." default:"nineapis.ch-f178254"`
 }
 
@@ -32,19 +27,18 @@ func (l *LogoutCmd) Run(ctx context.Context, command string, tk api.TokenGetter)
 		IssuerURL: l.IssuerURL,
 	}
 
-	filename, err := computeFilename(key)
+	cacheDir := api.TokenCacheDir()
+	filename, err := api.TokenCacheFilename(key)
 	if err != nil {
 		return err
 	}
-	filePath := path.Join(homedir.HomeDir(), api.DefaultTokenCachePath, filename)
 
-	if _, err = os.Stat(filePath); err != nil {
+	if _, err = os.Stat(filepath.Join(cacheDir, filename)); err != nil {
 		format.PrintFailuref("🤔", "seems like you are already logged out from %s", l.APIURL)
 		return nil
 	}
 
-	r := repository.Repository{}
-	cache, err := r.FindByKey(path.Join(homedir.HomeDir(), api.DefaultTokenCachePath), key)
+	cache, err := api.TokenCacheRepository().FindByKey(cacheDir, key)
 	if err != nil {
 		return fmt.Errorf("error finding cache file: %w", err)
 	}
@@ -79,7 +73,7 @@ func (l *LogoutCmd) Run(ctx context.Context, command string, tk api.TokenGetter)
 		return fmt.Errorf("http request error %d to %s", resp.StatusCode, logoutEndpoint)
 	}
 
-	if err := os.Remove(filePath); err != nil {
+	if err := api.ClearTokenCache(cacheDir, l.IssuerURL, l.ClientID); err != nil {
 		return fmt.Errorf("error removing the local cache: %w", err)
 	}
 
@@ -87,15 +81,3 @@ func (l *LogoutCmd) Run(ctx context.Context, command string, tk api.TokenGetter)
 
 	return nil
 }
-
-// This function is copied from kubelogin to find out the cache filename
-// See: github.com/int128/kubelogin/pkg/tokencache/repository
-func computeFilename(key tokencache.Key) (string, error) {
-	s := sha256.New()
-	e := gob.NewEncoder(s)
-	if err := e.Encode(&key); err != nil {
-		return "", fmt.Errorf("could not encode the key: %w", err)
-	}
-	h := hex.EncodeToString(s.Sum(nil))
-	return h, nil
-}
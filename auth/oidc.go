@@ -2,23 +2,76 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/url"
+	"os"
+	"strings"
 
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+// OIDCCmd is the credential plugin which is written to the kubeconfigs
+// created by nctl. Its flags are therefore a stable interface and must stay
+// compatible, otherwise existing kubeconfigs break with an nctl upgrade.
 type OIDCCmd struct {
-	IssuerURL string
-	ClientID  string
-	UsePKCE   bool
+	IssuerURL string `help:"URL of the OIDC issuer."`
+	ClientID  string `help:"OIDC client ID."`
+	UsePKCE   bool   `help:"Use PKCE for the authorization code flow."`
+	// tokenCacheDir is the directory of the token cache, it defaults to
+	// the kubelogin cache directory.
+	tokenCacheDir string
+	getToken      func(ctx context.Context, issuerURL, clientID string, usePKCE bool, out io.Writer) error
+	interactive   func() bool
 }
 
 const OIDCCmdName = "auth oidc"
 
 func (o *OIDCCmd) Run(ctx context.Context, out io.Writer) error {
-	return api.GetToken(ctx, o.IssuerURL, o.ClientID, o.UsePKCE, out)
+	if o.getToken == nil {
+		o.getToken = api.GetToken
+	}
+	if o.tokenCacheDir == "" {
+		o.tokenCacheDir = api.TokenCacheDir()
+	}
+	if o.interactive == nil {
+		// stdout is read by kubectl, so only stdin and stderr need to be
+		// terminals.
+		o.interactive = func() bool {
+			return format.IsInteractiveEnvironment(os.Stdin) && format.IsInteractiveEnvironment(os.Stderr)
+		}
+	}
+
+	err := o.getToken(ctx, o.IssuerURL, o.ClientID, o.UsePKCE, out)
+	if err == nil || !isRevokedLogin(err) {
+		return err
+	}
+
+	// the cached token can't be used anymore, so it is removed to make
+	// sure the next attempt starts a new login.
+	if err := api.ClearTokenCache(o.tokenCacheDir, o.IssuerURL, o.ClientID); err != nil {
+		return fmt.Errorf("unable to clear the token cache: %w", err)
+	}
+
+	if o.interactive() {
+		fmt.Fprintf(os.Stderr, "Your login to %s has expired or was revoked. Log in again now? [y|n]: ", o.IssuerURL)
+		var input string
+		if _, scanErr := fmt.Scanln(&input); scanErr == nil && (strings.EqualFold(input, "y") || strings.EqualFold(input, "yes")) {
+			return o.getToken(ctx, o.IssuerURL, o.ClientID, o.UsePKCE, out)
+		}
+	}
+
+	return fmt.Errorf("your login has expired or was revoked, please log in again with \"%s auth login\": %w", util.NctlName, err)
+}
+
+// isRevokedLogin returns true if the error is caused by a refresh token which
+// is not valid anymore or an unusable token cache.
+func isRevokedLogin(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "invalid_grant") || strings.Contains(msg, "invalid token cache")
 }
 
 // execConfig returns an *clientcmdapi.ExecConfig that can be used to login to
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/int128/kubelogin/pkg/tokencache"
+	"github.com/ninech/nctl/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestOIDCCmdRevokedLogin(t *testing.T) {
+	keyring.MockInit()
+	dir := t.TempDir()
+	filename, err := api.TokenCacheFilename(tokencache.Key{IssuerURL: "https://issuer.example.org", ClientID: "nctl"})
+	require.NoError(t, err)
+	cache := path.Join(dir, filename)
+	require.NoError(t, os.WriteFile(cache, []byte("{}"), 0o600))
+
+	calls := 0
+	cmd := &OIDCCmd{
+		IssuerURL:     "https://issuer.example.org",
+		ClientID:      "nctl",
+		tokenCacheDir: dir,
+		interactive:   func() bool { return false },
+		getToken: func(context.Context, string, string, bool, io.Writer) error {
+			calls++
+			return errors.New(`oauth2: "invalid_grant" "Token is not active"`)
+		},
+	}
+	err = cmd.Run(context.Background(), io.Discard)
+	assert.ErrorContains(t, err, "auth login")
+	assert.Equal(t, 1, calls)
+	assert.NoFileExists(t, cache)
+
+	// other errors are returned as is and keep the cache
+	require.NoError(t, os.WriteFile(cache, []byte("{}"), 0o600))
+	cmd.getToken = func(context.Context, string, string, bool, io.Writer) error {
+		return errors.New("connection refused")
+	}
+	assert.EqualError(t, cmd.Run(context.Background(), io.Discard), "connection refused")
+	assert.FileExists(t, cache)
+}
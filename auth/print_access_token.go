@@ -3,13 +3,63 @@ package auth
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
 	"github.com/ninech/nctl/api"
 )
 
-type PrintAccessTokenCmd struct{}
+type PrintAccessTokenCmd struct {
+	Cluster string `help:"Print an ExecCredential of this Kubernetes cluster instead of the API token, so it can be used as a kubectl credential plugin. Also accepts 'name/project' format." placeholder:"NAME"`
+	out     io.Writer
+	// getToken is used to get the ExecCredential of a cluster.
+	getToken func(ctx context.Context, issuerURL, clientID string, usePKCE bool, out io.Writer) error
+}
+
+func (o *PrintAccessTokenCmd) Help() string {
+	return `Without --cluster the access token of the API is printed.
+
+With --cluster the credentials of a Kubernetes cluster are printed as
+ExecCredential JSON, which makes nctl usable as kubectl credential plugin:
+
+  users:
+  - name: mycluster
+    user:
+      exec:
+        apiVersion: client.authentication.k8s.io/v1beta1
+        command: nctl
+        args: [auth, print-access-token, --cluster, mycluster/myproject]
+
+Tokens are cached in ~/` + api.DefaultTokenCachePath + ` and only refreshed once
+they expire. The flags of this command are stable, so kubeconfigs using it keep
+working across nctl upgrades.
+`
+}
 
 func (o *PrintAccessTokenCmd) Run(ctx context.Context, client *api.Client) error {
-	fmt.Println(client.Token(ctx))
-	return nil
+	if o.out == nil {
+		o.out = os.Stdout
+	}
+	if o.Cluster == "" {
+		fmt.Fprintln(o.out, client.Token(ctx))
+		return nil
+	}
+
+	name, err := clusterName(o.Cluster, client.Project)
+	if err != nil {
+		return err
+	}
+	cluster := &infrastructure.KubernetesCluster{}
+	if err := client.Get(ctx, name, cluster); err != nil {
+		return err
+	}
+	if cluster.Status.AtProvider.OIDCIssuerURL == "" || cluster.Status.AtProvider.OIDCClientID == "" {
+		return fmt.Errorf("cluster %s is not ready for authentication yet", name.Name)
+	}
+
+	if o.getToken == nil {
+		o.getToken = api.GetToken
+	}
+	return o.getToken(ctx, cluster.Status.AtProvider.OIDCIssuerURL, cluster.Status.AtProvider.OIDCClientID, true, o.out)
 }
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrintAccessToken(t *testing.T) {
+	ctx := context.Background()
+	cluster := &infrastructure.KubernetesCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "other"},
+		Status: infrastructure.KubernetesClusterStatus{
+			AtProvider: infrastructure.KubernetesClusterObservation{
+				ClusterObservation: infrastructure.ClusterObservation{
+					OIDCIssuerURL: "https://issuer.example.org",
+					OIDCClientID:  "mycluster",
+				},
+			},
+		},
+	}
+	pending := &infrastructure.KubernetesCluster{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: test.DefaultProject}}
+	apiClient, err := test.SetupClient(test.WithObjects(cluster, pending))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := &PrintAccessTokenCmd{out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Equal(t, test.FakeJWTToken+"\n", out.String())
+
+	out.Reset()
+	cmd = &PrintAccessTokenCmd{
+		Cluster: "mycluster/other",
+		out:     out,
+		getToken: func(_ context.Context, issuerURL, clientID string, usePKCE bool, w io.Writer) error {
+			assert.Equal(t, "https://issuer.example.org", issuerURL)
+			assert.Equal(t, "mycluster", clientID)
+			assert.True(t, usePKCE)
+			_, err := io.WriteString(w, `{"kind":"ExecCredential"}`)
+			return err
+		},
+	}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Equal(t, `{"kind":"ExecCredential"}`, out.String())
+
+	cmd = &PrintAccessTokenCmd{Cluster: "pending", out: out}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "not ready")
+}
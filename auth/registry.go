@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	// registryUsernameKey and registryPasswordKey are the keys of the basic
+	// auth credentials in the connection secret of a registry.
+	registryUsernameKey = "username"
+	registryPasswordKey = "password"
+)
+
+// dockerLookPath allows to replace the lookup of the docker binary in tests.
+var dockerLookPath = osexec.LookPath
+
+type RegistryCmd struct {
+	Name        string `arg:"" predictor:"resource_name" help:"Name of the registry."`
+	Print       bool   `xor:"mode" help:"Print the credentials instead of logging in."`
+	WriteConfig bool   `xor:"mode" help:"Write the credentials to the docker config file instead of running \"docker login\". Useful if docker is not installed, e.g. when building images with other tools."`
+	out         io.Writer
+	// dockerConfig is the path of the docker config file, it is determined
+	// from the environment if empty.
+	dockerConfig string
+}
+
+func (cmd *RegistryCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	registry := &storage.Registry{}
+	if err := client.Get(ctx, client.Name(cmd.Name), registry); err != nil {
+		return err
+	}
+	if registry.Status.AtProvider.URL == "" {
+		return fmt.Errorf("registry %q is not ready yet, it has no URL", cmd.Name)
+	}
+	host, err := registryHost(registry.Status.AtProvider.URL)
+	if err != nil {
+		return err
+	}
+
+	secret, err := client.GetConnectionSecret(ctx, registry)
+	if err != nil {
+		return fmt.Errorf("unable to get credentials of registry %q: %w", cmd.Name, err)
+	}
+	username, password := string(secret.Data[registryUsernameKey]), string(secret.Data[registryPasswordKey])
+	if username == "" || password == "" {
+		return fmt.Errorf("connection secret of registry %q contains no credentials", cmd.Name)
+	}
+
+	switch {
+	case cmd.Print:
+		fmt.Fprintf(cmd.out, "Registry: %s\n", host)
+		fmt.Fprintf(cmd.out, "Username: %s\n", username)
+		fmt.Fprintf(cmd.out, "Password: %s\n", password)
+		return nil
+	case cmd.WriteConfig:
+		path, err := cmd.dockerConfigPath()
+		if err != nil {
+			return err
+		}
+		if err := writeDockerAuth(path, host, username, password); err != nil {
+			return err
+		}
+		format.PrintSuccessf("🔑", "added credentials for %s to %s", host, path)
+		return nil
+	}
+
+	path, err := dockerLookPath("docker")
+	if err != nil {
+		return fmt.Errorf("docker CLI not found, use --write-config to write the credentials without it: %w", err)
+	}
+	login := osexec.CommandContext(ctx, path, "login", host, "--username", username, "--password-stdin")
+	login.Stdin, login.Stdout, login.Stderr = strings.NewReader(password), cmd.out, os.Stderr
+	return login.Run()
+}
+
+func (cmd *RegistryCmd) dockerConfigPath() (string, error) {
+	if cmd.dockerConfig != "" {
+		return cmd.dockerConfig, nil
+	}
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find docker config: %w", err)
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// registryHost returns the host of the registry URL as docker expects it.
+func registryHost(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid registry URL %q: %w", raw, err)
+	}
+	if u.Host == "" {
+		return raw, nil
+	}
+	return u.Host, nil
+}
+
+// writeDockerAuth adds the credentials of the host to the "auths" of the
+// docker config at path, keeping all other settings.
+func writeDockerAuth(path, host, username, password string) error {
+	config := map[string]any{}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if len(content) != 0 {
+		if err := json.Unmarshal(content, &config); err != nil {
+			return fmt.Errorf("unable to parse docker config %s: %w", path, err)
+		}
+	}
+
+	auths, _ := config["auths"].(map[string]any)
+	if auths == nil {
+		auths = map[string]any{}
+	}
+	auths[host] = map[string]any{
+		"auth": base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+	config["auths"] = auths
+
+	content, err = json.MarshalIndent(config, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
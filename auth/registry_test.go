@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := &storage.Registry{
+		ObjectMeta: metav1.ObjectMeta{Name: "images", Namespace: test.DefaultProject},
+		Spec: storage.RegistrySpec{ResourceSpec: runtimev1.ResourceSpec{
+			WriteConnectionSecretToReference: &runtimev1.SecretReference{Name: "registry-images", Namespace: test.DefaultProject},
+		}},
+		Status: storage.RegistryStatus{AtProvider: storage.RegistryObservation{URL: "https://images.example.org"}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-images", Namespace: test.DefaultProject},
+		Data: map[string][]byte{
+			registryUsernameKey: []byte("robot"),
+			registryPasswordKey: []byte("topsecret"),
+		},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(registry, secret))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := &RegistryCmd{Name: "images", Print: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Contains(t, out.String(), "Registry: images.example.org")
+	assert.Contains(t, out.String(), "robot")
+	assert.Contains(t, out.String(), "topsecret")
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"credsStore":"desktop","auths":{"other.example.org":{}}}`), 0o600))
+	cmd = &RegistryCmd{Name: "images", WriteConfig: true, out: &bytes.Buffer{}, dockerConfig: configPath}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	config := struct {
+		CredsStore string                       `json:"credsStore"`
+		Auths      map[string]map[string]string `json:"auths"`
+	}{}
+	require.NoError(t, json.Unmarshal(content, &config))
+	assert.Equal(t, "desktop", config.CredsStore)
+	assert.Contains(t, config.Auths, "other.example.org")
+	assert.Equal(t, "cm9ib3Q6dG9wc2VjcmV0", config.Auths["images.example.org"]["auth"])
+
+	dockerLookPath = func(string) (string, error) { return "", errors.New("not found") }
+	cmd = &RegistryCmd{Name: "images", out: &bytes.Buffer{}}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "docker CLI not found")
+}
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/config"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type StatusCmd struct {
+	out io.Writer
+}
+
+func (s *StatusCmd) Help() string {
+	return `Shows the claims of the current API token and the kubeconfig contexts
+which have been created by nctl. This helps to find out why a request is
+unauthorized, e.g. because the token has expired or lacks an audience.`
+}
+
+func (s *StatusCmd) Run(ctx context.Context, client *api.Client) error {
+	if s.out == nil {
+		s.out = os.Stdout
+	}
+
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	claims, tokenErr := currentClaims(ctx, client)
+	if tokenErr != nil {
+		fmt.Fprintf(w, "Token:\t%s\n", tokenErr)
+	} else {
+		fmt.Fprintf(w, "Subject:\t%s\n", claims.subject)
+		fmt.Fprintf(w, "Issuer:\t%s\n", claims.issuer)
+		fmt.Fprintf(w, "Audience:\t%s\n", strings.Join(claims.audience, ", "))
+		if claims.authorizedParty != "" {
+			fmt.Fprintf(w, "Client:\t%s\n", claims.authorizedParty)
+		}
+		fmt.Fprintf(w, "Scopes:\t%s\n", strings.Join(claims.scopes, ", "))
+		if !claims.issuedAt.IsZero() {
+			fmt.Fprintf(w, "Issued:\t%s\n", format.Timestamp(claims.issuedAt))
+		}
+		fmt.Fprintf(w, "Expires:\t%s\n", expiry(claims.expiresAt, time.Now()))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if err := s.printContexts(client); err != nil {
+		return err
+	}
+	if tokenErr != nil {
+		return fmt.Errorf("unable to get a token, please log in with \"%s auth login\"", util.NctlName)
+	}
+	return nil
+}
+
+func (s *StatusCmd) printContexts(client *api.Client) error {
+	contexts, err := nctlContexts(client.KubeconfigPath, client.KubeconfigContext)
+	if err != nil {
+		return err
+	}
+	if len(contexts) == 0 {
+		return nil
+	}
+	fmt.Fprintf(s.out, "\nKubeconfig contexts created by %s (%s):\n", util.NctlName, client.KubeconfigPath)
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tCONTEXT\tSERVER\tAUTH")
+	for _, c := range contexts {
+		marker := ""
+		if c.current {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, c.name, c.server, c.auth)
+	}
+	return w.Flush()
+}
+
+// currentClaims returns the claims of the current API token. The error
+// describes why there is no valid token, e.g. if the login has expired.
+func currentClaims(ctx context.Context, client *api.Client) (*tokenClaims, error) {
+	if client.Config == nil {
+		return nil, errors.New("no API config found")
+	}
+	token, err := api.GetTokenFromConfig(ctx, client.Config)
+	if err != nil {
+		return nil, err
+	}
+	return parseTokenClaims(token)
+}
+
+type tokenClaims struct {
+	subject         string
+	issuer          string
+	audience        []string
+	authorizedParty string
+	scopes          []string
+	issuedAt        time.Time
+	expiresAt       time.Time
+}
+
+func parseTokenClaims(token string) (*tokenClaims, error) {
+	mapClaims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, mapClaims); err != nil {
+		return nil, fmt.Errorf("failed to parse JWT token: %w", err)
+	}
+
+	claims := &tokenClaims{
+		subject:         stringClaim(mapClaims, "email"),
+		issuer:          stringClaim(mapClaims, "iss"),
+		authorizedParty: stringClaim(mapClaims, "azp"),
+		scopes:          strings.Fields(stringClaim(mapClaims, "scope")),
+		issuedAt:        timeClaim(mapClaims, "iat"),
+		expiresAt:       timeClaim(mapClaims, "exp"),
+	}
+	if claims.subject == "" {
+		claims.subject = stringClaim(mapClaims, "sub")
+	}
+	// the audience can either be a single string or a list of them.
+	switch aud := mapClaims["aud"].(type) {
+	case string:
+		claims.audience = []string{aud}
+	case []any:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				claims.audience = append(claims.audience, s)
+			}
+		}
+	}
+	return claims, nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+func timeClaim(claims jwt.MapClaims, name string) time.Time {
+	seconds, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(seconds), 0)
+}
+
+// expiry describes when the token expires relative to now.
+func expiry(expiresAt, now time.Time) string {
+	if expiresAt.IsZero() {
+		return "never"
+	}
+	if expiresAt.Before(now) {
+		return fmt.Sprintf("%s (expired %s ago)", format.Timestamp(expiresAt), duration.HumanDuration(now.Sub(expiresAt)))
+	}
+	return fmt.Sprintf("%s (in %s)", format.Timestamp(expiresAt), duration.HumanDuration(expiresAt.Sub(now)))
+}
+
+type kubeconfigContext struct {
+	name    string
+	server  string
+	auth    string
+	current bool
+}
+
+// nctlContexts returns the contexts of the kubeconfig which have been created
+// by nctl. These are the API context and the contexts using nctl as
+// credential plugin.
+func nctlContexts(kubeconfigPath, apiContext string) ([]kubeconfigContext, error) {
+	if kubeconfigPath == "" {
+		return nil, nil
+	}
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+
+	contexts := []kubeconfigContext{}
+	for name, c := range kubeconfig.Contexts {
+		_, hasExtension := c.Extensions[config.NctlExtensionContext]
+		authInfo := kubeconfig.AuthInfos[c.AuthInfo]
+		auth := authMethod(authInfo)
+		if !hasExtension && name != apiContext && auth != util.NctlName {
+			continue
+		}
+		kc := kubeconfigContext{name: name, auth: auth, current: name == kubeconfig.CurrentContext}
+		if cluster, ok := kubeconfig.Clusters[c.Cluster]; ok {
+			kc.server = cluster.Server
+		}
+		contexts = append(contexts, kc)
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].name < contexts[j].name })
+	return contexts, nil
+}
+
+// authMethod describes how the auth info authenticates.
+func authMethod(authInfo *clientcmdapi.AuthInfo) string {
+	switch {
+	case authInfo == nil:
+		return "none"
+	case authInfo.Exec != nil:
+		if strings.TrimSuffix(filepath.Base(authInfo.Exec.Command), ".exe") == util.NctlName &&
+			len(authInfo.Exec.Args) > 0 && authInfo.Exec.Args[0] == "auth" &&
+			(slices.Contains(authInfo.Exec.Args, "oidc") || slices.Contains(authInfo.Exec.Args, "print-access-token")) {
+			return util.NctlName
+		}
+		return "exec " + filepath.Base(authInfo.Exec.Command)
+	case authInfo.Token != "" || authInfo.TokenFile != "":
+		return "static token"
+	case authInfo.ClientCertificate != "" || len(authInfo.ClientCertificateData) > 0:
+		return "client certificate"
+	default:
+		return "none"
+	}
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestStatusCmd(t *testing.T) {
+	apiClient, err := test.SetupClient(test.WithKubeconfig(t))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, (&StatusCmd{out: out}).Run(context.Background(), apiClient))
+
+	assert.Regexp(t, `Subject:\s+jrocket@example.com`, out.String())
+	assert.Regexp(t, `Issuer:\s+Online JWT Builder`, out.String())
+	assert.Regexp(t, `Audience:\s+www.example.com`, out.String())
+	assert.Regexp(t, `Expires:\s+2134-07-1\dT.* \(in `, out.String())
+	assert.Contains(t, out.String(), apiClient.KubeconfigContext)
+
+	// the reason for a missing token is shown, the contexts are still
+	// listed.
+	out.Reset()
+	apiClient.Config = &rest.Config{}
+	assert.Error(t, (&StatusCmd{out: out}).Run(context.Background(), apiClient))
+	assert.Regexp(t, `Token:\s+config does not contain execProvider`, out.String())
+	assert.Contains(t, out.String(), apiClient.KubeconfigContext)
+
+	out.Reset()
+	apiClient.Config = &rest.Config{BearerToken: "not-a-jwt"}
+	assert.Error(t, (&StatusCmd{out: out}).Run(context.Background(), apiClient))
+	assert.Regexp(t, `Token:\s+failed to parse JWT token`, out.String())
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2025-01-01T12:05:00Z (in 5m)", expiry(now.Add(5*time.Minute), now))
+	assert.Equal(t, "2025-01-01T09:00:00Z (expired 3h ago)", expiry(now.Add(-3*time.Hour), now))
+	assert.Equal(t, "never", expiry(time.Time{}, now))
+}
+
+func TestAuthMethod(t *testing.T) {
+	for want, authInfo := range map[string]*clientcmdapi.AuthInfo{
+		"nctl":         {Exec: &clientcmdapi.ExecConfig{Command: "/usr/local/bin/nctl", Args: []string{"auth", "oidc", "--use-pkce"}}},
+		"exec kubectl": {Exec: &clientcmdapi.ExecConfig{Command: "kubectl", Args: []string{"oidc-login"}}},
+		"static token": {Token: "abc"},
+		"none":         nil,
+	} {
+		assert.Equal(t, want, authMethod(authInfo))
+	}
+}
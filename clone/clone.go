@@ -0,0 +1,199 @@
+package clone
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	secretsPrompt = "prompt"
+	secretsCopy   = "copy"
+	secretsSkip   = "skip"
+)
+
+type Cmd struct {
+	Project projectCmd `cmd:"" aliases:"proj" help:"Clone all resources of a project into another project."`
+}
+
+type projectCmd struct {
+	Source      string   `arg:"" predictor:"resource_name" help:"Name of the project to clone."`
+	Destination string   `arg:"" help:"Name of the project the resources are created in. The project needs to exist already."`
+	Kinds       []string `help:"Only clone resources of these kinds."`
+	Secrets     string   `enum:"prompt,copy,skip" default:"prompt" help:"How to handle secrets like git credentials of applications: ask for each secret, copy all or skip all. ${enum}"`
+	DryRun      bool     `help:"Only print the resources which would be created."`
+	out         io.Writer
+	// confirm asks if a secret should be copied, defaults to format.Confirmf.
+	confirm func(format string, a ...any) (bool, error)
+}
+
+func (cmd *projectCmd) Help() string {
+	return "Exports all resources of the source project and creates them in the destination\n" +
+		"project, e.g. to set up a staging project with the same resources as production.\n" +
+		"Status and generated resources like builds and releases are not copied. Secrets\n" +
+		"are only copied after confirmation, see --secrets. To clone into a project of\n" +
+		"another organization, switch to that organization with \"nctl auth set-org\" first."
+}
+
+func (cmd *projectCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.confirm == nil {
+		cmd.confirm = format.Confirmf
+	}
+	if cmd.Source == cmd.Destination {
+		return fmt.Errorf("source and destination project need to be different")
+	}
+
+	if err := cmd.checkDestination(ctx, client); err != nil {
+		return err
+	}
+
+	items, warnings, err := client.ProjectResources(ctx, []string{cmd.Source}, cmd.Kinds, false)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		format.PrintWarningf("%s\n", w)
+	}
+
+	created, skipped := 0, 0
+	for _, item := range items {
+		if api.Generated(item) {
+			continue
+		}
+
+		obj, err := cmd.clone(item)
+		if err != nil {
+			return err
+		}
+
+		if cmd.DryRun {
+			fmt.Fprintf(cmd.out, "would create %s %q\n", strings.ToLower(obj.GetKind()), obj.GetName())
+			continue
+		}
+
+		if err := cmd.cloneSecrets(ctx, client, item); err != nil {
+			return err
+		}
+
+		if err := client.Create(ctx, obj); err != nil {
+			if kerrors.IsAlreadyExists(err) {
+				fmt.Fprintf(cmd.out, "%s %q already exists in project %s, skipping\n", strings.ToLower(obj.GetKind()), obj.GetName(), cmd.Destination)
+				skipped++
+				continue
+			}
+			return fmt.Errorf("unable to create %s %q: %w", strings.ToLower(obj.GetKind()), obj.GetName(), err)
+		}
+		fmt.Fprintln(cmd.out, format.SuccessMessagef("📋", "created %s %q", strings.ToLower(obj.GetKind()), obj.GetName()))
+		created++
+	}
+
+	if !cmd.DryRun {
+		fmt.Fprintf(cmd.out, "cloned %d resources from project %s to %s, %d already existed\n", created, cmd.Source, cmd.Destination, skipped)
+	}
+	return nil
+}
+
+// checkDestination ensures the destination project exists as we can't
+// create any resources otherwise.
+func (cmd *projectCmd) checkDestination(ctx context.Context, client *api.Client) error {
+	projects, err := client.Projects(ctx, cmd.Destination)
+	if err != nil {
+		return err
+	}
+	if len(projects) != 0 {
+		return nil
+	}
+	org, err := client.Organization()
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf(
+		"project %q does not exist in organization %s, create it first with \"nctl create project %s\"",
+		cmd.Destination, org, cmd.Destination,
+	)
+}
+
+// clone returns an exported copy of the object placed in the destination
+// project.
+func (cmd *projectCmd) clone(item *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	obj, err := format.ExportObject(item)
+	if err != nil {
+		return nil, err
+	}
+	obj.SetNamespace(cmd.Destination)
+	// the project config is named after the project it belongs to.
+	if item.GroupVersionKind() == apps.ProjectConfigGroupVersionKind {
+		obj.SetName(cmd.Destination)
+	}
+	return obj, nil
+}
+
+// cloneSecrets copies the secrets referenced by the item to the destination
+// project, depending on the secrets mode after asking for confirmation.
+func (cmd *projectCmd) cloneSecrets(ctx context.Context, client *api.Client, item *unstructured.Unstructured) error {
+	name := gitAuthSecret(item)
+	if name == "" || cmd.Secrets == secretsSkip {
+		return nil
+	}
+
+	if cmd.Secrets == secretsPrompt {
+		ok, err := cmd.confirm("application %q uses git credentials, copy them to project %s?", item.GetName(), cmd.Destination)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			format.PrintWarningf("git credentials of application %q not copied, the build will fail until they are added\n", item.GetName())
+			return nil
+		}
+	}
+
+	source := &corev1.Secret{}
+	if err := client.Get(ctx, api.NamespacedName(name, cmd.Source), source); err != nil {
+		return fmt.Errorf("unable to get git credentials of application %q: %w", item.GetName(), err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   cmd.Destination,
+			Labels:      source.Labels,
+			Annotations: map[string]string{util.ManagedByAnnotation: util.NctlName},
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+	if err := client.Create(ctx, secret); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("unable to copy git credentials of application %q: %w", item.GetName(), err)
+	}
+	return nil
+}
+
+// gitAuthSecret returns the name of the secret containing the git
+// credentials if the item is an application which references one.
+func gitAuthSecret(item *unstructured.Unstructured) string {
+	if item.GroupVersionKind() != apps.SchemeGroupVersion.WithKind(apps.ApplicationKind) {
+		return ""
+	}
+	app := &apps.Application{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, app); err != nil {
+		return ""
+	}
+	if app.Spec.ForProvider.Git.Auth == nil || app.Spec.ForProvider.Git.Auth.FromSecret == nil {
+		return ""
+	}
+	return app.Spec.ForProvider.Git.Auth.FromSecret.Name
+}
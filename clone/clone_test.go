@@ -0,0 +1,118 @@
+package clone
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	management "github.com/ninech/apis/management/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCloneProject(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) *api.Client {
+		app := &apps.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+			Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+				Git: apps.ApplicationGitConfig{
+					GitTarget: apps.GitTarget{URL: "https://git.example.org/web.git"},
+					Auth:      &apps.GitAuth{FromSecret: &meta.LocalReference{Name: "web"}},
+				},
+			}},
+		}
+		gitAuth := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+			Data:       map[string][]byte{util.PasswordSecretKey: []byte("secret")},
+		}
+		release := &apps.Release{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-release",
+				Namespace:       "prod",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: apps.SchemeGroupVersion.String(), Kind: apps.ApplicationKind, Name: "web"}},
+			},
+		}
+		config := &apps.ProjectConfig{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "prod"}}
+		pg := test.Postgres("db", "prod", "nine-es34")
+		pg.Status.AtProvider.FQDN = "db.example.org"
+
+		apiClient, err := test.SetupClient(
+			test.WithProjects("prod", "staging"),
+			test.WithObjects(app, gitAuth, release, config, pg),
+			test.WithNameIndexFor(&management.Project{}),
+			test.WithKubeconfig(t),
+		)
+		require.NoError(t, err)
+		return apiClient
+	}
+
+	t.Run("clone with secrets", func(t *testing.T) {
+		apiClient := setup(t)
+		asked := 0
+		cmd := &projectCmd{
+			Source: "prod", Destination: "staging", Secrets: secretsPrompt, out: &bytes.Buffer{},
+			confirm: func(string, ...any) (bool, error) { asked++; return true, nil },
+		}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+		assert.Equal(t, 1, asked)
+
+		pg := &storage.Postgres{}
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("db", "staging"), pg))
+		assert.Equal(t, meta.LocationName("nine-es34"), pg.Spec.ForProvider.Location)
+		assert.Empty(t, pg.Status.AtProvider.FQDN)
+
+		app := &apps.Application{}
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("web", "staging"), app))
+		secret := &corev1.Secret{}
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("web", "staging"), secret))
+		assert.Equal(t, "secret", string(secret.Data[util.PasswordSecretKey]))
+
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("staging", "staging"), &apps.ProjectConfig{}))
+
+		err := apiClient.Get(ctx, api.NamespacedName("web-release", "staging"), &apps.Release{})
+		assert.True(t, kerrors.IsNotFound(err), "releases should not be cloned")
+
+		// cloning again skips existing resources
+		require.NoError(t, cmd.Run(ctx, apiClient))
+	})
+
+	t.Run("skip secrets", func(t *testing.T) {
+		apiClient := setup(t)
+		cmd := &projectCmd{Source: "prod", Destination: "staging", Secrets: secretsSkip, out: &bytes.Buffer{}}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("web", "staging"), &apps.Application{}))
+		err := apiClient.Get(ctx, api.NamespacedName("web", "staging"), &corev1.Secret{})
+		assert.True(t, kerrors.IsNotFound(err), "secret should not be copied")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		apiClient := setup(t)
+		out := &bytes.Buffer{}
+		cmd := &projectCmd{Source: "prod", Destination: "staging", Secrets: secretsCopy, DryRun: true, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+		assert.Contains(t, out.String(), `would create postgres "db"`)
+
+		list := &storage.PostgresList{}
+		require.NoError(t, apiClient.List(ctx, list, client.InNamespace("staging")))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		apiClient := setup(t)
+		cmd := &projectCmd{Source: "prod", Destination: "missing", Secrets: secretsSkip, out: &bytes.Buffer{}}
+		assert.ErrorContains(t, cmd.Run(ctx, apiClient), "does not exist")
+	})
+}
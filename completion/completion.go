@@ -0,0 +1,230 @@
+package completion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/ninech/nctl/api/util"
+	"github.com/riywo/loginshell"
+	"k8s.io/client-go/util/homedir"
+)
+
+// CommandName is the name of the completion command.
+const CommandName = "completions"
+
+type Cmd struct {
+	Shell   string `arg:"" help:"The shell to print or install the completion for. Detected from the login shell if not set. ${enum}" enum:"bash,zsh,fish,powershell," default:""`
+	Code    bool   `short:"c" help:"Print the initialization code of the completion."`
+	Install bool   `help:"Add the completion to the init file of the shell."`
+	out     io.Writer
+	home    string
+	binPath string
+}
+
+func (cmd *Cmd) Help() string {
+	return `Prints how to activate tab completion for nctl in the given shell. To
+activate it permanently, run:
+
+  nctl completions --install
+
+This adds the activation to the init file of the shell, e.g. ~/.bashrc.`
+}
+
+type shell struct {
+	name string
+	// initCode registers the completion in the shell.
+	initCode string
+	// activation is added to the init file of the shell and loads the
+	// init code.
+	activation string
+	// initFile is the path of the init file relative to the home directory.
+	initFile string
+}
+
+var shells = map[string]shell{
+	"bash": {
+		name:       "bash",
+		initCode:   `complete -o default -o bashdefault -C {{.BinPath}} {{.BinName}}`,
+		activation: `source <({{.BinName}} {{.CmdName}} -c bash)`,
+		initFile:   ".bashrc",
+	},
+	"zsh": {
+		name: "zsh",
+		initCode: `autoload -U +X bashcompinit && bashcompinit
+complete -o default -o bashdefault -C {{.BinPath}} {{.BinName}}`,
+		activation: `source <({{.BinName}} {{.CmdName}} -c zsh)`,
+		initFile:   ".zshrc",
+	},
+	"fish": {
+		name: "fish",
+		initCode: `function __complete_{{.BinName}}
+    set -lx COMP_LINE (commandline -cp)
+    test -z (commandline -ct)
+    and set COMP_LINE "$COMP_LINE "
+    {{.BinPath}}
+end
+complete -f -c {{.BinName}} -a "(__complete_{{.BinName}})"`,
+		activation: `{{.BinName}} {{.CmdName}} -c fish | source`,
+		initFile:   filepath.Join(".config", "fish", "config.fish"),
+	},
+	"powershell": {
+		name: "powershell",
+		initCode: `Register-ArgumentCompleter -Native -CommandName '{{.BinName}}', '{{.BinName}}.exe' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $line = $commandAst.ToString().PadRight($cursorPosition - $commandAst.Extent.StartOffset).Substring(0, $cursorPosition - $commandAst.Extent.StartOffset)
+    $env:COMP_LINE = $line
+    & '{{.BinPath}}' | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+    Remove-Item Env:\COMP_LINE
+}`,
+		activation: `{{.BinName}} {{.CmdName}} -c powershell | Out-String | Invoke-Expression`,
+		initFile:   powershellProfile(runtime.GOOS, os.Getenv("PSModulePath")),
+	},
+}
+
+// powershellProfile returns the path of the PowerShell profile relative to the
+// home directory. On Windows, the profile of Windows PowerShell is used if
+// its modules come first in the module path, i.e. if it is the running
+// PowerShell.
+func powershellProfile(goos, modulePath string) string {
+	if goos != "windows" {
+		return filepath.Join(".config", "powershell", "Microsoft.PowerShell_profile.ps1")
+	}
+	dir := "PowerShell"
+	for _, p := range strings.Split(modulePath, ";") {
+		if strings.HasSuffix(p, `\WindowsPowerShell\Modules`) {
+			dir = "WindowsPowerShell"
+			break
+		}
+		if strings.HasSuffix(p, `\PowerShell\Modules`) {
+			break
+		}
+	}
+	return strings.Join([]string{"Documents", dir, "Microsoft.PowerShell_profile.ps1"}, `\`)
+}
+
+func (cmd *Cmd) Run() error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.home == "" {
+		cmd.home = homedir.HomeDir()
+	}
+	if cmd.binPath == "" {
+		bin, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("unable to determine the path of %s: %w", util.NctlName, err)
+		}
+		cmd.binPath = bin
+	}
+
+	sh, err := cmd.shell()
+	if err != nil {
+		return err
+	}
+
+	if cmd.Code {
+		code, err := cmd.render(sh.initCode)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.out, code)
+		return err
+	}
+
+	activation, err := cmd.render(sh.activation)
+	if err != nil {
+		return err
+	}
+	initFile := filepath.Join(cmd.home, sh.initFile)
+
+	if cmd.Install {
+		return cmd.install(initFile, activation)
+	}
+
+	fmt.Fprintf(cmd.out, "Execute the following command to activate tab completion for %s in %s:\n\n", util.NctlName, sh.name)
+	fmt.Fprintf(cmd.out, "    %s\n\n", activation)
+	fmt.Fprintf(cmd.out, "To activate it permanently, add the command to %s or run \"%s %s --install %s\".\n", initFile, util.NctlName, CommandName, sh.name)
+	return nil
+}
+
+// install adds the activation to the init file unless it is already there.
+func (cmd *Cmd) install(initFile, activation string) error {
+	content, err := os.ReadFile(initFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to read %s: %w", initFile, err)
+	}
+	if bytes.Contains(content, []byte(activation)) {
+		fmt.Fprintf(cmd.out, "The completion is already installed in %s.\n", initFile)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(initFile), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(initFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", initFile, err)
+	}
+	defer f.Close()
+
+	prefix := ""
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		prefix = "\n"
+	}
+	if _, err := fmt.Fprintf(f, "%s\n# %s completion\n%s\n", prefix, util.NctlName, activation); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.out, "Installed the completion in %s, it is active in new shell sessions.\n", initFile)
+	return nil
+}
+
+// shell returns the configured shell or detects it.
+func (cmd *Cmd) shell() (shell, error) {
+	name := cmd.Shell
+	switch {
+	case name != "":
+	case runtime.GOOS == "windows":
+		// the login shell is always reported as cmd on Windows, which has no
+		// completion support.
+		name = "powershell"
+	default:
+		detected, err := loginshell.Shell()
+		if err != nil {
+			return shell{}, errors.New("unable to detect your shell, please specify it")
+		}
+		name = filepath.Base(detected)
+	}
+	if name == "pwsh" {
+		name = "powershell"
+	}
+
+	sh, ok := shells[name]
+	if !ok {
+		return shell{}, fmt.Errorf("the shell %s is not supported", name)
+	}
+	return sh, nil
+}
+
+func (cmd *Cmd) render(text string) (string, error) {
+	t, err := template.New("").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	err = t.Execute(buf, struct{ BinName, BinPath, CmdName string }{
+		BinName: util.NctlName,
+		BinPath: cmd.binPath,
+		CmdName: CommandName,
+	})
+	return buf.String(), err
+}
@@ -0,0 +1,57 @@
+package completion
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode(t *testing.T) {
+	for name := range shells {
+		t.Run(name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &Cmd{Shell: name, Code: true, out: out, home: t.TempDir(), binPath: "/usr/local/bin/nctl"}
+			require.NoError(t, cmd.Run())
+			assert.Contains(t, out.String(), "/usr/local/bin/nctl")
+		})
+	}
+}
+
+func TestInstall(t *testing.T) {
+	home := t.TempDir()
+	bashrc := filepath.Join(home, ".bashrc")
+	require.NoError(t, os.WriteFile(bashrc, []byte("export EDITOR=vim"), 0o644))
+
+	cmd := &Cmd{Shell: "bash", Install: true, out: &bytes.Buffer{}, home: home, binPath: "/usr/local/bin/nctl"}
+	require.NoError(t, cmd.Run())
+	// installing twice doesn't add it again
+	require.NoError(t, cmd.Run())
+
+	content, err := os.ReadFile(bashrc)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "export EDITOR=vim\n"))
+	assert.Equal(t, 1, strings.Count(string(content), "source <(nctl completions -c bash)"))
+
+	// the init file and its directory are created if needed
+	cmd = &Cmd{Shell: "fish", Install: true, out: &bytes.Buffer{}, home: home, binPath: "/usr/local/bin/nctl"}
+	require.NoError(t, cmd.Run())
+	assert.FileExists(t, filepath.Join(home, ".config", "fish", "config.fish"))
+}
+
+func TestPowershellProfile(t *testing.T) {
+	assert.Equal(t, filepath.Join(".config", "powershell", "Microsoft.PowerShell_profile.ps1"), powershellProfile("linux", ""))
+	assert.Equal(t, `Documents\PowerShell\Microsoft.PowerShell_profile.ps1`, powershellProfile("windows", ""))
+	assert.Equal(t,
+		`Documents\PowerShell\Microsoft.PowerShell_profile.ps1`,
+		powershellProfile("windows", `C:\Users\dev\Documents\PowerShell\Modules;C:\Program Files\PowerShell\Modules;C:\Users\dev\Documents\WindowsPowerShell\Modules`),
+	)
+	assert.Equal(t,
+		`Documents\WindowsPowerShell\Microsoft.PowerShell_profile.ps1`,
+		powershellProfile("windows", `C:\Users\dev\Documents\WindowsPowerShell\Modules;C:\Program Files\WindowsPowerShell\Modules`),
+	)
+}
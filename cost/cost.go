@@ -0,0 +1,106 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"k8s.io/utils/ptr"
+)
+
+type Cmd struct {
+	App      appCmd      `cmd:"" aliases:"application,apps" help:"Estimate the monthly cost of a deplo.io Application."`
+	Postgres postgresCmd `cmd:"" aliases:"pg" help:"Estimate the monthly cost of a PostgreSQL instance."`
+	MySQL    mySQLCmd    `cmd:"" name:"mysql" help:"Estimate the monthly cost of a MySQL instance."`
+}
+
+// helpText explains where the prices come from.
+const helpText = "The prices are read from the price table configured with --price-table, a\n" +
+	"YAML or JSON file or URL like this:\n\n" +
+	"  currency: CHF\n" +
+	"  prices:\n" +
+	"    application:\n" +
+	"      micro: 8\n" +
+	"    postgres:\n" +
+	"      nine-db-s: 90"
+
+type appCmd struct {
+	Size     string `default:"${app_default_size}" help:"Size of the app."`
+	Replicas int    `default:"${app_default_replicas}" help:"Amount of replicas of the app."`
+	out      io.Writer
+}
+
+func (cmd *appCmd) Help() string {
+	return helpText
+}
+
+func (cmd *appCmd) Run(ctx context.Context, prices api.PriceSource) error {
+	return Print(ctx, prices, cmd.out, api.PriceApplication, cmd.Size, cmd.Replicas)
+}
+
+type postgresCmd struct {
+	MachineType string `default:"${postgres_machine_default}" help:"Machine type of the instance. Available types: ${postgres_machine_types}"`
+	out         io.Writer
+}
+
+func (cmd *postgresCmd) Help() string {
+	return helpText
+}
+
+func (cmd *postgresCmd) Run(ctx context.Context, prices api.PriceSource) error {
+	return Print(ctx, prices, cmd.out, api.PricePostgres, cmd.MachineType, 1)
+}
+
+type mySQLCmd struct {
+	MachineType string `default:"${mysql_machine_default}" help:"Machine type of the instance. Available types: ${mysql_machine_types}"`
+	out         io.Writer
+}
+
+func (cmd *mySQLCmd) Help() string {
+	return helpText
+}
+
+func (cmd *mySQLCmd) Run(ctx context.Context, prices api.PriceSource) error {
+	return Print(ctx, prices, cmd.out, api.PriceMySQL, cmd.MachineType, 1)
+}
+
+// PriceTabler returns a price table, it is implemented by the API client and
+// api.PriceSource.
+type PriceTabler interface {
+	PriceTable(ctx context.Context) (*api.PriceTable, error)
+}
+
+// Print prints the estimated monthly cost of count resources of the kind
+// and size to out, which defaults to stdout.
+func Print(ctx context.Context, prices PriceTabler, out io.Writer, kind, size string, count int) error {
+	if out == nil {
+		out = os.Stdout
+	}
+	table, err := prices.PriceTable(ctx)
+	if err != nil {
+		return err
+	}
+	estimate, err := table.Estimate(kind, size, count)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "estimated cost: %s\n", estimate)
+	return nil
+}
+
+// PrintApplication prints the estimated monthly cost of an application with
+// the given config. Unset values are estimated with the defaults.
+func PrintApplication(ctx context.Context, prices PriceTabler, out io.Writer, config apps.Config) error {
+	size := config.Size
+	if size == "" {
+		size = apps.DefaultConfig.Size
+	}
+	replicas := apps.DefaultConfig.Replicas
+	if config.Replicas != nil {
+		replicas = config.Replicas
+	}
+	return Print(ctx, prices, out, api.PriceApplication, string(size), int(ptr.Deref(replicas, 1)))
+}
@@ -0,0 +1,45 @@
+package cost
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestCost(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "prices.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "currency": "CHF",
+  "prices": {
+    "application": {"micro": 8, "standard-1": 30},
+    "postgres": {"nine-db-s": 90}
+  }
+}`), 0600))
+	prices := api.PriceSource{Source: path}
+
+	out := &bytes.Buffer{}
+	cmd := &appCmd{Size: "standard-1", Replicas: 2, out: out}
+	require.NoError(t, cmd.Run(ctx, prices))
+	assert.Equal(t, "estimated cost: CHF 60.00 per month (2 × application standard-1 at CHF 30.00)\n", out.String())
+
+	out.Reset()
+	pg := &postgresCmd{MachineType: "nine-db-s", out: out}
+	require.NoError(t, pg.Run(ctx, prices))
+	assert.Contains(t, out.String(), "CHF 90.00 per month")
+
+	out.Reset()
+	require.NoError(t, PrintApplication(ctx, prices, out, apps.Config{Replicas: ptr.To(int32(3))}))
+	assert.Contains(t, out.String(), "3 × application "+string(apps.DefaultConfig.Size))
+
+	mysql := &mySQLCmd{MachineType: "nine-db-s", out: out}
+	assert.Error(t, mysql.Run(ctx, prices))
+}
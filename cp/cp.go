@@ -0,0 +1,224 @@
+// Package cp contains the commands to copy settings between resources.
+package cp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type Cmd struct {
+	Env envCmd `cmd:"" help:"Copy the env variables of an application to another application."`
+}
+
+type envCmd struct {
+	From     string   `required:"" placeholder:"[PROJECT/]app/NAME" help:"Application to copy the env variables from."`
+	To       string   `required:"" placeholder:"[PROJECT/]app/NAME" help:"Application to copy the env variables to."`
+	Exclude  []string `help:"Names of env variables which are not copied."`
+	BuildEnv bool     `help:"Copy the build env variables instead of the runtime env variables."`
+	DryRun   bool     `help:"Only print the changes which would be made."`
+	out      io.Writer
+	// yes skips all confirmations, sensitive env variables are copied as
+	// well. It is set by the global --yes flag.
+	yes bool
+	// confirm asks for confirmation, defaults to format.Confirmf.
+	confirm func(format string, a ...any) (bool, error)
+}
+
+// envChange is an env variable which is added or changed in the destination.
+type envChange struct {
+	name     string
+	old, new string
+	exists   bool
+}
+
+// sensitive reports if the env variable likely contains a secret. Its
+// values are not printed and copying it needs to be confirmed.
+func (c envChange) sensitive() bool {
+	return format.SensitiveName(c.name)
+}
+
+func (c envChange) String() string {
+	old, new := c.old, c.new
+	if c.sensitive() {
+		old, new = format.Redacted, format.Redacted
+	}
+	if !c.exists {
+		return fmt.Sprintf("+ %s=%s", c.name, new)
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.name, old, new)
+}
+
+func (cmd *envCmd) Help() string {
+	return "Env variables which only exist in the destination are kept. The changes are\n" +
+		"shown before they are applied. Values of env variables whose names look like\n" +
+		"they contain secrets (e.g. API_TOKEN or DB_PASSWORD) are not shown and copying\n" +
+		"them needs to be confirmed separately, unless the global --yes flag is set.\n\n" +
+		"Example:\n\n" +
+		"  nctl copy env --from app/staging-api --to prod/app/prod-api --exclude DB_URL"
+}
+
+func (cmd *envCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.confirm == nil {
+		cmd.confirm = format.Confirmf
+	}
+	if format.AssumeYes() {
+		cmd.yes = true
+	}
+
+	fromName, err := appName(client, cmd.From)
+	if err != nil {
+		return fmt.Errorf("invalid --from: %w", err)
+	}
+	toName, err := appName(client, cmd.To)
+	if err != nil {
+		return fmt.Errorf("invalid --to: %w", err)
+	}
+	if fromName == toName {
+		return fmt.Errorf("source and destination application need to be different")
+	}
+
+	from := &apps.Application{}
+	if err := client.Get(ctx, fromName, from); err != nil {
+		return fmt.Errorf("unable to get application %q: %w", fromName.Name, err)
+	}
+	to := &apps.Application{}
+	if err := client.Get(ctx, toName, to); err != nil {
+		return fmt.Errorf("unable to get application %q: %w", toName.Name, err)
+	}
+
+	changes := cmd.changes(*cmd.env(from), *cmd.env(to))
+	if len(changes) == 0 {
+		fmt.Fprintf(cmd.out, "%s of application %q are already up to date\n", cmd.kind(), to.Name)
+		return nil
+	}
+
+	fmt.Fprintf(cmd.out, "%s of application %q in project %s:\n", cmd.kind(), to.Name, to.Namespace)
+	for _, c := range changes {
+		fmt.Fprintln(cmd.out, c)
+	}
+	if cmd.DryRun {
+		return nil
+	}
+
+	if !cmd.yes {
+		changes, err = cmd.confirmSensitive(changes)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			format.PrintFailuref("", "nothing to copy")
+			return nil
+		}
+		ok, err := cmd.confirm("copy %d %s to application %q?", len(changes), cmd.kind(), to.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			format.PrintFailuref("", "copy canceled")
+			return nil
+		}
+	}
+
+	values := map[string]string{}
+	for _, c := range changes {
+		values[c.name] = c.new
+	}
+	env := cmd.env(to)
+	*env = util.UpdateEnvVars(*env, values, nil)
+	if err := client.Update(ctx, to); err != nil {
+		return fmt.Errorf("unable to update application %q: %w", to.Name, err)
+	}
+
+	fmt.Fprintln(cmd.out, format.SuccessMessagef("📋", "copied %d %s from application %q to %q",
+		len(changes), cmd.kind(), from.Name, to.Name))
+	return nil
+}
+
+// appName parses a reference like "app/NAME" or "PROJECT/app/NAME".
+func appName(client *api.Client, ref string) (types.NamespacedName, error) {
+	parts := strings.Split(ref, "/")
+	project := client.Project
+	switch len(parts) {
+	case 2:
+	case 3:
+		project, parts = parts[0], parts[1:]
+	default:
+		return types.NamespacedName{}, fmt.Errorf("%q needs to be in the form [PROJECT/]app/NAME", ref)
+	}
+	gvk, err := api.LookupKind(client.Scheme(), parts[0])
+	if err != nil {
+		return types.NamespacedName{}, err
+	}
+	if gvk.Kind != apps.ApplicationKind {
+		return types.NamespacedName{}, fmt.Errorf("only applications have env variables, got %s", strings.ToLower(gvk.Kind))
+	}
+	return api.NamespacedName(parts[1], project), nil
+}
+
+// env returns the env variables of the app which are copied.
+func (cmd *envCmd) env(app *apps.Application) *apps.EnvVars {
+	if cmd.BuildEnv {
+		return &app.Spec.ForProvider.BuildEnv
+	}
+	return &app.Spec.ForProvider.Config.Env
+}
+
+func (cmd *envCmd) kind() string {
+	if cmd.BuildEnv {
+		return "build env variables"
+	}
+	return "env variables"
+}
+
+// changes returns the env variables of the source which are missing or have
+// a different value in the destination.
+func (cmd *envCmd) changes(from, to apps.EnvVars) []envChange {
+	changes := []envChange{}
+	for _, env := range from {
+		if slices.Contains(cmd.Exclude, env.Name) {
+			continue
+		}
+		current := util.EnvVarByName(to, env.Name)
+		if current == nil {
+			changes = append(changes, envChange{name: env.Name, new: env.Value})
+			continue
+		}
+		if current.Value != env.Value {
+			changes = append(changes, envChange{name: env.Name, old: current.Value, new: env.Value, exists: true})
+		}
+	}
+	slices.SortFunc(changes, func(a, b envChange) int { return strings.Compare(a.name, b.name) })
+	return changes
+}
+
+// confirmSensitive asks if each sensitive env variable should be copied and
+// returns the changes without the declined ones.
+func (cmd *envCmd) confirmSensitive(changes []envChange) ([]envChange, error) {
+	confirmed := []envChange{}
+	for _, c := range changes {
+		if c.sensitive() {
+			ok, err := cmd.confirm("%s looks like it contains a secret, copy it?", c.name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		confirmed = append(confirmed, c)
+	}
+	return confirmed, nil
+}
@@ -0,0 +1,124 @@
+package cp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCopyEnv(t *testing.T) {
+	ctx := context.Background()
+
+	app := func(name, project string, env map[string]string) *apps.Application {
+		a := &apps.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: project}}
+		a.Spec.ForProvider.Config.Env = util.EnvVarsFromMap(env)
+		return a
+	}
+
+	tests := []struct {
+		name    string
+		cmd     envCmd
+		answers map[string]bool
+		wantEnv map[string]string
+		wantOut []string
+		wantErr string
+	}{
+		{
+			name: "copy all",
+			cmd:  envCmd{From: "app/staging", To: "prod/app/web", yes: true},
+			wantEnv: map[string]string{
+				"LOG_LEVEL": "debug", "DB_URL": "postgres://staging", "API_TOKEN": "staging-token", "ONLY_PROD": "1",
+			},
+			wantOut: []string{
+				"+ API_TOKEN=<redacted>",
+				"+ DB_URL=postgres://staging",
+				"~ LOG_LEVEL: info -> debug",
+			},
+		},
+		{
+			name:    "exclude",
+			cmd:     envCmd{From: "app/staging", To: "prod/app/web", Exclude: []string{"DB_URL", "API_TOKEN"}, yes: true},
+			wantEnv: map[string]string{"LOG_LEVEL": "debug", "ONLY_PROD": "1"},
+		},
+		{
+			name:    "decline sensitive",
+			cmd:     envCmd{From: "app/staging", To: "prod/app/web"},
+			answers: map[string]bool{"API_TOKEN": false, "copy": true},
+			wantEnv: map[string]string{"LOG_LEVEL": "debug", "DB_URL": "postgres://staging", "ONLY_PROD": "1"},
+		},
+		{
+			name:    "cancel",
+			cmd:     envCmd{From: "app/staging", To: "prod/app/web"},
+			answers: map[string]bool{"API_TOKEN": true, "copy": false},
+			wantEnv: map[string]string{"LOG_LEVEL": "info", "ONLY_PROD": "1"},
+		},
+		{
+			name:    "dry run",
+			cmd:     envCmd{From: "app/staging", To: "prod/app/web", DryRun: true},
+			wantEnv: map[string]string{"LOG_LEVEL": "info", "ONLY_PROD": "1"},
+			wantOut: []string{"~ LOG_LEVEL: info -> debug"},
+		},
+		{
+			name:    "not an application",
+			cmd:     envCmd{From: "postgres/staging", To: "prod/app/web"},
+			wantErr: "only applications",
+		},
+		{
+			name:    "invalid reference",
+			cmd:     envCmd{From: "staging", To: "prod/app/web"},
+			wantErr: "[PROJECT/]app/NAME",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := app("web", "prod", map[string]string{"LOG_LEVEL": "info", "ONLY_PROD": "1"})
+			apiClient, err := test.SetupClient(test.WithObjects(
+				app("staging", test.DefaultProject, map[string]string{
+					"LOG_LEVEL": "debug", "DB_URL": "postgres://staging", "API_TOKEN": "staging-token",
+				}),
+				dest,
+			))
+			require.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			tt.cmd.out = out
+			tt.cmd.confirm = func(format string, a ...any) (bool, error) {
+				msg := fmt.Sprintf(format, a...)
+				for key, answer := range tt.answers {
+					if strings.HasPrefix(msg, key) {
+						return answer, nil
+					}
+				}
+				return false, fmt.Errorf("unexpected question %q", msg)
+			}
+			err = tt.cmd.Run(ctx, apiClient)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, line := range tt.wantOut {
+				assert.Contains(t, out.String(), line)
+			}
+			assert.NotContains(t, out.String(), "staging-token")
+
+			require.NoError(t, apiClient.Get(ctx, api.ObjectName(dest), dest))
+			env := map[string]string{}
+			for _, e := range dest.Spec.ForProvider.Config.Env {
+				env[e.Name] = e.Value
+			}
+			assert.Equal(t, tt.wantEnv, env)
+		})
+	}
+}
@@ -14,7 +14,7 @@ type apiServiceAccountCmd struct {
 }
 
 func (asa *apiServiceAccountCmd) Run(ctx context.Context, client *api.Client) error {
-	c := newCreator(client, asa.newAPIServiceAccount(client.Project), iam.APIServiceAccountKind)
+	c := newCreator(client, asa.newAPIServiceAccount(client.Project), iam.APIServiceAccountKind, dryRun(asa.serverDryRun()), ifExists(asa.existingPolicy()))
 	ctx, cancel := context.WithTimeout(ctx, asa.WaitTimeout)
 	defer cancel()
 
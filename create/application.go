@@ -18,6 +18,7 @@ import (
 	"github.com/ninech/nctl/api/log"
 	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/api/validation"
+	"github.com/ninech/nctl/cost"
 	"github.com/ninech/nctl/internal/format"
 	"github.com/ninech/nctl/internal/logbox"
 	"github.com/ninech/nctl/logs"
@@ -45,12 +46,18 @@ type applicationCmd struct {
 	BuildEnv                 map[string]string `help:"Environment variables which are passed to the app build process."`
 	DeployJob                deployJob         `embed:"" prefix:"deploy-job-"`
 	WorkerJob                workerJob         `embed:"" prefix:"worker-job-"`
+	Workers                  []string          `name:"worker" sep:"none" placeholder:"name=NAME,command=COMMAND[,size=SIZE]" help:"Worker process to run next to the app, e.g. name=queue,command=\"bundle exec sidekiq\",size=mini. Can be repeated to add multiple workers."`
 	ScheduledJob             scheduledJob      `embed:"" prefix:"scheduled-job-"`
 	GitInformationServiceURL string            `help:"URL of the git information service." default:"https://git-info.deplo.io" env:"GIT_INFORMATION_SERVICE_URL" hidden:""`
 	SkipRepoAccessCheck      bool              `help:"Skip the git repository access check" default:"false"`
-	Debug                    bool              `help:"Enable debug messages" default:"false"`
 	Language                 string            `help:"${app_language_help} Possible values: ${enum}" enum:"ruby,php,python,golang,nodejs,static," default:""`
 	DockerfileBuild          dockerfileBuild   `embed:""`
+	ShowCost                 bool              `help:"Print the estimated monthly cost before creating the app, see \"nctl cost\"."`
+	// workers are parsed from Workers.
+	workers []apps.WorkerJob
+	// app is created instead of the application of the flags, see
+	// Application.
+	app *apps.Application
 }
 
 type gitConfig struct {
@@ -116,9 +123,71 @@ const (
 	releaseStatusReplicaFailure = "replicaFailure"
 )
 
+// AfterApply parses the workers given with --worker.
+func (app *applicationCmd) AfterApply() error {
+	for _, w := range app.Workers {
+		job, err := util.ParseWorkerJob(w)
+		if err != nil {
+			return err
+		}
+		if job.Command == "" {
+			return fmt.Errorf("invalid worker %q: the command is missing", w)
+		}
+		for _, existing := range app.workers {
+			if existing.Name == job.Name {
+				return fmt.Errorf("worker %q is given multiple times", job.Name)
+			}
+		}
+		app.workers = append(app.workers, job)
+	}
+	return nil
+}
+
+// ApplicationOptions are the settings of creating an application with
+// Application which are not part of the application itself.
+type ApplicationOptions struct {
+	GitUsername              *string
+	GitPassword              *string
+	GitSSHPrivateKeyFromFile *string
+	SkipRepoAccessCheck      bool
+	GitInformationServiceURL string
+	Wait                     bool
+	WaitTimeout              time.Duration
+}
+
+// Application creates the application the same way as "nctl create
+// application": the access to the git repository is checked, the git
+// credentials are stored in a secret and the release is waited for.
+func Application(ctx context.Context, client *api.Client, newApp *apps.Application, opts ApplicationOptions) error {
+	cmd := &applicationCmd{
+		resourceCmd: resourceCmd{
+			Name:        newApp.Name,
+			Wait:        opts.Wait,
+			WaitTimeout: opts.WaitTimeout,
+		},
+		Git: gitConfig{
+			Username:              opts.GitUsername,
+			Password:              opts.GitPassword,
+			SSHPrivateKeyFromFile: opts.GitSSHPrivateKeyFromFile,
+		},
+		SkipRepoAccessCheck:      opts.SkipRepoAccessCheck,
+		GitInformationServiceURL: opts.GitInformationServiceURL,
+		app:                      newApp,
+	}
+	return cmd.Run(ctx, client)
+}
+
 func (app *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 	fmt.Println("Creating new application")
-	newApp := app.newApplication(client.Project)
+	newApp := app.app
+	if newApp == nil {
+		newApp = app.newApplication(client.Project)
+	}
+	if app.ShowCost {
+		if err := cost.PrintApplication(ctx, client, nil, newApp.Spec.ForProvider.Config); err != nil {
+			return err
+		}
+	}
 
 	sshPrivateKey, err := app.Git.sshPrivateKey()
 	if err != nil {
@@ -134,7 +203,7 @@ func (app *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 		validator := &validation.RepositoryValidator{
 			GitInformationServiceURL: app.GitInformationServiceURL,
 			Token:                    client.Token(ctx),
-			Debug:                    app.Debug,
+			Debug:                    client.Debug,
 		}
 		if err := validator.Validate(ctx, &newApp.Spec.ForProvider.Git.GitTarget, auth); err != nil {
 			return err
@@ -147,18 +216,23 @@ func (app *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 		}
 
 		secret := auth.Secret(newApp)
+		createOpts := []runtimeclient.CreateOption{}
+		updateOpts := []runtimeclient.UpdateOption{}
+		if app.serverDryRun() {
+			createOpts = append(createOpts, runtimeclient.DryRunAll)
+			updateOpts = append(updateOpts, runtimeclient.DryRunAll)
+		}
 		// for git auth we create a separate secret and then reference it in the app.
-		if err := client.Create(ctx, secret); err != nil {
+		if err := client.Create(ctx, secret, createOpts...); err != nil {
 			if kerrors.IsAlreadyExists(err) {
+				if err := client.Get(ctx, client.Name(secret.Name), secret); err != nil {
+					return err
+				}
 				// only update the secret if it is managed by nctl in the first place
-				if v, exists := newApp.Annotations[util.ManagedByAnnotation]; exists && v == util.NctlName {
+				if secret.Annotations[util.ManagedByAnnotation] == util.NctlName {
 					fmt.Println("updating git auth credentials")
-					if err := client.Get(ctx, client.Name(secret.Name), secret); err != nil {
-						return err
-					}
-
 					auth.UpdateSecret(secret)
-					if err := client.Update(ctx, secret); err != nil {
+					if err := client.Update(ctx, secret, updateOpts...); err != nil {
 						return err
 					}
 				}
@@ -183,12 +257,13 @@ func (app *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 		}
 	}
 
-	c := newCreator(client, newApp, strings.ToLower(apps.ApplicationKind))
+	c := newCreator(client, newApp, strings.ToLower(apps.ApplicationKind), dryRun(app.serverDryRun()), ifExists(app.existingPolicy()))
 	appWaitCtx, cancel := context.WithTimeout(ctx, app.WaitTimeout)
 	defer cancel()
 
 	if err := c.createResource(appWaitCtx); err != nil {
-		if auth.Enabled() {
+		// the git auth secret of an existing app is still in use.
+		if auth.Enabled() && !app.serverDryRun() && !c.existed {
 			secret := auth.Secret(newApp)
 			if gitErr := client.Delete(ctx, secret); gitErr != nil {
 				return errors.Join(err, fmt.Errorf("unable to delete git auth secret: %w", gitErr))
@@ -198,7 +273,7 @@ func (app *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 		return err
 	}
 
-	if !app.Wait {
+	if !app.Wait || app.serverDryRun() {
 		return nil
 	}
 
@@ -307,6 +382,9 @@ func (app *applicationCmd) config() apps.Config {
 		}
 		config.WorkerJobs = append(config.WorkerJobs, workerJob)
 	}
+	for _, job := range app.workers {
+		util.SetWorkerJob(&config, job)
+	}
 
 	if len(app.ScheduledJob.Command) != 0 && len(app.ScheduledJob.Name) != 0 && len(app.ScheduledJob.Schedule) != 0 {
 		scheduledJob := apps.ScheduledJob{
@@ -20,9 +20,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func createTempKeyFile(content string) (string, error) {
@@ -713,3 +717,60 @@ func setResourceCondition(ctx context.Context, apiClient *api.Client, mg resourc
 	mg.SetConditions(condition)
 	return apiClient.Update(ctx, mg)
 }
+
+func TestApplicationWorkers(t *testing.T) {
+	cmd := applicationCmd{
+		resourceCmd: resourceCmd{Name: "workers"},
+		WorkerJob:   workerJob{Name: "legacy", Command: "bin/legacy"},
+		Workers:     []string{`name=queue,command="bundle exec sidekiq",size=mini`, "name=cron,command=bin/cron"},
+	}
+	require.NoError(t, cmd.AfterApply())
+	app := cmd.newApplication("default")
+	assert.Equal(t, []apps.WorkerJob{
+		{Job: apps.Job{Name: "legacy", Command: "bin/legacy"}},
+		{Job: apps.Job{Name: "queue", Command: "bundle exec sidekiq"}, Size: ptr.To(apps.ApplicationSize("mini"))},
+		{Job: apps.Job{Name: "cron", Command: "bin/cron"}},
+	}, app.Spec.ForProvider.Config.WorkerJobs)
+
+	for _, workers := range [][]string{
+		{"name=queue"},
+		{"command=bin/queue"},
+		{"name=queue,command=a", "name=queue,command=b"},
+		{"name=queue,command=a,replicas=2"},
+		{"name=queue,command=a,cpu=2"},
+	} {
+		cmd := applicationCmd{Workers: workers}
+		assert.Error(t, cmd.AfterApply(), workers)
+	}
+}
+
+func TestApplicationDryRunKeepsGitAuthSecret(t *testing.T) {
+	ctx := context.Background()
+	cmd := applicationCmd{
+		resourceCmd: resourceCmd{Name: "dry-run", DryRun: dryRunServer},
+		Git: gitConfig{
+			URL:      "https://github.com/ninech/doesnotexist.git",
+			Username: ptr.To("deploy"),
+			Password: ptr.To("new"),
+		},
+		SkipRepoAccessCheck: true,
+	}
+	stored := util.GitAuth{Username: ptr.To("deploy"), Password: ptr.To("old")}.Secret(cmd.newApplication(test.DefaultProject))
+	apiClient, err := test.SetupClient(test.WithObjects(stored), test.WithInterceptorFuncs(interceptor.Funcs{
+		// unlike the fake client, the API also rejects existing objects
+		// in a dry run.
+		Create: func(ctx context.Context, c runtimeclient.WithWatch, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+			if err := c.Get(ctx, api.ObjectName(obj), obj.DeepCopyObject().(runtimeclient.Object)); err == nil {
+				return kerrors.NewAlreadyExists(schema.GroupResource{}, obj.GetName())
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, cmd.Run(ctx, apiClient))
+
+	secret := stored.DeepCopy()
+	require.NoError(t, apiClient.Get(ctx, api.ObjectName(secret), secret))
+	assert.Equal(t, "old", string(secret.Data[util.PasswordSecretKey]))
+}
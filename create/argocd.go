@@ -0,0 +1,97 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	devtools "github.com/ninech/apis/devtools/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	"github.com/ninech/nctl/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type argoCDCmd struct {
+	resourceCmd
+	Clusters              []string `required:"" placeholder:"CLUSTER[/PROJECT]" help:"Kubernetes Clusters Argo CD has access to. Clusters of other projects can be referenced with 'name/project'."`
+	EnableApplicationSets bool     `default:"true" negatable:"" help:"Enable support for ApplicationSets."`
+	CustomSecret          string   `placeholder:"SECRET" help:"Name of a secret in the project which is passed to Argo CD, e.g. to be used in ApplicationSets."`
+}
+
+func (cmd *argoCDCmd) Run(ctx context.Context, client *api.Client) error {
+	argoCD, err := cmd.newArgoCD(client.Project)
+	if err != nil {
+		return err
+	}
+
+	c := newCreator(client, argoCD, strings.ToLower(devtools.ArgoCDKind), dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	if err := c.createResource(ctx); err != nil {
+		return err
+	}
+
+	if !cmd.Wait || cmd.serverDryRun() {
+		return nil
+	}
+
+	if err := c.wait(ctx, waitStage{
+		objectList: &devtools.ArgoCDList{},
+		onResult: func(event watch.Event) (bool, error) {
+			if c, ok := event.Object.(*devtools.ArgoCD); ok {
+				argoCD = c
+				return isAvailable(c), nil
+			}
+			return false, nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n Argo CD %s is now available at %s, log in with: nctl auth argocd %s\n\n", argoCD.Name, argoCD.Status.AtProvider.URL, argoCD.Name)
+	return nil
+}
+
+func (cmd *argoCDCmd) newArgoCD(namespace string) (*devtools.ArgoCD, error) {
+	name := getName(cmd.Name)
+
+	clusters := make([]meta.Reference, 0, len(cmd.Clusters))
+	for _, c := range cmd.Clusters {
+		cluster, project, _ := strings.Cut(c, "/")
+		if cluster == "" {
+			return nil, fmt.Errorf("invalid cluster %q, expected CLUSTER[/PROJECT]", c)
+		}
+		if project == "" {
+			project = namespace
+		}
+		clusters = append(clusters, meta.Reference{Name: cluster, Namespace: project})
+	}
+
+	argoCD := &devtools.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: devtools.ArgoCDSpec{
+			ResourceSpec: runtimev1.ResourceSpec{
+				WriteConnectionSecretToReference: &runtimev1.SecretReference{
+					Name:      "argocd-" + name,
+					Namespace: namespace,
+				},
+			},
+			ForProvider: devtools.ArgoCDParameters{
+				Clusters:              clusters,
+				EnableApplicationSets: cmd.EnableApplicationSets,
+			},
+		},
+	}
+
+	if cmd.CustomSecret != "" {
+		argoCD.Spec.ForProvider.CustomSecret = &meta.LocalReference{Name: cmd.CustomSecret}
+	}
+
+	return argoCD, nil
+}
@@ -0,0 +1,73 @@
+package create
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	devtools "github.com/ninech/apis/devtools/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestArgoCD(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		create  argoCDCmd
+		want    devtools.ArgoCDParameters
+		wantErr bool
+	}{
+		{
+			name:   "simple",
+			create: argoCDCmd{Clusters: []string{"prod"}, EnableApplicationSets: true},
+			want: devtools.ArgoCDParameters{
+				Clusters:              []meta.Reference{{Name: "prod", Namespace: test.DefaultProject}},
+				EnableApplicationSets: true,
+			},
+		},
+		{
+			name:   "cluster in other project and custom secret",
+			create: argoCDCmd{Clusters: []string{"prod/other"}, CustomSecret: "repo-creds"},
+			want: devtools.ArgoCDParameters{
+				Clusters:     []meta.Reference{{Name: "prod", Namespace: "other"}},
+				CustomSecret: &meta.LocalReference{Name: "repo-creds"},
+			},
+		},
+		{
+			name:    "invalid cluster",
+			create:  argoCDCmd{Clusters: []string{"/other"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.create.Name = "test-" + t.Name()
+			tt.create.Wait = false
+			tt.create.WaitTimeout = time.Second
+
+			apiClient, err := test.SetupClient()
+			require.NoError(t, err)
+
+			if err := tt.create.Run(ctx, apiClient); (err != nil) != tt.wantErr {
+				t.Errorf("argoCDCmd.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			created := &devtools.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: tt.create.Name, Namespace: apiClient.Project}}
+			if err := apiClient.Get(ctx, api.ObjectName(created), created); (err != nil) != tt.wantErr {
+				t.Fatalf("expected argocd to exist, got: %s", err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(created.Spec.ForProvider, tt.want) {
+				t.Fatalf("expected ArgoCD.Spec.ForProvider = %v, got: %v", tt.want, created.Spec.ForProvider)
+			}
+		})
+	}
+}
@@ -19,12 +19,12 @@ type cloudVMCmd struct {
 	Location            string            `default:"nine-es34" help:"Location where the CloudVM instance is created."`
 	MachineType         string            `default:"" help:"The machine type defines the sizing for a particular CloudVM."`
 	Hostname            string            `default:"" help:"Hostname allows to set the hostname explicitly. If unset, the name of the resource will be used as the hostname. This does not affect the DNS name."`
-	PowerState          string            `default:"on" help:"Specify the initial power state of the CloudVM. Set to off to create "`
+	PowerState          string            `default:"on" help:"Specify the initial power state of the CloudVM. Set to off to create the CloudVM without starting it."`
 	OS                  string            `default:"" help:"OS which should be used to boot the VM."`
 	BootDiskSize        string            `default:"20Gi" help:"Configures the size of the boot disk."`
 	Disks               map[string]string `default:"" help:"Disks specifies which additional disks to mount to the machine."`
 	PublicKeys          []string          `default:"" help:"SSH public keys that can be used to connect to the CloudVM as root. The keys are expected to be in SSH format as defined in RFC4253. Immutable after creation."`
-	PublicKeysFromFiles []string          `default:"" predictor:"file" help:"SSH public key files that can be used to connect to the VM as root. The keys are expected to be in SSH format as defined in RFC4253. Immutable after creation."`
+	PublicKeysFromFiles []string          `default:"" predictor:"file" aliases:"public-key-from-file" help:"SSH public key files that can be used to connect to the VM as root. The keys are expected to be in SSH format as defined in RFC4253. Immutable after creation."`
 	CloudConfig         string            `default:"" help:"CloudConfig allows to pass custom cloud config data (https://cloudinit.readthedocs.io/en/latest/topics/format.html#cloud-config-data) to the cloud VM. If a CloudConfig is passed, the PublicKey parameter is ignored. Immutable after creation."`
 	CloudConfigFromFile string            `default:"" predictor:"file" help:"CloudConfig via file. Has precedence over args. CloudConfig allows to pass custom cloud config data (https://cloudinit.readthedocs.io/en/latest/topics/format.html#cloud-config-data) to the cloud VM. If a CloudConfig is passed, the PublicKey parameter is ignored. Immutable after creation."`
 }
@@ -35,7 +35,7 @@ func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client) error {
 		return err
 	}
 
-	c := newCreator(client, cloudVM, infrastructure.CloudVirtualMachineKind)
+	c := newCreator(client, cloudVM, infrastructure.CloudVirtualMachineKind, dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -43,7 +43,7 @@ func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client) error {
 		return err
 	}
 
-	if !cmd.Wait {
+	if !cmd.Wait || cmd.serverDryRun() {
 		return nil
 	}
 
@@ -60,7 +60,7 @@ func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client) error {
 		return err
 	}
 
-	fmt.Printf("\n Your Cloud VM %s is now available, you can now connect with: ssh %s\n\n", cloudVM.Name, cloudVM.Status.AtProvider.FQDN)
+	fmt.Printf("\n Your Cloud VM %s is now available, you can now connect with: nctl ssh vm %s\n\n", cloudVM.Name, cloudVM.Name)
 
 	return nil
 }
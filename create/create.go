@@ -2,8 +2,10 @@ package create
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -11,32 +13,60 @@ import (
 	"github.com/lucasepe/codename"
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/internal/format"
-	"github.com/theckman/yacspin"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/util/retry"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 type Cmd struct {
 	Filename            string               `short:"f" help:"Create any resource from a yaml or json file." predictor:"file"`
-	FromFile            fromFile             `cmd:"" default:"1" name:"-f <file>" help:"Create any resource from a yaml or json file."`
+	FromFile            fromFile             `cmd:"" default:"withargs" name:"-f <file>" help:"Create any resource from a yaml or json file."`
 	VCluster            vclusterCmd          `cmd:"" group:"infrastructure.nine.ch" name:"vcluster" help:"Create a new vcluster."`
 	APIServiceAccount   apiServiceAccountCmd `cmd:"" group:"iam.nine.ch" name:"apiserviceaccount" aliases:"asa" help:"Create a new API Service Account."`
-	Project             projectCmd           `cmd:"" group:"management.nine.ch" name:"project" help:"Create a new project."`
+	Project             projectCmd           `cmd:"" group:"management.nine.ch" name:"project" aliases:"proj" help:"Create a new project."`
 	Config              configCmd            `cmd:"" group:"deplo.io" name:"config"  help:"Create a new deplo.io Project Configuration."`
-	Application         applicationCmd       `cmd:"" group:"deplo.io" name:"application" aliases:"app,application" help:"Create a new deplo.io Application."`
+	Application         applicationCmd       `cmd:"" group:"deplo.io" name:"application" aliases:"app,apps" help:"Create a new deplo.io Application."`
 	MySQL               mySQLCmd             `cmd:"" group:"storage.nine.ch" name:"mysql" help:"Create a new MySQL instance."`
-	Postgres            postgresCmd          `cmd:"" group:"storage.nine.ch" name:"postgres" help:"Create a new PostgreSQL instance."`
+	Postgres            postgresCmd          `cmd:"" group:"storage.nine.ch" name:"postgres" aliases:"pg" help:"Create a new PostgreSQL instance."`
 	KeyValueStore       keyValueStoreCmd     `cmd:"" group:"storage.nine.ch" name:"keyvaluestore" aliases:"kvs" help:"Create a new KeyValueStore instance"`
-	CloudVirtualMachine cloudVMCmd           `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm" help:"Create a new CloudVM."`
+	CloudVirtualMachine cloudVMCmd           `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm,vm" help:"Create a new CloudVM."`
+	ArgoCD              argoCDCmd            `cmd:"" group:"devtools.nine.ch" name:"argocd" aliases:"argo" help:"Create a new managed Argo CD instance."`
+	Grafana             grafanaCmd           `cmd:"" group:"observability.nine.ch" name:"grafana" help:"Create a new managed Grafana instance."`
+	Registry            registryCmd          `cmd:"" group:"storage.nine.ch" name:"registry" help:"Create a new container registry."`
+	SSHKey              sshKeyCmd            `cmd:"" group:"security.nine.ch" name:"sshkey" help:"Create a new SSH key pair."`
 }
 
 type resourceCmd struct {
-	Name        string        `arg:"" help:"Name of the new resource. A random name is generated if omitted." default:""`
-	Wait        bool          `default:"true" help:"Wait until resource is fully created."`
-	WaitTimeout time.Duration `default:"30m" help:"Duration to wait for resource getting ready. Only relevant if wait is set."`
+	Name           string        `arg:"" help:"Name of the new resource. A random name is generated if omitted." default:""`
+	Wait           bool          `default:"true" help:"Wait until resource is fully created."`
+	WaitTimeout    time.Duration `default:"30m" help:"Duration to wait for resource getting ready. Only relevant if wait is set."`
+	DryRun         string        `help:"Must be \"none\" or \"server\". If server, the resource is validated by the API (including webhooks) and printed without being persisted. ${enum}" enum:"none,server" default:"none"`
+	IfNotExists    bool          `help:"Do not fail if the resource already exists with a matching spec. Fields which are not set by the command are ignored in the comparison."`
+	UpdateExisting bool          `help:"Update the spec of the resource if it already exists with a different spec, implies --if-not-exists."`
+}
+
+const dryRunServer = "server"
+
+// serverDryRun returns true if the resource should only be submitted as a
+// server-side dry-run.
+func (cmd resourceCmd) serverDryRun() bool {
+	return cmd.DryRun == dryRunServer
+}
+
+// existingPolicy returns what should happen if the resource already exists.
+func (cmd resourceCmd) existingPolicy() existingPolicy {
+	switch {
+	case cmd.UpdateExisting:
+		return existingUpdate
+	case cmd.IfNotExists:
+		return existingSkip
+	default:
+		return existingFail
+	}
 }
 
 // resultFunc is the function called on a watch event during creation. It
@@ -44,11 +74,19 @@ type resourceCmd struct {
 type resultFunc func(watch.Event) (bool, error)
 
 type creator struct {
-	client *api.Client
-	mg     resource.Managed
-	kind   string
+	client     *api.Client
+	mg         resource.Managed
+	kind       string
+	dryRun     bool
+	onExisting existingPolicy
+	// existed is set if the resource has not been created as it already
+	// existed.
+	existed bool
 }
 
+// creatorOption allows to set options for the creation
+type creatorOption func(*creator)
+
 type waitStage struct {
 	kind           string
 	waitMessage    *message
@@ -56,7 +94,7 @@ type waitStage struct {
 	objectList     runtimeclient.ObjectList
 	listOpts       []runtimeclient.ListOption
 	onResult       resultFunc
-	spinner        *yacspin.Spinner
+	spinner        *format.Progress
 	disableSpinner bool
 	// beforeWait is a hook that is called just before the wait is being run.
 	beforeWait func()
@@ -93,12 +131,53 @@ func (m *message) printSuccess() {
 	format.PrintSuccess(m.icon, m.text)
 }
 
-func newCreator(client *api.Client, mg resource.Managed, resourceName string) *creator {
-	return &creator{client: client, mg: mg, kind: resourceName}
+func newCreator(client *api.Client, mg resource.Managed, resourceName string, opts ...creatorOption) *creator {
+	c := &creator{client: client, mg: mg, kind: resourceName}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// dryRun configures the creator to only submit a server-side dry-run. No
+// waiting is done in this case as the resource will never get ready.
+func dryRun(enabled bool) creatorOption {
+	return func(c *creator) {
+		c.dryRun = enabled
+	}
+}
+
+// ifExists configures what happens if the resource already exists.
+func ifExists(policy existingPolicy) creatorOption {
+	return func(c *creator) {
+		c.onExisting = policy
+	}
 }
 
 func (c *creator) createResource(ctx context.Context) error {
+	if c.dryRun {
+		if err := c.client.Create(ctx, c.mg, runtimeclient.DryRunAll); err != nil {
+			if kerrors.IsAlreadyExists(err) {
+				c.existed = true
+				if c.onExisting != existingFail {
+					return c.adopt(ctx)
+				}
+			}
+			return fmt.Errorf("unable to create %s %q: %w", c.kind, c.mg.GetName(), err)
+		}
+
+		format.PrintSuccessf("🧪", "created %s %q in project %q (server dry run)", c.kind, c.mg.GetName(), c.mg.GetNamespace())
+		return format.PrettyPrintObject(c.mg, format.PrintOpts{})
+	}
+
 	if err := c.client.Create(ctx, c.mg); err != nil {
+		if kerrors.IsAlreadyExists(err) {
+			c.existed = true
+			if c.onExisting != existingFail {
+				return c.adopt(ctx)
+			}
+		}
 		return fmt.Errorf("unable to create %s %q: %w", c.kind, c.mg.GetName(), err)
 	}
 
@@ -107,6 +186,13 @@ func (c *creator) createResource(ctx context.Context) error {
 }
 
 func (c *creator) wait(ctx context.Context, stages ...waitStage) error {
+	// a dry-run resource has never been persisted, so there is nothing to
+	// wait for. An existing resource might never go through the stages of
+	// a new one, e.g. a new build.
+	if c.dryRun || c.existed {
+		return nil
+	}
+
 	for _, stage := range stages {
 		if stage.afterWait != nil {
 			defer stage.afterWait()
@@ -114,7 +200,7 @@ func (c *creator) wait(ctx context.Context, stages ...waitStage) error {
 
 		stage.setDefaults(c)
 
-		spinner, err := format.NewSpinner(
+		spinner, err := format.NewProgress(
 			stage.waitMessage.progress(),
 			stage.waitMessage.progress(),
 		)
@@ -132,6 +218,9 @@ func (c *creator) wait(ctx context.Context, stages ...waitStage) error {
 		}); err != nil {
 			_ = stage.spinner.StopFail()
 			_ = stage.spinner.Stop()
+			if errors.Is(err, context.Canceled) {
+				return c.interrupted()
+			}
 			return err
 		}
 	}
@@ -139,6 +228,18 @@ func (c *creator) wait(ctx context.Context, stages ...waitStage) error {
 	return nil
 }
 
+// interrupted returns the error for an interrupted wait. The resource has
+// been created already, so only the wait can be continued.
+func (c *creator) interrupted() error {
+	kind := c.kind
+	if gvk, err := apiutil.GVKForObject(c.mg, c.client.Scheme()); err == nil {
+		kind = strings.ToLower(gvk.Kind)
+	}
+	return format.InterruptedError{
+		Resume: format.Command().Wait(kind, c.mg.GetName(), c.mg.GetNamespace(), "condition=Ready"),
+	}
+}
+
 func (w *waitStage) setDefaults(c *creator) {
 	if len(w.kind) == 0 {
 		w.kind = c.kind
@@ -203,6 +304,10 @@ func (w *waitStage) watch(ctx context.Context, client *api.Client) error {
 				return watchError{kind: w.kind}
 			}
 
+			if mg, ok := res.Object.(resource.Managed); ok {
+				w.spinner.SetStatus(conditionStatus(mg))
+			}
+
 			done, err := w.onResult(res)
 			if err != nil {
 				_ = w.spinner.StopFail()
@@ -227,7 +332,7 @@ func (w *waitStage) watch(ctx context.Context, client *api.Client) error {
 				return fmt.Errorf(msg, w.kind)
 			case context.Canceled:
 				_ = w.spinner.StopFail()
-				return nil
+				return ctx.Err()
 			}
 		}
 	}
@@ -242,6 +347,16 @@ func resourceAvailable(event watch.Event) (bool, error) {
 	return isAvailable(mg), nil
 }
 
+// conditionStatus returns the message of the ready condition or its reason
+// if there is no message.
+func conditionStatus(mg resource.Managed) string {
+	ready := mg.GetCondition(runtimev1.TypeReady)
+	if len(ready.Message) != 0 {
+		return strings.ReplaceAll(ready.Message, "\n", " ")
+	}
+	return string(ready.Reason)
+}
+
 func isAvailable(mg resource.Managed) bool {
 	return mg.GetCondition(runtimev1.TypeReady).Reason == runtimev1.ReasonAvailable &&
 		mg.GetCondition(runtimev1.TypeReady).Status == corev1.ConditionTrue
@@ -7,8 +7,13 @@ import (
 
 	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	iam "github.com/ninech/apis/iam/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
@@ -88,3 +93,68 @@ func TestCreate(t *testing.T) {
 		t.Fatal("result func has not been called")
 	}
 }
+
+func TestCreateDryRun(t *testing.T) {
+	asa := &iam.APIServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	apiClient, err := test.SetupClient()
+	require.NoError(t, err)
+	c := newCreator(apiClient, asa, "apiserviceaccount", dryRun(true))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	require.NoError(t, c.createResource(ctx))
+	// waiting should return immediately as nothing has been created
+	require.NoError(t, c.wait(ctx, waitStage{objectList: &iam.APIServiceAccountList{}}))
+
+	err = apiClient.Get(ctx, types.NamespacedName{Name: asa.Name, Namespace: asa.Namespace}, &iam.APIServiceAccount{})
+	require.True(t, errors.IsNotFound(err), "expected resource to not exist after dry run, got %v", err)
+}
+
+func TestCreateExisting(t *testing.T) {
+	ctx := context.Background()
+	existing := test.Postgres("db", test.DefaultProject, "nine-es34")
+	// fields set by the API are ignored
+	existing.Spec.ForProvider.Version = storage.PostgresVersion("16")
+
+	tests := []struct {
+		name         string
+		policy       existingPolicy
+		location     string
+		wantErr      bool
+		wantLocation string
+	}{
+		{name: "fail", policy: existingFail, location: "nine-es34", wantErr: true, wantLocation: "nine-es34"},
+		{name: "matching spec", policy: existingSkip, location: "nine-es34", wantLocation: "nine-es34"},
+		{name: "different spec", policy: existingSkip, location: "nine-cz41", wantErr: true, wantLocation: "nine-es34"},
+		{name: "update", policy: existingUpdate, location: "nine-cz41", wantLocation: "nine-cz41"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			apiClient, err := test.SetupClient(test.WithObjects(existing.DeepCopy()))
+			require.NoError(t, err)
+
+			c := newCreator(apiClient, test.Postgres("db", test.DefaultProject, tc.location), "postgres", ifExists(tc.policy))
+			err = c.createResource(ctx)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.True(t, c.existed)
+			// the wait is skipped for existing resources
+			require.NoError(t, c.wait(ctx, waitStage{objectList: &storage.PostgresList{}, onResult: resourceAvailable}))
+
+			pg := &storage.Postgres{}
+			require.NoError(t, apiClient.Get(ctx, api.ObjectName(existing), pg))
+			assert.Equal(t, meta.LocationName(tc.wantLocation), pg.Spec.ForProvider.Location)
+			assert.Equal(t, storage.PostgresVersion("16"), pg.Spec.ForProvider.Version)
+		})
+	}
+}
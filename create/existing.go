@@ -0,0 +1,137 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// existingPolicy defines what happens if a resource which should be created
+// already exists.
+type existingPolicy int
+
+const (
+	// existingFail returns the already exists error of the API.
+	existingFail existingPolicy = iota
+	// existingSkip succeeds if the spec of the existing resource matches.
+	existingSkip
+	// existingUpdate updates the spec of the existing resource.
+	existingUpdate
+)
+
+// adopt handles a resource which already exists according to the policy of
+// the creator.
+func (c *creator) adopt(ctx context.Context) error {
+	gvk, err := apiutil.GVKForObject(c.mg, c.client.Scheme())
+	if err != nil {
+		return err
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	if err := c.client.Get(ctx, api.ObjectName(c.mg), current); err != nil {
+		return fmt.Errorf("unable to get existing %s %q: %w", c.kind, c.mg.GetName(), err)
+	}
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(c.mg)
+	if err != nil {
+		return err
+	}
+	spec := withoutNil(desired["spec"])
+
+	suffix := ""
+	if c.dryRun {
+		suffix = " (server dry run)"
+	}
+	if specMatches(current.Object["spec"], spec) {
+		format.PrintSuccessf("👌", "%s %q already exists in project %q%s", c.kind, c.mg.GetName(), c.mg.GetNamespace(), suffix)
+		return nil
+	}
+	if c.onExisting != existingUpdate {
+		return fmt.Errorf("%s %q already exists in project %q with a different spec, use --update-existing to update it",
+			c.kind, c.mg.GetName(), c.mg.GetNamespace())
+	}
+
+	patch, err := json.Marshal(map[string]any{"spec": spec})
+	if err != nil {
+		return err
+	}
+	opts := []runtimeclient.PatchOption{}
+	if c.dryRun {
+		opts = append(opts, runtimeclient.DryRunAll)
+	}
+	if err := c.client.Patch(ctx, current, runtimeclient.RawPatch(types.MergePatchType, patch), opts...); err != nil {
+		return fmt.Errorf("unable to update existing %s %q: %w", c.kind, c.mg.GetName(), err)
+	}
+	c.existed = true
+	format.PrintSuccessf("🏗", "updated existing %s %q in project %q%s", c.kind, c.mg.GetName(), c.mg.GetNamespace(), suffix)
+	return nil
+}
+
+// specMatches reports if all fields set in desired have the same value in
+// current. Fields which are only set in current, e.g. defaults of the API,
+// are ignored. Lists need to have the same length.
+func specMatches(current, desired any) bool {
+	switch d := desired.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		c, ok := current.(map[string]any)
+		if !ok {
+			return len(d) == 0 && current == nil
+		}
+		for k, v := range d {
+			if !specMatches(c[k], v) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		c, ok := current.([]any)
+		if !ok {
+			return len(d) == 0 && current == nil
+		}
+		if len(c) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !specMatches(c[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(current, desired)
+	}
+}
+
+// withoutNil removes all nil values from maps, so that they don't remove
+// fields when used in a merge patch.
+func withoutNil(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, v := range t {
+			if v == nil {
+				continue
+			}
+			m[k] = withoutNil(v)
+		}
+		return m
+	case []any:
+		l := make([]any, len(t))
+		for i := range t {
+			l[i] = withoutNil(t[i])
+		}
+		return l
+	default:
+		return v
+	}
+}
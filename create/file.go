@@ -8,8 +8,9 @@ import (
 )
 
 type fromFile struct {
+	DryRun string `help:"Must be \"none\" or \"server\". If server, the resources are validated by the API (including webhooks) without being persisted. ${enum}" enum:"none,server" default:"none"`
 }
 
 func (cmd *fromFile) Run(ctx context.Context, client *api.Client, create *Cmd) error {
-	return apply.File(ctx, client, create.Filename)
+	return apply.File(ctx, client, create.Filename, apply.DryRun(cmd.DryRun == apply.DryRunServer))
 }
@@ -0,0 +1,78 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	observability "github.com/ninech/apis/observability/v1alpha1"
+	"github.com/ninech/nctl/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type grafanaCmd struct {
+	resourceCmd
+	DataSourceProjects []string          `placeholder:"PROJECT" help:"Projects which are searched for data sources like Prometheus or Loki. Defaults to the project of the Grafana instance."`
+	DataSourceLabels   map[string]string `placeholder:"KEY=VALUE" help:"Only add data sources with these labels."`
+	EnableAdminAccess  bool              `help:"Get admin permissions in the Grafana instance."`
+}
+
+func (cmd *grafanaCmd) Run(ctx context.Context, client *api.Client) error {
+	grafana := cmd.newGrafana(client.Project)
+
+	c := newCreator(client, grafana, strings.ToLower(observability.GrafanaKind), dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	if err := c.createResource(ctx); err != nil {
+		return err
+	}
+
+	if !cmd.Wait || cmd.serverDryRun() {
+		return nil
+	}
+
+	if err := c.wait(ctx, waitStage{
+		objectList: &observability.GrafanaList{},
+		onResult: func(event watch.Event) (bool, error) {
+			if c, ok := event.Object.(*observability.Grafana); ok {
+				grafana = c
+				return isAvailable(c), nil
+			}
+			return false, nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n Grafana %s is now available at %s, get the credentials with: nctl get grafana %s --print-credentials\n\n", grafana.Name, grafana.Status.AtProvider.URL, grafana.Name)
+	return nil
+}
+
+func (cmd *grafanaCmd) newGrafana(namespace string) *observability.Grafana {
+	name := getName(cmd.Name)
+
+	return &observability.Grafana{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: observability.GrafanaSpec{
+			ResourceSpec: runtimev1.ResourceSpec{
+				WriteConnectionSecretToReference: &runtimev1.SecretReference{
+					Name:      "grafana-" + name,
+					Namespace: namespace,
+				},
+			},
+			ForProvider: observability.GrafanaParameters{
+				DataSource: observability.DataSourceSelection{
+					SearchNamespaces: cmd.DataSourceProjects,
+					FilterLabels:     cmd.DataSourceLabels,
+				},
+				EnableAdminAccess: cmd.EnableAdminAccess,
+			},
+		},
+	}
+}
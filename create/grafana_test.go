@@ -0,0 +1,62 @@
+package create
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	observability "github.com/ninech/apis/observability/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrafana(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		create grafanaCmd
+		want   observability.GrafanaParameters
+	}{
+		{
+			name:   "simple",
+			create: grafanaCmd{},
+			want:   observability.GrafanaParameters{},
+		},
+		{
+			name: "data sources and admin access",
+			create: grafanaCmd{
+				DataSourceProjects: []string{"dev", "prod"},
+				DataSourceLabels:   map[string]string{"team": "web"},
+				EnableAdminAccess:  true,
+			},
+			want: observability.GrafanaParameters{
+				DataSource: observability.DataSourceSelection{
+					SearchNamespaces: []string{"dev", "prod"},
+					FilterLabels:     map[string]string{"team": "web"},
+				},
+				EnableAdminAccess: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.create.Name = "test-" + t.Name()
+			tt.create.Wait = false
+			tt.create.WaitTimeout = time.Second
+
+			apiClient, err := test.SetupClient()
+			require.NoError(t, err)
+			require.NoError(t, tt.create.Run(ctx, apiClient))
+
+			created := &observability.Grafana{ObjectMeta: metav1.ObjectMeta{Name: tt.create.Name, Namespace: apiClient.Project}}
+			require.NoError(t, apiClient.Get(ctx, api.ObjectName(created), created))
+			require.Equal(t, "grafana-"+tt.create.Name, created.Spec.WriteConnectionSecretToReference.Name)
+			if !reflect.DeepEqual(created.Spec.ForProvider, tt.want) {
+				t.Fatalf("expected grafana parameters = %v, got: %v", tt.want, created.Spec.ForProvider)
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ func (cmd *keyValueStoreCmd) Run(ctx context.Context, client *api.Client) error
 		return err
 	}
 
-	c := newCreator(client, keyValueStore, "keyvaluestore")
+	c := newCreator(client, keyValueStore, "keyvaluestore", dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -15,9 +15,14 @@ import (
 	storage "github.com/ninech/apis/storage/v1alpha1"
 
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/cost"
 	"github.com/ninech/nctl/internal/file"
 )
 
+// mySQLVersions are the available MySQL versions, the API only defines the
+// default version.
+var mySQLVersions = []storage.MySQLVersion{storage.MySQLVersion8}
+
 type mySQLCmd struct {
 	resourceCmd
 	Location              string                                 `placeholder:"${mysql_location_default}" help:"Location where the MySQL instance is created. Available locations are: ${mysql_location_options}"`
@@ -31,7 +36,9 @@ type mySQLCmd struct {
 	LongQueryTime         storage.LongQueryTime                  `placeholder:"${mysql_long_query_time}" help:"Configures the long_query_time variable. If a query takes longer than this duration, the query is logged to the slow query log file."`
 	MinWordLength         *int                                   `placeholder:"${mysql_min_word_length}" help:"Configures the ft_min_word_len and innodb_ft_min_token_size variables."`
 	TransactionIsolation  storage.MySQLTransactionCharacteristic `placeholder:"${mysql_transaction_isolation}" help:"Configures the transaction_isolation variable."`
+	MySQLVersion          storage.MySQLVersion                   `placeholder:"${mysql_version_default}" help:"Release version with which the MySQL instance is created. Available versions: ${mysql_versions}"`
 	KeepDailyBackups      *int                                   `placeholder:"${mysql_backup_retention_days}" help:"Number of daily database backups to keep. Note that setting this to 0, backup will be disabled and existing dumps deleted immediately."`
+	ShowCost              bool                                   `help:"Print the estimated monthly cost before creating the instance, see \"nctl cost\"."`
 }
 
 func (cmd *mySQLCmd) Run(ctx context.Context, client *api.Client) error {
@@ -43,8 +50,17 @@ func (cmd *mySQLCmd) Run(ctx context.Context, client *api.Client) error {
 
 	fmt.Printf("Creating new mysql. This might take some time (waiting up to %s).\n", cmd.WaitTimeout)
 	mysql := cmd.newMySQL(client.Project)
+	if cmd.ShowCost {
+		machineType := mysql.Spec.ForProvider.MachineType
+		if machineType.String() == "" {
+			machineType = storage.MySQLMachineTypeDefault
+		}
+		if err := cost.Print(ctx, client, nil, api.PriceMySQL, machineType.String(), 1); err != nil {
+			return err
+		}
+	}
 
-	c := newCreator(client, mysql, "mysql")
+	c := newCreator(client, mysql, "mysql", dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -97,6 +113,7 @@ func (cmd *mySQLCmd) newMySQL(namespace string) *storage.MySQL {
 				MinWordLength:        cmd.MinWordLength,
 				TransactionIsolation: cmd.TransactionIsolation,
 				KeepDailyBackups:     cmd.KeepDailyBackups,
+				Version:              cmd.MySQLVersion,
 			},
 		},
 	}
@@ -119,6 +136,8 @@ func MySQLKongVars() kong.Vars {
 	result["mysql_machine_default"] = storage.MySQLMachineTypeDefault.String()
 	result["mysql_location_options"] = strings.Join(storage.MySQLLocationOptions, ", ")
 	result["mysql_location_default"] = string(storage.MySQLLocationDefault)
+	result["mysql_version_default"] = string(storage.MySQLVersionDefault)
+	result["mysql_versions"] = strings.Join(stringSlice(mySQLVersions), ", ")
 	result["mysql_user"] = string(storage.MySQLUser)
 	result["mysql_mode"] = strings.Join(storage.MySQLModeDefault, ", ")
 	result["mysql_long_query_time"] = string(storage.MySQLLongQueryTimeDefault)
@@ -132,7 +151,7 @@ func MySQLKongVars() kong.Vars {
 
 func mtStringSlice(machineTypes []infra.MachineType) []string {
 	types := make([]string, len(machineTypes))
-	for i, machineType := range storage.MySQLMachineTypes {
+	for i, machineType := range machineTypes {
 		types[i] = machineType.String()
 	}
 	return types
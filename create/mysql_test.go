@@ -88,6 +88,11 @@ func TestMySQL(t *testing.T) {
 			create: mySQLCmd{KeepDailyBackups: ptr.To(5)},
 			want:   storage.MySQLParameters{KeepDailyBackups: ptr.To(5)},
 		},
+		{
+			name:   "version",
+			create: mySQLCmd{MySQLVersion: storage.MySQLVersionDefault},
+			want:   storage.MySQLParameters{Version: storage.MySQLVersionDefault},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -15,6 +15,7 @@ import (
 	storage "github.com/ninech/apis/storage/v1alpha1"
 
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/cost"
 	"github.com/ninech/nctl/internal/file"
 )
 
@@ -27,6 +28,7 @@ type postgresCmd struct {
 	SSHKeysFile      string                  `help:"Path to a file containing a list of SSH public keys (see above), separated by newlines."`
 	PostgresVersion  storage.PostgresVersion `placeholder:"${postgres_version_default}" help:"Release version with which the PostgreSQL instance is created. Available versions: ${postgres_versions}"`
 	KeepDailyBackups *int                    `placeholder:"${postgres_backup_retention_days}" help:"Number of daily database backups to keep. Note that setting this to 0, backup will be disabled and existing dumps deleted immediately."`
+	ShowCost         bool                    `help:"Print the estimated monthly cost before creating the instance, see \"nctl cost\"."`
 }
 
 func (cmd *postgresCmd) Run(ctx context.Context, client *api.Client) error {
@@ -38,8 +40,17 @@ func (cmd *postgresCmd) Run(ctx context.Context, client *api.Client) error {
 
 	fmt.Printf("Creating new postgres. This might take some time (waiting up to %s).\n", cmd.WaitTimeout)
 	postgres := cmd.newPostgres(client.Project)
+	if cmd.ShowCost {
+		machineType := postgres.Spec.ForProvider.MachineType
+		if machineType.String() == "" {
+			machineType = storage.PostgresMachineTypeDefault
+		}
+		if err := cost.Print(ctx, client, nil, api.PricePostgres, machineType.String(), 1); err != nil {
+			return err
+		}
+	}
 
-	c := newCreator(client, postgres, "postgres")
+	c := newCreator(client, postgres, "postgres", dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -103,7 +114,7 @@ func (cmd *postgresCmd) newPostgres(namespace string) *storage.Postgres {
 // create command
 func PostgresKongVars() kong.Vars {
 	result := make(kong.Vars)
-	result["postgres_machine_types"] = strings.Join(mtStringSlice(storage.MySQLMachineTypes), ", ")
+	result["postgres_machine_types"] = strings.Join(mtStringSlice(storage.PostgresMachineTypes), ", ")
 	result["postgres_machine_default"] = storage.PostgresMachineTypeDefault.String()
 	result["postgres_location_options"] = strings.Join(storage.PostgresLocationOptions, ", ")
 	result["postgres_location_default"] = string(storage.PostgresLocationDefault)
@@ -23,7 +23,7 @@ func (proj *projectCmd) Run(ctx context.Context, client *api.Client) error {
 
 	p := newProject(proj.Name, org, proj.DisplayName)
 	fmt.Printf("Creating new project %s for organization %s\n", p.Name, org)
-	c := newCreator(client, p, strings.ToLower(management.ProjectKind))
+	c := newCreator(client, p, strings.ToLower(management.ProjectKind), dryRun(proj.serverDryRun()), ifExists(proj.existingPolicy()))
 	ctx, cancel := context.WithTimeout(ctx, proj.WaitTimeout)
 	defer cancel()
 
@@ -19,10 +19,11 @@ type configCmd struct {
 	Env       *map[string]string `help:"Environment variables which are passed to the app at runtime."`
 	BasicAuth *bool              `help:"Enable/Disable basic authentication for applications."`
 	DeployJob deployJob          `embed:"" prefix:"deploy-job-"`
+	DryRun    string             `help:"Must be \"none\" or \"server\". If server, the resource is validated by the API (including webhooks) and printed without being persisted. ${enum}" enum:"none,server" default:"none"`
 }
 
 func (cmd *configCmd) Run(ctx context.Context, client *api.Client) error {
-	c := newCreator(client, cmd.newProjectConfig(client.Project), apps.ProjectConfigGroupKind)
+	c := newCreator(client, cmd.newProjectConfig(client.Project), apps.ProjectConfigGroupKind, dryRun(cmd.DryRun == dryRunServer))
 
 	return c.createResource(ctx)
 }
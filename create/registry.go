@@ -0,0 +1,72 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type registryCmd struct {
+	resourceCmd
+	NineAuthServer bool `help:"Authenticate against the registry with nine API credentials instead of basic auth."`
+}
+
+func (cmd *registryCmd) Run(ctx context.Context, client *api.Client) error {
+	registry := cmd.newRegistry(client.Project)
+
+	c := newCreator(client, registry, strings.ToLower(storage.RegistryKind), dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	if err := c.createResource(ctx); err != nil {
+		return err
+	}
+
+	if !cmd.Wait || cmd.serverDryRun() {
+		return nil
+	}
+
+	if err := c.wait(ctx, waitStage{
+		objectList: &storage.RegistryList{},
+		onResult: func(event watch.Event) (bool, error) {
+			if c, ok := event.Object.(*storage.Registry); ok {
+				registry = c
+				return isAvailable(c), nil
+			}
+			return false, nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n Registry %s is now available at %s, log in with: nctl auth registry %s\n\n", registry.Name, registry.Status.AtProvider.URL, registry.Name)
+	return nil
+}
+
+func (cmd *registryCmd) newRegistry(namespace string) *storage.Registry {
+	name := getName(cmd.Name)
+
+	return &storage.Registry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: storage.RegistrySpec{
+			ResourceSpec: runtimev1.ResourceSpec{
+				WriteConnectionSecretToReference: &runtimev1.SecretReference{
+					Name:      "registry-" + name,
+					Namespace: namespace,
+				},
+			},
+			ForProvider: storage.RegistryParameters{
+				NineAuthServer: cmd.NineAuthServer,
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package create
+
+import (
+	"context"
+	"fmt"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	security "github.com/ninech/apis/security/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type sshKeyCmd struct {
+	resourceCmd
+	Format string `help:"Format of the generated key pair. ${enum}" enum:"ed25519,rsa" default:"ed25519"`
+}
+
+func (cmd *sshKeyCmd) Help() string {
+	return `Generates a new SSH key pair. The private key is stored in the
+connection secret "sshkey-<name>" of the project, the public key is shown
+with "nctl get sshkeys". Existing keys can't be uploaded.`
+}
+
+func (cmd *sshKeyCmd) Run(ctx context.Context, client *api.Client) error {
+	key := cmd.newSSHKey(client.Project)
+
+	c := newCreator(client, key, security.SSHKeyKind, dryRun(cmd.serverDryRun()), ifExists(cmd.existingPolicy()))
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	if err := c.createResource(ctx); err != nil {
+		return err
+	}
+
+	if !cmd.Wait || cmd.serverDryRun() {
+		return nil
+	}
+
+	if err := c.wait(ctx, waitStage{
+		objectList: &security.SSHKeyList{},
+		onResult: func(event watch.Event) (bool, error) {
+			if k, ok := event.Object.(*security.SSHKey); ok {
+				key = k
+				return isAvailable(k) && k.Status.AtProvider.PublicKey != "", nil
+			}
+			return false, nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n SSH key %s (%s) is now available, the private key is stored in the secret %s:\n\n%s\n",
+		key.Name, util.SSHKeyFingerprint(key.Status.AtProvider.PublicKey), key.Spec.WriteConnectionSecretToReference.Name, key.Status.AtProvider.PublicKey)
+	return nil
+}
+
+func (cmd *sshKeyCmd) newSSHKey(namespace string) *security.SSHKey {
+	name := getName(cmd.Name)
+
+	return &security.SSHKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: security.SSHKeySpec{
+			ResourceSpec: runtimev1.ResourceSpec{
+				WriteConnectionSecretToReference: &runtimev1.SecretReference{
+					Name:      "sshkey-" + name,
+					Namespace: namespace,
+				},
+			},
+			ForProvider: security.SSHKeyParameters{
+				Format: cmd.Format,
+			},
+		},
+	}
+}
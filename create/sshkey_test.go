@@ -0,0 +1,31 @@
+package create
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	security "github.com/ninech/apis/security/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSSHKey(t *testing.T) {
+	ctx := context.Background()
+	cmd := sshKeyCmd{
+		resourceCmd: resourceCmd{Name: "deploy", WaitTimeout: time.Second},
+		Format:      "rsa",
+	}
+
+	apiClient, err := test.SetupClient()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Run(ctx, apiClient))
+
+	created := &security.SSHKey{ObjectMeta: metav1.ObjectMeta{Name: cmd.Name, Namespace: apiClient.Project}}
+	require.NoError(t, apiClient.Get(ctx, api.ObjectName(created), created))
+	assert.Equal(t, "rsa", created.Spec.ForProvider.Format)
+	assert.Equal(t, "sshkey-deploy", created.Spec.WriteConnectionSecretToReference.Name)
+}
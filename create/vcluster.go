@@ -25,7 +25,7 @@ type vclusterCmd struct {
 
 func (vc *vclusterCmd) Run(ctx context.Context, client *api.Client) error {
 	cluster := vc.newCluster(client.Project)
-	c := newCreator(client, cluster, "vcluster")
+	c := newCreator(client, cluster, "vcluster", dryRun(vc.serverDryRun()), ifExists(vc.existingPolicy()))
 	ctx, cancel := context.WithTimeout(ctx, vc.WaitTimeout)
 	defer cancel()
 
@@ -33,7 +33,7 @@ func (vc *vclusterCmd) Run(ctx context.Context, client *api.Client) error {
 		return err
 	}
 
-	if !vc.Wait {
+	if !vc.Wait || vc.serverDryRun() {
 		return nil
 	}
 
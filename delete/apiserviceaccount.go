@@ -14,6 +14,10 @@ type apiServiceAccountCmd struct {
 }
 
 func (asa *apiServiceAccountCmd) Run(ctx context.Context, client *api.Client) error {
+	if asa.bulk() {
+		return asa.bulkDelete(ctx, client, &iam.APIServiceAccountList{}, iam.APIServiceAccountKind)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, asa.WaitTimeout)
 	defer cancel()
 
@@ -22,7 +26,7 @@ func (asa *apiServiceAccountCmd) Run(ctx context.Context, client *api.Client) er
 		Namespace: client.Project,
 	}}
 
-	d := newDeleter(sa, iam.APIServiceAccountKind)
+	d := newDeleter(sa, iam.APIServiceAccountKind, dryRun(asa.serverDryRun()), forceProtected(asa.ForceProtected))
 
 	if err := d.deleteResource(ctx, client, asa.WaitTimeout, asa.Wait, asa.Force); err != nil {
 		return fmt.Errorf("error while deleting %s: %w", iam.APIServiceAccountKind, err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/hashicorp/go-multierror"
 	apps "github.com/ninech/apis/apps/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -19,6 +20,14 @@ type applicationCmd struct {
 }
 
 func (app *applicationCmd) Run(ctx context.Context, client *api.Client) error {
+	if app.bulk() {
+		return app.bulkDelete(ctx, client, &apps.ApplicationList{}, apps.ApplicationKind,
+			deleteWith(func(ctx context.Context, client *api.Client, mg resource.Managed) error {
+				return app.deleteApplication(ctx, client, mg.(*apps.Application), true)
+			}),
+		)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, app.WaitTimeout)
 	defer cancel()
 
@@ -28,16 +37,28 @@ func (app *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 			Namespace: client.Project,
 		},
 	}
+	return app.deleteApplication(ctx, client, a, app.Force)
+}
+
+// deleteApplication deletes the application and the git auth secrets which
+// have been created for it.
+func (app *applicationCmd) deleteApplication(ctx context.Context, client *api.Client, a *apps.Application, force bool) error {
 	gitAuthSecrets, err := findGitAuthSecrets(ctx, client, a)
 	if err != nil {
 		return err
 	}
 
-	d := newDeleter(a, apps.ApplicationKind)
-	if err := d.deleteResource(ctx, client, app.WaitTimeout, app.Wait, app.Force); err != nil {
+	d := newDeleter(a, apps.ApplicationKind, dryRun(app.serverDryRun()), forceProtected(app.ForceProtected))
+	if err := d.deleteResource(ctx, client, app.WaitTimeout, app.Wait, force); err != nil {
 		return fmt.Errorf("error while deleting %s: %w", apps.ApplicationKind, err)
 	}
 
+	// the git auth secrets are still in use if the application was not
+	// actually deleted.
+	if app.serverDryRun() {
+		return nil
+	}
+
 	var secretErrors error
 	for _, s := range gitAuthSecrets {
 		if err := deleteGitAuthSecret(ctx, client, s); err != nil {
@@ -0,0 +1,29 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	devtools "github.com/ninech/apis/devtools/v1alpha1"
+	"github.com/ninech/nctl/api"
+)
+
+type argoCDCmd struct {
+	resourceCmd
+}
+
+func (cmd *argoCDCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &devtools.ArgoCDList{}, devtools.ArgoCDKind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	argoCD := &devtools.ArgoCD{}
+	if err := client.Get(ctx, client.Name(cmd.Name), argoCD); err != nil {
+		return fmt.Errorf("unable to get argocd %q: %w", cmd.Name, err)
+	}
+
+	return newDeleter(argoCD, devtools.ArgoCDKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+}
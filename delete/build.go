@@ -0,0 +1,31 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type buildCmd struct {
+	resourceCmd
+}
+
+func (cmd *buildCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &apps.BuildList{}, apps.BuildKind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	build := &apps.Build{}
+	buildName := types.NamespacedName{Name: cmd.Name, Namespace: client.Project}
+	if err := client.Get(ctx, buildName, build); err != nil {
+		return fmt.Errorf("unable to get build %q: %w", cmd.Name, err)
+	}
+
+	return newDeleter(build, apps.BuildKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+}
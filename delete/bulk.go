@@ -0,0 +1,226 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/hashicorp/go-multierror"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// age is a duration which additionally supports days, e.g. "30d".
+type age time.Duration
+
+func (a *age) UnmarshalText(text []byte) error {
+	s := string(text)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return fmt.Errorf("invalid age %q: %w", s, err)
+		}
+		*a = age(time.Duration(n * float64(24*time.Hour)))
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid age %q: %w", s, err)
+	}
+	*a = age(d)
+	return nil
+}
+
+// bulk returns true if multiple resources should be deleted.
+func (cmd resourceCmd) bulk() bool {
+	return cmd.All || len(cmd.Selector) != 0
+}
+
+// Validate ensures that either a single resource or a bulk selection is
+// given.
+func (cmd resourceCmd) Validate() error {
+	if cmd.All && len(cmd.Selector) != 0 {
+		return errors.New("--all and --selector can not be combined")
+	}
+	if len(cmd.Name) != 0 && cmd.bulk() {
+		return errors.New("a name can not be combined with --all or --selector")
+	}
+	if len(cmd.Name) == 0 && !cmd.bulk() {
+		return errors.New("either a name, --all or --selector is required")
+	}
+	if cmd.OlderThan != 0 && !cmd.bulk() {
+		return errors.New("--older-than can only be used together with --all or --selector")
+	}
+	return nil
+}
+
+// bulkDeleteFunc deletes a single resource which is part of a bulk deletion.
+type bulkDeleteFunc func(ctx context.Context, client *api.Client, mg resource.Managed) error
+
+type bulkDeleter struct {
+	kind      string
+	list      runtimeclient.ObjectList
+	namespace string
+	filter    func(resource.Managed) bool
+	delete    bulkDeleteFunc
+}
+
+// bulkOption allows to set options for a bulk deletion
+type bulkOption func(*bulkDeleter)
+
+// inNamespace lists the resources to delete in the given namespace instead of
+// the current project.
+func inNamespace(namespace string) bulkOption {
+	return func(b *bulkDeleter) {
+		b.namespace = namespace
+	}
+}
+
+// matching only deletes resources for which the filter returns true.
+func matching(filter func(resource.Managed) bool) bulkOption {
+	return func(b *bulkDeleter) {
+		b.filter = filter
+	}
+}
+
+// deleteWith allows to replace the function used to delete each resource.
+func deleteWith(f bulkDeleteFunc) bulkOption {
+	return func(b *bulkDeleter) {
+		b.delete = f
+	}
+}
+
+// bulkDelete deletes all resources of the list type matching the selector
+// and age of the command. A plan of the resources to delete is printed and
+// confirmed before anything is deleted.
+func (cmd resourceCmd) bulkDelete(ctx context.Context, client *api.Client, list runtimeclient.ObjectList, kind string, opts ...bulkOption) error {
+	b := &bulkDeleter{
+		kind:      kind,
+		list:      list,
+		namespace: client.Project,
+		delete: func(ctx context.Context, client *api.Client, mg resource.Managed) error {
+			return newDeleter(mg, kind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, true)
+		},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	selector, err := labels.Parse(cmd.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector %q: %w", cmd.Selector, err)
+	}
+
+	if err := client.List(ctx, b.list,
+		runtimeclient.InNamespace(b.namespace),
+		runtimeclient.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return fmt.Errorf("unable to list %s resources: %w", kind, err)
+	}
+
+	items, err := b.matchingItems(time.Duration(cmd.OlderThan))
+	if err != nil {
+		return err
+	}
+	if !cmd.ForceProtected {
+		items = skipProtected(items, kind)
+	}
+
+	if len(items) == 0 {
+		format.PrintWarningf("no %s resources found in project %q to delete\n", kind, b.namespace)
+		return nil
+	}
+
+	if err := printPlan(items, kind, b.namespace); err != nil {
+		return err
+	}
+
+	if !cmd.Force && !cmd.serverDryRun() {
+		ok, err := format.Confirmf("do you really want to delete %d %s resources?", len(items), kind)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			format.PrintFailuref("", "%s deletion canceled", kind)
+			return nil
+		}
+	}
+
+	var deleteErrors error
+	deleted := 0
+	for _, mg := range items {
+		if err := b.delete(ctx, client, mg); err != nil {
+			deleteErrors = multierror.Append(deleteErrors, fmt.Errorf("%s %q: %w", kind, mg.GetName(), err))
+			continue
+		}
+		deleted++
+	}
+
+	fmt.Printf("\ndeleted %d of %d %s resources\n", deleted, len(items), kind)
+	return deleteErrors
+}
+
+func (b *bulkDeleter) matchingItems(olderThan time.Duration) ([]resource.Managed, error) {
+	objs, err := meta.ExtractList(b.list)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []resource.Managed{}
+	for _, obj := range objs {
+		mg, ok := obj.(resource.Managed)
+		if !ok {
+			continue
+		}
+		if olderThan != 0 && time.Since(mg.GetCreationTimestamp().Time) < olderThan {
+			continue
+		}
+		if b.filter != nil && !b.filter(mg) {
+			continue
+		}
+		items = append(items, mg)
+	}
+
+	return items, nil
+}
+
+// skipProtected removes the resources which are protected against deletion
+// and warns about each of them.
+func skipProtected(items []resource.Managed, kind string) []resource.Managed {
+	unprotected := []resource.Managed{}
+	for _, mg := range items {
+		if util.IsProtected(mg) {
+			format.PrintWarningf("skipping protected %s %q, use --force-protected to delete it\n", kind, mg.GetName())
+			continue
+		}
+		unprotected = append(unprotected, mg)
+	}
+	return unprotected
+}
+
+func printPlan(items []resource.Managed, kind, namespace string) error {
+	fmt.Printf("The following %d %s resources in project %q will be deleted:\n\n", len(items), kind, namespace)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "NAME\tAGE")
+	for _, mg := range items {
+		fmt.Fprintf(w, "%s\t%s\n", mg.GetName(), format.Age(mg.GetCreationTimestamp().Time))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
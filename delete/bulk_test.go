@@ -0,0 +1,157 @@
+package delete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestBulkDelete(t *testing.T) {
+	ctx := context.Background()
+
+	newPostgres := func(name string, labels map[string]string, age time.Duration) *storage.Postgres {
+		pg := test.Postgres(name, test.DefaultProject, "nine-es34")
+		pg.Labels = labels
+		pg.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		return pg
+	}
+
+	tests := []struct {
+		name        string
+		cmd         resourceCmd
+		wantDeleted []string
+	}{
+		{
+			name:        "all",
+			cmd:         resourceCmd{All: true},
+			wantDeleted: []string{"payments", "payments-old", "checkout"},
+		},
+		{
+			name:        "selector",
+			cmd:         resourceCmd{Selector: "team=payments"},
+			wantDeleted: []string{"payments", "payments-old"},
+		},
+		{
+			name:        "older-than",
+			cmd:         resourceCmd{All: true, OlderThan: age(30 * 24 * time.Hour)},
+			wantDeleted: []string{"payments-old"},
+		},
+		{
+			name:        "selector-and-older-than",
+			cmd:         resourceCmd{Selector: "team=checkout", OlderThan: age(30 * 24 * time.Hour)},
+			wantDeleted: []string{},
+		},
+		{
+			name:        "dry-run",
+			cmd:         resourceCmd{All: true, DryRun: dryRunServer},
+			wantDeleted: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient, err := test.SetupClient(test.WithObjects(
+				newPostgres("payments", map[string]string{"team": "payments"}, time.Hour),
+				newPostgres("payments-old", map[string]string{"team": "payments"}, 40*24*time.Hour),
+				newPostgres("checkout", map[string]string{"team": "checkout"}, time.Hour),
+			))
+			require.NoError(t, err)
+
+			tt.cmd.Force = true
+			tt.cmd.WaitTimeout = time.Second
+			cmd := postgresCmd{resourceCmd: tt.cmd}
+			require.NoError(t, cmd.Validate())
+			require.NoError(t, cmd.Run(ctx, apiClient))
+
+			list := &storage.PostgresList{}
+			require.NoError(t, apiClient.List(ctx, list, runtimeclient.InNamespace(test.DefaultProject)))
+			remaining := map[string]bool{}
+			for _, pg := range list.Items {
+				remaining[pg.Name] = true
+			}
+			assert.Len(t, remaining, 3-len(tt.wantDeleted))
+			for _, name := range tt.wantDeleted {
+				assert.False(t, remaining[name], "expected %q to be deleted", name)
+			}
+		})
+	}
+}
+
+func TestResourceCmdValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     resourceCmd
+		wantErr bool
+	}{
+		{name: "name", cmd: resourceCmd{Name: "test"}},
+		{name: "all", cmd: resourceCmd{All: true, OlderThan: age(time.Hour)}},
+		{name: "selector", cmd: resourceCmd{Selector: "team=payments"}},
+		{name: "nothing", cmd: resourceCmd{}, wantErr: true},
+		{name: "name-and-all", cmd: resourceCmd{Name: "test", All: true}, wantErr: true},
+		{name: "all-and-selector", cmd: resourceCmd{All: true, Selector: "team=payments"}, wantErr: true},
+		{name: "older-than-with-name", cmd: resourceCmd{Name: "test", OlderThan: age(time.Hour)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"30d":  30 * 24 * time.Hour,
+		"1.5d": 36 * time.Hour,
+		"12h":  12 * time.Hour,
+		"90m":  90 * time.Minute,
+	} {
+		var a age
+		require.NoError(t, a.UnmarshalText([]byte(in)))
+		assert.Equal(t, want, time.Duration(a), in)
+	}
+
+	var a age
+	assert.Error(t, a.UnmarshalText([]byte("30x")))
+}
+
+func TestBulkDeleteProtected(t *testing.T) {
+	ctx := context.Background()
+
+	for name, forced := range map[string]bool{"skipped": false, "forced": true} {
+		t.Run(name, func(t *testing.T) {
+			protected := test.Postgres("protected", test.DefaultProject, "nine-es34")
+			util.SetProtected(protected, true)
+			apiClient, err := test.SetupClient(test.WithObjects(
+				protected,
+				test.Postgres("unprotected", test.DefaultProject, "nine-es34"),
+			))
+			require.NoError(t, err)
+
+			cmd := postgresCmd{resourceCmd: resourceCmd{All: true, Force: true, ForceProtected: forced, WaitTimeout: time.Second}}
+			require.NoError(t, cmd.Run(ctx, apiClient))
+
+			list := &storage.PostgresList{}
+			require.NoError(t, apiClient.List(ctx, list, runtimeclient.InNamespace(test.DefaultProject)))
+			if forced {
+				assert.Empty(t, list.Items)
+				return
+			}
+			require.Len(t, list.Items, 1)
+			assert.Equal(t, "protected", list.Items[0].Name)
+		})
+	}
+}
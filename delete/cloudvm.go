@@ -15,6 +15,10 @@ type cloudVMCmd struct {
 }
 
 func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &infrastructure.CloudVirtualMachineList{}, infrastructure.CloudVirtualMachineKind)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -24,5 +28,5 @@ func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client) error {
 		return fmt.Errorf("unable to get cloud virtual machine %q: %w", cloudVM.Name, err)
 	}
 
-	return newDeleter(cloudVM, infrastructure.CloudVirtualMachineKind).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+	return newDeleter(cloudVM, infrastructure.CloudVirtualMachineKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
 }
@@ -5,31 +5,52 @@ import (
 	"fmt"
 	"time"
 
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/format"
 	"k8s.io/apimachinery/pkg/api/errors"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type Cmd struct {
 	Filename            string               `short:"f" predictor:"file"`
-	FromFile            fromFile             `cmd:"" default:"1" name:"-f <file>" help:"Delete any resource from a yaml or json file."`
+	FromFile            fromFile             `cmd:"" default:"withargs" name:"-f <file>" help:"Delete any resource from a yaml or json file."`
 	VCluster            vclusterCmd          `cmd:"" group:"infrastructure.nine.ch" name:"vcluster" help:"Delete a vcluster."`
 	APIServiceAccount   apiServiceAccountCmd `cmd:"" group:"iam.nine.ch" name:"apiserviceaccount" aliases:"asa" help:"Delete an API Service Account."`
 	Project             projectCmd           `cmd:"" group:"management.nine.ch" name:"project" aliases:"proj" help:"Delete a Project."`
 	Config              configCmd            `cmd:"" group:"deplo.io" name:"config" help:"Delete a deplo.io Project Configuration."`
-	Application         applicationCmd       `cmd:"" group:"deplo.io" name:"application" aliases:"app,application" help:"Delete a deplo.io Application."`
+	Application         applicationCmd       `cmd:"" group:"deplo.io" name:"application" aliases:"app,apps" help:"Delete a deplo.io Application."`
+	Build               buildCmd             `cmd:"" group:"deplo.io" name:"build" aliases:"builds" help:"Delete a deplo.io Build."`
 	MySQL               mySQLCmd             `cmd:"" group:"storage.nine.ch" name:"mysql" help:"Delete a MySQL instance."`
-	Postgres            postgresCmd          `cmd:"" group:"storage.nine.ch" name:"postgres" help:"Delete a PostgreSQL instance."`
+	Postgres            postgresCmd          `cmd:"" group:"storage.nine.ch" name:"postgres" aliases:"pg" help:"Delete a PostgreSQL instance."`
 	KeyValueStore       keyValueStoreCmd     `cmd:"" group:"storage.nine.ch" name:"keyvaluestore" aliases:"kvs" help:"Delete a KeyValueStore instance."`
-	CloudVirtualMachine cloudVMCmd           `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm" help:"Delete a CloudVM."`
+	CloudVirtualMachine cloudVMCmd           `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm,vm" help:"Delete a CloudVM."`
+	ArgoCD              argoCDCmd            `cmd:"" group:"devtools.nine.ch" name:"argocd" aliases:"argo" help:"Delete an Argo CD instance."`
+	Grafana             grafanaCmd           `cmd:"" group:"observability.nine.ch" name:"grafana" help:"Delete a Grafana instance."`
+	Registry            registryCmd          `cmd:"" group:"storage.nine.ch" name:"registry" help:"Delete a container registry."`
+	SSHKey              sshKeyCmd            `cmd:"" group:"security.nine.ch" name:"sshkey" aliases:"sshkeys" help:"Delete an SSH key pair."`
 }
 
 type resourceCmd struct {
-	Name        string        `arg:"" predictor:"resource_name" help:"Name of the resource to delete."`
-	Force       bool          `default:"false" help:"Do not ask for confirmation of deletion."`
-	Wait        bool          `default:"true" help:"Wait until resource is fully deleted"`
-	WaitTimeout time.Duration `default:"5m" help:"Duration to wait for the deletion. Only relevant if wait is set."`
+	Name           string        `arg:"" optional:"" predictor:"resource_name" help:"Name of the resource to delete."`
+	Selector       string        `short:"l" help:"Delete all resources matching this label selector (e.g. team=payments)."`
+	All            bool          `help:"Delete all resources of this kind in the project."`
+	OlderThan      age           `help:"Only delete resources older than this age (e.g. 30d or 12h). Only relevant together with --all or --selector."`
+	Force          bool          `default:"false" help:"Do not ask for confirmation of deletion."`
+	Wait           bool          `default:"true" help:"Wait until resource is fully deleted"`
+	WaitTimeout    time.Duration `default:"5m" help:"Duration to wait for the deletion. Only relevant if wait is set."`
+	DryRun         string        `help:"Must be \"none\" or \"server\". If server, the deletion is validated by the API (including webhooks) without actually deleting the resource. ${enum}" enum:"none,server" default:"none"`
+	ForceProtected bool          `help:"Delete the resource even if it has been protected against deletion, e.g. with \"nctl update app --protect\"."`
+}
+
+const dryRunServer = "server"
+
+// serverDryRun returns true if the deletion should only be submitted as a
+// server-side dry-run.
+func (cmd resourceCmd) serverDryRun() bool {
+	return cmd.DryRun == dryRunServer
 }
 
 // cleanupFunc is called after the resource has been deleted in order to do
@@ -44,6 +65,9 @@ type deleter struct {
 	mg      resource.Managed
 	cleanup cleanupFunc
 	prompt  promptFunc
+	dryRun  bool
+	// forceProtected allows to delete protected resources.
+	forceProtected bool
 }
 
 // deleterOption allows to set options for the deletion
@@ -77,6 +101,23 @@ func prompt(prompt promptFunc) deleterOption {
 	}
 }
 
+// dryRun configures the deleter to only submit a server-side dry-run. No
+// confirmation is asked, nothing is waited for and no cleanup is done as the
+// resource is never actually deleted.
+func dryRun(enabled bool) deleterOption {
+	return func(d *deleter) {
+		d.dryRun = enabled
+	}
+}
+
+// forceProtected configures the deleter to delete the resource even if it
+// has been protected against deletion.
+func forceProtected(enabled bool) deleterOption {
+	return func(d *deleter) {
+		d.forceProtected = enabled
+	}
+}
+
 func noCleanup(client *api.Client) error {
 	return nil
 }
@@ -94,6 +135,18 @@ func (d *deleter) deleteResource(ctx context.Context, client *api.Client, waitTi
 		return fmt.Errorf("unable to get %s %q: %w", d.kind, d.mg.GetName(), err)
 	}
 
+	if util.IsProtected(d.mg) && !d.forceProtected {
+		return protectedError(d.kind, d.mg.GetName())
+	}
+
+	if d.dryRun {
+		if err := client.Delete(ctx, d.mg, runtimeclient.DryRunAll); err != nil {
+			return fmt.Errorf("unable to delete %s %q: %w", d.kind, d.mg.GetName(), err)
+		}
+		format.PrintSuccessf("🧪", "deleted %s %q (server dry run)", d.kind, d.mg.GetName())
+		return nil
+	}
+
 	if !force {
 		ok, err := format.Confirm(d.prompt(d.kind, d.mg.GetName()))
 		if err != nil {
@@ -120,8 +173,12 @@ func (d *deleter) deleteResource(ctx context.Context, client *api.Client, waitTi
 	return d.cleanup(client)
 }
 
+func protectedError(kind, name string) error {
+	return fmt.Errorf("%s %q is protected against deletion, use --force-protected to delete it anyway", kind, name)
+}
+
 func (d *deleter) waitForDeletion(ctx context.Context, client *api.Client) error {
-	spinner, err := format.NewSpinner(
+	spinner, err := format.NewProgress(
 		format.ProgressMessagef("⏳", "%s is being deleted", d.kind),
 		format.ProgressMessagef("🗑", "%s deleted", d.kind),
 	)
@@ -132,32 +189,31 @@ func (d *deleter) waitForDeletion(ctx context.Context, client *api.Client) error
 	_ = spinner.Start()
 	defer func() { _ = spinner.Stop() }()
 
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ticker.C:
-			if err := client.Get(ctx, client.Name(d.mg.GetName()), d.mg); err != nil {
-				if errors.IsNotFound(err) {
-					_ = spinner.Stop()
-					return nil
-				}
-
-				_ = spinner.StopFail()
-				return fmt.Errorf("unable to get %s %q: %w", d.kind, d.mg.GetName(), err)
-			}
-		case <-ctx.Done():
-			switch ctx.Err() {
-			case context.DeadlineExceeded:
-				msg := "timeout waiting for %s"
-				spinner.StopFailMessage(format.ProgressMessagef("", msg, d.kind))
-				_ = spinner.StopFail()
-
-				return fmt.Errorf(msg, d.kind)
-			case context.Canceled:
-				_ = spinner.StopFail()
-				return nil
+	err = client.WaitUntil(ctx, time.Second, func() (bool, error) {
+		if err := client.Get(ctx, client.Name(d.mg.GetName()), d.mg); err != nil {
+			if errors.IsNotFound(err) {
+				return true, nil
 			}
+			return false, fmt.Errorf("unable to get %s %q: %w", d.kind, d.mg.GetName(), err)
 		}
+		spinner.SetStatus(string(d.mg.GetCondition(runtimev1.TypeReady).Reason))
+		return false, nil
+	}, api.WatchObject(d.mg))
+	switch {
+	case err == nil:
+		_ = spinner.Stop()
+		return nil
+	case ctx.Err() == context.DeadlineExceeded:
+		msg := "timeout waiting for %s"
+		spinner.StopFailMessage(format.ProgressMessagef("", msg, d.kind))
+		_ = spinner.StopFail()
+
+		return fmt.Errorf(msg, d.kind)
+	case ctx.Err() == context.Canceled:
+		_ = spinner.StopFail()
+		return nil
+	default:
+		_ = spinner.StopFail()
+		return err
 	}
 }
@@ -3,10 +3,12 @@ package delete
 import (
 	"context"
 	"testing"
+	"time"
 
 	apps "github.com/ninech/apis/apps/v1alpha1"
 	iam "github.com/ninech/apis/iam/v1alpha1"
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/test"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -37,3 +39,44 @@ func TestDeleter(t *testing.T) {
 		t.Fatalf("expected resource to not exist after delete, got %s", err)
 	}
 }
+
+func TestDeleterDryRun(t *testing.T) {
+	ctx := context.Background()
+	asa := &iam.APIServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: test.DefaultProject,
+		},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(asa),
+	)
+	require.NoError(t, err)
+
+	d := newDeleter(asa, iam.APIServiceAccountKind, dryRun(true))
+	require.NoError(t, d.deleteResource(ctx, apiClient, time.Second, true, false))
+	require.NoError(t, apiClient.Get(ctx, api.ObjectName(asa), asa))
+}
+
+func TestDeleterProtected(t *testing.T) {
+	ctx := context.Background()
+	asa := &iam.APIServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   test.DefaultProject,
+			Annotations: map[string]string{util.ProtectedAnnotation: "true"},
+		},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(asa),
+	)
+	require.NoError(t, err)
+
+	d := newDeleter(asa, iam.APIServiceAccountKind)
+	require.ErrorContains(t, d.deleteResource(ctx, apiClient, time.Second, false, true), "--force-protected")
+	require.NoError(t, apiClient.Get(ctx, api.ObjectName(asa), asa))
+
+	d = newDeleter(asa, iam.APIServiceAccountKind, forceProtected(true))
+	require.NoError(t, d.deleteResource(ctx, apiClient, time.Second, false, true))
+	require.True(t, errors.IsNotFound(apiClient.Get(ctx, api.ObjectName(asa), asa)))
+}
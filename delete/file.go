@@ -8,8 +8,10 @@ import (
 )
 
 type fromFile struct {
+	DryRun         string `help:"Must be \"none\" or \"server\". If server, the deletion is validated by the API (including webhooks) without actually deleting the resources. ${enum}" enum:"none,server" default:"none"`
+	ForceProtected bool   `help:"Delete the resource even if it has been protected against deletion, e.g. with \"nctl update app --protect\"."`
 }
 
 func (cmd *fromFile) Run(ctx context.Context, client *api.Client, delete *Cmd) error {
-	return apply.File(ctx, client, delete.Filename, apply.Delete())
+	return apply.File(ctx, client, delete.Filename, apply.Delete(), apply.DryRun(cmd.DryRun == apply.DryRunServer), apply.ForceProtected(cmd.ForceProtected))
 }
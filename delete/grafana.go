@@ -0,0 +1,29 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	observability "github.com/ninech/apis/observability/v1alpha1"
+	"github.com/ninech/nctl/api"
+)
+
+type grafanaCmd struct {
+	resourceCmd
+}
+
+func (cmd *grafanaCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &observability.GrafanaList{}, observability.GrafanaKind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	grafana := &observability.Grafana{}
+	if err := client.Get(ctx, client.Name(cmd.Name), grafana); err != nil {
+		return fmt.Errorf("unable to get grafana %q: %w", cmd.Name, err)
+	}
+
+	return newDeleter(grafana, observability.GrafanaKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+}
@@ -15,6 +15,10 @@ type keyValueStoreCmd struct {
 }
 
 func (cmd *keyValueStoreCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &storage.KeyValueStoreList{}, storage.KeyValueStoreKind)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -24,5 +28,5 @@ func (cmd *keyValueStoreCmd) Run(ctx context.Context, client *api.Client) error
 		return fmt.Errorf("unable to get keyvaluestore %q: %w", keyValueStore.Name, err)
 	}
 
-	return newDeleter(keyValueStore, storage.KeyValueStoreKind).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+	return newDeleter(keyValueStore, storage.KeyValueStoreKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
 }
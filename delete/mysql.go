@@ -15,6 +15,10 @@ type mySQLCmd struct {
 }
 
 func (cmd *mySQLCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &storage.MySQLList{}, storage.MySQLKind)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -24,5 +28,5 @@ func (cmd *mySQLCmd) Run(ctx context.Context, client *api.Client) error {
 		return fmt.Errorf("unable to get mysql %q: %w", mysql.Name, err)
 	}
 
-	return newDeleter(mysql, storage.MySQLKind).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+	return newDeleter(mysql, storage.MySQLKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
 }
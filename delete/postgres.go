@@ -15,6 +15,10 @@ type postgresCmd struct {
 }
 
 func (cmd *postgresCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &storage.PostgresList{}, storage.PostgresKind)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
 	defer cancel()
 
@@ -24,5 +28,5 @@ func (cmd *postgresCmd) Run(ctx context.Context, client *api.Client) error {
 		return fmt.Errorf("unable to get postgres %q: %w", postgres.Name, err)
 	}
 
-	return newDeleter(postgres, storage.PostgresKind).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+	return newDeleter(postgres, storage.PostgresKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
 }
@@ -14,14 +14,20 @@ type projectCmd struct {
 }
 
 func (proj *projectCmd) Run(ctx context.Context, client *api.Client) error {
-	ctx, cancel := context.WithTimeout(ctx, proj.WaitTimeout)
-	defer cancel()
-
 	org, err := client.Organization()
 	if err != nil {
 		return err
 	}
 
+	if proj.bulk() {
+		// projects are always in the main organization namespace
+		client.Project = org
+		return proj.bulkDelete(ctx, client, &management.ProjectList{}, management.ProjectKind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, proj.WaitTimeout)
+	defer cancel()
+
 	d := newDeleter(
 		&management.Project{
 			ObjectMeta: metav1.ObjectMeta{
@@ -31,6 +37,7 @@ func (proj *projectCmd) Run(ctx context.Context, client *api.Client) error {
 		},
 		management.ProjectKind,
 		prompt(projectDeletePrompt(org)),
+		dryRun(proj.serverDryRun()), forceProtected(proj.ForceProtected),
 	)
 
 	// we need to overwrite the namespace as projects are always in the
@@ -11,9 +11,11 @@ import (
 )
 
 type configCmd struct {
-	Force       bool          `default:"false" help:"Do not ask for confirmation of deletion."`
-	Wait        bool          `default:"true" help:"Wait until Project Configuration is fully deleted."`
-	WaitTimeout time.Duration `default:"10s" help:"Duration to wait for the deletion. Only relevant if wait is set."`
+	Force          bool          `default:"false" help:"Do not ask for confirmation of deletion."`
+	Wait           bool          `default:"true" help:"Wait until Project Configuration is fully deleted."`
+	WaitTimeout    time.Duration `default:"10s" help:"Duration to wait for the deletion. Only relevant if wait is set."`
+	DryRun         string        `help:"Must be \"none\" or \"server\". If server, the deletion is validated by the API (including webhooks) without actually deleting the resource. ${enum}" enum:"none,server" default:"none"`
+	ForceProtected bool          `help:"Delete the Project Configuration even if it has been protected against deletion."`
 }
 
 func (cmd *configCmd) Run(ctx context.Context, client *api.Client) error {
@@ -27,7 +29,7 @@ func (cmd *configCmd) Run(ctx context.Context, client *api.Client) error {
 		},
 	}
 
-	d := newDeleter(c, apps.ProjectConfigKind)
+	d := newDeleter(c, apps.ProjectConfigKind, dryRun(cmd.DryRun == dryRunServer), forceProtected(cmd.ForceProtected))
 
 	if err := d.deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force); err != nil {
 		return fmt.Errorf("error while deleting %s: %w", apps.ProjectConfigKind, err)
@@ -6,6 +6,7 @@ import (
 
 	apps "github.com/ninech/apis/apps/v1alpha1"
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -46,3 +47,41 @@ func TestProjectConfig(t *testing.T) {
 		t.Fatalf("expected project configuration to not exist after delete, got %s", err)
 	}
 }
+
+func TestProjectConfigProtected(t *testing.T) {
+	project := "some-project"
+
+	cfg := &apps.ProjectConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        project,
+			Namespace:   project,
+			Annotations: map[string]string{util.ProtectedAnnotation: "true"},
+		},
+	}
+
+	apiClient, err := test.SetupClient(
+		test.WithProjects(project),
+		test.WithDefaultProject(project),
+		test.WithObjects(cfg),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	cmd := configCmd{Force: true}
+	if err := cmd.Run(ctx, apiClient); err == nil {
+		t.Fatal("expected deleting a protected project configuration to fail")
+	}
+	if err := apiClient.Get(ctx, api.ObjectName(cfg), cfg); err != nil {
+		t.Fatalf("expected project configuration to still exist, got %s", err)
+	}
+
+	cmd.ForceProtected = true
+	if err := cmd.Run(ctx, apiClient); err != nil {
+		t.Fatal(err)
+	}
+	if !errors.IsNotFound(apiClient.Get(ctx, api.ObjectName(cfg), cfg)) {
+		t.Fatal("expected project configuration to not exist after forced delete")
+	}
+}
@@ -0,0 +1,29 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+)
+
+type registryCmd struct {
+	resourceCmd
+}
+
+func (cmd *registryCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &storage.RegistryList{}, storage.RegistryKind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	registry := &storage.Registry{}
+	if err := client.Get(ctx, client.Name(cmd.Name), registry); err != nil {
+		return fmt.Errorf("unable to get registry %q: %w", cmd.Name, err)
+	}
+
+	return newDeleter(registry, storage.RegistryKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+}
@@ -0,0 +1,29 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	security "github.com/ninech/apis/security/v1alpha1"
+	"github.com/ninech/nctl/api"
+)
+
+type sshKeyCmd struct {
+	resourceCmd
+}
+
+func (cmd *sshKeyCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.bulk() {
+		return cmd.bulkDelete(ctx, client, &security.SSHKeyList{}, security.SSHKeyKind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	key := &security.SSHKey{}
+	if err := client.Get(ctx, client.Name(cmd.Name), key); err != nil {
+		return fmt.Errorf("unable to get ssh key %q: %w", cmd.Name, err)
+	}
+
+	return newDeleter(key, security.SSHKeyKind, dryRun(cmd.serverDryRun()), forceProtected(cmd.ForceProtected)).deleteResource(ctx, client, cmd.WaitTimeout, cmd.Wait, cmd.Force)
+}
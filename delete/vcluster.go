@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/api/config"
@@ -13,9 +14,24 @@ import (
 
 type vclusterCmd struct {
 	resourceCmd
+	// ContextNameTemplate needs to be the same as the one of auth cluster,
+	// so that the context of the vcluster is found.
+	ContextNameTemplate string `help:"Go template for the name of the kubeconfig context of the vcluster which is removed, see \"nctl auth cluster --help\". Defaults to name/project." env:"NCTL_CONTEXT_NAME_TEMPLATE" placeholder:"TEMPLATE"`
 }
 
 func (vc *vclusterCmd) Run(ctx context.Context, client *api.Client) error {
+	if vc.bulk() {
+		return vc.bulkDelete(ctx, client, &infrastructure.KubernetesClusterList{}, "vcluster",
+			matching(func(mg resource.Managed) bool {
+				cluster, ok := mg.(*infrastructure.KubernetesCluster)
+				return ok && cluster.Spec.ForProvider.VCluster != nil
+			}),
+			deleteWith(func(ctx context.Context, client *api.Client, mg resource.Managed) error {
+				return vc.newDeleter(mg.(*infrastructure.KubernetesCluster)).deleteResource(ctx, client, vc.WaitTimeout, vc.Wait, true)
+			}),
+		)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, vc.WaitTimeout)
 	defer cancel()
 
@@ -29,17 +45,37 @@ func (vc *vclusterCmd) Run(ctx context.Context, client *api.Client) error {
 		return fmt.Errorf("supplied cluster %q is not a vcluster", config.ContextName(cluster))
 	}
 
-	d := newDeleter(cluster, "vcluster", cleanup(
+	if err := vc.newDeleter(cluster).deleteResource(ctx, client, vc.WaitTimeout, vc.Wait, vc.Force); err != nil {
+		return fmt.Errorf("unable to delete vcluster: %w", err)
+	}
+
+	return nil
+}
+
+func (vc *vclusterCmd) newDeleter(cluster *infrastructure.KubernetesCluster) *deleter {
+	return newDeleter(cluster, "vcluster", cleanup(
 		func(client *api.Client) error {
-			if err := config.RemoveClusterFromKubeConfig(client.KubeconfigPath, config.ContextName(cluster)); err != nil {
+			contextName, err := vc.contextName(client, cluster)
+			if err == nil {
+				err = config.RemoveClusterFromKubeConfig(client.KubeconfigPath, contextName)
+			}
+			if err != nil {
 				format.PrintWarningf("unable to remove cluster from kubeconfig: %s\n", err)
 			}
 			return nil
-		}))
+		}),
+		dryRun(vc.serverDryRun()), forceProtected(vc.ForceProtected),
+	)
+}
 
-	if err := d.deleteResource(ctx, client, vc.WaitTimeout, vc.Wait, vc.Force); err != nil {
-		return fmt.Errorf("unable to delete vcluster: %w", err)
+// contextName returns the name of the kubeconfig context of the cluster.
+func (vc *vclusterCmd) contextName(client *api.Client, cluster *infrastructure.KubernetesCluster) (string, error) {
+	if vc.ContextNameTemplate == "" {
+		return config.ContextName(cluster), nil
 	}
-
-	return nil
+	org, err := client.Organization()
+	if err != nil {
+		return "", err
+	}
+	return config.ClusterContextName(vc.ContextNameTemplate, org, cluster)
 }
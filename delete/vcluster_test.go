@@ -0,0 +1,51 @@
+package delete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestVClusterRemovesContext(t *testing.T) {
+	ctx := context.Background()
+	cluster := &infrastructure.KubernetesCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: test.DefaultProject},
+		Spec: infrastructure.KubernetesClusterSpec{
+			ForProvider: infrastructure.KubernetesClusterParameters{VCluster: &infrastructure.VClusterSettings{}},
+		},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(cluster),
+		test.WithKubeconfig(t),
+		test.WithOrganization("evilcorp"),
+	)
+	require.NoError(t, err)
+
+	// the context written by auth cluster with the same template
+	contextName := "evilcorp-" + test.DefaultProject + "-dev"
+	kubeconfig, err := clientcmd.LoadFromFile(apiClient.KubeconfigPath)
+	require.NoError(t, err)
+	kubeconfig.Clusters[contextName] = &clientcmdapi.Cluster{Server: "https://dev.example.org"}
+	kubeconfig.AuthInfos[contextName] = &clientcmdapi.AuthInfo{}
+	kubeconfig.Contexts[contextName] = &clientcmdapi.Context{Cluster: contextName, AuthInfo: contextName}
+	require.NoError(t, clientcmd.WriteToFile(*kubeconfig, apiClient.KubeconfigPath))
+
+	cmd := vclusterCmd{
+		resourceCmd:         resourceCmd{Name: "dev", Force: true, WaitTimeout: time.Second},
+		ContextNameTemplate: "{{.Org}}-{{.Project}}-{{.Name}}",
+	}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+
+	kubeconfig, err = clientcmd.LoadFromFile(apiClient.KubeconfigPath)
+	require.NoError(t, err)
+	assert.NotContains(t, kubeconfig.Contexts, contextName)
+	assert.NotContains(t, kubeconfig.Clusters, contextName)
+}
@@ -0,0 +1,168 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultFile is the app config file which is read if no other file is
+// given. It is meant to be checked in to the root of the app repository.
+const DefaultFile = "nctl.yaml"
+
+// Config describes an application in the app config file. Fields which are
+// not set are left as they are on the live application, so they can still
+// be managed with "nctl update app".
+type Config struct {
+	// Name of the application.
+	Name string `json:"name"`
+	// Project of the application. Defaults to the current project.
+	Project   string            `json:"project,omitempty"`
+	Git       GitConfig         `json:"git"`
+	Language  string            `json:"language,omitempty"`
+	Size      string            `json:"size,omitempty"`
+	Port      *int32            `json:"port,omitempty"`
+	Replicas  *int32            `json:"replicas,omitempty"`
+	BasicAuth *bool             `json:"basicAuth,omitempty"`
+	Hosts     []string          `json:"hosts,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	BuildEnv  map[string]string `json:"buildEnv,omitempty"`
+}
+
+// GitConfig is the git source of the application. Credentials are not part
+// of the config file as it is checked in, they have to be set up with
+// "nctl create app" or "nctl update app".
+type GitConfig struct {
+	URL      string `json:"url"`
+	SubPath  string `json:"subPath,omitempty"`
+	Revision string `json:"revision,omitempty"`
+}
+
+// ReadConfig reads and validates the app config file at path. Unknown
+// fields are rejected to catch typos.
+func ReadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read app config: %w", err)
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("invalid app config %s: %w", path, err)
+	}
+	if config.Name == "" {
+		return nil, errors.New("app config has no name")
+	}
+	if config.Git.URL == "" {
+		return nil, errors.New("app config has no git url")
+	}
+	return config, nil
+}
+
+// NewApplication returns a new application as described by the config.
+func (c *Config) NewApplication(project string) *apps.Application {
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        c.Name,
+			Namespace:   project,
+			Annotations: map[string]string{util.ManagedByAnnotation: util.NctlName},
+		},
+	}
+	c.apply(app, false)
+	return app
+}
+
+// apply sets all fields of the config which are set on the app. The env
+// variables of the config are added to the ones of the app, other variables
+// are only removed if pruneEnv is set.
+func (c *Config) apply(app *apps.Application, pruneEnv bool) {
+	params := &app.Spec.ForProvider
+	params.Git.URL = c.Git.URL
+	if c.Git.SubPath != "" {
+		params.Git.SubPath = c.Git.SubPath
+	}
+	if c.Git.Revision != "" {
+		params.Git.Revision = c.Git.Revision
+	}
+	if params.Git.Revision == "" {
+		params.Git.Revision = "main"
+	}
+	if c.Language != "" {
+		params.Language = apps.Language(c.Language)
+	}
+	if c.Size != "" {
+		params.Config.Size = apps.ApplicationSize(c.Size)
+	}
+	if c.Port != nil {
+		params.Config.Port = c.Port
+	}
+	if c.Replicas != nil {
+		params.Config.Replicas = c.Replicas
+	}
+	if c.BasicAuth != nil {
+		params.Config.EnableBasicAuth = c.BasicAuth
+	}
+	if c.Hosts != nil {
+		params.Hosts = c.Hosts
+	}
+	if c.Env != nil {
+		params.Config.Env = mergeEnv(params.Config.Env, c.Env, pruneEnv)
+	}
+	if c.BuildEnv != nil {
+		params.BuildEnv = mergeEnv(params.BuildEnv, c.BuildEnv, pruneEnv)
+	}
+}
+
+func mergeEnv(live apps.EnvVars, env map[string]string, prune bool) apps.EnvVars {
+	if prune {
+		return util.EnvVarsFromMap(env)
+	}
+	return util.UpdateEnvVars(live, env, nil)
+}
+
+// ConfigFromApplication returns the config describing an existing
+// application. The env variables are left out as the config file is meant to
+// be checked in and they might contain secrets.
+func ConfigFromApplication(app *apps.Application) *Config {
+	params := app.Spec.ForProvider
+	c := &Config{
+		Name:    app.Name,
+		Project: app.Namespace,
+		Git: GitConfig{
+			URL:      params.Git.URL,
+			SubPath:  params.Git.SubPath,
+			Revision: params.Git.Revision,
+		},
+		Language: string(params.Language),
+		Size:     string(params.Config.Size),
+		Port:     params.Config.Port,
+		Replicas: params.Config.Replicas,
+	}
+	if len(params.Hosts) != 0 {
+		c.Hosts = params.Hosts
+	}
+	return c
+}
+
+// WriteConfig writes the config to a new app config file at path. An
+// existing file is not overwritten.
+func WriteConfig(path string, c *Config) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to write app config: %w", err)
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write app config: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,281 @@
+// Package deploy contains the command to deploy an application described in
+// an app config file.
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type Cmd struct {
+	File         string            `short:"f" default:"nctl.yaml" predictor:"file" help:"App config file describing the application."`
+	DryRun       bool              `help:"Only print the changes which would be made to the application."`
+	Revision     string            `help:"Git revision to deploy instead of the one of the app config, e.g. the commit which triggered a CI pipeline."`
+	PruneEnv     bool              `help:"Remove the env variables of the application which are not in the env or buildEnv of the app config. Without it, variables like secrets set with \"nctl update app --env\" are kept."`
+	Force        bool              `help:"Do not ask for confirmation before updating the application."`
+	Wait         bool              `help:"Wait until the build and release triggered by the deploy are done."`
+	WaitTimeout  time.Duration     `default:"30m" help:"Duration to wait for the release. Only relevant if wait is set."`
+	NotifyURL    string            `help:"URL to post the deploy, build and release events to as JSON, e.g. a Slack incoming webhook. Implies --wait." env:"NCTL_NOTIFY_URL" placeholder:"URL"`
+	Message      string            `short:"m" help:"Message describing the deploy. It is stored on the release and shown by \"nctl get releases -o wide\". Implies --wait."`
+	Annotations  map[string]string `name:"annotation" placeholder:"KEY=VALUE" help:"Annotation to add to the release, e.g. jira=SHOP-123. Can be repeated. Implies --wait."`
+	GitHubStatus bool              `name:"github-status" help:"Post the progress of the deploy as commit status to the deployed commit, or the head commit of the pull request or the commit which triggered the GitHub Actions workflow if the revision is not a commit. Needs GITHUB_TOKEN to be set. Implies --wait."`
+	Resume       bool              `help:"Continue waiting for the rollout of the last deploy of the application, e.g. after the wait has been interrupted. The application is not changed."`
+	out          io.Writer
+	// github posts commit statuses if --github-status is set.
+	github *githubStatus
+	// confirm asks if the changes should be applied, defaults to
+	// format.Confirmf.
+	confirm func(format string, a ...any) (bool, error)
+}
+
+func (cmd *Cmd) Help() string {
+	return `Creates or updates an application as described in the app config file.
+Changes to an existing application are shown before they are applied.
+
+Example nctl.yaml:
+
+  name: myapp
+  git:
+    url: https://github.com/ninech/deploio-examples
+    subPath: ruby/basic
+    revision: main
+  size: mini
+  replicas: 2
+  hosts:
+    - myapp.example.org
+  env:
+    RAILS_ENV: production
+  buildEnv:
+    BUNDLE_WITHOUT: development
+
+Fields which are not set in the file are left untouched on the application.
+The env variables of the file are added to the ones of the application,
+variables which are only set on the application are kept unless --prune-env
+is given.
+
+With --notify-url, an event like the following is posted for the deploy, the
+build and the release:
+
+  {"text": "release myapp-abc of application myapp in project acme is available",
+   "event": "release", "status": "success", "application": "myapp",
+   "project": "acme", "name": "myapp-abc", "time": "2024-01-01T12:00:00Z"}
+
+A message and annotations can be added to the release of the deploy for later
+reference:
+
+  nctl deploy --message "fix checkout bug" --annotation jira=SHOP-123
+
+In GitHub Actions, the deploy can be shown on the commit which triggered the
+workflow with --github-status. The job needs the "statuses: write" permission:
+
+  steps:
+    - run: nctl deploy --github-status
+      env:
+        GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}
+
+If the wait for the rollout is interrupted, e.g. with ctrl-c, it can be
+continued with --resume.
+`
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.confirm == nil {
+		cmd.confirm = format.Confirmf
+	}
+
+	if err := validateAnnotations(cmd.Annotations); err != nil {
+		return err
+	}
+	config, err := ReadConfig(cmd.File)
+	if err != nil {
+		return err
+	}
+	if cmd.Revision != "" {
+		config.Git.Revision = cmd.Revision
+	}
+	if cmd.GitHubStatus && !cmd.DryRun {
+		github, err := githubStatusFromEnv(config.Git.Revision)
+		if err != nil {
+			return err
+		}
+		cmd.github = github
+	}
+	project := client.Project
+	if config.Project != "" {
+		project = config.Project
+	}
+
+	app := &apps.Application{}
+	if err := client.Get(ctx, api.NamespacedName(config.Name, project), app); err != nil {
+		if !kerrors.IsNotFound(err) || cmd.Resume {
+			return err
+		}
+		return cmd.create(ctx, client, config.NewApplication(project))
+	}
+	if cmd.Resume {
+		return cmd.resume(ctx, client, app)
+	}
+	desired := app.DeepCopy()
+	config.apply(desired, cmd.PruneEnv)
+	diff := specDiff(app, desired)
+	if diff == "" {
+		format.PrintSuccessf("✅", "application %q is up to date", app.Name)
+		return nil
+	}
+
+	fmt.Fprintf(cmd.out, "changes to application %q (-live +%s):\n%s\n", app.Name, cmd.File, diff)
+	if cmd.DryRun {
+		return nil
+	}
+	if !cmd.Force {
+		ok, err := cmd.confirm("update application %q?", app.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	since := time.Now()
+	before, err := cmd.existing(ctx, client, desired)
+	if err != nil {
+		return err
+	}
+	if err := client.Update(ctx, desired); err != nil {
+		cmd.notifyDeploy(ctx, desired, err)
+		return err
+	}
+	format.PrintSuccessf("🚀", "updated application %q", app.Name)
+	cmd.notifyDeploy(ctx, desired, nil)
+	return cmd.wait(ctx, client, desired, since, before)
+}
+
+func (cmd *Cmd) create(ctx context.Context, client *api.Client, app *apps.Application) error {
+	if cmd.DryRun {
+		fmt.Fprintf(cmd.out, "application %q does not exist yet and would be created in project %s\n", app.Name, app.Namespace)
+		return nil
+	}
+
+	// builds and releases of an earlier application with the same name
+	// might still exist.
+	since := time.Now()
+	before, err := cmd.existing(ctx, client, app)
+	if err != nil {
+		return err
+	}
+	if err := client.Create(ctx, app); err != nil {
+		cmd.notifyDeploy(ctx, app, err)
+		return err
+	}
+	format.PrintSuccessf("🚀", "created application %q, follow the build with: nctl logs build -a %s", app.Name, app.Name)
+	cmd.notifyDeploy(ctx, app, nil)
+	return cmd.wait(ctx, client, app, since, before)
+}
+
+// resume continues waiting for the rollout of the last deploy of the app.
+func (cmd *Cmd) resume(ctx context.Context, client *api.Client, app *apps.Application) error {
+	token, err := loadResumeToken(app.Namespace, app.Name)
+	if err != nil {
+		return err
+	}
+	cmd.Annotations, cmd.Message = token.Annotations, ""
+	if token.GitHubSHA != "" {
+		// the commit status of the interrupted deploy is finished by
+		// the resumed one.
+		if cmd.github == nil {
+			github, err := githubStatusFromEnv(token.GitHubSHA)
+			if err != nil {
+				format.PrintWarningf("unable to post the commit status of the deploy: %s\n", err)
+			}
+			cmd.github = github
+		}
+		if cmd.github != nil {
+			cmd.github.sha = token.GitHubSHA
+		}
+	}
+	format.PrintSuccessf("🔁", "resuming the deploy of application %q from %s", app.Name, format.Timestamp(token.Since))
+	return cmd.waitForRollout(ctx, client, app, token.Since, token.Existing)
+}
+
+// waits returns if the rollout of the deploy is waited for.
+func (cmd *Cmd) waits() bool {
+	return cmd.Wait || cmd.NotifyURL != "" || cmd.github != nil || len(cmd.releaseAnnotations()) > 0
+}
+
+// existing returns the builds and releases of the app which exist before the
+// deploy, if its rollout is waited for.
+func (cmd *Cmd) existing(ctx context.Context, client *api.Client, app *apps.Application) (existing, error) {
+	if !cmd.waits() {
+		return existing{}, nil
+	}
+	return listExisting(ctx, client, app)
+}
+
+// wait waits for the rollout of the deploy if requested.
+func (cmd *Cmd) wait(ctx context.Context, client *api.Client, app *apps.Application, since time.Time, before existing) error {
+	if !cmd.waits() {
+		return nil
+	}
+	return cmd.waitForRollout(ctx, client, app, since, before)
+}
+
+// releaseAnnotations returns the annotations which are added to the release
+// of the deploy.
+func (cmd *Cmd) releaseAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for k, v := range cmd.Annotations {
+		annotations[k] = v
+	}
+	if cmd.Message != "" {
+		annotations[util.ReleaseMessageAnnotation] = cmd.Message
+	}
+	return annotations
+}
+
+func validateAnnotations(annotations map[string]string) error {
+	for k := range annotations {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation %q: %s", k, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+func (cmd *Cmd) notifyDeploy(ctx context.Context, app *apps.Application, err error) {
+	e := Event{Event: eventDeploy, Status: statusSuccess, Application: app.Name, Project: app.Namespace,
+		Text: fmt.Sprintf("deployed application %s in project %s", app.Name, app.Namespace)}
+	if err != nil {
+		e.Status = statusFailure
+		e.Text = fmt.Sprintf("deploying application %s in project %s failed: %s", app.Name, app.Namespace, err)
+	}
+	cmd.notify(ctx, e)
+}
+
+// specDiff returns the differences between the parameters of the live and
+// the desired application. The order of env variables is ignored as they
+// are unordered in the config file.
+func specDiff(live, desired *apps.Application) string {
+	return cmp.Diff(
+		live.Spec.ForProvider,
+		desired.Spec.ForProvider,
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b apps.EnvVar) bool { return a.Name < b.Name }),
+	)
+}
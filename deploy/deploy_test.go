@@ -0,0 +1,502 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const appConfig = `name: myapp
+git:
+  url: https://github.com/ninech/deploio-examples
+  subPath: ruby/basic
+size: mini
+replicas: 2
+env:
+  B: two
+  A: one
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// deployClient returns a client which creates the pending objects once the
+// application has been created or updated, like the builds and releases
+// started by a deploy.
+func deployClient(t *testing.T, pending *[]runtimeclient.Object, opts ...test.ClientSetupOption) *api.Client {
+	t.Helper()
+	rollout := func(ctx context.Context, c runtimeclient.WithWatch, obj runtimeclient.Object) error {
+		if _, ok := obj.(*apps.Application); !ok {
+			return nil
+		}
+		for _, o := range *pending {
+			if err := c.Create(ctx, o); err != nil {
+				return err
+			}
+		}
+		*pending = nil
+		return nil
+	}
+	apiClient, err := test.SetupClient(append(opts, test.WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c runtimeclient.WithWatch, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+			if err := c.Create(ctx, obj, opts...); err != nil {
+				return err
+			}
+			return rollout(ctx, c, obj)
+		},
+		Update: func(ctx context.Context, c runtimeclient.WithWatch, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
+			if err := c.Update(ctx, obj, opts...); err != nil {
+				return err
+			}
+			return rollout(ctx, c, obj)
+		},
+	}))...)
+	require.NoError(t, err)
+	return apiClient
+}
+
+// tempResumeDir stores the resume tokens in a temporary directory.
+func tempResumeDir(t *testing.T) string {
+	dir := t.TempDir()
+	resumeDir = func() (string, error) { return dir, nil }
+	return dir
+}
+
+func TestDeploy(t *testing.T) {
+	ctx := context.Background()
+	path := writeConfig(t, appConfig)
+	apiClient, err := test.SetupClient()
+	require.NoError(t, err)
+
+	// the app does not exist yet and gets created
+	cmd := &Cmd{File: path, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	app := &apps.Application{}
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, "ruby/basic", app.Spec.ForProvider.Git.SubPath)
+	assert.Equal(t, "main", app.Spec.ForProvider.Git.Revision)
+	assert.Equal(t, apps.ApplicationSize("mini"), app.Spec.ForProvider.Config.Size)
+	assert.Equal(t, util.NctlName, app.Annotations[util.ManagedByAnnotation])
+
+	// the env order does not matter and nothing changed
+	out := &bytes.Buffer{}
+	cmd = &Cmd{File: path, out: out, confirm: func(string, ...any) (bool, error) {
+		t.Fatal("no confirmation expected without changes")
+		return false, nil
+	}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Empty(t, out.String())
+
+	// changes are only printed on dry run
+	app.Spec.ForProvider.Config.Replicas = ptr.To(int32(5))
+	require.NoError(t, apiClient.Update(ctx, app))
+	out.Reset()
+	cmd = &Cmd{File: path, DryRun: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Contains(t, out.String(), "Replicas")
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, int32(5), *app.Spec.ForProvider.Config.Replicas)
+
+	// changes are not applied if not confirmed
+	cmd = &Cmd{File: path, out: &bytes.Buffer{}, confirm: func(string, ...any) (bool, error) { return false, nil }}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, int32(5), *app.Spec.ForProvider.Config.Replicas)
+
+	cmd = &Cmd{File: path, Force: true, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, int32(2), *app.Spec.ForProvider.Config.Replicas)
+}
+
+func TestDeployKeepsUnmanagedFields(t *testing.T) {
+	ctx := context.Background()
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: test.DefaultProject},
+		Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+			Git:   apps.ApplicationGitConfig{GitTarget: apps.GitTarget{URL: "https://example.org/old.git", SubPath: "web", Revision: "main"}},
+			Hosts: []string{"myapp.example.org"},
+		}},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(app))
+	require.NoError(t, err)
+
+	cmd := &Cmd{File: writeConfig(t, "name: myapp\ngit:\n  url: https://example.org/new.git\n"), Force: true, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, "https://example.org/new.git", app.Spec.ForProvider.Git.URL)
+	assert.Equal(t, "web", app.Spec.ForProvider.Git.SubPath)
+	assert.Equal(t, []string{"myapp.example.org"}, app.Spec.ForProvider.Hosts)
+
+	// the revision of the config can be overridden, e.g. by a CI pipeline
+	cmd = &Cmd{File: cmd.File, Revision: "abc123", Force: true, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, "abc123", app.Spec.ForProvider.Git.Revision)
+}
+
+func TestDeployKeepsEnv(t *testing.T) {
+	ctx := context.Background()
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: test.DefaultProject},
+		Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+			Git:    apps.ApplicationGitConfig{GitTarget: apps.GitTarget{URL: "https://github.com/ninech/deploio-examples", SubPath: "ruby/basic", Revision: "main"}},
+			Config: apps.Config{Env: util.EnvVarsFromMap(map[string]string{"A": "old", "SECRET": "s3cret"})},
+		}},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(app))
+	require.NoError(t, err)
+
+	cmd := &Cmd{File: writeConfig(t, appConfig), Force: true, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, map[string]string{"A": "one", "B": "two", "SECRET": "s3cret"}, util.NewAppEnv(app).Env)
+
+	cmd.PruneEnv = true
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	assert.Equal(t, map[string]string{"A": "one", "B": "two"}, util.NewAppEnv(app).Env)
+}
+
+func TestReadConfig(t *testing.T) {
+	_, err := ReadConfig(writeConfig(t, "name: myapp\ngit:\n  url: https://example.org/app.git\nreplica: 2\n"))
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = ReadConfig(writeConfig(t, "git:\n  url: https://example.org/app.git\n"))
+	assert.ErrorContains(t, err, "no name")
+
+	_, err = ReadConfig(filepath.Join(t.TempDir(), DefaultFile))
+	assert.Error(t, err)
+}
+
+func TestWriteConfig(t *testing.T) {
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "dev"},
+		Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+			Git: apps.ApplicationGitConfig{GitTarget: apps.GitTarget{URL: "https://example.org/app.git", Revision: "main"}},
+			Config: apps.Config{
+				Size: "mini",
+				Env:  util.EnvVarsFromMap(map[string]string{"SECRET": "s3cret"}),
+			},
+		}},
+	}
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	require.NoError(t, WriteConfig(path, ConfigFromApplication(app)))
+	assert.Error(t, WriteConfig(path, ConfigFromApplication(app)), "existing files are not overwritten")
+
+	config, err := ReadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "myapp", config.Name)
+	assert.Equal(t, "dev", config.Project)
+	assert.Equal(t, "https://example.org/app.git", config.Git.URL)
+	assert.Equal(t, "mini", config.Size)
+	assert.Empty(t, config.Env)
+}
+
+func TestDeployNotify(t *testing.T) {
+	ctx := context.Background()
+	pollInterval = 10 * time.Millisecond
+	tempResumeDir(t)
+
+	var mu sync.Mutex
+	events := []Event{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e := Event{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&e))
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	labels := map[string]string{util.ApplicationNameLabel: "myapp"}
+	build := &apps.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp-build", Namespace: test.DefaultProject, Labels: labels},
+		Status:     apps.BuildStatus{AtProvider: apps.BuildObservation{BuildStatus: apps.BuildProcessStatusSuccess}},
+	}
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp-release", Namespace: test.DefaultProject, Labels: labels},
+		Status:     apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusAvailable}},
+	}
+	pending := []runtimeclient.Object{build, release}
+	apiClient := deployClient(t, &pending)
+
+	cmd := &Cmd{File: writeConfig(t, appConfig), NotifyURL: srv.URL, WaitTimeout: time.Second, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+
+	require.Len(t, events, 3)
+	for i, want := range []struct{ event, name string }{
+		{eventDeploy, ""},
+		{eventBuild, "myapp-build"},
+		{eventRelease, "myapp-release"},
+	} {
+		assert.Equal(t, want.event, events[i].Event)
+		assert.Equal(t, want.name, events[i].Name)
+		assert.Equal(t, statusSuccess, events[i].Status)
+		assert.Equal(t, "myapp", events[i].Application)
+		assert.NotEmpty(t, events[i].Text)
+	}
+
+	// a failed release fails the deploy
+	events = nil
+	app := &apps.Application{}
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("myapp"), app))
+	app.Spec.ForProvider.Config.Replicas = ptr.To(int32(5))
+	require.NoError(t, apiClient.Update(ctx, app))
+	pending = []runtimeclient.Object{&apps.Release{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp-release-2", Namespace: test.DefaultProject, Labels: labels},
+		Status:     apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusFailure}},
+	}}
+
+	cmd = &Cmd{File: cmd.File, Force: true, NotifyURL: srv.URL, WaitTimeout: time.Second, out: &bytes.Buffer{}}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "release myapp-release-2 failed")
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	assert.Equal(t, eventRelease, last.Event)
+	assert.Equal(t, statusFailure, last.Status)
+}
+
+func TestDeployAnnotatesRelease(t *testing.T) {
+	ctx := context.Background()
+	pollInterval = 10 * time.Millisecond
+	tempResumeDir(t)
+
+	labels := map[string]string{util.ApplicationNameLabel: "myapp"}
+	// a release which existed before the deploy doesn't belong to it, even
+	// if its creation timestamp is newer than the clock of the client.
+	old := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "myapp-old",
+			Namespace:         test.DefaultProject,
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+		},
+		Status: apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusAvailable}},
+	}
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp-release", Namespace: test.DefaultProject, Labels: labels},
+		Status:     apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusAvailable}},
+	}
+	pending := []runtimeclient.Object{release}
+	apiClient := deployClient(t, &pending, test.WithObjects(old))
+
+	cmd := &Cmd{
+		File:        writeConfig(t, appConfig),
+		Message:     "fix checkout bug",
+		Annotations: map[string]string{"jira": "SHOP-123"},
+		WaitTimeout: time.Second,
+		out:         &bytes.Buffer{},
+	}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name(release.Name), release))
+	assert.Equal(t, "fix checkout bug", release.Annotations[util.ReleaseMessageAnnotation])
+	assert.Equal(t, "SHOP-123", release.Annotations["jira"])
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name(old.Name), old))
+	assert.Empty(t, old.Annotations)
+
+	cmd = &Cmd{File: cmd.File, Annotations: map[string]string{"not valid": "x"}, out: &bytes.Buffer{}}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "invalid annotation")
+}
+
+func TestDeployGitHubStatus(t *testing.T) {
+	ctx := context.Background()
+	pollInterval = 10 * time.Millisecond
+	tempResumeDir(t)
+
+	var mu sync.Mutex
+	statuses := []commitStatus{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/ninech/myapp/statuses/abc123", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		s := commitStatus{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&s))
+		mu.Lock()
+		statuses = append(statuses, s)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	pending := []runtimeclient.Object{&apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp-release",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "myapp"},
+		},
+		Status: apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusAvailable}},
+	}}
+	apiClient := deployClient(t, &pending)
+
+	cmd := &Cmd{File: writeConfig(t, appConfig), GitHubStatus: true, WaitTimeout: time.Second, out: &bytes.Buffer{}}
+	t.Setenv("GITHUB_ACTIONS", "")
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "only be used in GitHub Actions")
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_API_URL", srv.URL)
+	t.Setenv("GITHUB_REPOSITORY", "ninech/myapp")
+	t.Setenv("GITHUB_SHA", "abc123")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_RUN_ID", "42")
+	t.Setenv("GITHUB_TOKEN", "")
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "GITHUB_TOKEN")
+
+	t.Setenv("GITHUB_TOKEN", "secret")
+	require.NoError(t, cmd.Run(ctx, apiClient))
+
+	require.Len(t, statuses, 2)
+	assert.Equal(t, githubPending, statuses[0].State)
+	assert.Equal(t, githubSuccess, statuses[1].State)
+	for _, s := range statuses {
+		assert.Equal(t, githubStatusContext, s.Context)
+		assert.Equal(t, "https://github.com/ninech/myapp/actions/runs/42", s.TargetURL)
+		assert.NotEmpty(t, s.Description)
+	}
+}
+
+func TestDeployGitHubStatusInterrupted(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	tempResumeDir(t)
+	head := strings.Repeat("a", 40)
+
+	var mu sync.Mutex
+	states := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the status is posted to the head of the pull request, not
+		// to the merge commit of GITHUB_SHA.
+		assert.Equal(t, "/repos/ninech/myapp/statuses/"+head, r.URL.Path)
+		s := commitStatus{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&s))
+		mu.Lock()
+		states = append(states, s.State)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	require.NoError(t, os.WriteFile(eventPath, []byte(`{"pull_request": {"head": {"sha": "`+head+`"}}}`), 0o600))
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_API_URL", srv.URL)
+	t.Setenv("GITHUB_REPOSITORY", "ninech/myapp")
+	t.Setenv("GITHUB_SHA", "merge")
+	t.Setenv("GITHUB_EVENT_PATH", eventPath)
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	apiClient, err := test.SetupClient()
+	require.NoError(t, err)
+
+	// the interrupted wait does not leave the status pending
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	cmd := &Cmd{File: writeConfig(t, appConfig), GitHubStatus: true, WaitTimeout: time.Second, out: &bytes.Buffer{}}
+	require.ErrorAs(t, cmd.Run(ctx, apiClient), &format.InterruptedError{})
+	assert.Equal(t, []string{githubPending, githubFailure}, states)
+
+	// the resumed deploy posts to the same commit, even if the event
+	// of the workflow changed.
+	t.Setenv("GITHUB_EVENT_PATH", "")
+	ctx = context.Background()
+	require.NoError(t, apiClient.Create(ctx, &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp-release",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "myapp"},
+		},
+		Status: apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusAvailable}},
+	}))
+	cmd = &Cmd{File: cmd.File, Resume: true, WaitTimeout: time.Second, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Equal(t, []string{githubPending, githubFailure, githubSuccess}, states)
+}
+
+func TestGitHubStatusCommit(t *testing.T) {
+	sha := strings.Repeat("b", 40)
+	t.Setenv("GITHUB_SHA", "merge")
+	t.Setenv("GITHUB_EVENT_PATH", "")
+	assert.Equal(t, sha, statusCommit(sha))
+	assert.Equal(t, "merge", statusCommit("main"))
+}
+
+func TestGitHubStatusDescription(t *testing.T) {
+	var description string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := commitStatus{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&s))
+		description = s.Description
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	s := &githubStatus{apiURL: srv.URL, repository: "ninech/myapp", sha: "abc123", token: "secret"}
+	require.NoError(t, s.post(context.Background(), Event{Text: strings.Repeat("ä", 200)}))
+	assert.True(t, utf8.ValidString(description))
+	assert.Equal(t, githubDescriptionLimit, utf8.RuneCountInString(description))
+}
+
+func TestDeployResume(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	dir := tempResumeDir(t)
+
+	apiClient, err := test.SetupClient()
+	require.NoError(t, err)
+
+	// interrupt the wait, there is no release yet
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	cmd := &Cmd{
+		File:        writeConfig(t, appConfig),
+		Message:     "fix checkout bug",
+		WaitTimeout: time.Second,
+		out:         &bytes.Buffer{},
+	}
+	err = cmd.Run(ctx, apiClient)
+	interrupted := format.InterruptedError{}
+	require.ErrorAs(t, err, &interrupted)
+	assert.Contains(t, interrupted.Resume, "deploy --resume -f "+cmd.File)
+	assert.FileExists(t, filepath.Join(dir, test.DefaultProject+"_myapp.json"))
+
+	ctx = context.Background()
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp-release",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "myapp"},
+		},
+		Status: apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusAvailable}},
+	}
+	require.NoError(t, apiClient.Create(ctx, release))
+
+	cmd = &Cmd{File: cmd.File, Resume: true, WaitTimeout: time.Second, out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name(release.Name), release))
+	assert.Equal(t, "fix checkout bug", release.Annotations[util.ReleaseMessageAnnotation])
+	assert.NoFileExists(t, filepath.Join(dir, test.DefaultProject+"_myapp.json"))
+
+	// nothing left to resume
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "no deploy")
+}
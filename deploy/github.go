@@ -0,0 +1,162 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+)
+
+const (
+	githubStatusContext = "nctl/deploy"
+	defaultGitHubAPIURL = "https://api.github.com"
+	// githubDescriptionLimit is the maximum length of the description of a
+	// commit status.
+	githubDescriptionLimit = 140
+
+	githubPending = "pending"
+	githubSuccess = "success"
+	githubFailure = "failure"
+)
+
+// fullCommitSHA matches a full commit SHA, which is needed to post a commit
+// status.
+var fullCommitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// githubStatus posts the progress of a deploy as commit statuses to the
+// commit which triggered the GitHub Actions workflow.
+type githubStatus struct {
+	apiURL     string
+	repository string
+	sha        string
+	token      string
+	targetURL  string
+}
+
+// commitStatus is the body of a commit status, see
+// https://docs.github.com/en/rest/commits/statuses
+type commitStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+// githubStatusFromEnv configures the commit statuses from the environment of
+// a GitHub Actions workflow. The token needs to be passed to nctl explicitly
+// as GITHUB_TOKEN with the "statuses: write" permission. The statuses are
+// posted to the deployed revision, see statusCommit.
+func githubStatusFromEnv(revision string) (*githubStatus, error) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return nil, fmt.Errorf("--github-status can only be used in GitHub Actions")
+	}
+	s := &githubStatus{
+		apiURL:     os.Getenv("GITHUB_API_URL"),
+		repository: os.Getenv("GITHUB_REPOSITORY"),
+		sha:        statusCommit(revision),
+		token:      os.Getenv("GITHUB_TOKEN"),
+	}
+	if s.apiURL == "" {
+		s.apiURL = defaultGitHubAPIURL
+	}
+	if s.repository == "" || s.sha == "" {
+		return nil, fmt.Errorf("GITHUB_REPOSITORY and GITHUB_SHA need to be set for --github-status")
+	}
+	if s.token == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN needs to be set for --github-status, e.g. with \"env: GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}\"")
+	}
+	if server, runID := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_RUN_ID"); server != "" && runID != "" {
+		s.targetURL = fmt.Sprintf("%s/%s/actions/runs/%s", server, s.repository, runID)
+	}
+	return s, nil
+}
+
+// statusCommit returns the commit the statuses are posted to. This is the
+// deployed revision if it is a commit, else the head of the pull request
+// which triggered the workflow or GITHUB_SHA. For pull requests, GITHUB_SHA
+// is a merge commit whose statuses are not shown on the pull request.
+func statusCommit(revision string) string {
+	if fullCommitSHA.MatchString(revision) {
+		return revision
+	}
+	if sha := pullRequestHead(os.Getenv("GITHUB_EVENT_PATH")); sha != "" {
+		return sha
+	}
+	return os.Getenv("GITHUB_SHA")
+}
+
+// pullRequestHead returns the head commit of the pull request from the event
+// payload of the workflow. It is empty for other events.
+func pullRequestHead(eventPath string) string {
+	if eventPath == "" {
+		return ""
+	}
+	data, err := os.ReadFile(eventPath)
+	if err != nil {
+		return ""
+	}
+	event := struct {
+		PullRequest struct {
+			Head struct {
+				SHA string `json:"sha"`
+			} `json:"head"`
+		} `json:"pull_request"`
+	}{}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return ""
+	}
+	return event.PullRequest.Head.SHA
+}
+
+// state returns the commit status for an event. The deploy is pending until
+// the release is available.
+func (s *githubStatus) state(e Event) string {
+	switch {
+	case e.Status == statusFailure:
+		return githubFailure
+	case e.Event == eventRelease:
+		return githubSuccess
+	default:
+		return githubPending
+	}
+}
+
+func (s *githubStatus) post(ctx context.Context, e Event) error {
+	description := e.Text
+	if runes := []rune(description); len(runes) > githubDescriptionLimit {
+		description = string(runes[:githubDescriptionLimit-3]) + "..."
+	}
+	body, err := json.Marshal(commitStatus{
+		State:       s.state(e),
+		TargetURL:   s.targetURL,
+		Description: description,
+		Context:     githubStatusContext,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s/repos/%s/statuses/%s", s.apiURL, s.repository, s.sha)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ninech/nctl/internal/format"
+)
+
+// notifyTimeout limits how long posting an event may take.
+const notifyTimeout = 10 * time.Second
+
+const (
+	eventDeploy  = "deploy"
+	eventBuild   = "build"
+	eventRelease = "release"
+
+	statusSuccess = "success"
+	statusFailure = "failure"
+)
+
+// Event is posted to the notify URL for every step of a deploy.
+type Event struct {
+	// Text is a summary of the event. It is named like this so the event
+	// can be posted to Slack incoming webhooks directly.
+	Text        string    `json:"text"`
+	Event       string    `json:"event"`
+	Status      string    `json:"status"`
+	Application string    `json:"application"`
+	Project     string    `json:"project"`
+	Name        string    `json:"name,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// notify posts the event to the notify URL and as GitHub commit status.
+// Failing to do so only results in a warning as the deploy itself is not
+// affected.
+func (cmd *Cmd) notify(ctx context.Context, e Event) {
+	e.Time = time.Now().UTC()
+
+	if cmd.NotifyURL != "" {
+		if err := postEvent(ctx, cmd.NotifyURL, e); err != nil {
+			format.PrintWarningf("unable to send %s notification: %s\n", e.Event, err)
+		}
+	}
+	if cmd.github != nil {
+		if err := cmd.github.post(ctx, e); err != nil {
+			format.PrintWarningf("unable to post %s status to GitHub: %s\n", e.Event, err)
+		}
+	}
+}
+
+func postEvent(ctx context.Context, url string, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
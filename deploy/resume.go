@@ -0,0 +1,92 @@
+package deploy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// resumeToken is persisted while waiting for the rollout of a deploy, so that
+// "nctl deploy --resume" can continue following it if the wait has been
+// interrupted.
+type resumeToken struct {
+	Application string `json:"application"`
+	Project     string `json:"project"`
+	// Since is the time of the deploy.
+	Since time.Time `json:"since"`
+	// Existing are the builds and releases which existed before the
+	// deploy and don't belong to it.
+	Existing existing `json:"existing"`
+	// Annotations are added to the release of the deploy.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// GitHubSHA is the commit the GitHub commit statuses of the deploy
+	// are posted to, if any.
+	GitHubSHA string `json:"githubSHA,omitempty"`
+}
+
+// resumeDir returns the directory the resume tokens are stored in.
+var resumeDir = func() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "nctl", "deploys"), nil
+}
+
+func resumeTokenPath(project, app string) (string, error) {
+	dir, err := resumeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s_%s.json", project, app)), nil
+}
+
+func (t resumeToken) save() error {
+	path, err := resumeTokenPath(t.Project, t.Application)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (t resumeToken) remove() error {
+	path, err := resumeTokenPath(t.Project, t.Application)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// loadResumeToken returns the token of the last interrupted deploy of the
+// application.
+func loadResumeToken(project, app string) (*resumeToken, error) {
+	path, err := resumeTokenPath(project, app)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("there is no deploy of application %q in project %s to resume", app, project)
+		}
+		return nil, err
+	}
+	t := &resumeToken{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("invalid resume token %s: %w", path, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,231 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pollInterval is the interval in which the builds and releases are checked
+// while waiting if changes to them can't be watched.
+var pollInterval = 5 * time.Second
+
+// existing are the names of the builds and releases of an application which
+// existed before a deploy. The ones of the deploy are found by excluding
+// them, as the clock of the client can't be compared with the creation
+// timestamps of the server.
+type existing struct {
+	Builds   []string `json:"builds,omitempty"`
+	Releases []string `json:"releases,omitempty"`
+}
+
+// listExisting returns the builds and releases which currently exist for the
+// app.
+func listExisting(ctx context.Context, client *api.Client, app *apps.Application) (existing, error) {
+	builds := &apps.BuildList{}
+	if err := listOfApp(ctx, client, app, builds); err != nil {
+		return existing{}, err
+	}
+	releases := &apps.ReleaseList{}
+	if err := listOfApp(ctx, client, app, releases); err != nil {
+		return existing{}, err
+	}
+	var e existing
+	for _, build := range builds.Items {
+		e.Builds = append(e.Builds, build.Name)
+	}
+	for _, release := range releases.Items {
+		e.Releases = append(e.Releases, release.Name)
+	}
+	return e, nil
+}
+
+// waitForRollout waits until the release which was triggered by the deploy
+// at since is available. Builds and releases which existed before the deploy
+// are ignored. If a build has been started as well, it is followed first.
+// Every finished step is notified.
+func (cmd *Cmd) waitForRollout(ctx context.Context, client *api.Client, app *apps.Application, since time.Time, before existing) error {
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	// the token is kept if the wait does not finish, so that it can be
+	// resumed.
+	token := resumeToken{Application: app.Name, Project: app.Namespace, Since: since, Existing: before, Annotations: cmd.releaseAnnotations()}
+	if cmd.github != nil {
+		token.GitHubSHA = cmd.github.sha
+	}
+	if err := token.save(); err != nil {
+		format.PrintWarningf("unable to save the resume token of the deploy: %s\n", err)
+	}
+	finished := func() {
+		if err := token.remove(); err != nil {
+			format.PrintWarningf("unable to remove the resume token of the deploy: %s\n", err)
+		}
+	}
+
+	spinner, err := format.NewProgress(
+		format.ProgressMessagef("⏳", "waiting for the release of application %q", app.Name),
+		format.ProgressMessagef("⛺", "release of application %q available", app.Name),
+	)
+	if err != nil {
+		return err
+	}
+	_ = spinner.Start()
+
+	event := func(kind, status, name, text string) Event {
+		return Event{Event: kind, Status: status, Name: name, Application: app.Name, Project: app.Namespace, Text: text}
+	}
+
+	buildNotified := map[string]bool{}
+	annotated := map[string]bool{}
+	// failure is returned once the wait is done, as WaitUntil only returns
+	// errors of the checks.
+	var failure error
+	check := func() (bool, error) {
+		builds := &apps.BuildList{}
+		if err := listOfApp(ctx, client, app, builds); err != nil {
+			return false, err
+		}
+		for _, build := range builds.Items {
+			if buildNotified[build.Name] || slices.Contains(before.Builds, build.Name) {
+				continue
+			}
+			switch build.Status.AtProvider.BuildStatus {
+			case apps.BuildProcessStatusSuccess:
+				buildNotified[build.Name] = true
+				spinner.SetStatus(fmt.Sprintf("build %s succeeded", build.Name))
+				cmd.notify(ctx, event(eventBuild, statusSuccess, build.Name,
+					fmt.Sprintf("build %s of application %s in project %s succeeded", build.Name, app.Name, app.Namespace)))
+			case apps.BuildProcessStatusError, apps.BuildProcessStatusImageUploadFailed, apps.BuildProcessStatusUnknown:
+				cmd.notify(ctx, event(eventBuild, statusFailure, build.Name,
+					fmt.Sprintf("build %s of application %s in project %s failed", build.Name, app.Name, app.Namespace)))
+				failure = fmt.Errorf("build %s failed with status %s, show the log with: nctl logs build %s",
+					build.Name, build.Status.AtProvider.BuildStatus, build.Name)
+				return true, nil
+			}
+		}
+
+		releases := &apps.ReleaseList{}
+		if err := listOfApp(ctx, client, app, releases); err != nil {
+			return false, err
+		}
+		release := newestRelease(releases, before.Releases)
+		if release == nil {
+			return false, nil
+		}
+		if !annotated[release.Name] {
+			annotated[release.Name] = true
+			if err := cmd.annotate(ctx, client, release); err != nil {
+				format.PrintWarningf("unable to annotate release %s: %s\n", release.Name, err)
+			}
+		}
+		switch release.Status.AtProvider.ReleaseStatus {
+		case apps.ReleaseProcessStatusAvailable:
+			cmd.notify(ctx, event(eventRelease, statusSuccess, release.Name,
+				fmt.Sprintf("release %s of application %s in project %s is available", release.Name, app.Name, app.Namespace)))
+			return true, nil
+		case apps.ReleaseProcessStatusFailure, apps.ReleaseProcessStatusReplicaFailure:
+			cmd.notify(ctx, event(eventRelease, statusFailure, release.Name,
+				fmt.Sprintf("release %s of application %s in project %s failed", release.Name, app.Name, app.Namespace)))
+			failure = fmt.Errorf("release %s failed with status %s", release.Name, release.Status.AtProvider.ReleaseStatus)
+			return true, nil
+		default:
+			spinner.SetStatus(fmt.Sprintf("release %s %s", release.Name, release.Status.AtProvider.ReleaseStatus))
+			return false, nil
+		}
+	}
+
+	opts := []runtimeclient.ListOption{
+		runtimeclient.InNamespace(app.Namespace),
+		runtimeclient.MatchingLabels{util.ApplicationNameLabel: app.Name},
+	}
+	err = client.WaitUntil(ctx, pollInterval, check,
+		api.WatchList(&apps.BuildList{}, opts...),
+		api.WatchList(&apps.ReleaseList{}, opts...),
+	)
+	switch {
+	case err == nil && failure == nil:
+		_ = spinner.Stop()
+		finished()
+		return nil
+	case err == nil:
+		_ = spinner.StopFail()
+		finished()
+		return failure
+	// the context of the wait might be done, so the final status is
+	// notified with a new one.
+	case errors.Is(ctx.Err(), context.Canceled):
+		_ = spinner.StopFail()
+		cmd.notify(context.Background(), event(eventRelease, statusFailure, "",
+			fmt.Sprintf("waiting for the release of application %s in project %s was interrupted", app.Name, app.Namespace)))
+		return cmd.interrupted()
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		_ = spinner.StopFail()
+		cmd.notify(context.Background(), event(eventRelease, statusFailure, "",
+			fmt.Sprintf("timeout waiting for the release of application %s in project %s", app.Name, app.Namespace)))
+		return fmt.Errorf("timeout waiting for the release of application %q", app.Name)
+	default:
+		_ = spinner.StopFail()
+		cmd.notify(context.Background(), event(eventRelease, statusFailure, "",
+			fmt.Sprintf("unable to wait for the release of application %s in project %s: %s", app.Name, app.Namespace, err)))
+		return err
+	}
+}
+
+// interrupted returns the error for an interrupted wait with the command to
+// resume it.
+func (cmd *Cmd) interrupted() error {
+	resume := fmt.Sprintf("%s deploy --resume", format.Command())
+	if cmd.File != DefaultFile {
+		resume += " -f " + cmd.File
+	}
+	return format.InterruptedError{Resume: resume}
+}
+
+// annotate adds the annotations of the deploy to the release.
+func (cmd *Cmd) annotate(ctx context.Context, client *api.Client, release *apps.Release) error {
+	annotations := cmd.releaseAnnotations()
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	patch := runtimeclient.MergeFrom(release.DeepCopy())
+	if release.Annotations == nil {
+		release.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		release.Annotations[k] = v
+	}
+	return client.Patch(ctx, release, patch)
+}
+
+// listOfApp lists the objects which belong to the app.
+func listOfApp(ctx context.Context, client *api.Client, app *apps.Application, list runtimeclient.ObjectList) error {
+	return client.List(ctx, list,
+		runtimeclient.InNamespace(app.Namespace),
+		runtimeclient.MatchingLabels{util.ApplicationNameLabel: app.Name},
+	)
+}
+
+// newestRelease returns the release which has been created last, ignoring
+// the given existing ones.
+func newestRelease(releases *apps.ReleaseList, existing []string) *apps.Release {
+	var newest *apps.Release
+	for i := range releases.Items {
+		if slices.Contains(existing, releases.Items[i].Name) {
+			continue
+		}
+		if newest == nil || newest.CreationTimestamp.Before(&releases.Items[i].CreationTimestamp) {
+			newest = &releases.Items[i]
+		}
+	}
+	return newest
+}
@@ -0,0 +1,121 @@
+package deprecations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type Cmd struct {
+	AllProjects bool `short:"A" help:"Scan the resources of all projects."`
+	out         io.Writer
+}
+
+// deprecation is a resource which uses something deprecated.
+type deprecation struct {
+	project string
+	kind    string
+	name    string
+	message string
+}
+
+func (cmd *Cmd) Help() string {
+	return "Lists the resources which use deprecated versions or settings, e.g. a\n" +
+		"PostgreSQL version which won't be supported anymore, or which report a\n" +
+		"deprecation in their status conditions. Warnings the API returns while\n" +
+		"listing the resources, e.g. about deprecated API versions, are printed\n" +
+		"afterwards."
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	projectName := client.Project
+	if cmd.AllProjects {
+		projectName = ""
+	}
+	projects, err := client.Projects(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(projects))
+	for i, p := range projects {
+		names[i] = p.Name
+	}
+
+	items, warnings, err := client.ProjectResources(ctx, names, nil, false)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		format.PrintWarningf("%s\n", w)
+	}
+
+	found := []deprecation{}
+	for _, item := range items {
+		for _, message := range deprecations(item) {
+			found = append(found, deprecation{
+				project: item.GetNamespace(),
+				kind:    strings.ToLower(item.GetKind()),
+				name:    item.GetName(),
+				message: message,
+			})
+		}
+	}
+
+	if len(found) == 0 {
+		fmt.Fprintln(cmd.out, "no resources with deprecations found")
+		return nil
+	}
+
+	w := format.NewTable(cmd.out, format.Truncate())
+	fmt.Fprintln(w, "PROJECT\tKIND\tNAME\tDEPRECATION")
+	for _, d := range found {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.project, d.kind, d.name, d.message)
+	}
+	return w.Flush()
+}
+
+// deprecations returns what is deprecated about the item.
+func deprecations(item *unstructured.Unstructured) []string {
+	messages := []string{}
+
+	if item.GroupVersionKind() == storage.PostgresGroupVersionKind {
+		version, _, _ := unstructured.NestedString(item.Object, "spec", "forProvider", "version")
+		if slices.Contains(storage.PostgresVersionsDeprecated, storage.PostgresVersion(version)) {
+			supported := make([]string, len(storage.PostgresVersions))
+			for i, v := range storage.PostgresVersions {
+				supported[i] = string(v)
+			}
+			messages = append(messages, fmt.Sprintf("PostgreSQL version %s is deprecated, supported versions are %s",
+				version, strings.Join(supported, ", ")))
+		}
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(item.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		if strings.Contains(strings.ToLower(reason+" "+message), "deprecat") {
+			if message == "" {
+				message = reason
+			}
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,59 @@
+package deprecations
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	management "github.com/ninech/apis/management/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDeprecations(t *testing.T) {
+	ctx := context.Background()
+
+	old := test.Postgres("old", "dev", "nine-es34")
+	old.Spec.ForProvider.Version = storage.PostgresVersion13
+	current := test.Postgres("current", "dev", "nine-es34")
+	current.Spec.ForProvider.Version = storage.PostgresVersion16
+	mysql := test.MySQL("db", "prod", "nine-es34")
+	mysql.Status.SetConditions(runtimev1.Condition{
+		Type:    runtimev1.TypeReady,
+		Status:  corev1.ConditionTrue,
+		Reason:  "Available",
+		Message: "machine type nine-standard-1 is deprecated",
+	})
+
+	apiClient, err := test.SetupClient(
+		test.WithObjects(append(test.Projects(test.DefaultProject, "dev", "prod"), old, current, mysql)...),
+		test.WithDefaultProject("dev"),
+		test.WithNameIndexFor(&management.Project{}),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := &Cmd{out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Contains(t, out.String(), "dev        postgres    old     PostgreSQL version 13 is deprecated")
+	assert.NotContains(t, out.String(), "current")
+	assert.NotContains(t, out.String(), "mysql")
+
+	out.Reset()
+	cmd = &Cmd{AllProjects: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Contains(t, out.String(), "old")
+	assert.Contains(t, out.String(), "machine type nine-standard-1 is deprecated")
+
+	out.Reset()
+	apiClient.Project = "prod"
+	require.NoError(t, apiClient.Delete(ctx, mysql))
+	cmd = &Cmd{out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Equal(t, "no resources with deprecations found\n", out.String())
+}
@@ -0,0 +1,156 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Cmd struct {
+	Kind   string `arg:"" help:"Kind of the resource to describe, e.g. application, postgres or build."`
+	Name   string `arg:"" predictor:"resource_name" help:"Name of the resource to describe."`
+	Recent int    `default:"5" help:"Amount of recent related resources (e.g. releases and builds of an application) to show."`
+	out    io.Writer
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	gvk, err := api.LookupKind(client.Scheme(), cmd.Kind)
+	if err != nil {
+		return err
+	}
+
+	name, err := client.NamespacedNameFor(gvk, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := client.Get(ctx, name, obj); err != nil {
+		return fmt.Errorf("unable to get %s %q: %w", strings.ToLower(gvk.Kind), cmd.Name, err)
+	}
+
+	d := describeObject(obj)
+	if gvk.GroupKind() == apps.ApplicationGroupVersionKind.GroupKind() {
+		if err := cmd.describeApplication(ctx, client, obj, d); err != nil {
+			return err
+		}
+	}
+
+	return d.Print(cmd.out)
+}
+
+// describeObject describes the parts which all resources have in common.
+func describeObject(obj *unstructured.Unstructured) *format.Description {
+	d := format.NewDescription().
+		Field("Name", obj.GetName()).
+		Field("Project", obj.GetNamespace()).
+		Field("Kind", obj.GetKind()+"."+obj.GroupVersionKind().Group).
+		Field("Created", format.AgeDetailed(obj.GetCreationTimestamp().Time)).
+		Field("Labels", obj.GetLabels())
+	if ts := obj.GetDeletionTimestamp(); ts != nil {
+		d.Field("Deleting", format.AgeDetailed(ts.Time))
+	}
+
+	spec, found, _ := unstructured.NestedMap(obj.Object, "spec", "forProvider")
+	if !found {
+		spec, _, _ = unstructured.NestedMap(obj.Object, "spec")
+	}
+	d.Section("Spec").Object(spec)
+
+	status, found, _ := unstructured.NestedMap(obj.Object, "status", "atProvider")
+	if !found {
+		status, _, _ = unstructured.NestedMap(obj.Object, "status")
+		delete(status, "conditions")
+	}
+	d.Section("Status").Object(status)
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	table := d.Section("Conditions").Table("TYPE", "STATUS", "REASON", "AGE", "MESSAGE")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		transition, _ := time.Parse(time.RFC3339, fmt.Sprint(cm["lastTransitionTime"]))
+		table.Row(
+			stringValue(cm, "type"),
+			stringValue(cm, "status"),
+			stringValue(cm, "reason"),
+			format.Age(transition),
+			strings.ReplaceAll(stringValue(cm, "message"), "\n", " "),
+		)
+	}
+
+	return d
+}
+
+func (cmd *Cmd) describeApplication(ctx context.Context, client *api.Client, obj *unstructured.Unstructured, d *format.Description) error {
+	app := &apps.Application{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, app); err != nil {
+		return err
+	}
+	processes := d.Section("Processes").Table("NAME", "TYPE", "SIZE", "REPLICAS", "SCHEDULE", "COMMAND")
+	for _, p := range util.ApplicationProcesses(app) {
+		replicas := ""
+		if p.Replicas != nil {
+			replicas = strconv.Itoa(int(*p.Replicas))
+		}
+		processes.Row(p.Name, p.Type, string(p.Size), replicas, p.Schedule, p.Command)
+	}
+
+	inApp := []runtimeclient.ListOption{
+		runtimeclient.InNamespace(obj.GetNamespace()),
+		runtimeclient.MatchingLabels{util.ApplicationNameLabel: obj.GetName()},
+	}
+
+	releases := &apps.ReleaseList{}
+	if err := client.List(ctx, releases, inApp...); err != nil {
+		return fmt.Errorf("unable to list releases: %w", err)
+	}
+	util.OrderReleaseList(releases, false)
+	table := d.Section("Recent Releases").Table("NAME", "BUILD", "STATUS", "AGE")
+	for i, r := range releases.Items {
+		if i >= cmd.Recent {
+			break
+		}
+		table.Row(r.Name, r.Spec.ForProvider.Build.Name, string(r.Status.AtProvider.ReleaseStatus), format.Age(r.CreationTimestamp.Time))
+	}
+
+	builds := &apps.BuildList{}
+	if err := client.List(ctx, builds, inApp...); err != nil {
+		return fmt.Errorf("unable to list builds: %w", err)
+	}
+	sort.Slice(builds.Items, func(i, j int) bool {
+		return builds.Items[j].CreationTimestamp.Before(&builds.Items[i].CreationTimestamp)
+	})
+	table = d.Section("Recent Builds").Table("NAME", "STATUS", "AGE")
+	for i, b := range builds.Items {
+		if i >= cmd.Recent {
+			break
+		}
+		table.Row(b.Name, string(b.Status.AtProvider.BuildStatus), format.Age(b.CreationTimestamp.Time))
+	}
+
+	return nil
+}
+
+func stringValue(m map[string]any, key string) string {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
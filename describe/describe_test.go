@@ -0,0 +1,79 @@
+package describe
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	infra "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDescribe(t *testing.T) {
+	ctx := context.Background()
+
+	pg := test.Postgres("db", test.DefaultProject, "nine-es34")
+	pg.Spec.ForProvider.MachineType = infra.MachineTypeNineDBS
+	pg.Status.AtProvider.FQDN = "db.example.org"
+	pg.SetConditions(runtimev1.Available())
+
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: test.DefaultProject},
+		Spec: apps.ApplicationSpec{
+			ForProvider: apps.ApplicationParameters{
+				Git: apps.ApplicationGitConfig{
+					GitTarget: apps.GitTarget{URL: "https://github.com/ninech/deploio-examples"},
+				},
+				Config: apps.Config{
+					WorkerJobs: []apps.WorkerJob{{Job: apps.Job{Name: "queue", Command: "bundle exec sidekiq"}}},
+				},
+			},
+		},
+	}
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-release-1",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: app.Name},
+		},
+	}
+	release.Status.AtProvider.ReleaseStatus = apps.ReleaseProcessStatusAvailable
+
+	apiClient, err := test.SetupClient(test.WithObjects(pg, app, release))
+	require.NoError(t, err)
+
+	t.Run("postgres", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &Cmd{Kind: "postgres", Name: pg.Name, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		assert.Contains(t, out.String(), "Name:")
+		assert.Contains(t, out.String(), "Machine Type:")
+		assert.Contains(t, out.String(), "db.example.org")
+		assert.Contains(t, out.String(), "Available")
+		assert.NotContains(t, out.String(), "Recent Releases")
+	})
+
+	t.Run("application", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &Cmd{Kind: "app", Name: app.Name, Recent: 5, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		assert.Contains(t, out.String(), "https://github.com/ninech/deploio-examples")
+		assert.Contains(t, out.String(), "Recent Releases")
+		assert.Contains(t, out.String(), "app-release-1")
+		assert.Contains(t, out.String(), "Processes")
+		assert.Regexp(t, `queue\s+worker\s+micro\s+1\s+bundle exec sidekiq`, out.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cmd := &Cmd{Kind: "app", Name: "doesnotexist", out: &bytes.Buffer{}}
+		assert.Error(t, cmd.Run(ctx, apiClient))
+	})
+}
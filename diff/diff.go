@@ -0,0 +1,267 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type Cmd struct {
+	Release releaseCmd `cmd:"" aliases:"releases" help:"Show what changed between two releases of an application."`
+}
+
+type releaseCmd struct {
+	From string `arg:"" predictor:"resource_name" help:"Name of the older release."`
+	To   string `arg:"" predictor:"resource_name" help:"Name of the newer release."`
+	out  io.Writer
+}
+
+// property is a single value of a release which is compared.
+type property struct {
+	name  string
+	value string
+}
+
+// field is a property with its values in both releases.
+type field struct {
+	name     string
+	from, to string
+}
+
+func (cmd *releaseCmd) Help() string {
+	return "Compares the image, git revision, size, replicas, jobs, hosts and env variables\n" +
+		"of two releases, e.g. to find out what actually shipped during an incident. Use\n" +
+		"\"nctl get releases -a <app>\" to list the releases of an application. Values of\n" +
+		"env variables whose names look like they contain secrets are redacted.\n\n" +
+		"Example:\n\n" +
+		"  nctl diff release myapp-a1b2c3 myapp-d4e5f6"
+}
+
+func (cmd *releaseCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	from, err := cmd.release(ctx, client, cmd.From)
+	if err != nil {
+		return err
+	}
+	to, err := cmd.release(ctx, client, cmd.To)
+	if err != nil {
+		return err
+	}
+	fromApp, toApp := from.Labels[util.ApplicationNameLabel], to.Labels[util.ApplicationNameLabel]
+	if fromApp != toApp {
+		format.PrintWarningf("release %s belongs to application %q and %s to %q\n", from.Name, fromApp, to.Name, toApp)
+	}
+
+	fromRevision, err := revision(ctx, client, from)
+	if err != nil {
+		return err
+	}
+	toRevision, err := revision(ctx, client, to)
+	if err != nil {
+		return err
+	}
+	changed := []field{}
+	for _, f := range compare(properties(from, fromRevision), properties(to, toRevision)) {
+		if f.from != f.to {
+			changed = append(changed, f)
+		}
+	}
+	fromCfg := from.Spec.ForProvider.Configuration.WithoutOrigin()
+	toCfg := to.Spec.ForProvider.Configuration.WithoutOrigin()
+	env := envChanges(fromCfg.Env, toCfg.Env)
+
+	if len(changed) == 0 && len(env) == 0 {
+		fmt.Fprintf(cmd.out, "no differences between release %s and %s\n", from.Name, to.Name)
+		return nil
+	}
+
+	if len(changed) != 0 {
+		w := format.NewTable(cmd.out)
+		fmt.Fprintf(w, "FIELD\t%s\t%s\n", from.Name, to.Name)
+		for _, f := range changed {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", f.name, noneIfEmpty(f.from), noneIfEmpty(f.to))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	if len(env) != 0 {
+		if len(changed) != 0 {
+			fmt.Fprintln(cmd.out)
+		}
+		fmt.Fprintln(cmd.out, "ENV")
+		for _, line := range env {
+			fmt.Fprintln(cmd.out, line)
+		}
+	}
+	return nil
+}
+
+func (cmd *releaseCmd) release(ctx context.Context, client *api.Client, name string) (*apps.Release, error) {
+	release := &apps.Release{}
+	if err := client.Get(ctx, client.Name(name), release); err != nil {
+		return nil, fmt.Errorf("unable to get release %q: %w", name, err)
+	}
+	return release, nil
+}
+
+// revision returns the git revision the release has been built from. It is
+// empty if the build does not exist anymore.
+func revision(ctx context.Context, client *api.Client, release *apps.Release) (string, error) {
+	build := &apps.Build{}
+	if err := client.Get(ctx, api.NamespacedName(release.Spec.ForProvider.Build.Name, release.Namespace), build); err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to get build %q of release %s: %w", release.Spec.ForProvider.Build.Name, release.Name, err)
+	}
+	return build.Spec.ForProvider.SourceConfig.Git.Revision, nil
+}
+
+// properties returns the compared properties of a release in the order they
+// are printed.
+func properties(release *apps.Release, revision string) []property {
+	cfg := release.Spec.ForProvider.Configuration.WithoutOrigin()
+	return []property{
+		{name: "BUILD", value: release.Spec.ForProvider.Build.Name},
+		{name: "REVISION", value: revision},
+		{name: "IMAGE", value: image(release.Spec.ForProvider.Image)},
+		{name: "DIGEST", value: release.Spec.ForProvider.Image.Digest},
+		{name: "SIZE", value: string(cfg.Size)},
+		{name: "REPLICAS", value: int32String(cfg.Replicas)},
+		{name: "PORT", value: int32String(cfg.Port)},
+		{name: "BASIC AUTH", value: boolString(cfg.EnableBasicAuth)},
+		{name: "DEPLOY JOB", value: deployJob(cfg.DeployJob)},
+		{name: "WORKER JOBS", value: workerJobs(cfg.WorkerJobs)},
+		{name: "SCHEDULED JOBS", value: scheduledJobs(cfg.ScheduledJobs)},
+		{name: "HOSTS", value: strings.Join(release.Spec.ForProvider.VerifiedHosts, ",")},
+	}
+}
+
+// compare combines the properties of two releases.
+func compare(from, to []property) []field {
+	result := make([]field, len(from))
+	for i := range from {
+		result[i] = field{name: from[i].name, from: from[i].value, to: to[i].value}
+	}
+	return result
+}
+
+// envChanges returns the added (+), removed (-) and changed (~) env
+// variables sorted by name. The values of env variables which likely contain
+// secrets are redacted, a change of them is still shown.
+func envChanges(from, to apps.EnvVars) []string {
+	fromMap, toMap := envMap(from), envMap(to)
+	names := []string{}
+	for name := range fromMap {
+		names = append(names, name)
+	}
+	for name := range toMap {
+		if _, ok := fromMap[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []string{}
+	for _, name := range names {
+		old, inFrom := fromMap[name]
+		new, inTo := toMap[name]
+		if old == new && inFrom && inTo {
+			continue
+		}
+		if format.SensitiveName(name) {
+			old, new = format.Redacted, format.Redacted
+		}
+		switch {
+		case !inFrom:
+			changes = append(changes, fmt.Sprintf("+ %s=%s", name, new))
+		case !inTo:
+			changes = append(changes, fmt.Sprintf("- %s=%s", name, old))
+		default:
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", name, old, new))
+		}
+	}
+	return changes
+}
+
+func envMap(env apps.EnvVars) map[string]string {
+	m := map[string]string{}
+	for _, e := range env {
+		m[e.Name] = e.Value
+	}
+	return m
+}
+
+func image(img meta.Image) string {
+	s := strings.Trim(img.Registry+"/"+img.Repository, "/")
+	if img.Tag != "" {
+		s += ":" + img.Tag
+	}
+	return s
+}
+
+func deployJob(job *apps.DeployJob) string {
+	if job == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", job.Name, job.Command)
+}
+
+func workerJobs(jobs []apps.WorkerJob) string {
+	s := []string{}
+	for _, j := range jobs {
+		s = append(s, fmt.Sprintf("%s: %s (%s)", j.Name, j.Command, sizeString(j.Size)))
+	}
+	return strings.Join(s, ", ")
+}
+
+func scheduledJobs(jobs []apps.ScheduledJob) string {
+	s := []string{}
+	for _, j := range jobs {
+		s = append(s, fmt.Sprintf("%s: %s (%s, %s)", j.Name, j.Command, j.Schedule, sizeString(j.Size)))
+	}
+	return strings.Join(s, ", ")
+}
+
+func sizeString(size *apps.ApplicationSize) string {
+	if size == nil {
+		return ""
+	}
+	return string(*size)
+}
+
+func int32String(i *int32) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*i))
+}
+
+func boolString(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func noneIfEmpty(s string) string {
+	if s == "" {
+		return util.NoneText
+	}
+	return s
+}
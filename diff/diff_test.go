@@ -0,0 +1,97 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDiffRelease(t *testing.T) {
+	ctx := context.Background()
+
+	build := func(name, revision string) *apps.Build {
+		b := &apps.Build{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: test.DefaultProject}}
+		b.Spec.ForProvider.SourceConfig.Git.Revision = revision
+		return b
+	}
+	release := func(name, build, digest string, replicas int32, env map[string]string) *apps.Release {
+		r := &apps.Release{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "myapp"},
+		}}
+		r.Spec.ForProvider.Build.Name = build
+		r.Spec.ForProvider.Image = meta.Image{Registry: "registry.deplo.io", Repository: "myapp", Tag: build, Digest: digest}
+		r.Spec.ForProvider.Configuration = apps.Config{
+			Size:     "micro",
+			Replicas: ptr.To(replicas),
+			Env:      util.EnvVarsFromMap(env),
+		}.WithOrigin(apps.ConfigOriginApplication)
+		return r
+	}
+
+	objects := []runtimeclient.Object{
+		build("build-a", "abc123"),
+		build("build-b", "def456"),
+		release("rel-a", "build-a", "sha256:aaa", 1, map[string]string{"LOG_LEVEL": "info", "OLD": "1", "API_TOKEN": "old"}),
+		release("rel-b", "build-b", "sha256:bbb", 3, map[string]string{"LOG_LEVEL": "debug", "NEW": "2", "API_TOKEN": "new", "DB_PASSWORD": "s3cret"}),
+		release("rel-c", "build-b", "sha256:bbb", 3, map[string]string{"LOG_LEVEL": "debug", "NEW": "2", "API_TOKEN": "new", "DB_PASSWORD": "s3cret"}),
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(objects...))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := &releaseCmd{From: "rel-a", To: "rel-b", out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Equal(t, "FIELD       rel-a                              rel-b\n"+
+		"BUILD       build-a                            build-b\n"+
+		"REVISION    abc123                             def456\n"+
+		"IMAGE       registry.deplo.io/myapp:build-a    registry.deplo.io/myapp:build-b\n"+
+		"DIGEST      sha256:aaa                         sha256:bbb\n"+
+		"REPLICAS    1                                  3\n"+
+		"\n"+
+		"ENV\n"+
+		"~ API_TOKEN: <redacted> -> <redacted>\n"+
+		"+ DB_PASSWORD=<redacted>\n"+
+		"~ LOG_LEVEL: info -> debug\n"+
+		"+ NEW=2\n"+
+		"- OLD=1\n",
+		out.String())
+
+	out.Reset()
+	cmd = &releaseCmd{From: "rel-b", To: "rel-c", out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Equal(t, "no differences between release rel-b and rel-c\n", out.String())
+
+	cmd = &releaseCmd{From: "rel-a", To: "doesnotexist", out: out}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "doesnotexist")
+
+	// the revision is only empty if the build does not exist anymore,
+	// other errors are returned.
+	apiClient, err = test.SetupClient(
+		test.WithObjects(objects...),
+		test.WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c runtimeclient.WithWatch, key runtimeclient.ObjectKey, obj runtimeclient.Object, opts ...runtimeclient.GetOption) error {
+				if _, ok := obj.(*apps.Build); ok {
+					return errors.New("connection refused")
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}),
+	)
+	require.NoError(t, err)
+	cmd = &releaseCmd{From: "rel-a", To: "rel-b", out: out}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "connection refused")
+}
@@ -0,0 +1,104 @@
+// Package docs generates documentation of the commands and flags of nctl from
+// its kong grammar.
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+)
+
+type Cmd struct {
+	Man  manCmd  `cmd:"" help:"Generate man pages of all commands."`
+	JSON jsonCmd `cmd:"" name:"json" help:"Print a JSON description of all commands and flags."`
+}
+
+// commandPath returns the names of the commands from the root to the node.
+func commandPath(n *kong.Node) []string {
+	var path []string
+	for ; n != nil; n = n.Parent {
+		name := n.Name
+		if n.Type == kong.ArgumentNode {
+			name = "<" + name + ">"
+		}
+		path = append([]string{name}, path...)
+	}
+	return path
+}
+
+// visible returns the child commands of the node which are not hidden.
+func visible(n *kong.Node, hidden bool) []*kong.Node {
+	children := []*kong.Node{}
+	for _, child := range n.Children {
+		if hidden || !child.Hidden {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// flags returns the flags of the node which are not hidden, the help flag is
+// left out as every command has it.
+func flags(n *kong.Node, hidden bool) []*kong.Flag {
+	flags := []*kong.Flag{}
+	for _, f := range n.Flags {
+		if (hidden || !f.Hidden) && f.Name != "help" {
+			flags = append(flags, f)
+		}
+	}
+	return flags
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// valueType returns a simple description of the type of the value.
+func valueType(v *kong.Value) string {
+	switch {
+	case v.IsCounter():
+		return "counter"
+	case v.IsBool():
+		return "bool"
+	case v.IsSlice():
+		return "list"
+	case v.IsMap():
+		return "map"
+	}
+	if !v.Target.IsValid() {
+		return "string"
+	}
+	t := v.Target.Type()
+	switch {
+	case t == durationType:
+		return "duration"
+	case t == timeType:
+		return "time"
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	default:
+		return "string"
+	}
+}
+
+// enum returns the allowed values of the value, without the empty one.
+func enum(v *kong.Value) []string {
+	if v.Enum == "" {
+		return nil
+	}
+	values := []string{}
+	for _, e := range strings.Split(v.Enum, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			values = append(values, e)
+		}
+	}
+	return values
+}
@@ -0,0 +1,75 @@
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCLI struct {
+	Project string `short:"p" help:"Project to use." env:"TEST_PROJECT"`
+	Get     struct {
+		Apps struct {
+			Name   string `arg:"" optional:"" help:"Name of the app."`
+			Output string `short:"o" enum:"full,yaml" default:"full" help:"Output format."`
+		} `cmd:"" aliases:"app" help:"Get apps."`
+		Secret struct{} `cmd:"" hidden:"" help:"Hidden command."`
+	} `cmd:"" help:"Get resources."`
+}
+
+func testApp(t *testing.T) *kong.Application {
+	parser, err := kong.New(&testCLI{}, kong.Name("nctl"))
+	require.NoError(t, err)
+	return parser.Model
+}
+
+func TestJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, (&jsonCmd{out: out}).Run(testApp(t)))
+
+	root := command{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &root))
+	assert.Equal(t, "nctl", root.Name)
+	require.Len(t, root.Flags, 1)
+	assert.Equal(t, flag{Name: "project", Short: "p", Help: "Project to use.", Type: "string", Env: []string{"TEST_PROJECT"}}, root.Flags[0])
+
+	require.Len(t, root.Commands, 1)
+	require.Len(t, root.Commands[0].Commands, 1, "hidden commands are left out")
+	apps := root.Commands[0].Commands[0]
+	assert.Equal(t, "nctl get apps", apps.Path)
+	assert.Equal(t, []string{"app"}, apps.Aliases)
+	assert.Equal(t, []argument{{Name: "name", Help: "Name of the app.", Type: "string"}}, apps.Arguments)
+	assert.Equal(t, []string{"full", "yaml"}, apps.Flags[0].Enum)
+	assert.Equal(t, "full", apps.Flags[0].Default)
+}
+
+func TestMan(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, (&manCmd{Dir: dir}).Run(testApp(t), "v1.0.0"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"nctl.1", "nctl-get.1", "nctl-get-apps.1"}, names)
+
+	page, err := os.ReadFile(filepath.Join(dir, "nctl-get-apps.1"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), `.TH "NCTL-GET-APPS" "1" "" "nctl v1.0.0" "nctl Manual"`)
+	assert.Contains(t, string(page), `nctl-get-apps \- Get apps.`)
+	assert.Contains(t, string(page), `\fB\-o\fR, \fB\-\-output\fR=\fISTRING\fR`)
+	assert.Contains(t, string(page), "Can be set with $TEST_PROJECT.")
+	assert.Contains(t, string(page), `\fBnctl-get\fR(1)`)
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, "a\\eb\n\\&.c", escape("a\\b\n.c"))
+}
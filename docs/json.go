@@ -0,0 +1,109 @@
+package docs
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+type jsonCmd struct {
+	Hidden bool `help:"Include hidden commands and flags."`
+	out    io.Writer
+}
+
+// command is the JSON description of a command.
+type command struct {
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	Aliases   []string   `json:"aliases,omitempty"`
+	Help      string     `json:"help,omitempty"`
+	Detail    string     `json:"detail,omitempty"`
+	Group     string     `json:"group,omitempty"`
+	Hidden    bool       `json:"hidden,omitempty"`
+	Arguments []argument `json:"arguments,omitempty"`
+	Flags     []flag     `json:"flags,omitempty"`
+	Commands  []command  `json:"commands,omitempty"`
+}
+
+type argument struct {
+	Name     string   `json:"name"`
+	Help     string   `json:"help,omitempty"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Default  string   `json:"default,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+}
+
+type flag struct {
+	Name        string   `json:"name"`
+	Short       string   `json:"short,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Help        string   `json:"help,omitempty"`
+	Type        string   `json:"type"`
+	Placeholder string   `json:"placeholder,omitempty"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Env         []string `json:"env,omitempty"`
+	Negatable   bool     `json:"negatable,omitempty"`
+	Hidden      bool     `json:"hidden,omitempty"`
+}
+
+func (cmd *jsonCmd) Run(app *kong.Application) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	enc := json.NewEncoder(cmd.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cmd.describe(app.Node))
+}
+
+func (cmd *jsonCmd) describe(n *kong.Node) command {
+	c := command{
+		Name:    n.Name,
+		Path:    strings.Join(commandPath(n), " "),
+		Aliases: n.Aliases,
+		Help:    n.Help,
+		Detail:  n.Detail,
+		Hidden:  n.Hidden,
+	}
+	if n.Group != nil {
+		c.Group = n.Group.Title
+	}
+	for _, p := range n.Positional {
+		c.Arguments = append(c.Arguments, argument{
+			Name:     p.Name,
+			Help:     p.Help,
+			Type:     valueType(p),
+			Required: p.Required,
+			Default:  p.Default,
+			Enum:     enum(p),
+		})
+	}
+	for _, f := range flags(n, cmd.Hidden) {
+		fl := flag{
+			Name:        f.Name,
+			Aliases:     f.Aliases,
+			Help:        f.Help,
+			Type:        valueType(f.Value),
+			Placeholder: f.PlaceHolder,
+			Required:    f.Required,
+			Default:     f.Default,
+			Enum:        enum(f.Value),
+			Env:         f.Envs,
+			Negatable:   f.Tag.Negatable,
+			Hidden:      f.Hidden,
+		}
+		if f.Short != 0 {
+			fl.Short = string(f.Short)
+		}
+		c.Flags = append(c.Flags, fl)
+	}
+	for _, child := range visible(n, cmd.Hidden) {
+		c.Commands = append(c.Commands, cmd.describe(child))
+	}
+	return c
+}
@@ -0,0 +1,174 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/ninech/nctl/api/util"
+)
+
+type manCmd struct {
+	Dir    string `help:"Directory to write the man pages to." default:"man" type:"path" placeholder:"DIR"`
+	Hidden bool   `help:"Include hidden commands and flags."`
+}
+
+func (cmd *manCmd) Help() string {
+	return `Writes a man page for every command to the directory, e.g. nctl-get-apps.1.
+They can be viewed with "man ./man/nctl.1" or installed to the man path.`
+}
+
+func (cmd *manCmd) Run(app *kong.Application, version string) error {
+	if err := os.MkdirAll(cmd.Dir, 0o755); err != nil {
+		return err
+	}
+	count := 0
+	err := cmd.write(app.Node, version, &count)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("wrote %d man pages to %s\n", count, cmd.Dir)
+	return nil
+}
+
+func (cmd *manCmd) write(n *kong.Node, version string, count *int) error {
+	path := filepath.Join(cmd.Dir, pageName(n)+".1")
+	if err := os.WriteFile(path, []byte(cmd.page(n, version)), 0o644); err != nil {
+		return fmt.Errorf("unable to write man page: %w", err)
+	}
+	*count++
+	for _, child := range visible(n, cmd.Hidden) {
+		if err := cmd.write(child, version, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var unsafePageChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// pageName returns the name of the man page of the node, e.g. nctl-get-apps.
+func pageName(n *kong.Node) string {
+	name := unsafePageChars.ReplaceAllString(strings.ToLower(strings.Join(commandPath(n), " ")), "-")
+	return strings.Trim(name, "-")
+}
+
+// page renders the man page of the node in roff.
+func (cmd *manCmd) page(n *kong.Node, version string) string {
+	b := &bytes.Buffer{}
+	name := pageName(n)
+	fmt.Fprintf(b, ".TH %q \"1\" \"\" %q \"%s Manual\"\n", strings.ToUpper(name), util.NctlName+" "+version, util.NctlName)
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(b, "%s \\- %s\n", name, escape(n.Help))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(b, ".B %s\n", escape(strings.Join(commandPath(n), " ")))
+	synopsis := []string{}
+	for _, p := range n.Positional {
+		synopsis = append(synopsis, p.ShortSummary())
+	}
+	if len(n.Children) > 0 {
+		synopsis = append(synopsis, "<command>")
+	}
+	synopsis = append(synopsis, "[flags]")
+	fmt.Fprintln(b, escape(strings.Join(synopsis, " ")))
+
+	if n.Detail != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(paragraphs(n.Detail))
+	}
+
+	if len(n.Positional) > 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+		for _, p := range n.Positional {
+			fmt.Fprintf(b, ".TP\n\\fI%s\\fR\n%s\n", escape(p.ShortSummary()), escape(p.Help))
+		}
+	}
+
+	if own := flags(n, cmd.Hidden); len(own) > 0 {
+		b.WriteString(".SH OPTIONS\n")
+		writeFlags(b, own)
+	}
+	inherited := []*kong.Flag{}
+	for p := n.Parent; p != nil; p = p.Parent {
+		inherited = append(flags(p, cmd.Hidden), inherited...)
+	}
+	if len(inherited) > 0 {
+		b.WriteString(".SH GLOBAL OPTIONS\n")
+		writeFlags(b, inherited)
+	}
+
+	if children := visible(n, cmd.Hidden); len(children) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+		for _, child := range children {
+			fmt.Fprintf(b, ".TP\n\\fB%s\\fR(1)\n%s\n", pageName(child), escape(child.Help))
+		}
+	}
+
+	if n.Parent != nil {
+		b.WriteString(".SH SEE ALSO\n")
+		fmt.Fprintf(b, "\\fB%s\\fR(1)\n", pageName(n.Parent))
+	}
+	return b.String()
+}
+
+func writeFlags(b *bytes.Buffer, flags []*kong.Flag) {
+	for _, f := range flags {
+		b.WriteString(".TP\n")
+		if f.Short != 0 {
+			fmt.Fprintf(b, "\\fB\\-%c\\fR, ", f.Short)
+		}
+		fmt.Fprintf(b, "\\fB\\-\\-%s\\fR", escape(f.Name))
+		if !f.IsBool() && !f.IsCounter() {
+			placeholder := f.PlaceHolder
+			if placeholder == "" {
+				placeholder = strings.ToUpper(valueType(f.Value))
+			}
+			fmt.Fprintf(b, "=\\fI%s\\fR", escape(placeholder))
+		}
+		b.WriteString("\n")
+
+		help := strings.TrimSpace(f.Help)
+		if help != "" && !strings.HasSuffix(help, ".") {
+			help += "."
+		}
+		if f.HasDefault && f.Default != "" {
+			help += fmt.Sprintf(" Defaults to %q.", f.Default)
+		}
+		if len(f.Envs) > 0 {
+			help += " Can be set with $" + strings.Join(f.Envs, ", $") + "."
+		}
+		fmt.Fprintln(b, escape(strings.TrimSpace(help)))
+	}
+}
+
+// paragraphs renders the text as roff paragraphs, indented lines are kept
+// as they are, e.g. for examples.
+func paragraphs(text string) string {
+	b := &strings.Builder{}
+	for _, para := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if strings.HasPrefix(para, " ") || strings.HasPrefix(para, "\t") {
+			fmt.Fprintf(b, ".PP\n.nf\n%s\n.fi\n", escape(para))
+			continue
+		}
+		fmt.Fprintf(b, ".PP\n%s\n", escape(para))
+	}
+	return b.String()
+}
+
+// escape escapes the text so it is not interpreted by roff.
+func escape(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\e`)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
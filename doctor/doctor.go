@@ -0,0 +1,347 @@
+// Package doctor contains a command to diagnose the local environment of
+// nctl, e.g. when commands fail for unclear reasons.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/int128/kubelogin/pkg/tokencache"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/log"
+	"github.com/ninech/nctl/internal/format"
+	"github.com/ninech/nctl/internal/updater"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// maxClockSkew is the maximum difference between the local and the API
+// server clock before tokens might be considered invalid.
+const maxClockSkew = 30 * time.Second
+
+type Cmd struct {
+	Timeout time.Duration `default:"10s" help:"Timeout of each network check."`
+	out     io.Writer
+	// tokenCacheDir is the directory of the cached OIDC tokens, defaults to
+	// the kubelogin cache in the home directory.
+	tokenCacheDir string
+	opts          []updater.Option
+	// httpClient is used for the network checks, it connects with the
+	// proxy and TLS settings of the global flags.
+	httpClient *http.Client
+}
+
+type result string
+
+const (
+	resultPass result = "PASS"
+	resultWarn result = "WARN"
+	resultFail result = "FAIL"
+	resultSkip result = "SKIP"
+)
+
+type checkResult struct {
+	name   string
+	result result
+	detail string
+	// hint is shown for failed and warned checks to fix the problem.
+	hint string
+}
+
+// environment is the state gathered by the checks which is needed by
+// following checks.
+type environment struct {
+	apiCluster string
+	endpoints  api.Endpoints
+	logAddress string
+	version    string
+	server     string
+	issuerURL  string
+	clientID   string
+	serverTime time.Time
+}
+
+func (cmd *Cmd) Help() string {
+	return "Checks the kubeconfig, the login token, the reachability of the APIs and\n" +
+		"the local clock and prints hints on how to fix found problems. Please\n" +
+		"include the output when contacting support."
+}
+
+func (cmd *Cmd) Run(ctx context.Context, apiCluster string, endpoints api.Endpoints, logAddress, version string, transport log.TransportConfig) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.tokenCacheDir == "" {
+		cmd.tokenCacheDir = api.TokenCacheDir()
+	}
+	httpClient, err := transport.HTTPClient()
+	if err != nil {
+		return err
+	}
+	cmd.httpClient = httpClient
+	cmd.opts = append([]updater.Option{updater.HTTPClient(httpClient)}, cmd.opts...)
+
+	env := &environment{apiCluster: apiCluster, endpoints: endpoints, logAddress: logAddress, version: version}
+	checks := []func(context.Context, *environment) checkResult{
+		cmd.checkKubeconfig,
+		cmd.checkToken,
+		cmd.checkAPI,
+		cmd.checkLogAPI,
+		cmd.checkIssuerDNS,
+		cmd.checkClockSkew,
+		cmd.checkVersion,
+	}
+
+	w := tabwriter.NewWriter(cmd.out, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tRESULT\tDETAILS")
+	results := make([]checkResult, 0, len(checks))
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, cmd.Timeout)
+		r := check(checkCtx, env)
+		cancel()
+		results = append(results, r)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.name, r.result, r.detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.result == resultFail {
+			failed++
+		}
+		if r.hint != "" && (r.result == resultFail || r.result == resultWarn) {
+			fmt.Fprintf(cmd.out, "\n%s: %s", r.name, r.hint)
+		}
+	}
+	fmt.Fprintln(cmd.out)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(results))
+	}
+	format.PrintSuccessf("🩺", "all checks passed")
+	return nil
+}
+
+func (cmd *Cmd) checkKubeconfig(_ context.Context, env *environment) checkResult {
+	r := checkResult{name: "kubeconfig", hint: fmt.Sprintf("log in again with %q", format.Command().Login())}
+
+	rules, err := api.LoadingRules()
+	if err != nil {
+		return r.fail(err.Error())
+	}
+	config, err := rules.Load()
+	if err != nil {
+		return r.fail(fmt.Sprintf("unable to load kubeconfig: %s", err))
+	}
+	kubeContext, ok := config.Contexts[env.apiCluster]
+	if !ok {
+		return r.fail(fmt.Sprintf("context %q not found in %s", env.apiCluster, rules.GetDefaultFilename()))
+	}
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok || cluster.Server == "" {
+		return r.fail(fmt.Sprintf("cluster %q of context %q not found", kubeContext.Cluster, env.apiCluster))
+	}
+	env.server = cluster.Server
+	env.issuerURL = execArg(config.AuthInfos[kubeContext.AuthInfo], api.IssuerURLArg)
+	env.clientID = execArg(config.AuthInfos[kubeContext.AuthInfo], api.ClientIDArg)
+	if env.endpoints.API != "" {
+		env.server = env.endpoints.API
+	}
+	if env.endpoints.Issuer != "" {
+		env.issuerURL = env.endpoints.Issuer
+	}
+
+	r.result = resultPass
+	r.detail = fmt.Sprintf("context %q, server %s", env.apiCluster, env.server)
+	return r
+}
+
+// execArg returns the value of the arg with the prefix, e.g. the OIDC issuer
+// URL, from the exec config of the user.
+func execArg(user *clientcmdapi.AuthInfo, prefix string) string {
+	if user == nil || user.Exec == nil {
+		return ""
+	}
+	for _, arg := range user.Exec.Args {
+		if strings.HasPrefix(arg, prefix) {
+			return strings.TrimPrefix(arg, prefix)
+		}
+	}
+	return ""
+}
+
+// checkToken inspects the cached tokens instead of requesting one, as that
+// might start an interactive login.
+func (cmd *Cmd) checkToken(_ context.Context, env *environment) checkResult {
+	r := checkResult{name: "token", hint: fmt.Sprintf("log in again with %q", format.Command().Login())}
+	if env.issuerURL == "" {
+		return r.skip("no OIDC login configured")
+	}
+
+	// the token is read from the same cache as on login, so the refresh
+	// token is found in the keychain as well.
+	key := tokencache.Key{IssuerURL: env.issuerURL, ClientID: env.clientID}
+	tokenSet, err := api.TokenCacheRepository().FindByKey(cmd.tokenCacheDir, key)
+	if err != nil {
+		return r.fail(fmt.Sprintf("no cached token for issuer %s", env.issuerURL))
+	}
+	claims := &jwt.StandardClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(tokenSet.IDToken, claims); err != nil {
+		return r.fail(fmt.Sprintf("unable to parse the cached token: %s", err))
+	}
+	expiry := time.Unix(claims.ExpiresAt, 0)
+	refreshable := tokenSet.RefreshToken != ""
+
+	switch {
+	case expiry.After(time.Now()):
+		r.result = resultPass
+		r.detail = fmt.Sprintf("valid until %s", format.Timestamp(expiry))
+	case refreshable:
+		r.result = resultWarn
+		r.detail = fmt.Sprintf("expired on %s, it will be refreshed on the next command", format.Timestamp(expiry))
+		r.hint = fmt.Sprintf("if commands still fail with authentication errors, log in again with %q", format.Command().Login())
+	default:
+		return r.fail(fmt.Sprintf("expired on %s", format.Timestamp(expiry)))
+	}
+	return r
+}
+
+func (cmd *Cmd) checkAPI(ctx context.Context, env *environment) checkResult {
+	r := checkResult{name: "api", hint: "check your network connection and proxy settings"}
+	if env.server == "" {
+		return r.skip("no API server configured")
+	}
+
+	resp, err := cmd.get(ctx, env.server+"/version")
+	if err != nil {
+		return r.fail(err.Error())
+	}
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		env.serverTime = date
+	}
+
+	r.result = resultPass
+	r.detail = fmt.Sprintf("%s is reachable", env.server)
+	return r
+}
+
+func (cmd *Cmd) checkLogAPI(ctx context.Context, env *environment) checkResult {
+	r := checkResult{name: "log api", hint: "check your network connection and proxy settings, logs will not be available"}
+	if env.logAddress == "" {
+		return r.skip("no log API configured")
+	}
+
+	if _, err := cmd.get(ctx, strings.TrimSuffix(env.logAddress, "/")+"/ready"); err != nil {
+		return r.fail(err.Error())
+	}
+	r.result = resultPass
+	r.detail = fmt.Sprintf("%s is reachable", env.logAddress)
+	return r
+}
+
+func (cmd *Cmd) checkIssuerDNS(ctx context.Context, env *environment) checkResult {
+	r := checkResult{name: "issuer dns", hint: "check your DNS resolver, logging in will not be possible"}
+	if env.issuerURL == "" {
+		return r.skip("no OIDC login configured")
+	}
+	u, err := url.Parse(env.issuerURL)
+	if err != nil || u.Hostname() == "" {
+		return r.fail(fmt.Sprintf("invalid issuer URL %q", env.issuerURL))
+	}
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, u.Hostname())
+	if err != nil {
+		return r.fail(err.Error())
+	}
+	r.result = resultPass
+	r.detail = fmt.Sprintf("%s resolves to %s", u.Hostname(), strings.Join(addrs, ", "))
+	return r
+}
+
+func (cmd *Cmd) checkClockSkew(_ context.Context, env *environment) checkResult {
+	r := checkResult{name: "clock", hint: "synchronize your clock, e.g. by enabling NTP, tokens are rejected otherwise"}
+	if env.serverTime.IsZero() {
+		return r.skip("server time unknown")
+	}
+
+	skew := time.Since(env.serverTime).Round(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+	// the Date header only has a precision of a second.
+	if skew > maxClockSkew {
+		return r.fail(fmt.Sprintf("local clock is off by %s", skew))
+	}
+	r.result = resultPass
+	r.detail = fmt.Sprintf("off by %s", skew)
+	return r
+}
+
+func (cmd *Cmd) checkVersion(ctx context.Context, env *environment) checkResult {
+	r := checkResult{name: "version", hint: fmt.Sprintf("update with %q or your package manager", "nctl self-update")}
+
+	latest, err := updater.New(cmd.opts...).Latest(ctx)
+	if err != nil {
+		r.result = resultWarn
+		r.detail = fmt.Sprintf("%s, unable to check for updates: %s", env.version, err)
+		r.hint = ""
+		return r
+	}
+	newer, err := updater.IsNewer(env.version, latest.TagName)
+	if err != nil {
+		r.result = resultWarn
+		r.detail = err.Error()
+		r.hint = ""
+		return r
+	}
+	if newer {
+		r.result = resultWarn
+		r.detail = fmt.Sprintf("%s, a new version %s is available", env.version, latest.TagName)
+		return r
+	}
+	r.result = resultPass
+	r.detail = fmt.Sprintf("%s is up to date", env.version)
+	return r
+}
+
+func (r checkResult) fail(detail string) checkResult {
+	r.result = resultFail
+	r.detail = detail
+	return r
+}
+
+func (r checkResult) skip(detail string) checkResult {
+	r.result = resultSkip
+	r.detail = detail
+	return r
+}
+
+// get requests the URL and only returns an error if no response has been
+// received, as any response shows that the server is reachable.
+func (cmd *Cmd) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cmd.httpClient.Do(req)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			return nil, fmt.Errorf("unable to resolve %s", dnsErr.Name)
+		}
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
@@ -0,0 +1,157 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/int128/kubelogin/pkg/oidc"
+	"github.com/int128/kubelogin/pkg/tokencache"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/log"
+	"github.com/ninech/nctl/internal/updater"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+const issuer = "http://localhost/auth/realms/pub"
+
+func TestDoctor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/ninech/nctl/releases/latest" {
+			fmt.Fprint(w, `{"tag_name": "v1.2.0"}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: nineapis.ch
+  cluster:
+    server: %s
+contexts:
+- name: nineapis.ch
+  context:
+    cluster: nineapis.ch
+    user: nineapis.ch
+current-context: nineapis.ch
+users:
+- name: nineapis.ch
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: nctl
+      args:
+      - auth
+      - oidc
+      - --issuer-url=%s
+      - --client-id=nctl
+`, server.URL, issuer)), 0o600))
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	tests := []struct {
+		name         string
+		expiry       time.Duration
+		refreshToken string
+		version      string
+		wantErr      bool
+		wantContain  []string
+	}{
+		{
+			name:        "all checks pass",
+			expiry:      time.Hour,
+			version:     "1.2.0",
+			wantContain: []string{"valid until", "is up to date"},
+		},
+		{
+			name:         "expired token is refreshed",
+			expiry:       -time.Hour,
+			refreshToken: "refresh",
+			version:      "1.1.0",
+			wantContain:  []string{"WARN", "will be refreshed", "a new version v1.2.0 is available"},
+		},
+		{
+			name:        "expired token",
+			expiry:      -time.Hour,
+			version:     "1.2.0",
+			wantErr:     true,
+			wantContain: []string{"FAIL", "log in again"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the refresh token is stored in the keychain, like on login.
+			keyring.MockInit()
+			cacheDir := t.TempDir()
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+				Issuer:    issuer,
+				ExpiresAt: time.Now().Add(tt.expiry).Unix(),
+			}).SignedString([]byte("secret"))
+			require.NoError(t, err)
+			require.NoError(t, api.TokenCacheRepository().Save(
+				cacheDir,
+				tokencache.Key{IssuerURL: issuer, ClientID: "nctl"},
+				oidc.TokenSet{IDToken: token, RefreshToken: tt.refreshToken},
+			))
+
+			out := &bytes.Buffer{}
+			cmd := &Cmd{
+				Timeout:       5 * time.Second,
+				out:           out,
+				tokenCacheDir: cacheDir,
+				opts:          []updater.Option{updater.BaseURL(server.URL)},
+			}
+			err = cmd.Run(context.Background(), "nineapis.ch", api.Endpoints{}, server.URL, tt.version, log.TransportConfig{})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, out.String())
+			}
+			for _, s := range tt.wantContain {
+				assert.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func TestDoctorMissingContext(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("apiVersion: v1\nkind: Config\n"), 0o600))
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	out := &bytes.Buffer{}
+	cmd := &Cmd{Timeout: time.Second, out: out, tokenCacheDir: t.TempDir(), opts: []updater.Option{updater.BaseURL("http://127.0.0.1:0")}}
+	assert.Error(t, cmd.Run(context.Background(), "nineapis.ch", api.Endpoints{}, "", "1.0.0", log.TransportConfig{}))
+	assert.Contains(t, out.String(), `context "nineapis.ch" not found`)
+	assert.Contains(t, out.String(), "SKIP")
+}
+
+func TestDoctorTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "config"))
+
+	for _, insecure := range []bool{false, true} {
+		out := &bytes.Buffer{}
+		cmd := &Cmd{Timeout: time.Second, out: out, tokenCacheDir: t.TempDir(), opts: []updater.Option{updater.BaseURL("http://127.0.0.1:0")}}
+		// the other checks fail without a kubeconfig
+		assert.Error(t, cmd.Run(context.Background(), "nineapis.ch", api.Endpoints{}, server.URL, "1.0.0", log.TransportConfig{Insecure: insecure}))
+		if insecure {
+			assert.Contains(t, out.String(), server.URL+" is reachable")
+		} else {
+			assert.Contains(t, out.String(), "certificate")
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package edit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const header = "# Edit the %s below and save the file to apply the changes. Only the spec,\n" +
+	"# labels and annotations can be changed. Comments are ignored, an empty file\n" +
+	"# aborts the edit.\n"
+
+type Cmd struct {
+	Kind string `arg:"" help:"Kind of the resource to edit, e.g. application, postgres or project."`
+	Name string `arg:"" predictor:"resource_name" help:"Name of the resource to edit."`
+	out  io.Writer
+	// edit opens the file in an editor and returns once it has been
+	// closed, defaults to running the editor of the environment.
+	edit func(ctx context.Context, path string) error
+}
+
+func (cmd *Cmd) Help() string {
+	return "Opens the resource as YAML in the editor set in $NCTL_EDITOR, $EDITOR or $VISUAL.\n" +
+		"After saving and closing the editor, the changes are validated and sent to the\n" +
+		"API as a patch, so that changes made in the meantime are not overwritten.\n\n" +
+		"Example:\n\n" +
+		"  EDITOR=\"code --wait\" nctl edit app myapp"
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.edit == nil {
+		cmd.edit = runEditor
+	}
+
+	gvk, err := api.LookupKind(client.Scheme(), cmd.Kind)
+	if err != nil {
+		return err
+	}
+	name, err := client.NamespacedNameFor(gvk, cmd.Name)
+	if err != nil {
+		return err
+	}
+	kind := strings.ToLower(gvk.Kind)
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	if err := client.Get(ctx, name, current); err != nil {
+		return fmt.Errorf("unable to get %s %q: %w", kind, cmd.Name, err)
+	}
+
+	original, err := yaml.Marshal(editable(current).Object)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp("", fmt.Sprintf("nctl-edit-%s-%s-*.yaml", kind, cmd.Name))
+	if err != nil {
+		return err
+	}
+	path := f.Name()
+	_, err = fmt.Fprintf(f, header+"\n%s", kind, original)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	// the file is kept if applying the changes fails, so that they are
+	// not lost.
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(path)
+		}
+	}()
+
+	if err := cmd.edit(ctx, path); err != nil {
+		return fmt.Errorf("editor failed: %w", err)
+	}
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	e := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(edited, &e.Object); err != nil {
+		keep = true
+		return fmt.Errorf("unable to edit %s %q, your changes have been saved to %s: invalid YAML: %w", kind, cmd.Name, path, err)
+	}
+	if len(e.Object) == 0 {
+		format.PrintFailuref("", "edit canceled, the file is empty")
+		return nil
+	}
+	// the edit is compared in the same format as the original, so that
+	// comments and formatting are not considered as changes.
+	normalized, err := yaml.Marshal(e.Object)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(normalized, original) {
+		fmt.Fprintf(cmd.out, "%s %q not changed\n", kind, cmd.Name)
+		return nil
+	}
+
+	updated, err := apply(current, e)
+	if err == nil {
+		err = client.Patch(ctx, updated, runtimeclient.MergeFrom(current))
+	}
+	if err != nil {
+		keep = true
+		return fmt.Errorf("unable to edit %s %q, your changes have been saved to %s: %w", kind, cmd.Name, path, err)
+	}
+
+	fmt.Fprintln(cmd.out, format.SuccessMessagef("📝", "edited %s %q", kind, cmd.Name))
+	return nil
+}
+
+// editable returns the parts of obj which can be edited.
+func editable(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	e := &unstructured.Unstructured{Object: map[string]any{}}
+	e.SetAPIVersion(obj.GetAPIVersion())
+	e.SetKind(obj.GetKind())
+	e.SetName(obj.GetName())
+	e.SetNamespace(obj.GetNamespace())
+	e.SetLabels(obj.GetLabels())
+	e.SetAnnotations(obj.GetAnnotations())
+	if spec, ok := obj.Object["spec"]; ok {
+		e.Object["spec"] = spec
+	}
+	return e
+}
+
+// apply returns a copy of current with the edited spec, labels and
+// annotations. It fails if the edit changed the identity of the resource.
+func apply(current, e *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if e.GetAPIVersion() != current.GetAPIVersion() || e.GetKind() != current.GetKind() ||
+		e.GetName() != current.GetName() || e.GetNamespace() != current.GetNamespace() {
+		return nil, fmt.Errorf("the apiVersion, kind, name and namespace can not be changed")
+	}
+
+	updated := current.DeepCopy()
+	updated.SetLabels(e.GetLabels())
+	updated.SetAnnotations(e.GetAnnotations())
+	if spec, ok := e.Object["spec"]; ok {
+		updated.Object["spec"] = spec
+	} else {
+		delete(updated.Object, "spec")
+	}
+	return updated, nil
+}
+
+// editorCommand returns the editor configured in the environment.
+func editorCommand() []string {
+	for _, env := range []string{"NCTL_EDITOR", "EDITOR", "VISUAL"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) != 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+func runEditor(ctx context.Context, path string) error {
+	editor := editorCommand()
+	c := osexec.CommandContext(ctx, editor[0], append(editor[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if format.Quiet() {
+		// stdout is discarded in quiet mode, the editor still needs a
+		// terminal.
+		c.Stdout = os.Stderr
+	}
+	return c.Run()
+}
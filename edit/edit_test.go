@@ -0,0 +1,110 @@
+package edit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEdit(t *testing.T) {
+	ctx := context.Background()
+
+	// replace returns an editor which replaces old with new in the file.
+	replace := func(old, new string) func(context.Context, string) error {
+		return func(_ context.Context, path string) error {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(path, []byte(strings.Replace(string(data), old, new, 1)), 0o600)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		edit            func(context.Context, string) error
+		wantSize        apps.ApplicationSize
+		wantAnnotations map[string]string
+		wantOut         string
+		wantErr         string
+	}{
+		{
+			name:     "change spec",
+			edit:     replace("size: micro", "size: standard-1"),
+			wantSize: "standard-1",
+			wantOut:  `edited application "myapp"`,
+		},
+		{
+			name:     "no changes",
+			edit:     replace("", ""),
+			wantSize: "micro",
+			wantOut:  `application "myapp" not changed`,
+		},
+		{
+			name:     "only comments",
+			edit:     func(_ context.Context, path string) error { return os.WriteFile(path, []byte("# nothing\n"), 0o600) },
+			wantSize: "micro",
+		},
+		{
+			name:            "comment characters in block scalars are kept",
+			edit:            replace("  name: myapp", "  annotations:\n    script: |\n      #!/bin/sh\n      # keep me\n  name: myapp"),
+			wantSize:        "micro",
+			wantAnnotations: map[string]string{"script": "#!/bin/sh\n# keep me\n"},
+			wantOut:         `edited application "myapp"`,
+		},
+		{
+			name:    "changed name",
+			edit:    replace("name: myapp", "name: other"),
+			wantErr: "can not be changed",
+		},
+		{
+			name:    "invalid yaml",
+			edit:    replace("size: micro", "size: [micro"),
+			wantErr: "invalid YAML",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &apps.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "myapp",
+					Namespace: test.DefaultProject,
+				},
+			}
+			app.Spec.ForProvider.Config.Size = "micro"
+			apiClient, err := test.SetupClient(test.WithObjects(app))
+			require.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			var path string
+			cmd := &Cmd{Kind: "app", Name: app.Name, out: out, edit: func(ctx context.Context, p string) error {
+				path = p
+				return tt.edit(ctx, p)
+			}}
+			err = cmd.Run(ctx, apiClient)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.FileExists(t, path, "changes should be kept")
+				os.Remove(path)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoFileExists(t, path)
+			assert.Contains(t, out.String(), tt.wantOut)
+
+			require.NoError(t, apiClient.Get(ctx, api.ObjectName(app), app))
+			assert.Equal(t, tt.wantSize, app.Spec.ForProvider.Config.Size)
+			assert.Equal(t, tt.wantAnnotations, app.Annotations)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Cmd struct {
+	Kind      string `arg:"" help:"Kind of the resource to show events for, e.g. application, postgres or build."`
+	Name      string `arg:"" predictor:"resource_name" help:"Name of the resource to show events for."`
+	NoRelated bool   `help:"Do not include the builds and releases of an application."`
+	out       io.Writer
+}
+
+// entry is either a status condition or an event of a resource.
+type entry struct {
+	time    time.Time
+	object  string
+	typ     string
+	reason  string
+	message string
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	gvk, err := api.LookupKind(client.Scheme(), cmd.Kind)
+	if err != nil {
+		return err
+	}
+
+	name, err := client.NamespacedNameFor(gvk, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := client.Get(ctx, name, obj); err != nil {
+		return fmt.Errorf("unable to get %s %q: %w", strings.ToLower(gvk.Kind), cmd.Name, err)
+	}
+
+	objects := []*unstructured.Unstructured{obj}
+	if gvk.GroupKind() == apps.ApplicationGroupVersionKind.GroupKind() && !cmd.NoRelated {
+		for _, kind := range []string{apps.BuildKind, apps.ReleaseKind} {
+			related, err := listRelated(ctx, client, apps.SchemeGroupVersion.WithKind(kind+"List"), name.Namespace, cmd.Name)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, related...)
+		}
+	}
+
+	eventList := &corev1.EventList{}
+	if err := client.List(ctx, eventList, runtimeclient.InNamespace(name.Namespace)); err != nil {
+		return fmt.Errorf("unable to list events: %w", err)
+	}
+
+	entries := []entry{}
+	for _, o := range objects {
+		entries = append(entries, conditionEntries(o)...)
+		entries = append(entries, eventEntries(o, eventList.Items)...)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(cmd.out, "no events found for %s %q\n", strings.ToLower(gvk.Kind), cmd.Name)
+		return nil
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].time.Before(entries[j].time)
+	})
+
+	return printEntries(cmd.out, entries)
+}
+
+func listRelated(ctx context.Context, client *api.Client, listGVK schema.GroupVersionKind, namespace, appName string) ([]*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(listGVK)
+	if err := client.List(ctx, list,
+		runtimeclient.InNamespace(namespace),
+		runtimeclient.MatchingLabels{util.ApplicationNameLabel: appName},
+	); err != nil {
+		return nil, fmt.Errorf("unable to list %s: %w", strings.TrimSuffix(listGVK.Kind, "List"), err)
+	}
+
+	items := []*unstructured.Unstructured{}
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	return items, nil
+}
+
+func objectRef(kind, name string) string {
+	return strings.ToLower(kind) + "/" + name
+}
+
+func conditionEntries(obj *unstructured.Unstructured) []entry {
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return nil
+	}
+
+	entries := []entry{}
+	for _, c := range conditions {
+		cm, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		transition, _ := time.Parse(time.RFC3339, stringValue(cm, "lastTransitionTime"))
+		entries = append(entries, entry{
+			time:    transition,
+			object:  objectRef(obj.GetKind(), obj.GetName()),
+			typ:     fmt.Sprintf("%s=%s", stringValue(cm, "type"), stringValue(cm, "status")),
+			reason:  stringValue(cm, "reason"),
+			message: stringValue(cm, "message"),
+		})
+	}
+	return entries
+}
+
+func eventEntries(obj *unstructured.Unstructured, events []corev1.Event) []entry {
+	entries := []entry{}
+	for _, ev := range events {
+		if ev.InvolvedObject.Name != obj.GetName() || ev.InvolvedObject.Kind != obj.GetKind() {
+			continue
+		}
+		entries = append(entries, entry{
+			time:    eventTime(ev),
+			object:  objectRef(obj.GetKind(), obj.GetName()),
+			typ:     ev.Type,
+			reason:  ev.Reason,
+			message: ev.Message,
+		})
+	}
+	return entries
+}
+
+// eventTime returns the time an event has last been seen.
+func eventTime(ev corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	}
+	return ev.FirstTimestamp.Time
+}
+
+func stringValue(m map[string]any, key string) string {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func printEntries(out io.Writer, entries []entry) error {
+	w := format.NewTable(out, format.Truncate())
+	fmt.Fprintln(w, "LAST SEEN\tOBJECT\tTYPE\tREASON\tMESSAGE")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", format.Age(e.time), e.object, e.typ, e.reason, strings.ReplaceAll(e.message, "\n", " "))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: test.DefaultProject},
+	}
+	app.SetConditions(runtimev1.Creating().WithMessage("waiting for release"))
+	app.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-time.Hour))
+
+	build := &apps.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-build-1",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: app.Name},
+		},
+	}
+	build.SetConditions(runtimev1.Available())
+	build.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-30 * time.Minute))
+
+	otherBuild := &apps.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-build-1",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "other"},
+		},
+	}
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "app.1", Namespace: test.DefaultProject},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: apps.ApplicationKind,
+			Name: app.Name,
+		},
+		Type:          corev1.EventTypeWarning,
+		Reason:        "ReleaseFailed",
+		Message:       "release is stuck in progressing",
+		LastTimestamp: metav1.NewTime(now.Add(-10 * time.Minute)),
+	}
+
+	apiClient, err := test.SetupClient(test.WithObjects(app, build, otherBuild, event))
+	require.NoError(t, err)
+
+	t.Run("with related", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &Cmd{Kind: "app", Name: app.Name, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 4)
+		assert.Contains(t, lines[1], "application/app")
+		assert.Contains(t, lines[1], "Ready=False")
+		assert.Contains(t, lines[2], "build/app-build-1")
+		assert.Contains(t, lines[3], "ReleaseFailed")
+		assert.NotContains(t, out.String(), "other-build-1")
+	})
+
+	t.Run("without related", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &Cmd{Kind: "app", Name: app.Name, NoRelated: true, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+		assert.NotContains(t, out.String(), "app-build-1")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cmd := &Cmd{Kind: "app", Name: "doesnotexist", out: &bytes.Buffer{}}
+		assert.Error(t, cmd.Run(ctx, apiClient))
+	})
+}
@@ -34,12 +34,15 @@ type remoteCommandParameters struct {
 	replicaName      string
 	replicaNamespace string
 	command          []string
-	tty              bool
-	enableStdin      bool
-	stdin            io.Reader
-	stdout           io.Writer
-	stderr           io.Writer
-	restConfig       *rest.Config
+	// attach attaches to the running process of the replica instead of
+	// executing command.
+	attach      bool
+	tty         bool
+	enableStdin bool
+	stdin       io.Reader
+	stdout      io.Writer
+	stderr      io.Writer
+	restConfig  *rest.Config
 }
 
 type applicationCmd struct {
@@ -184,15 +187,25 @@ func executeRemoteCommand(ctx context.Context, params remoteCommandParameters) e
 			Post().
 			Namespace(params.replicaNamespace).
 			Resource("pods").
-			Name(params.replicaName).
-			SubResource("exec").
-			VersionedParams(&corev1.PodExecOptions{
-				Command: params.command,
-				Stdin:   params.enableStdin,
-				Stdout:  params.stdout != nil,
-				Stderr:  params.stderr != nil,
-				TTY:     params.tty,
-			}, scheme.ParameterCodec)
+			Name(params.replicaName)
+		if params.attach {
+			request = request.SubResource("attach").
+				VersionedParams(&corev1.PodAttachOptions{
+					Stdin:  params.enableStdin,
+					Stdout: params.stdout != nil,
+					Stderr: params.stderr != nil,
+					TTY:    params.tty,
+				}, scheme.ParameterCodec)
+		} else {
+			request = request.SubResource("exec").
+				VersionedParams(&corev1.PodExecOptions{
+					Command: params.command,
+					Stdin:   params.enableStdin,
+					Stdout:  params.stdout != nil,
+					Stderr:  params.stderr != nil,
+					TTY:     params.tty,
+				}, scheme.ParameterCodec)
+		}
 
 		exec, err := remotecommand.NewSPDYExecutor(params.restConfig, "POST", request.URL())
 		if err != nil {
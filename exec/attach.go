@@ -0,0 +1,102 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+)
+
+type AttachCmd struct {
+	Application attachAppCmd `cmd:"" group:"deplo.io" aliases:"app,apps" name:"application" help:"Stream the output of a running deplo.io application replica."`
+}
+
+type attachAppCmd struct {
+	resourceCmd
+	Replica   string `help:"Name of the replica to attach to, see \"nctl get app <name> --replicas\". Defaults to the first ready replica."`
+	WorkerJob string `name:"worker-job" short:"w" help:"Attach to a replica of the worker job by name."`
+}
+
+func (cmd *attachAppCmd) Help() string {
+	return `Streams the stdout and stderr of the process running in a replica of an
+application directly from the deplo.io cluster, without going through the
+log pipeline. Only output which is written after attaching is shown, use
+"nctl logs app" to see earlier output. Press Ctrl+C to detach, the
+replica keeps running.
+
+Examples:
+
+  # attach to the first ready replica of the web process
+  nctl attach app myapp
+
+  # attach to a replica of the worker job "queue"
+  nctl attach app myapp --worker-job queue
+
+  # attach to a specific replica
+  nctl attach app myapp --replica myapp-7d9c8b5f6-x2x4k`
+}
+
+func (cmd *attachAppCmd) Run(ctx context.Context, client *api.Client) error {
+	replica, err := cmd.getReplica(ctx, client)
+	if err != nil {
+		return fmt.Errorf("error when searching for replica to attach to: %w", err)
+	}
+	config, err := client.DeploioRuntimeConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("can not create deplo.io cluster rest config: %w", err)
+	}
+	// the notice goes to stderr to keep stdout to the output of the replica.
+	if !format.Quiet() {
+		fmt.Fprintln(os.Stderr, format.SuccessMessagef("🔗", "attached to replica %s of application %q, press Ctrl+C to detach", replica, cmd.Name))
+	}
+	return executeRemoteCommand(
+		ctx,
+		remoteCommandParameters{
+			replicaName:      replica,
+			replicaNamespace: client.Project,
+			attach:           true,
+			stdout:           os.Stdout,
+			stderr:           os.Stderr,
+			restConfig:       config,
+		})
+}
+
+// getReplica returns the replica given by name or the first ready replica of
+// the web process or worker job of the latest available release.
+func (cmd *attachAppCmd) getReplica(ctx context.Context, client *api.Client) (string, error) {
+	release, err := util.ApplicationLatestAvailableRelease(ctx, client, client.Name(cmd.Name))
+	if err != nil {
+		return "", err
+	}
+	if cmd.Replica != "" {
+		for _, r := range util.ReleaseReplicas(release, true) {
+			if r.ReplicaName == cmd.Replica {
+				return r.ReplicaName, nil
+			}
+		}
+		return "", fmt.Errorf("replica %q of application %q not found, list the replicas with: nctl get app %s --replicas", cmd.Replica, cmd.Name, cmd.Name)
+	}
+
+	process := util.ProcessTypeWeb
+	if cmd.WorkerJob != "" {
+		process = cmd.WorkerJob
+	}
+	found := false
+	for _, r := range util.ReleaseReplicas(release, false) {
+		if r.Process != process {
+			continue
+		}
+		found = true
+		if replica := readyReplica([]apps.ReplicaObservation{r.ReplicaObservation}); replica != "" {
+			return replica, nil
+		}
+	}
+	if !found && cmd.WorkerJob != "" {
+		return "", fmt.Errorf("worker job %q not found", cmd.WorkerJob)
+	}
+	return "", fmt.Errorf("no ready replica found for release %s", release.Name)
+}
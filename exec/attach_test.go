@@ -0,0 +1,72 @@
+package exec
+
+import (
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachReplicaSelection(t *testing.T) {
+	ctx := context.Background()
+	release := newRelease(
+		"shop",
+		[]apps.ReplicaObservation{
+			{Status: apps.ReplicaStatusFailing, ReplicaName: "web-1"},
+			{Status: apps.ReplicaStatusReady, ReplicaName: "web-2"},
+		},
+		apps.ReleaseProcessStatusAvailable,
+		false,
+	)
+	release.Status.AtProvider.WorkerJobStatus = []apps.WorkerJobStatus{
+		{Name: "queue", ReplicaObservation: []apps.ReplicaObservation{{Status: apps.ReplicaStatusReady, ReplicaName: "queue-1"}}},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithKubeconfig(t),
+		test.WithNameIndexFor(&apps.Release{}),
+		test.WithObjects(addCreationTimestamp([]apps.Release{release})...),
+		test.WithDefaultProject(project),
+	)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		cmd             attachAppCmd
+		expectedReplica string
+		expectedError   string
+	}{
+		"first ready replica of the web process": {
+			cmd:             attachAppCmd{},
+			expectedReplica: "web-2",
+		},
+		"replica of a worker job": {
+			cmd:             attachAppCmd{WorkerJob: "queue"},
+			expectedReplica: "queue-1",
+		},
+		"unknown worker job": {
+			cmd:           attachAppCmd{WorkerJob: "mail"},
+			expectedError: `worker job "mail" not found`,
+		},
+		"replica by name, even if not ready": {
+			cmd:             attachAppCmd{Replica: "web-1"},
+			expectedReplica: "web-1",
+		},
+		"unknown replica": {
+			cmd:           attachAppCmd{Replica: "web-3"},
+			expectedError: `replica "web-3" of application "shop" not found`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.cmd.Name = "shop"
+			replica, err := tc.cmd.getReplica(ctx, apiClient)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReplica, replica)
+		})
+	}
+}
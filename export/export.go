@@ -0,0 +1,5 @@
+package export
+
+type Cmd struct {
+	Terraform terraformCmd `cmd:"" aliases:"tofu" help:"Print Terraform/OpenTofu import blocks for the resources of the current project."`
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// terraformResourceType is the resource of the kubernetes provider which is
+// used to manage resources of the Nine API.
+const terraformResourceType = "kubernetes_manifest"
+
+var invalidIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// templateEscaper escapes the template sequences of Terraform, which are
+// interpreted in heredocs as well.
+var templateEscaper = strings.NewReplacer("${", "$${", "%{", "%%{")
+
+type terraformCmd struct {
+	Kinds      []string `help:"Only export resources of these kinds."`
+	ImportOnly bool     `help:"Only print the import blocks without the resource definitions."`
+	out        io.Writer
+}
+
+func (cmd *terraformCmd) Help() string {
+	return "Prints Terraform/OpenTofu import blocks and resource definitions for all resources\n" +
+		"of the current project, to adopt them into a configuration managed with the\n" +
+		"kubernetes provider. Run \"terraform plan\" afterwards to import the resources."
+}
+
+func (cmd *terraformCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	items, warnings, err := client.ProjectResources(ctx, []string{client.Project}, cmd.Kinds, false)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		format.PrintWarningf("%s\n", w)
+	}
+
+	exported := 0
+	names := map[string]bool{}
+	for _, item := range items {
+		if api.Generated(item) {
+			continue
+		}
+		if exported != 0 {
+			fmt.Fprintln(cmd.out)
+		}
+		if err := cmd.write(item, uniqueName(names, resourceName(item))); err != nil {
+			return err
+		}
+		exported++
+	}
+
+	if exported == 0 {
+		fmt.Fprintf(os.Stderr, "no resources found in project %s\n", client.Project)
+	}
+	return nil
+}
+
+func (cmd *terraformCmd) write(item *unstructured.Unstructured, name string) error {
+	address := fmt.Sprintf("%s.%s", terraformResourceType, name)
+	fmt.Fprintf(cmd.out, "import {\n  to = %s\n  id = %q\n}\n", address, importID(item))
+	if cmd.ImportOnly {
+		return nil
+	}
+
+	obj, err := format.ExportObject(item)
+	if err != nil {
+		return err
+	}
+	// the provider needs the namespace to find the resource.
+	obj.SetNamespace(item.GetNamespace())
+	manifest, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.out, "\nresource %q %q {\n", terraformResourceType, name)
+	fmt.Fprintln(cmd.out, "  manifest = yamldecode(<<-EOT")
+	for _, line := range strings.Split(strings.TrimSuffix(string(manifest), "\n"), "\n") {
+		fmt.Fprintf(cmd.out, "    %s\n", templateEscaper.Replace(line))
+	}
+	fmt.Fprintln(cmd.out, "  EOT\n  )\n}")
+	return nil
+}
+
+// importID returns the ID of the resource in the format of the kubernetes
+// provider, e.g.
+// "apiVersion=storage.nine.ch/v1alpha1,kind=Postgres,namespace=acme,name=db".
+func importID(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("apiVersion=%s,kind=%s,namespace=%s,name=%s",
+		obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// resourceName returns a valid Terraform identifier for the resource, e.g.
+// "postgres_my_db". Names which only differ in invalid characters, like
+// "my-db" and "my_db", result in the same identifier, see uniqueName.
+func resourceName(obj *unstructured.Unstructured) string {
+	return invalidIdentifierChars.ReplaceAllString(strings.ToLower(obj.GetKind())+"_"+obj.GetName(), "_")
+}
+
+// uniqueName returns the name with a numbered suffix if it has already been
+// used, so that no two resource blocks have the same address.
+func uniqueName(used map[string]bool, name string) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
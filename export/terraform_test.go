@@ -0,0 +1,68 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestTerraform(t *testing.T) {
+	ctx := context.Background()
+	pg := test.Postgres("my-db", test.DefaultProject, "nine-es34")
+	pg.Status.AtProvider.FQDN = "db.example.org"
+	release := &apps.Release{ObjectMeta: metav1.ObjectMeta{Name: "web-release", Namespace: test.DefaultProject}}
+	other := test.Postgres("other", "other-project", "nine-es34")
+	// results in the same identifier as my-db
+	dotted := test.Postgres("my.db", test.DefaultProject, "nine-es34")
+
+	apiClient, err := test.SetupClient(test.WithObjects(pg, release, other, dotted))
+	require.NoError(t, err)
+
+	t.Run("resources", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &terraformCmd{out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		assert.Contains(t, out.String(), "to = kubernetes_manifest.postgres_my_db\n")
+		assert.Contains(t, out.String(), `id = "apiVersion=storage.nine.ch/v1alpha1,kind=Postgres,namespace=default,name=my-db"`)
+		assert.Contains(t, out.String(), `resource "kubernetes_manifest" "postgres_my_db" {`)
+		assert.Contains(t, out.String(), "    kind: Postgres\n")
+		assert.Contains(t, out.String(), "      namespace: default\n")
+		assert.NotContains(t, out.String(), "db.example.org")
+		assert.NotContains(t, out.String(), "web-release")
+		assert.NotContains(t, out.String(), "other")
+		assert.Contains(t, out.String(), `resource "kubernetes_manifest" "postgres_my_db_2" {`)
+	})
+
+	t.Run("template sequences", func(t *testing.T) {
+		item := &unstructured.Unstructured{}
+		item.SetAPIVersion("apps.nine.ch/v1alpha1")
+		item.SetKind("Application")
+		item.SetName("web")
+		item.SetNamespace(test.DefaultProject)
+		item.SetAnnotations(map[string]string{"command": "echo ${HOME} %{ if x }"})
+
+		out := &bytes.Buffer{}
+		cmd := &terraformCmd{out: out}
+		require.NoError(t, cmd.write(item, "application_web"))
+		assert.Contains(t, out.String(), "command: echo $${HOME} %%{ if x }\n")
+	})
+
+	t.Run("import only", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &terraformCmd{ImportOnly: true, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		assert.Equal(t, 2, strings.Count(out.String(), "import {"))
+		assert.Equal(t, 1, strings.Count(out.String(), "to = kubernetes_manifest.postgres_my_db\n"))
+		assert.NotContains(t, out.String(), "resource ")
+	})
+}
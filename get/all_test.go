@@ -32,6 +32,7 @@ func TestAllContent(t *testing.T) {
 		allProjects          bool
 		includeNineResources bool
 		kinds                []string
+		fieldSelector        string
 		output               string
 		errorExpected        bool
 	}{
@@ -199,6 +200,17 @@ staging    melon          Release              apps.nine.ch
 			kinds:         []string{"jackofalltrades"},
 			errorExpected: true,
 		},
+		"field selector": {
+			projects: test.Projects(organization, "dev", "staging"),
+			objects: []client.Object{
+				testApplication("banana", "dev"), testRelease("pear", "dev"),
+				testApplication("apple", "staging"),
+			},
+			outputFormat:  noHeader,
+			allProjects:   true,
+			fieldSelector: "kind=Application,metadata.name!=apple",
+			output:        "dev    banana    Application    apps.nine.ch\n",
+		},
 		"excluded list kinds are not shown": {
 			projects: test.Projects(organization, "dev"),
 			objects: []client.Object{
@@ -220,6 +232,9 @@ dev        pear      Release        apps.nine.ch
 				Output:      testCase.outputFormat,
 				AllProjects: testCase.allProjects,
 			}
+			reqs, err := parseFieldSelector(testCase.fieldSelector)
+			require.NoError(t, err)
+			get.fieldRequirements = reqs
 
 			scheme, err := api.NewScheme()
 			if err != nil {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	iam "github.com/ninech/apis/iam/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -49,19 +48,21 @@ func (asa *apiServiceAccountsCmd) Run(ctx context.Context, client *api.Client, g
 	}
 
 	switch get.Output {
-	case full:
-		return asa.print(asaList.Items, get, true)
+	case full, wide:
+		return asa.print(asaList.Items, get, !get.NoHeaders)
 	case noHeader:
 		return asa.print(asaList.Items, get, false)
 	case yamlOut:
-		return format.PrettyPrintObjects(asaList.GetItems(), format.PrintOpts{})
+		return format.PrettyPrintObjects(asaList.GetItems(), format.PrintOpts{Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, asaList.GetItems(), nil)
 	}
 
 	return nil
 }
 
 func (asa *apiServiceAccountsCmd) print(sas []iam.APIServiceAccount, get *Cmd, header bool) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	w := format.NewTable(os.Stdout)
 
 	if header {
 		get.writeHeader(w, "NAME", "ROLE")
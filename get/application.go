@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
-	"text/tabwriter"
 
 	"github.com/hashicorp/go-multierror"
 	apps "github.com/ninech/apis/apps/v1alpha1"
@@ -24,6 +24,9 @@ type applicationsCmd struct {
 	resourceCmd
 	BasicAuthCredentials bool `help:"Show the basic auth credentials of the application."`
 	DNS                  bool `help:"Show the DNS details for custom hosts."`
+	Processes            bool `help:"Show the processes of the application: the web process, the workers and the scheduled jobs."`
+	Replicas             bool `help:"Show the replicas of the latest release of the application with their status, restarts and age."`
+	Env                  bool `help:"Show the runtime and build environment variables of the application. Use \"-o json\" or \"-o yaml\" to export them for \"nctl update app --env-from-file\"."`
 	out                  io.Writer
 }
 
@@ -54,13 +57,27 @@ func (cmd *applicationsCmd) Run(ctx context.Context, client *api.Client, get *Cm
 		return printDNSDetails(util.GatherDNSDetails(appList.Items), get, defaultOut(cmd.out))
 	}
 
+	if cmd.Processes {
+		return printProcesses(appList.Items, get, defaultOut(cmd.out))
+	}
+
+	if cmd.Replicas {
+		return printReplicas(ctx, client, appList.Items, get, defaultOut(cmd.out))
+	}
+
+	if cmd.Env {
+		return printEnv(appList.Items, get, defaultOut(cmd.out))
+	}
+
 	switch get.Output {
-	case full:
-		return printApplication(appList.Items, get, defaultOut(cmd.out), true)
+	case full, wide:
+		return printApplication(appList.Items, get, defaultOut(cmd.out), !get.NoHeaders)
 	case noHeader:
 		return printApplication(appList.Items, get, defaultOut(cmd.out), false)
 	case yamlOut:
-		return format.PrettyPrintObjects(appList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out)})
+		return format.PrettyPrintObjects(appList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out), Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, appList.GetItems(), defaultOut(cmd.out))
 	case stats:
 		return cmd.printStats(ctx, client, appList.Items, get, defaultOut(cmd.out))
 	}
@@ -81,10 +98,14 @@ func (cmd *applicationsCmd) Help() string {
 }
 
 func printApplication(apps []apps.Application, get *Cmd, out io.Writer, header bool) error {
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(out)
 
+	headings := []string{"NAME", "REPLICAS", "WORKERJOBS", "SCHEDULEDJOBS", "HOSTS", "UNVERIFIEDHOSTS"}
+	if get.Output == wide {
+		headings = append(headings, "SIZE", "GIT URL", "REVISION")
+	}
 	if header {
-		get.writeHeader(w, "NAME", "REPLICAS", "WORKERJOBS", "SCHEDULEDJOBS", "HOSTS", "UNVERIFIEDHOSTS")
+		get.writeHeader(w, headings...)
 	}
 
 	for _, app := range apps {
@@ -97,12 +118,117 @@ func printApplication(apps []apps.Application, get *Cmd, out io.Writer, header b
 		workerJobs := fmt.Sprintf("%d", len(app.Status.AtProvider.WorkerJobs))
 		scheduledJobs := fmt.Sprintf("%d", len(app.Status.AtProvider.ScheduledJobs))
 
-		get.writeTabRow(w, app.Namespace, app.Name, fmt.Sprintf("%d", replicas), workerJobs, scheduledJobs, join(verifiedHosts), join(unverifiedHosts))
+		replicaCount := fmt.Sprintf("%d", replicas)
+		if app.Spec.ForProvider.Paused {
+			replicaCount = "PAUSED"
+		}
+
+		row := []string{app.Name, replicaCount, workerJobs, scheduledJobs, join(verifiedHosts), join(unverifiedHosts)}
+		if get.Output == wide {
+			row = append(row, noneIfEmpty(string(app.Spec.ForProvider.Config.Size)), app.Spec.ForProvider.Git.URL, app.Spec.ForProvider.Git.Revision)
+		}
+		get.writeTabRow(w, app.Namespace, row...)
+	}
+
+	return w.Flush()
+}
+
+func printProcesses(items []apps.Application, get *Cmd, out io.Writer) error {
+	w := format.NewTable(out)
+	if get.Output != noHeader && !get.NoHeaders {
+		get.writeHeader(w, "NAME", "PROCESS", "TYPE", "SIZE", "REPLICAS", "SCHEDULE", "COMMAND")
+	}
+
+	for _, app := range items {
+		for _, p := range util.ApplicationProcesses(&app) {
+			replicas := ""
+			if p.Replicas != nil {
+				replicas = strconv.Itoa(int(*p.Replicas))
+			}
+			if app.Spec.ForProvider.Paused {
+				replicas = "PAUSED"
+			}
+			get.writeTabRow(w, app.Namespace, app.Name, p.Name, p.Type, string(p.Size), noneIfEmpty(replicas), noneIfEmpty(p.Schedule), noneIfEmpty(p.Command))
+		}
+	}
+
+	return w.Flush()
+}
+
+func printReplicas(ctx context.Context, c *api.Client, items []apps.Application, get *Cmd, out io.Writer) error {
+	// the age is only known by the deplo.io cluster, the replicas are still
+	// listed if it can't be reached.
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	runtimeClient, err := c.DeploioRuntimeClient(ctx, scheme)
+	if err != nil {
+		format.PrintWarningf("unable to get the age of the replicas: %s\n", err)
+	}
+
+	w := format.NewTable(out, format.StatusColumns("STATUS"))
+	if get.Output != noHeader && !get.NoHeaders {
+		get.writeHeader(w, "NAME", "PROCESS", "REPLICA", "STATUS", "RESTARTS", "AGE")
+	}
+	for _, app := range items {
+		rel, err := util.ApplicationLatestRelease(ctx, c, api.ObjectName(&app))
+		if err != nil {
+			format.PrintWarningf("unable to get latest release for app %s\n", c.Name(app.Name))
+			continue
+		}
+		for _, r := range util.ReleaseReplicas(rel, true) {
+			pod := &corev1.Pod{}
+			if runtimeClient != nil {
+				// the age stays unknown if the replica can't be fetched.
+				_ = runtimeClient.Get(ctx, api.NamespacedName(r.ReplicaName, app.Namespace), pod)
+			}
+			age := format.Age(pod.CreationTimestamp.Time)
+			get.writeTabRow(w, app.Namespace, app.Name, r.Process, r.ReplicaName, string(r.Status), strconv.Itoa(int(ptr.Deref(r.RestartCount, 0))), age)
+		}
+	}
+
+	return w.Flush()
+}
+
+func printEnv(items []apps.Application, get *Cmd, out io.Writer) error {
+	envs := make([]util.AppEnv, 0, len(items))
+	for _, app := range items {
+		envs = append(envs, util.NewAppEnv(&app))
+	}
+
+	switch get.Output {
+	case yamlOut:
+		return format.PrettyPrintObjects(envs, format.PrintOpts{Out: out})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, envs, out)
+	}
+
+	w := format.NewTable(out)
+	if get.Output != noHeader && !get.NoHeaders {
+		get.writeHeader(w, "NAME", "TYPE", "VARIABLE", "VALUE")
+	}
+	for _, env := range envs {
+		for _, name := range sortedKeys(env.Env) {
+			get.writeTabRow(w, env.Project, env.Application, "runtime", name, env.Env[name])
+		}
+		for _, name := range sortedKeys(env.BuildEnv) {
+			get.writeTabRow(w, env.Project, env.Application, "build", name, env.BuildEnv[name])
+		}
 	}
 
 	return w.Flush()
 }
 
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func printCredentials(creds []appCredentials, get *Cmd, out io.Writer) error {
 	if get.Output == yamlOut {
 		return format.PrettyPrintObjects(creds, format.PrintOpts{Out: out})
@@ -111,9 +237,9 @@ func printCredentials(creds []appCredentials, get *Cmd, out io.Writer) error {
 }
 
 func printCredentialsTabRow(creds []appCredentials, get *Cmd, out io.Writer) error {
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(out)
 
-	if get.Output == full {
+	if get.Output == full || get.Output == wide {
 		get.writeHeader(w, "NAME", "USERNAME", "PASSWORD")
 	}
 
@@ -157,6 +283,13 @@ func gatherCredentials(ctx context.Context, items []apps.Application, c *api.Cli
 	return creds, resultErrors
 }
 
+func noneIfEmpty(s string) string {
+	if len(s) == 0 {
+		return util.NoneText
+	}
+	return s
+}
+
 func join(list []string) string {
 	if len(list) == 0 {
 		return util.NoneText
@@ -172,9 +305,9 @@ func printDNSDetails(items []util.DNSDetail, get *Cmd, out io.Writer) error {
 }
 
 func printDNSDetailsTabRow(items []util.DNSDetail, get *Cmd, out io.Writer) error {
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(out)
 
-	if get.Output == full {
+	if get.Output == full || get.Output == wide {
 		get.writeHeader(w, "NAME", "TXT RECORD", "DNS TARGET")
 	}
 
@@ -218,7 +351,7 @@ func (cmd *applicationsCmd) printStats(ctx context.Context, c *api.Client, appLi
 	if err != nil {
 		return err
 	}
-	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	w := format.NewTable(out, format.StatusColumns("STATUS"))
 	get.writeHeader(w, "NAME", "REPLICA", "STATUS", "CPU", "CPU%", "MEMORY", "MEMORY%", "RESTARTS", "LASTEXITCODE")
 
 	type statsObservation struct {
@@ -3,6 +3,8 @@ package get
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	apps "github.com/ninech/apis/apps/v1alpha1"
@@ -13,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,6 +30,7 @@ func TestApplication(t *testing.T) {
 	}
 	app2 := app
 	app2.Name = app2.Name + "-2"
+	app2.Spec.ForProvider.Paused = true
 
 	app3 := app
 	app3.Name = app.Name + "-3"
@@ -56,6 +60,7 @@ func TestApplication(t *testing.T) {
 	}
 
 	assert.Equal(t, 3, test.CountLines(buf.String()), buf.String())
+	assert.Contains(t, buf.String(), "PAUSED")
 	buf.Reset()
 
 	cmd.Name = app.Name
@@ -463,3 +468,79 @@ func newBasicAuthSecret(name, project string, basicAuth util.BasicAuth) *corev1.
 		},
 	}
 }
+
+func TestApplicationProcesses(t *testing.T) {
+	ctx := context.Background()
+	app := newApplication("shop", test.DefaultProject)
+	app.Spec.ForProvider.Config.WorkerJobs = []apps.WorkerJob{{Job: apps.Job{Name: "queue", Command: "bundle exec sidekiq"}}}
+
+	apiClient, err := test.SetupClient(test.WithObjects(app))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := applicationsCmd{Processes: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Regexp(t, `NAME\s+PROCESS\s+TYPE\s+SIZE\s+REPLICAS\s+SCHEDULE\s+COMMAND`, out.String())
+	assert.Regexp(t, `shop\s+web\s+web\s+micro\s+1\s+<none>\s+<none>`, out.String())
+	assert.Regexp(t, `shop\s+queue\s+worker\s+micro\s+1\s+<none>\s+bundle exec sidekiq`, out.String())
+}
+
+func TestApplicationReplicas(t *testing.T) {
+	ctx := context.Background()
+	app := newApplication("shop", test.DefaultProject)
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shop-release",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: app.Name},
+		},
+	}
+	release.Status.AtProvider.ReleaseStatus = apps.ReleaseProcessStatusAvailable
+	release.Status.AtProvider.ReplicaObservation = []apps.ReplicaObservation{
+		{ReplicaName: "shop-web-1", Status: apps.ReplicaStatusReady, RestartCount: ptr.To(int32(3))},
+	}
+	release.Status.AtProvider.WorkerJobStatus = []apps.WorkerJobStatus{
+		{Name: "queue", ReplicaObservation: []apps.ReplicaObservation{{ReplicaName: "shop-queue-1", Status: apps.ReplicaStatusReady}}},
+	}
+
+	apiClient, err := test.SetupClient(test.WithObjects(app, release))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := applicationsCmd{Replicas: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Regexp(t, `NAME\s+PROCESS\s+REPLICA\s+STATUS\s+RESTARTS\s+AGE`, out.String())
+	// the age is unknown without a connection to the deplo.io cluster
+	assert.Regexp(t, `shop\s+web\s+shop-web-1\s+ready\s+3\s+<unknown>`, out.String())
+	assert.Regexp(t, `shop\s+queue\s+shop-queue-1\s+ready\s+0\s+<unknown>`, out.String())
+}
+
+func TestApplicationEnv(t *testing.T) {
+	ctx := context.Background()
+	app := newApplication("shop", test.DefaultProject)
+	app.Spec.ForProvider.Config.Env = util.EnvVarsFromMap(map[string]string{"B": "2", "A": "1"})
+	app.Spec.ForProvider.BuildEnv = util.EnvVarsFromMap(map[string]string{"NODE_ENV": "production"})
+	other := newApplication("blog", test.DefaultProject)
+
+	apiClient, err := test.SetupClient(test.WithObjects(app, other), test.WithNameIndexFor(&apps.Application{}))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := applicationsCmd{Env: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Regexp(t, `NAME\s+TYPE\s+VARIABLE\s+VALUE`, out.String())
+	assert.Regexp(t, `shop\s+runtime\s+A\s+1\n.*shop\s+runtime\s+B\s+2\n.*shop\s+build\s+NODE_ENV\s+production`, out.String())
+
+	// the exported env can be read again by "nctl update app --env-from-file"
+	for _, output := range []output{jsonOut, yamlOut} {
+		out.Reset()
+		cmd := applicationsCmd{resourceCmd: resourceCmd{Name: "shop"}, Env: true, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: output}))
+		envFile := filepath.Join(t.TempDir(), "env")
+		require.NoError(t, os.WriteFile(envFile, out.Bytes(), 0o600))
+		env, err := util.ReadAppEnv(envFile, "shop")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"A": "1", "B": "2"}, env.Env)
+		assert.Equal(t, map[string]string{"NODE_ENV": "production"}, env.BuildEnv)
+	}
+}
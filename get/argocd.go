@@ -0,0 +1,73 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	devtools "github.com/ninech/apis/devtools/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+type argoCDCmd struct {
+	resourceCmd
+	out io.Writer
+}
+
+func (cmd *argoCDCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	cmd.out = defaultOut(cmd.out)
+
+	argoCDList := &devtools.ArgoCDList{}
+	if err := get.list(ctx, client, argoCDList, api.MatchName(cmd.Name)); err != nil {
+		return err
+	}
+
+	if len(argoCDList.Items) == 0 {
+		get.printEmptyMessage(cmd.out, devtools.ArgoCDKind, client.Project)
+		return nil
+	}
+
+	switch get.Output {
+	case full, wide:
+		return cmd.printArgoCDInstances(argoCDList.Items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.printArgoCDInstances(argoCDList.Items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(argoCDList.GetItems(), format.PrintOpts{Out: cmd.out, Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, argoCDList.GetItems(), cmd.out)
+	}
+
+	return nil
+}
+
+func (cmd *argoCDCmd) printArgoCDInstances(list []devtools.ArgoCD, get *Cmd, header bool) error {
+	w := format.NewTable(cmd.out)
+
+	headings := []string{"NAME", "URL", "CLUSTERS"}
+	if get.Output == wide {
+		headings = append(headings, "CLI URL", "APPLICATION SETS")
+	}
+	if header {
+		get.writeHeader(w, headings...)
+	}
+
+	for _, argoCD := range list {
+		clusters := []string{}
+		for _, c := range argoCD.Spec.ForProvider.Clusters {
+			if c.Namespace == argoCD.Namespace {
+				clusters = append(clusters, c.Name)
+				continue
+			}
+			clusters = append(clusters, c.Name+"/"+c.Namespace)
+		}
+		row := []string{argoCD.Name, noneIfEmpty(argoCD.Status.AtProvider.URL), join(clusters)}
+		if get.Output == wide {
+			row = append(row, noneIfEmpty(argoCD.Status.AtProvider.CLIURL), fmt.Sprint(argoCD.Spec.ForProvider.EnableApplicationSets))
+		}
+		get.writeTabRow(w, argoCD.Namespace, row...)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,46 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	devtools "github.com/ninech/apis/devtools/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestArgoCD(t *testing.T) {
+	ctx := context.Background()
+	argoCD := &devtools.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argo", Namespace: test.DefaultProject},
+		Spec: devtools.ArgoCDSpec{ForProvider: devtools.ArgoCDParameters{
+			Clusters: []meta.Reference{
+				{Name: "prod", Namespace: test.DefaultProject},
+				{Name: "staging", Namespace: "other"},
+			},
+		}},
+		Status: devtools.ArgoCDStatus{AtProvider: devtools.ArgoCDObservation{URL: "https://argo.example.org"}},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(argoCD),
+		test.WithNameIndexFor(&devtools.ArgoCD{}),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := argoCDCmd{out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Contains(t, out.String(), "https://argo.example.org")
+	assert.Contains(t, out.String(), "prod,staging/other")
+	assert.Equal(t, 2, test.CountLines(out.String()))
+
+	out.Reset()
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: wide}))
+	assert.Contains(t, out.String(), "CLI URL")
+	assert.Contains(t, out.String(), "<none>")
+}
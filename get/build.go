@@ -6,8 +6,6 @@ import (
 	"io"
 	"os"
 	"path"
-	"text/tabwriter"
-	"time"
 
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
@@ -19,7 +17,6 @@ import (
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/format"
-	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 const (
@@ -29,11 +26,18 @@ const (
 type buildCmd struct {
 	resourceCmd
 	ApplicationName string `short:"a" help:"Name of the Application to get builds for. If omitted all in the project will be listed."`
-	PullImage       bool   `help:"Pull the image of the build. Uses the local docker socket at the env DOCKER_HOST if set."`
+	PullImage       bool   `help:"Pull the image of the build. Uses the local docker socket at the env DOCKER_HOST if set." xor:"build-action"`
+	Provenance      bool   `help:"Print the SLSA provenance of the image of the build as an unsigned in-toto statement with the source revision, builder and image digest. Printed as JSON unless -o yaml is given." xor:"build-action"`
+	Verify          bool   `help:"Verify that a release references the image of the build by comparing the build image with the release image digest (.spec.forProvider.image.digest). This does not check the image the replicas are currently running. Checks the latest available release of the application unless --release is given." xor:"build-action"`
+	Release         string `help:"Name of the release to check. Can only be used together with --verify."`
 	out             io.Writer
 }
 
 func (cmd *buildCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	if len(cmd.Release) != 0 && !cmd.Verify {
+		return fmt.Errorf("--release can only be used together with --verify")
+	}
+
 	buildList := &apps.BuildList{}
 
 	opts := []api.ListOpt{api.MatchName(cmd.Name)}
@@ -58,20 +62,32 @@ func (cmd *buildCmd) Run(ctx context.Context, client *api.Client, get *Cmd) erro
 		return pullImage(ctx, client, &buildList.Items[0])
 	}
 
+	if cmd.Provenance || cmd.Verify {
+		if len(cmd.Name) == 0 {
+			return fmt.Errorf("build name has to be specified for the provenance or verification of a build")
+		}
+		if cmd.Verify {
+			return verifyRelease(ctx, client, &buildList.Items[0], cmd.Release, defaultOut(cmd.out))
+		}
+		return printProvenance(&buildList.Items[0], get, defaultOut(cmd.out))
+	}
+
 	switch get.Output {
-	case full:
-		return printBuild(buildList.Items, get, defaultOut(cmd.out), true)
+	case full, wide:
+		return printBuild(buildList.Items, get, defaultOut(cmd.out), !get.NoHeaders)
 	case noHeader:
 		return printBuild(buildList.Items, get, defaultOut(cmd.out), false)
 	case yamlOut:
-		return format.PrettyPrintObjects(buildList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out)})
+		return format.PrettyPrintObjects(buildList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out), Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, buildList.GetItems(), defaultOut(cmd.out))
 	}
 
 	return nil
 }
 
 func printBuild(builds []apps.Build, get *Cmd, out io.Writer, header bool) error {
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(out, format.StatusColumns("STATUS"))
 
 	if header {
 		get.writeHeader(w, "NAME", "APPLICATION", "STATUS", "AGE")
@@ -81,7 +97,7 @@ func printBuild(builds []apps.Build, get *Cmd, out io.Writer, header bool) error
 		get.writeTabRow(w, build.Namespace, build.Name,
 			build.Labels[util.ApplicationNameLabel],
 			string(build.Status.AtProvider.BuildStatus),
-			duration.HumanDuration(time.Since(build.CreationTimestamp.Time)))
+			format.Age(build.CreationTimestamp.Time))
 	}
 
 	return w.Flush()
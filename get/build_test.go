@@ -3,9 +3,13 @@ package get
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	apps "github.com/ninech/apis/apps/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -65,3 +69,58 @@ func TestBuild(t *testing.T) {
 
 	assert.Equal(t, 1, test.CountLines(buf.String()))
 }
+
+func TestBuildProvenance(t *testing.T) {
+	ctx := context.Background()
+	build := &apps.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shop-build-1",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "shop"},
+		},
+	}
+	build.Spec.ForProvider.SourceConfig.Git = apps.GitTarget{URL: "https://github.com/acme/shop", Revision: "v1.2.0"}
+	build.Spec.ForProvider.Image = meta.Image{Registry: "registry.deplo.io", Repository: "default/shop", Digest: "sha256:abc"}
+	build.Status.AtProvider.BuildMetadata = apps.BuildpackMetadataList{{Id: "heroku/ruby", Version: "3.0.0"}}
+
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shop-release-1",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "shop"},
+		},
+	}
+	release.Spec.ForProvider.Image = meta.Image{Registry: "registry.deplo.io", Repository: "default/shop", Digest: "sha256:old"}
+	release.Status.AtProvider.ReleaseStatus = apps.ReleaseProcessStatusAvailable
+	current := release.DeepCopy()
+	current.Name = "shop-release-2"
+	current.CreationTimestampNano = time.Now().UnixNano()
+	current.Spec.ForProvider.Image.Digest = "sha256:abc"
+
+	apiClient, err := test.SetupClient(
+		test.WithNameIndexFor(&apps.Build{}),
+		test.WithObjects(build, release, current),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := buildCmd{resourceCmd: resourceCmd{Name: build.Name}, Provenance: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	p := &provenance{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), p))
+	assert.Equal(t, slsaProvenanceType, p.PredicateType)
+	assert.Equal(t, []resourceDescriptor{{Name: "registry.deplo.io/default/shop", Digest: map[string]string{"sha256": "abc"}}}, p.Subject)
+	assert.Equal(t, "git+https://github.com/acme/shop@v1.2.0", p.Predicate.BuildDefinition.ResolvedDependencies[0].URI)
+	assert.Equal(t, "heroku/ruby", p.Predicate.BuildDefinition.ResolvedDependencies[1].Name)
+
+	out.Reset()
+	cmd = buildCmd{resourceCmd: resourceCmd{Name: build.Name}, Verify: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Contains(t, out.String(), "release shop-release-2 references the image registry.deplo.io/default/shop@sha256:abc")
+
+	cmd.Release = release.Name
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}), "does not reference the image of build shop-build-1")
+
+	cmd = buildCmd{resourceCmd: resourceCmd{Name: build.Name}, Release: release.Name, out: out}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}), "--release can only be used together with --verify")
+}
@@ -3,7 +3,6 @@ package get
 import (
 	"context"
 	"io"
-	"text/tabwriter"
 
 	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -30,19 +29,21 @@ func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client, get *Cmd) er
 	}
 
 	switch get.Output {
-	case full:
-		return cmd.printCloudVirtualMachineInstances(cloudVMList.Items, get, true)
+	case full, wide:
+		return cmd.printCloudVirtualMachineInstances(cloudVMList.Items, get, !get.NoHeaders)
 	case noHeader:
 		return cmd.printCloudVirtualMachineInstances(cloudVMList.Items, get, false)
 	case yamlOut:
-		return format.PrettyPrintObjects(cloudVMList.GetItems(), format.PrintOpts{})
+		return format.PrettyPrintObjects(cloudVMList.GetItems(), format.PrintOpts{Out: cmd.out, Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, cloudVMList.GetItems(), cmd.out)
 	}
 
 	return nil
 }
 
 func (cmd *cloudVMCmd) printCloudVirtualMachineInstances(list []infrastructure.CloudVirtualMachine, get *Cmd, header bool) error {
-	w := tabwriter.NewWriter(cmd.out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(cmd.out)
 
 	if header {
 		get.writeHeader(w, "NAME", "FQDN", "POWER STATE", "IP ADDRESS")
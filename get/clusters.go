@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"strconv"
-	"text/tabwriter"
 
 	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -30,12 +29,14 @@ func (l *clustersCmd) Run(ctx context.Context, client *api.Client, get *Cmd) err
 	}
 
 	switch get.Output {
-	case full:
-		return printClusters(clusterList.Items, get, true)
+	case full, wide:
+		return printClusters(clusterList.Items, get, !get.NoHeaders)
 	case noHeader:
 		return printClusters(clusterList.Items, get, false)
 	case yamlOut:
-		return format.PrettyPrintObjects(clusterList.GetItems(), format.PrintOpts{})
+		return format.PrettyPrintObjects(clusterList.GetItems(), format.PrintOpts{Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, clusterList.GetItems(), nil)
 	case contexts:
 		for _, cluster := range clusterList.Items {
 			fmt.Printf("%s\n", config.ContextName(&cluster))
@@ -46,10 +47,14 @@ func (l *clustersCmd) Run(ctx context.Context, client *api.Client, get *Cmd) err
 }
 
 func printClusters(clusters []infrastructure.KubernetesCluster, get *Cmd, header bool) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	w := format.NewTable(os.Stdout)
 
+	headings := []string{"NAME", "PROVIDER", "NUM_NODES"}
+	if get.Output == wide {
+		headings = append(headings, "VERSION", "LOCATION", "NODE POOLS")
+	}
 	if header {
-		get.writeHeader(w, "NAME", "PROVIDER", "NUM_NODES")
+		get.writeHeader(w, headings...)
 	}
 
 	for _, cluster := range clusters {
@@ -66,7 +71,19 @@ func printClusters(clusters []infrastructure.KubernetesCluster, get *Cmd, header
 		if cluster.Spec.ForProvider.VCluster != nil {
 			provider = "vcluster"
 		}
-		get.writeTabRow(w, cluster.Namespace, cluster.Name, provider, strconv.Itoa(numNodes))
+		row := []string{cluster.Name, provider, strconv.Itoa(numNodes)}
+		if get.Output == wide {
+			pools := []string{}
+			for _, pool := range cluster.Spec.ForProvider.NodePools {
+				pools = append(pools, pool.Name)
+			}
+			row = append(row,
+				noneIfEmpty(cluster.Status.AtProvider.KubernetesVersion),
+				string(cluster.Spec.ForProvider.Location),
+				join(pools),
+			)
+		}
+		get.writeTabRow(w, cluster.Namespace, row...)
 	}
 
 	return w.Flush()
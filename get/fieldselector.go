@@ -0,0 +1,101 @@
+package get
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nameField is the only field the API can select custom resources by
+// (besides the namespace, which is set by the project).
+const nameField = "metadata.name"
+
+// parseFieldSelector parses a field selector like
+// "status.atProvider.releaseStatus=failed,metadata.name!=foo".
+func parseFieldSelector(s string) (fields.Requirements, error) {
+	if s == "" {
+		return nil, nil
+	}
+	selector, err := fields.ParseSelector(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector %q: %w", s, err)
+	}
+	return selector.Requirements(), nil
+}
+
+// serverFieldSelector returns the list options for the requirements which can
+// be evaluated by the API. Only an equality on the name is supported, all
+// other requirements are evaluated by filterFields.
+func serverFieldSelector(reqs fields.Requirements) []api.ListOpt {
+	opts := []api.ListOpt{}
+	for _, req := range reqs {
+		if req.Field == nameField && req.Operator != selection.NotEquals {
+			opts = append(opts, api.MatchName(req.Value))
+		}
+	}
+	return opts
+}
+
+// filterFields removes all items from the list which don't match all
+// requirements. Fields are given as a path with dots, e.g.
+// status.atProvider.releaseStatus, missing fields are treated as empty.
+func filterFields(list runtimeclient.ObjectList, reqs fields.Requirements) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+		if err != nil {
+			return err
+		}
+		if matchesFields(obj, reqs) {
+			filtered = append(filtered, item)
+		}
+	}
+	return meta.SetList(list, filtered)
+}
+
+func matchesFields(obj map[string]any, reqs fields.Requirements) bool {
+	for _, req := range reqs {
+		value := fieldValue(obj, req.Field)
+		switch req.Operator {
+		case selection.NotEquals:
+			if value == req.Value {
+				return false
+			}
+		default:
+			if value != req.Value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fieldValue returns the value of the field as a string. Lists, objects and
+// missing fields are returned as an empty string.
+func fieldValue(obj map[string]any, path string) string {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, strings.Split(path, ".")...)
+	if err != nil || !found {
+		return ""
+	}
+	switch v := value.(type) {
+	case map[string]any, []any, nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,53 @@
+package get
+
+import (
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFieldSelector(t *testing.T) {
+	release := func(name string, status apps.ReleaseProcessStatus) *apps.Release {
+		return &apps.Release{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: test.DefaultProject},
+			Status: apps.ReleaseStatus{
+				AtProvider: apps.ReleaseObservation{ReleaseStatus: status},
+			},
+		}
+	}
+	apiClient, err := test.SetupClient(test.WithNameIndexFor(&apps.Release{}), test.WithObjects(
+		release("a", apps.ReleaseProcessStatusAvailable),
+		release("b", apps.ReleaseProcessStatusFailure),
+		release("c", apps.ReleaseProcessStatusFailure),
+	))
+	require.NoError(t, err)
+
+	for selector, want := range map[string][]string{
+		"": {"a", "b", "c"},
+		"status.atProvider.releaseStatus=failure":                   {"b", "c"},
+		"status.atProvider.releaseStatus!=failure,metadata.name!=b": {"a"},
+		"status.atProvider.releaseStatus==failure,metadata.name=c":  {"c"},
+		"status.atProvider.unknown=foo":                             {},
+	} {
+		t.Run(selector, func(t *testing.T) {
+			get := &Cmd{Output: full, FieldSelector: selector}
+			require.NoError(t, get.AfterApply())
+
+			list := &apps.ReleaseList{}
+			require.NoError(t, get.list(context.Background(), apiClient, list))
+			names := []string{}
+			for _, r := range list.Items {
+				names = append(names, r.Name)
+			}
+			assert.ElementsMatch(t, want, names)
+		})
+	}
+
+	assert.Error(t, (&Cmd{Output: full, FieldSelector: "status"}).AfterApply())
+
+}
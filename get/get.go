@@ -5,15 +5,27 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/gobuffalo/flect"
 	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 type Cmd struct {
-	Output              output                `help:"Configures list output. ${enum}" short:"o" enum:"full,no-header,contexts,yaml,stats" default:"full"`
+	Output              output                `help:"Configures list output. One of full, no-header, wide, contexts, yaml, json, stats, custom-columns=<header>:<jsonpath>[,...], jsonpath=<template>, go-template=<template> or go-template-file=<file>." short:"o" default:"full"`
+	NoHeaders           bool                  `help:"Do not print the table headers, same as --output=no-header."`
+	Export              bool                  `help:"Print resources as re-applyable YAML without status and instance specific metadata. Implies --output=yaml."`
+	SortBy              sortBy                `help:"Sort the list by name, age (newest first) or status." enum:",name,age,status" default:"" placeholder:"name|age|status"`
+	Limit               int64                 `help:"Maximum amount of resources to return. If there are more, a token to continue the list is printed." placeholder:"N"`
+	Continue            string                `help:"Continue a limited list from the token returned by a previous call with --limit." placeholder:"TOKEN"`
+	FieldSelector       string                `help:"Only list resources whose fields match, e.g. status.atProvider.releaseStatus=failure. Supports =, == and != and multiple comma separated requirements. Apart from metadata.name, the fields are matched after listing, so --limit applies before the selector." placeholder:"FIELD=VALUE,..."`
 	AllProjects         bool                  `help:"apply the get over all projects." short:"A"`
 	AllNamespaces       bool                  `help:"apply the get over all namespaces." hidden:""`
 	Clusters            clustersCmd           `cmd:"" group:"infrastructure.nine.ch" aliases:"cluster,vcluster" help:"Get Kubernetes Clusters."`
@@ -24,10 +36,25 @@ type Cmd struct {
 	Releases            releasesCmd           `cmd:"" group:"deplo.io" name:"releases" aliases:"release" help:"Get deplo.io Releases."`
 	Configs             configsCmd            `cmd:"" group:"deplo.io" name:"configs" aliases:"config" help:"Get deplo.io Project Configuration."`
 	MySQL               mySQLCmd              `cmd:"" group:"storage.nine.ch" name:"mysql" help:"Get MySQL instances."`
-	Postgres            postgresCmd           `cmd:"" group:"storage.nine.ch" name:"postgres" help:"Get PostgreSQL instances."`
+	Postgres            postgresCmd           `cmd:"" group:"storage.nine.ch" name:"postgres" aliases:"pg" help:"Get PostgreSQL instances."`
 	KeyValueStore       keyValueStoreCmd      `cmd:"" group:"storage.nine.ch" name:"keyvaluestore" aliases:"kvs" help:"Get KeyValueStore instances."`
 	All                 allCmd                `cmd:"" name:"all" help:"Get project content"`
-	CloudVirtualMachine cloudVMCmd            `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm" help:"Get a CloudVM."`
+	CloudVirtualMachine cloudVMCmd            `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm,vm" help:"Get a CloudVM."`
+	ArgoCD              argoCDCmd             `cmd:"" group:"devtools.nine.ch" name:"argocd" aliases:"argo" help:"Get Argo CD instances."`
+	Grafana             grafanaCmd            `cmd:"" group:"observability.nine.ch" name:"grafana" help:"Get Grafana instances."`
+	Registry            registryCmd           `cmd:"" group:"storage.nine.ch" name:"registry" help:"Get container registries."`
+	SSHKeys             sshKeysCmd            `cmd:"" group:"security.nine.ch" name:"sshkeys" aliases:"sshkey" help:"Get SSH key pairs with their fingerprint."`
+	Quota               quotaCmd              `cmd:"" name:"quota" aliases:"usage" help:"Get the resource usage of projects and the organization."`
+	Locations           locationsCmd          `cmd:"" name:"locations" aliases:"location" help:"Get the available datacenter locations."`
+	MachineTypes        machineTypesCmd       `cmd:"" name:"machinetypes" aliases:"machinetype,sizes" help:"Get the available application sizes and machine types with their CPU and memory."`
+	Versions            versionsCmd           `cmd:"" name:"versions" aliases:"version" help:"Get the available database versions."`
+
+	stdErr io.Writer
+	// outputArg is the argument of an output format like custom-columns
+	// or jsonpath.
+	outputArg string
+	// fieldRequirements are parsed from the field selector.
+	fieldRequirements fields.Requirements
 }
 
 type resourceCmd struct {
@@ -41,9 +68,103 @@ const (
 	noHeader output = "no-header"
 	contexts output = "contexts"
 	yamlOut  output = "yaml"
+	jsonOut  output = "json"
 	stats    output = "stats"
+	// wide shows additional columns for some resources.
+	wide output = "wide"
+	// customColumns and jsonPath take an argument, e.g.
+	// "-o custom-columns=NAME:.metadata.name" or
+	// "-o jsonpath={.items[*].metadata.name}".
+	customColumns  output = "custom-columns"
+	jsonPath       output = "jsonpath"
+	goTemplate     output = "go-template"
+	goTemplateFile output = "go-template-file"
 )
 
+// AfterApply validates the output format, splits off its argument and
+// switches to the no-header output if --no-headers is set.
+func (cmd *Cmd) AfterApply() error {
+	name, arg, _ := strings.Cut(string(cmd.Output), "=")
+	switch o := output(name); o {
+	case full, noHeader, wide, contexts, yamlOut, jsonOut, stats:
+	case customColumns, jsonPath, goTemplate, goTemplateFile:
+		if len(arg) == 0 {
+			return fmt.Errorf("output format %s requires an argument, e.g. %s", o, outputExample(o))
+		}
+		cmd.Output, cmd.outputArg = o, arg
+		if o == goTemplateFile {
+			content, err := os.ReadFile(arg)
+			if err != nil {
+				return fmt.Errorf("unable to read go template: %w", err)
+			}
+			cmd.Output, cmd.outputArg = goTemplate, string(content)
+		}
+	default:
+		return fmt.Errorf("unknown output format %q", cmd.Output)
+	}
+
+	if cmd.Export {
+		switch cmd.Output {
+		case full:
+			cmd.Output = yamlOut
+		case yamlOut:
+		default:
+			return fmt.Errorf("--export can only be used with --output=yaml")
+		}
+	}
+
+	if cmd.NoHeaders && cmd.Output == full {
+		cmd.Output = noHeader
+	}
+
+	if (cmd.Limit > 0 || cmd.Continue != "") && cmd.AllProjects {
+		return fmt.Errorf("--limit and --continue can not be used together with --all-projects")
+	}
+
+	reqs, err := parseFieldSelector(cmd.FieldSelector)
+	if err != nil {
+		return err
+	}
+	cmd.fieldRequirements = reqs
+	return nil
+}
+
+func outputExample(o output) string {
+	switch o {
+	case customColumns:
+		return "custom-columns=NAME:.metadata.name"
+	case goTemplate:
+		return "go-template={{range .items}}{{.metadata.name}}{{end}}"
+	case goTemplateFile:
+		return "go-template-file=report.tmpl"
+	}
+	return "jsonpath={.items[*].metadata.name}"
+}
+
+// printCustom prints the items as JSON or in a user defined output format,
+// either as custom columns, with a JSONPath or with a Go template.
+func printCustom[T any](get *Cmd, items []T, out io.Writer) error {
+	opts := format.PrintOpts{Out: defaultOut(out)}
+	switch get.Output {
+	case jsonOut:
+		return format.PrintJSON(items, opts)
+	case jsonPath:
+		return format.PrintJSONPath(items, get.outputArg, opts)
+	case goTemplate:
+		return format.PrintGoTemplate(items, get.outputArg, opts)
+	}
+	return format.PrintCustomColumns(items, get.outputArg, !get.NoHeaders, opts)
+}
+
+// unpaginated returns an error if --limit or --continue is used for
+// resources which are not listed with a single paginated list.
+func (cmd *Cmd) unpaginated(resources string) error {
+	if cmd.Limit > 0 || cmd.Continue != "" {
+		return fmt.Errorf("--limit and --continue are not supported for %s", resources)
+	}
+	return nil
+}
+
 func (cmd *Cmd) list(ctx context.Context, client *api.Client, list runtimeclient.ObjectList, opts ...api.ListOpt) error {
 	if cmd.AllProjects {
 		opts = append(opts, api.AllProjects())
@@ -51,7 +172,67 @@ func (cmd *Cmd) list(ctx context.Context, client *api.Client, list runtimeclient
 	if cmd.AllNamespaces {
 		opts = append(opts, api.AllNamespaces())
 	}
-	return client.ListObjects(ctx, list, opts...)
+	opts = append(opts, api.Limit(cmd.Limit), api.Continue(cmd.Continue))
+	opts = append(opts, serverFieldSelector(cmd.fieldRequirements)...)
+	if err := client.ListObjects(ctx, list, opts...); err != nil {
+		return err
+	}
+	if err := filterFields(list, cmd.fieldRequirements); err != nil {
+		return err
+	}
+
+	if token := list.GetContinue(); token != "" {
+		fmt.Fprintf(defaultStdError(cmd.stdErr), "more resources available, continue with --limit=%d --continue=%s\n", cmd.Limit, token)
+	}
+	if cmd.Export {
+		// typed list items have no type information set, but exported
+		// resources need it to be applied again.
+		if err := setItemKinds(list, client.Scheme()); err != nil {
+			return err
+		}
+	}
+	return sortList(list, cmd.SortBy)
+}
+
+func setItemKinds(list runtimeclient.ObjectList, scheme *runtime.Scheme) error {
+	return meta.EachListItem(list, func(obj runtime.Object) error {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		return nil
+	})
+}
+
+// filterOwnedBy removes all items from the list which are owned by another
+// object of the given kind than the one with the given name. Items without
+// any owner reference of that kind are kept.
+func filterOwnedBy(list runtimeclient.ObjectList, kind, name string) error {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(runtimeclient.Object)
+		if !ok {
+			continue
+		}
+		hasOwner, owned := false, false
+		for _, ref := range obj.GetOwnerReferences() {
+			if ref.Kind != kind {
+				continue
+			}
+			hasOwner = true
+			owned = owned || ref.Name == name
+		}
+		if owned || !hasOwner {
+			filtered = append(filtered, item)
+		}
+	}
+	return meta.SetList(list, filtered)
 }
 
 // writeHeader writes the header row, prepending the always shown project
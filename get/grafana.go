@@ -0,0 +1,93 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	observability "github.com/ninech/apis/observability/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	// grafanaUsernameKey and grafanaPasswordKey are the keys of the
+	// credentials in the connection secret of a Grafana instance.
+	grafanaUsernameKey = "username"
+	grafanaPasswordKey = "password"
+)
+
+type grafanaCmd struct {
+	resourceCmd
+	PrintCredentials bool `help:"Print the URL and the credentials of the Grafana instance. Requires name to be set."`
+	out              io.Writer
+}
+
+func (cmd *grafanaCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	cmd.out = defaultOut(cmd.out)
+
+	grafanaList := &observability.GrafanaList{}
+	if err := get.list(ctx, client, grafanaList, api.MatchName(cmd.Name)); err != nil {
+		return err
+	}
+
+	if len(grafanaList.Items) == 0 {
+		get.printEmptyMessage(cmd.out, observability.GrafanaKind, client.Project)
+		return nil
+	}
+
+	if cmd.Name != "" && cmd.PrintCredentials {
+		return cmd.printCredentials(ctx, client, &grafanaList.Items[0])
+	}
+
+	switch get.Output {
+	case full, wide:
+		return cmd.printGrafanaInstances(grafanaList.Items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.printGrafanaInstances(grafanaList.Items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(grafanaList.GetItems(), format.PrintOpts{Out: cmd.out, Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, grafanaList.GetItems(), cmd.out)
+	}
+
+	return nil
+}
+
+func (cmd *grafanaCmd) printGrafanaInstances(list []observability.Grafana, get *Cmd, header bool) error {
+	w := format.NewTable(cmd.out)
+
+	headings := []string{"NAME", "URL"}
+	if get.Output == wide {
+		headings = append(headings, "DATA SOURCE PROJECTS", "ADMIN ACCESS")
+	}
+	if header {
+		get.writeHeader(w, headings...)
+	}
+
+	for _, grafana := range list {
+		row := []string{grafana.Name, noneIfEmpty(grafana.Status.AtProvider.URL)}
+		if get.Output == wide {
+			row = append(row, join(grafana.Spec.ForProvider.DataSource.SearchNamespaces), fmt.Sprint(grafana.Spec.ForProvider.EnableAdminAccess))
+		}
+		get.writeTabRow(w, grafana.Namespace, row...)
+	}
+
+	return w.Flush()
+}
+
+func (cmd *grafanaCmd) printCredentials(ctx context.Context, client *api.Client, grafana *observability.Grafana) error {
+	username, err := getConnectionSecret(ctx, client, grafanaUsernameKey, grafana)
+	if err != nil {
+		return err
+	}
+	password, err := getConnectionSecret(ctx, client, grafanaPasswordKey, grafana)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.out, "URL:      %s\n", grafana.Status.AtProvider.URL)
+	fmt.Fprintf(cmd.out, "Username: %s\n", username)
+	fmt.Fprintf(cmd.out, "Password: %s\n", password)
+	return nil
+}
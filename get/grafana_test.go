@@ -0,0 +1,60 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	observability "github.com/ninech/apis/observability/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrafana(t *testing.T) {
+	ctx := context.Background()
+	grafana := &observability.Grafana{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboards", Namespace: test.DefaultProject},
+		Spec: observability.GrafanaSpec{
+			ResourceSpec: runtimev1.ResourceSpec{
+				WriteConnectionSecretToReference: &runtimev1.SecretReference{Name: "grafana-dashboards", Namespace: test.DefaultProject},
+			},
+			ForProvider: observability.GrafanaParameters{
+				DataSource: observability.DataSourceSelection{SearchNamespaces: []string{"dev", "prod"}},
+			},
+		},
+		Status: observability.GrafanaStatus{AtProvider: observability.GrafanaObservation{URL: "https://grafana.example.org"}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "grafana-dashboards", Namespace: test.DefaultProject},
+		Data: map[string][]byte{
+			grafanaUsernameKey: []byte("admin"),
+			grafanaPasswordKey: []byte("topsecret"),
+		},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(grafana, secret),
+		test.WithNameIndexFor(&observability.Grafana{}),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := grafanaCmd{out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Contains(t, out.String(), "https://grafana.example.org")
+	assert.Equal(t, 2, test.CountLines(out.String()))
+
+	out.Reset()
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: wide}))
+	assert.Contains(t, out.String(), "dev,prod")
+
+	out.Reset()
+	cmd = grafanaCmd{resourceCmd: resourceCmd{Name: "dashboards"}, PrintCredentials: true, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Contains(t, out.String(), "Username: admin")
+	assert.Contains(t, out.String(), "Password: topsecret")
+}
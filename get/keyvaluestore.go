@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"text/tabwriter"
 
 	storage "github.com/ninech/apis/storage/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -37,26 +36,32 @@ func (cmd *keyValueStoreCmd) Run(ctx context.Context, client *api.Client, get *C
 	}
 
 	switch get.Output {
-	case full:
-		return cmd.printKeyValueStoreInstances(keyValueStoreList.Items, get, true)
+	case full, wide:
+		return cmd.printKeyValueStoreInstances(keyValueStoreList.Items, get, !get.NoHeaders)
 	case noHeader:
 		return cmd.printKeyValueStoreInstances(keyValueStoreList.Items, get, false)
 	case yamlOut:
-		return format.PrettyPrintObjects(keyValueStoreList.GetItems(), format.PrintOpts{})
+		return format.PrettyPrintObjects(keyValueStoreList.GetItems(), format.PrintOpts{Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, keyValueStoreList.GetItems(), cmd.out)
 	}
 
 	return nil
 }
 
 func (cmd *keyValueStoreCmd) printKeyValueStoreInstances(list []storage.KeyValueStore, get *Cmd, header bool) error {
-	w := tabwriter.NewWriter(cmd.out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(cmd.out)
 
 	if header {
 		get.writeHeader(w, "NAME", "FQDN", "TLS", "MEMORY SIZE")
 	}
 
 	for _, keyValueStore := range list {
-		get.writeTabRow(w, keyValueStore.Namespace, keyValueStore.Name, keyValueStore.Status.AtProvider.FQDN, "true", keyValueStore.Spec.ForProvider.MemorySize.String())
+		memorySize := ""
+		if keyValueStore.Spec.ForProvider.MemorySize != nil {
+			memorySize = keyValueStore.Spec.ForProvider.MemorySize.String()
+		}
+		get.writeTabRow(w, keyValueStore.Namespace, keyValueStore.Name, keyValueStore.Status.AtProvider.FQDN, "true", memorySize)
 	}
 
 	return w.Flush()
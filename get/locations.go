@@ -0,0 +1,89 @@
+package get
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+type locationsCmd struct {
+	out io.Writer
+}
+
+// location is a datacenter location and the products which can be created
+// there if they can't be created in all locations.
+type location struct {
+	Name     string   `json:"name"`
+	Default  bool     `json:"default"`
+	Products []string `json:"products"`
+}
+
+func (cmd *locationsCmd) Help() string {
+	return "Lists the datacenter locations which can be passed with --location when\n" +
+		"creating resources. Resources not listed under products can be created in\n" +
+		"all locations."
+}
+
+func (cmd *locationsCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	if err := get.unpaginated("locations"); err != nil {
+		return err
+	}
+
+	items := availableLocations()
+
+	switch get.Output {
+	case full, wide:
+		return cmd.print(items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.print(items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(items, format.PrintOpts{Out: cmd.out})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, items, cmd.out)
+	}
+
+	return nil
+}
+
+func (cmd *locationsCmd) print(items []location, get *Cmd, header bool) error {
+	w := format.NewTable(defaultOut(cmd.out))
+	if header {
+		get.writeTabRow(w, "NAME", "DEFAULT", "PRODUCTS")
+	}
+	for _, l := range items {
+		def := ""
+		if l.Default {
+			def = "*"
+		}
+		get.writeTabRow(w, l.Name, def, strings.Join(l.Products, ","))
+	}
+	return w.Flush()
+}
+
+func availableLocations() []location {
+	products := []struct {
+		name      string
+		locations []string
+	}{
+		{"postgres", storage.PostgresLocationOptions},
+		{"mysql", storage.MySQLLocationOptions},
+	}
+
+	items := []location{}
+	for _, name := range []meta.LocationName{meta.LocationNineCZ41, meta.LocationNineCZ42, meta.LocationNineES34} {
+		l := location{Name: string(name), Default: name == storage.DBLocationDefault, Products: []string{}}
+		for _, p := range products {
+			if slices.Contains(p.locations, string(name)) {
+				l.Products = append(l.Products, p.name)
+			}
+		}
+		items = append(items, l)
+	}
+	return items
+}
@@ -0,0 +1,21 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocations(t *testing.T) {
+	out := &bytes.Buffer{}
+	cmd := &locationsCmd{out: out}
+	require.NoError(t, cmd.Run(context.Background(), nil, &Cmd{Output: full}))
+	assert.Equal(t, "NAME         DEFAULT    PRODUCTS\n"+
+		"nine-cz41    *          postgres,mysql\n"+
+		"nine-cz42               postgres,mysql\n"+
+		"nine-es34               postgres,mysql\n",
+		out.String())
+}
@@ -0,0 +1,137 @@
+package get
+
+import (
+	"context"
+	"io"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	infra "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	machineKindApplication = "application"
+	machineKindDatabase    = "database"
+	machineKindVM          = "vm"
+)
+
+type machineTypesCmd struct {
+	Kind string `enum:",application,database,vm" default:"" help:"Only show the sizes of applications, the machine types of databases or the machine types of VMs and node pools. ${enum}"`
+	out  io.Writer
+}
+
+// machineTypeInfo is a size which can be selected for a resource.
+type machineTypeInfo struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+func (cmd *machineTypesCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	if err := get.unpaginated("machine types"); err != nil {
+		return err
+	}
+
+	items := []machineTypeInfo{}
+	for _, mt := range availableMachineTypes() {
+		if cmd.Kind == "" || cmd.Kind == mt.Kind {
+			items = append(items, mt)
+		}
+	}
+
+	switch get.Output {
+	case full, wide:
+		return cmd.print(items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.print(items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(items, format.PrintOpts{Out: cmd.out})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, items, cmd.out)
+	}
+
+	return nil
+}
+
+func (cmd *machineTypesCmd) print(items []machineTypeInfo, get *Cmd, header bool) error {
+	w := format.NewTable(defaultOut(cmd.out))
+	if header {
+		get.writeTabRow(w, "NAME", "KIND", "CPU", "MEMORY")
+	}
+	for _, mt := range items {
+		get.writeTabRow(w, mt.Name, mt.Kind, mt.CPU, mt.Memory)
+	}
+	return w.Flush()
+}
+
+// availableMachineTypes returns the application sizes and the machine types
+// of databases and VMs.
+func availableMachineTypes() []machineTypeInfo {
+	items := []machineTypeInfo{}
+	for _, size := range []apps.ApplicationSize{apps.AppMicro, apps.AppMini, apps.AppStandard1, apps.AppStandard2} {
+		res := apps.AppResources[size]
+		items = append(items, machineTypeInfo{
+			Name:   string(size),
+			Kind:   machineKindApplication,
+			CPU:    res.Cpu().String(),
+			Memory: res.Memory().String(),
+		})
+	}
+
+	db := map[string]bool{}
+	for _, mt := range infra.MachineTypesDB {
+		db[mt.String()] = true
+	}
+	for _, mt := range infra.MachineTypes {
+		kind := machineKindVM
+		if db[mt.String()] {
+			kind = machineKindDatabase
+		}
+		cpu, memory := machineTypeResources(mt)
+		items = append(items, machineTypeInfo{Name: mt.String(), Kind: kind, CPU: cpu, Memory: memory})
+	}
+	return items
+}
+
+// machineTypeSizes are the CPU cores and memory of the machine types.
+// The API does not export them, so they need to be updated if the machine
+// types change.
+var machineTypeSizes = map[string]corev1.ResourceList{
+	infra.MachineTypeNineStandard1.String(): machineResources("1", "4Gi"),
+	infra.MachineTypeNineStandard2.String(): machineResources("2", "8Gi"),
+	infra.MachineTypeNineStandard4.String(): machineResources("4", "16Gi"),
+	infra.MachineTypeNineHighMem2.String():  machineResources("2", "16Gi"),
+	infra.MachineTypeNineHighMem4.String():  machineResources("4", "32Gi"),
+	infra.MachineTypeNineHighCPU2.String():  machineResources("2", "4Gi"),
+	infra.MachineTypeNineHighCPU4.String():  machineResources("4", "8Gi"),
+	infra.MachineTypeNineHighCPU8.String():  machineResources("8", "16Gi"),
+	infra.MachineTypeNineSmall1.String():    machineResources("1", "2Gi"),
+	infra.MachineTypeNineDBXS.String():      machineResources("2", "4Gi"),
+	infra.MachineTypeNineDBS.String():       machineResources("4", "8Gi"),
+	infra.MachineTypeNineDBM.String():       machineResources("4", "12Gi"),
+	infra.MachineTypeNineDBL.String():       machineResources("6", "16Gi"),
+	infra.MachineTypeNineDBXL.String():      machineResources("8", "24Gi"),
+	infra.MachineTypeNineDBXXL.String():     machineResources("10", "32Gi"),
+}
+
+func machineResources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+// machineTypeResources returns the CPU cores and memory of a machine type.
+// If they are unknown, <none> is returned.
+func machineTypeResources(mt infra.MachineType) (string, string) {
+	res, ok := machineTypeSizes[mt.String()]
+	if !ok {
+		return util.NoneText, util.NoneText
+	}
+	return res.Cpu().String(), res.Memory().String()
+}
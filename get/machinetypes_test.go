@@ -0,0 +1,43 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	infra "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/api/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineTypes(t *testing.T) {
+	cpu, memory := machineTypeResources(infra.MachineTypeNineDBS)
+	assert.Equal(t, "4", cpu)
+	assert.Equal(t, "8Gi", memory)
+
+	// all machine types of the API need to be known.
+	for _, mt := range infra.MachineTypes {
+		assert.Contains(t, machineTypeSizes, mt.String())
+	}
+
+	cpu, memory = machineTypeResources(infra.NewMachineType("custom"))
+	assert.Equal(t, util.NoneText, cpu)
+	assert.Equal(t, util.NoneText, memory)
+
+	out := &bytes.Buffer{}
+	cmd := &machineTypesCmd{Kind: machineKindApplication, out: out}
+	require.NoError(t, cmd.Run(context.Background(), nil, &Cmd{Output: full}))
+	assert.Equal(t, "NAME          KIND           CPU     MEMORY\n"+
+		"micro         application    125m    256Mi\n"+
+		"mini          application    250m    512Mi\n"+
+		"standard-1    application    500m    1Gi\n"+
+		"standard-2    application    750m    2Gi\n",
+		out.String())
+
+	out.Reset()
+	cmd = &machineTypesCmd{Kind: machineKindDatabase, out: out}
+	require.NoError(t, cmd.Run(context.Background(), nil, &Cmd{Output: noHeader}))
+	assert.Contains(t, out.String(), "nine-db-xxl    database    10    32Gi\n")
+	assert.NotContains(t, out.String(), "nine-standard-1")
+}
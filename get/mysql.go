@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"text/tabwriter"
 
 	storage "github.com/ninech/apis/storage/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -46,26 +45,40 @@ func (cmd *mySQLCmd) Run(ctx context.Context, client *api.Client, get *Cmd) erro
 	}
 
 	switch get.Output {
-	case full:
-		return cmd.printMySQLInstances(mysqlList.Items, get, true)
+	case full, wide:
+		return cmd.printMySQLInstances(mysqlList.Items, get, !get.NoHeaders)
 	case noHeader:
 		return cmd.printMySQLInstances(mysqlList.Items, get, false)
 	case yamlOut:
-		return format.PrettyPrintObjects(mysqlList.GetItems(), format.PrintOpts{})
+		return format.PrettyPrintObjects(mysqlList.GetItems(), format.PrintOpts{Out: cmd.out, Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, mysqlList.GetItems(), cmd.out)
 	}
 
 	return nil
 }
 
 func (cmd *mySQLCmd) printMySQLInstances(list []storage.MySQL, get *Cmd, header bool) error {
-	w := tabwriter.NewWriter(cmd.out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(cmd.out)
 
+	headings := []string{"NAME", "FQDN", "LOCATION", "MACHINE TYPE"}
+	if get.Output == wide {
+		headings = append(headings, "VERSION", "ALLOWED CIDRS")
+	}
 	if header {
-		get.writeHeader(w, "NAME", "FQDN", "LOCATION", "MACHINE TYPE")
+		get.writeHeader(w, headings...)
 	}
 
 	for _, mysql := range list {
-		get.writeTabRow(w, mysql.Namespace, mysql.Name, mysql.Status.AtProvider.FQDN, string(mysql.Spec.ForProvider.Location), mysql.Spec.ForProvider.MachineType.String())
+		row := []string{mysql.Name, mysql.Status.AtProvider.FQDN, string(mysql.Spec.ForProvider.Location), mysql.Spec.ForProvider.MachineType.String()}
+		if get.Output == wide {
+			cidrs := []string{}
+			for _, cidr := range mysql.Spec.ForProvider.AllowedCIDRs {
+				cidrs = append(cidrs, string(cidr))
+			}
+			row = append(row, noneIfEmpty(string(mysql.Spec.ForProvider.Version)), join(cidrs))
+		}
+		get.writeTabRow(w, mysql.Namespace, row...)
 	}
 
 	return w.Flush()
@@ -134,6 +134,19 @@ func TestMySQL(t *testing.T) {
 			wantContain: []string{"test2-topsecret"},
 			wantLines:   1, // here no header gets printed
 		},
+		{
+			name: "wide",
+			instances: []mysqlInstance{
+				{
+					name:        "test1",
+					project:     test.DefaultProject,
+					machineType: machineType("nine-db-prod-s"),
+				},
+			},
+			out:         wide,
+			wantContain: []string{"VERSION", "ALLOWED CIDRS", "nine-db-prod-s", "<none>"},
+			wantLines:   2,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
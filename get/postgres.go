@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"text/tabwriter"
 
 	storage "github.com/ninech/apis/storage/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -46,26 +45,40 @@ func (cmd *postgresCmd) Run(ctx context.Context, client *api.Client, get *Cmd) e
 	}
 
 	switch get.Output {
-	case full:
-		return cmd.printPostgresInstances(postgresList.Items, get, true)
+	case full, wide:
+		return cmd.printPostgresInstances(postgresList.Items, get, !get.NoHeaders)
 	case noHeader:
 		return cmd.printPostgresInstances(postgresList.Items, get, false)
 	case yamlOut:
-		return format.PrettyPrintObjects(postgresList.GetItems(), format.PrintOpts{})
+		return format.PrettyPrintObjects(postgresList.GetItems(), format.PrintOpts{Out: cmd.out, Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, postgresList.GetItems(), cmd.out)
 	}
 
 	return nil
 }
 
 func (cmd *postgresCmd) printPostgresInstances(list []storage.Postgres, get *Cmd, header bool) error {
-	w := tabwriter.NewWriter(cmd.out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(cmd.out)
 
+	headings := []string{"NAME", "FQDN", "LOCATION", "MACHINE TYPE"}
+	if get.Output == wide {
+		headings = append(headings, "VERSION", "ALLOWED CIDRS")
+	}
 	if header {
-		get.writeHeader(w, "NAME", "FQDN", "LOCATION", "MACHINE TYPE")
+		get.writeHeader(w, headings...)
 	}
 
 	for _, postgres := range list {
-		get.writeTabRow(w, postgres.Namespace, postgres.Name, postgres.Status.AtProvider.FQDN, string(postgres.Spec.ForProvider.Location), postgres.Spec.ForProvider.MachineType.String())
+		row := []string{postgres.Name, postgres.Status.AtProvider.FQDN, string(postgres.Spec.ForProvider.Location), postgres.Spec.ForProvider.MachineType.String()}
+		if get.Output == wide {
+			cidrs := []string{}
+			for _, cidr := range postgres.Spec.ForProvider.AllowedCIDRs {
+				cidrs = append(cidrs, string(cidr))
+			}
+			row = append(row, noneIfEmpty(string(postgres.Spec.ForProvider.Version)), join(cidrs))
+		}
+		get.writeTabRow(w, postgres.Namespace, row...)
 	}
 
 	return w.Flush()
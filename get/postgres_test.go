@@ -134,6 +134,80 @@ func TestPostgres(t *testing.T) {
 			wantContain: []string{"test2-topsecret"},
 			wantLines:   1, // no header in this case
 		},
+		{
+			name: "wide",
+			instances: []postgresInstance{
+				{
+					name:        "test1",
+					project:     test.DefaultProject,
+					machineType: machineType("nine-db-prod-s"),
+				},
+			},
+			out:         wide,
+			wantContain: []string{"VERSION", "ALLOWED CIDRS", "nine-db-prod-s", "<none>"},
+			wantLines:   2,
+		},
+		{
+			name: "custom-columns",
+			instances: []postgresInstance{
+				{
+					name:        "test1",
+					project:     test.DefaultProject,
+					machineType: machineType("nine-db-prod-s"),
+				},
+			},
+			out:         "custom-columns=NAME:.metadata.name,TYPE:.spec.forProvider.machineType,MISSING:.status.atProvider.doesNotExist",
+			wantContain: []string{"NAME", "TYPE", "test1", "nine-db-prod-s", "<none>"},
+			wantLines:   2,
+		},
+		{
+			name: "jsonpath",
+			instances: []postgresInstance{
+				{
+					name:        "test1",
+					project:     test.DefaultProject,
+					machineType: machineType("nine-db-prod-s"),
+				},
+				{
+					name:        "test2",
+					project:     test.DefaultProject,
+					machineType: machineType("nine-db-prod-m"),
+				},
+			},
+			out:         "jsonpath={range .items[*]}{.metadata.name}{\"\\n\"}{end}",
+			wantContain: []string{"test1\ntest2\n"},
+			wantLines:   2,
+		},
+		{
+			name: "go-template",
+			instances: []postgresInstance{
+				{
+					name:        "test1",
+					project:     test.DefaultProject,
+					machineType: machineType("nine-db-prod-s"),
+				},
+			},
+			out:         `go-template={{range .items}}{{.metadata.name}}: {{.spec.forProvider.machineType | upper}}{{"\n"}}{{end}}`,
+			wantContain: []string{"test1: NINE-DB-PROD-S"},
+			wantLines:   1,
+		},
+		{
+			name: "invalid-custom-columns",
+			instances: []postgresInstance{
+				{
+					name:        "test1",
+					project:     test.DefaultProject,
+					machineType: machineType("nine-db-prod-s"),
+				},
+			},
+			out:     "custom-columns=NAME",
+			wantErr: true,
+		},
+		{
+			name:    "unknown-output",
+			out:     "table",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -163,7 +237,12 @@ func TestPostgres(t *testing.T) {
 			if tt.out == "" {
 				tt.out = full
 			}
-			if err := tt.get.Run(ctx, apiClient, &Cmd{Output: tt.out, AllProjects: tt.inAllProjects}); (err != nil) != tt.wantErr {
+			get := &Cmd{Output: tt.out, AllProjects: tt.inAllProjects}
+			err = get.AfterApply()
+			if err == nil {
+				err = tt.get.Run(ctx, apiClient, get)
+			}
+			if (err != nil) != tt.wantErr {
 				t.Errorf("postgresCmd.Run() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if tt.wantErr {
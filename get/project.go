@@ -4,7 +4,6 @@ import (
 	"context"
 	"io"
 	"sort"
-	"text/tabwriter"
 
 	management "github.com/ninech/apis/management/v1alpha1"
 	"github.com/ninech/nctl/api"
@@ -18,11 +17,23 @@ type projectCmd struct {
 }
 
 func (proj *projectCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	// projects are listed in the namespace of the organization, which is
+	// not paginated as the amount of projects is small.
+	if err := get.unpaginated("projects"); err != nil {
+		return err
+	}
+
 	projectList, err := client.Projects(ctx, proj.Name)
 	if err != nil {
 		return err
 	}
 
+	list := &management.ProjectList{Items: projectList}
+	if err := filterFields(list, get.fieldRequirements); err != nil {
+		return err
+	}
+	projectList = list.Items
+
 	if len(projectList) == 0 {
 		get.printEmptyMessage(proj.out, management.ProjectKind, "")
 		return nil
@@ -35,10 +46,14 @@ func (proj *projectCmd) Run(ctx context.Context, client *api.Client, get *Cmd) e
 			return projectList[i].Name < projectList[j].Name
 		},
 	)
+	if err := sortList(list, get.SortBy); err != nil {
+		return err
+	}
+	projectList = list.Items
 
 	switch get.Output {
-	case full:
-		return printProject(projectList, *get, defaultOut(proj.out), true)
+	case full, wide:
+		return printProject(projectList, *get, defaultOut(proj.out), !get.NoHeaders)
 	case noHeader:
 		return printProject(projectList, *get, defaultOut(proj.out), false)
 	case yamlOut:
@@ -47,15 +62,18 @@ func (proj *projectCmd) Run(ctx context.Context, client *api.Client, get *Cmd) e
 			format.PrintOpts{
 				Out:               proj.out,
 				ExcludeAdditional: projectYamlExcludes(),
+				Export:            get.Export,
 			},
 		)
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, projectList, proj.out)
 	}
 
 	return nil
 }
 
 func printProject(projects []management.Project, get Cmd, out io.Writer, header bool) error {
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(out)
 
 	// we don't want to include the PROJECT header as it doesn't make sense
 	// for projects
@@ -4,14 +4,11 @@ import (
 	"context"
 	"io"
 	"strconv"
-	"text/tabwriter"
-	"time"
 
 	apps "github.com/ninech/apis/apps/v1alpha1"
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/format"
-	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 type configsCmd struct {
@@ -30,19 +27,21 @@ func (cmd *configsCmd) Run(ctx context.Context, client *api.Client, get *Cmd) er
 	}
 
 	switch get.Output {
-	case full:
-		return printProjectConfigs(projectConfigList.Items, get, defaultOut(cmd.out), true)
+	case full, wide:
+		return printProjectConfigs(projectConfigList.Items, get, defaultOut(cmd.out), !get.NoHeaders)
 	case noHeader:
 		return printProjectConfigs(projectConfigList.Items, get, defaultOut(cmd.out), false)
 	case yamlOut:
-		return format.PrettyPrintObjects(projectConfigList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out)})
+		return format.PrettyPrintObjects(projectConfigList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out), Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, projectConfigList.GetItems(), defaultOut(cmd.out))
 	}
 
 	return nil
 }
 
 func printProjectConfigs(configs []apps.ProjectConfig, get *Cmd, out io.Writer, header bool) error {
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	w := format.NewTable(out)
 
 	if header {
 		get.writeHeader(
@@ -92,7 +91,7 @@ func printProjectConfigs(configs []apps.ProjectConfig, get *Cmd, out io.Writer,
 			util.EnvVarToString(c.Spec.ForProvider.Config.Env),
 			strconv.FormatBool(basicAuth),
 			deployJobName,
-			duration.HumanDuration(time.Since(c.ObjectMeta.CreationTimestamp.Time)),
+			format.Age(c.ObjectMeta.CreationTimestamp.Time),
 		)
 	}
 
@@ -5,12 +5,14 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	management "github.com/ninech/apis/management/v1alpha1"
 	"github.com/ninech/nctl/api/config"
 	"github.com/ninech/nctl/internal/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -150,3 +152,31 @@ func TestProjectsConfigErrors(t *testing.T) {
 	defer os.Remove(kubeconfig)
 	require.ErrorIs(t, cmd.Run(ctx, apiClient, get), config.ErrExtensionNotFound)
 }
+
+func TestProjectListFlags(t *testing.T) {
+	ctx := context.Background()
+	organization := "evilcorp"
+	projects := test.Projects(organization, "dev", "prod")
+	projects[0].SetCreationTimestamp(metav1.NewTime(time.Now().Add(-time.Hour)))
+	projects[1].SetCreationTimestamp(metav1.NewTime(time.Now()))
+
+	apiClient, err := test.SetupClient(
+		test.WithObjects(projects...),
+		test.WithKubeconfig(t),
+		test.WithOrganization(organization),
+	)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	cmd := projectCmd{out: buf}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: noHeader, SortBy: sortByAge}))
+	assert.Equal(t, "prod    <none>\ndev     <none>\n", buf.String())
+
+	buf.Reset()
+	get := &Cmd{Output: noHeader, FieldSelector: "metadata.name!=dev"}
+	require.NoError(t, get.AfterApply())
+	require.NoError(t, cmd.Run(ctx, apiClient, get))
+	assert.Equal(t, "prod    <none>\n", buf.String())
+
+	require.ErrorContains(t, cmd.Run(ctx, apiClient, &Cmd{Output: full, Limit: 1}), "not supported for projects")
+}
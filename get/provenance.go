@@ -0,0 +1,180 @@
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	inTotoStatementType = "https://in-toto.io/Statement/v1"
+	slsaProvenanceType  = "https://slsa.dev/provenance/v1"
+)
+
+// provenance is an in-toto statement with an SLSA provenance predicate, see
+// https://slsa.dev/spec/v1.0/provenance. It is assembled from the build
+// resource and is not signed.
+type provenance struct {
+	Type          string               `json:"_type"`
+	Subject       []resourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     provenancePredicate  `json:"predicate"`
+}
+
+type provenancePredicate struct {
+	BuildDefinition buildDefinition `json:"buildDefinition"`
+	RunDetails      runDetails      `json:"runDetails"`
+}
+
+type buildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   map[string]any       `json:"externalParameters"`
+	ResolvedDependencies []resourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type runDetails struct {
+	Builder  builder           `json:"builder"`
+	Metadata provenanceRunMeta `json:"metadata"`
+}
+
+type builder struct {
+	ID string `json:"id"`
+}
+
+type provenanceRunMeta struct {
+	InvocationID string `json:"invocationId"`
+	StartedOn    string `json:"startedOn,omitempty"`
+}
+
+type resourceDescriptor struct {
+	Name        string            `json:"name,omitempty"`
+	URI         string            `json:"uri,omitempty"`
+	Digest      map[string]string `json:"digest,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// buildProvenance returns the provenance of the image of the build.
+func buildProvenance(build *apps.Build) (*provenance, error) {
+	image := build.Spec.ForProvider.Image
+	if image.Digest == "" {
+		return nil, fmt.Errorf("build %s has no image yet, its status is %q", build.Name, build.Status.AtProvider.BuildStatus)
+	}
+	source := build.Spec.ForProvider.SourceConfig.Git
+
+	dependencies := []resourceDescriptor{{
+		Name: "source",
+		URI:  "git+" + source.URL + "@" + source.Revision,
+	}}
+	for _, bp := range build.Status.AtProvider.BuildMetadata {
+		dependencies = append(dependencies, resourceDescriptor{
+			Name:        bp.Id,
+			URI:         bp.Homepage,
+			Annotations: map[string]string{"version": bp.Version},
+		})
+	}
+
+	builderID := build.Spec.ForProvider.BuildReference.Cluster.Name
+	if builderID == "" {
+		builderID = "deplo.io"
+	}
+	startedOn := ""
+	if !build.CreationTimestamp.IsZero() {
+		startedOn = build.CreationTimestamp.UTC().Format(time.RFC3339)
+	}
+
+	return &provenance{
+		Type: inTotoStatementType,
+		Subject: []resourceDescriptor{{
+			Name:   imageName(image),
+			Digest: digestSet(image.Digest),
+		}},
+		PredicateType: slsaProvenanceType,
+		Predicate: provenancePredicate{
+			BuildDefinition: buildDefinition{
+				BuildType: apps.BuildKindAPIVersion,
+				ExternalParameters: map[string]any{
+					"application": build.Labels[util.ApplicationNameLabel],
+					"project":     build.Namespace,
+					"source": map[string]string{
+						"url":      source.URL,
+						"revision": source.Revision,
+						"subPath":  source.SubPath,
+					},
+					"stack": build.Status.AtProvider.Stack,
+				},
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: runDetails{
+				Builder: builder{ID: builderID},
+				Metadata: provenanceRunMeta{
+					InvocationID: build.Name,
+					StartedOn:    startedOn,
+				},
+			},
+		},
+	}, nil
+}
+
+// digestSet splits a digest like "sha256:abc" into its algorithm and value.
+func digestSet(digest string) map[string]string {
+	algorithm, value, ok := strings.Cut(digest, ":")
+	if !ok {
+		return map[string]string{"sha256": digest}
+	}
+	return map[string]string{algorithm: value}
+}
+
+func printProvenance(build *apps.Build, get *Cmd, out io.Writer) error {
+	p, err := buildProvenance(build)
+	if err != nil {
+		return err
+	}
+	if get.Output == yamlOut {
+		return format.PrettyPrintObject(p, format.PrintOpts{Out: out})
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// verifyRelease checks that the image digest of the release spec matches the
+// image of the build. It does not inspect the image the replicas are actually
+// running. If no release is given, the latest available release of the
+// application of the build is checked.
+func verifyRelease(ctx context.Context, client *api.Client, build *apps.Build, releaseName string, out io.Writer) error {
+	release := &apps.Release{}
+	if releaseName != "" {
+		if err := client.Get(ctx, api.NamespacedName(releaseName, build.Namespace), release); err != nil {
+			return fmt.Errorf("unable to get release %q: %w", releaseName, err)
+		}
+	} else {
+		app := build.Labels[util.ApplicationNameLabel]
+		if app == "" {
+			return fmt.Errorf("build %s has no application, select the release with --release", build.Name)
+		}
+		var err error
+		if release, err = util.ApplicationLatestAvailableRelease(ctx, client, api.NamespacedName(app, build.Namespace)); err != nil {
+			return err
+		}
+	}
+
+	want := build.Spec.ForProvider.Image.Digest
+	got := release.Spec.ForProvider.Image.Digest
+	if want == "" {
+		return fmt.Errorf("build %s has no image yet, its status is %q", build.Name, build.Status.AtProvider.BuildStatus)
+	}
+	if got != want {
+		return fmt.Errorf("release %s does not reference the image of build %s: it references %s, the build produced %s", release.Name, build.Name, got, want)
+	}
+
+	fmt.Fprintln(out, format.SuccessMessagef("✅", "release %s references the image %s of build %s", release.Name, ImageRef(build.Spec.ForProvider.Image), build.Name))
+	return nil
+}
@@ -0,0 +1,178 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// totalRow is shown in the project column of the organization total.
+const totalRow = "TOTAL"
+
+type quotaCmd struct {
+	out io.Writer
+}
+
+// usage is the resource usage of a project. The organization total has no
+// project set.
+type usage struct {
+	Organization   string `json:"organization"`
+	Project        string `json:"project,omitempty"`
+	Applications   int    `json:"applications"`
+	Replicas       int    `json:"replicas"`
+	Postgres       int    `json:"postgres"`
+	MySQL          int    `json:"mysql"`
+	KeyValueStores int    `json:"keyValueStores"`
+	Buckets        int    `json:"buckets"`
+	Clusters       int    `json:"clusters"`
+	CloudVMs       int    `json:"cloudVirtualMachines"`
+}
+
+func (cmd *quotaCmd) Help() string {
+	return "Shows the amount of resources used per project. With --all-projects, all\n" +
+		"projects of the organization are shown together with the organization total.\n" +
+		"Replicas are counted as currently observed, paused applications have none.\n" +
+		"Use \"-o json\" to process the usage further, e.g. in billing dashboards."
+}
+
+func (cmd *quotaCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	if err := get.unpaginated("quotas"); err != nil {
+		return err
+	}
+	org, err := client.Organization()
+	if err != nil {
+		return err
+	}
+	projectName := client.Project
+	if get.AllProjects {
+		projectName = ""
+	}
+	projects, err := client.Projects(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		get.printEmptyMessage(cmd.out, "Project", "")
+		return nil
+	}
+
+	usages := map[string]*usage{}
+	for _, p := range projects {
+		usages[p.Name] = &usage{Organization: org, Project: p.Name}
+	}
+	if err := cmd.count(ctx, client, get, usages); err != nil {
+		return err
+	}
+
+	items := make([]usage, 0, len(usages)+1)
+	total := usage{Organization: org}
+	for _, u := range usages {
+		items = append(items, *u)
+		total.add(*u)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Project < items[j].Project })
+	if len(items) > 1 {
+		items = append(items, total)
+	}
+
+	switch get.Output {
+	case full, wide:
+		return cmd.print(items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.print(items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(items, format.PrintOpts{Out: cmd.out})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, items, cmd.out)
+	}
+
+	return nil
+}
+
+// count lists all resources which are counted and adds them to the usage of
+// the project they are in.
+func (cmd *quotaCmd) count(ctx context.Context, client *api.Client, get *Cmd, usages map[string]*usage) error {
+	opts := []api.ListOpt{}
+	if get.AllProjects {
+		opts = append(opts, api.AllProjects())
+	}
+
+	counters := []struct {
+		list runtimeclient.ObjectList
+		add  func(u *usage, obj runtime.Object)
+	}{
+		{&apps.ApplicationList{}, func(u *usage, obj runtime.Object) {
+			app := obj.(*apps.Application)
+			u.Applications++
+			if app.Status.AtProvider.Replicas != nil && !app.Spec.ForProvider.Paused {
+				u.Replicas += int(*app.Status.AtProvider.Replicas)
+			}
+		}},
+		{&storage.PostgresList{}, func(u *usage, _ runtime.Object) { u.Postgres++ }},
+		{&storage.MySQLList{}, func(u *usage, _ runtime.Object) { u.MySQL++ }},
+		{&storage.KeyValueStoreList{}, func(u *usage, _ runtime.Object) { u.KeyValueStores++ }},
+		{&storage.BucketList{}, func(u *usage, _ runtime.Object) { u.Buckets++ }},
+		{&infrastructure.KubernetesClusterList{}, func(u *usage, _ runtime.Object) { u.Clusters++ }},
+		{&infrastructure.CloudVirtualMachineList{}, func(u *usage, _ runtime.Object) { u.CloudVMs++ }},
+	}
+
+	for _, c := range counters {
+		if err := client.ListObjects(ctx, c.list, opts...); err != nil {
+			return fmt.Errorf("unable to list %T: %w", c.list, err)
+		}
+		if err := meta.EachListItem(c.list, func(obj runtime.Object) error {
+			mo, err := meta.Accessor(obj)
+			if err != nil {
+				return err
+			}
+			if u, ok := usages[mo.GetNamespace()]; ok {
+				c.add(u, obj)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *usage) add(o usage) {
+	u.Applications += o.Applications
+	u.Replicas += o.Replicas
+	u.Postgres += o.Postgres
+	u.MySQL += o.MySQL
+	u.KeyValueStores += o.KeyValueStores
+	u.Buckets += o.Buckets
+	u.Clusters += o.Clusters
+	u.CloudVMs += o.CloudVMs
+}
+
+func (cmd *quotaCmd) print(items []usage, get *Cmd, header bool) error {
+	w := format.NewTable(defaultOut(cmd.out))
+
+	if header {
+		get.writeHeader(w, "APPS", "REPLICAS", "POSTGRES", "MYSQL", "KVS", "BUCKETS", "CLUSTERS", "VMS")
+	}
+	for _, u := range items {
+		project := u.Project
+		if project == "" {
+			project = totalRow
+		}
+		get.writeTabRow(w, project,
+			fmt.Sprint(u.Applications), fmt.Sprint(u.Replicas), fmt.Sprint(u.Postgres), fmt.Sprint(u.MySQL),
+			fmt.Sprint(u.KeyValueStores), fmt.Sprint(u.Buckets), fmt.Sprint(u.Clusters), fmt.Sprint(u.CloudVMs),
+		)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,73 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	management "github.com/ninech/apis/management/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestQuota(t *testing.T) {
+	ctx := context.Background()
+
+	app := func(name, project string, replicas int32, paused bool) *apps.Application {
+		a := &apps.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: project}}
+		a.Spec.ForProvider.Paused = paused
+		a.Status.AtProvider.Replicas = ptr.To(replicas)
+		return a
+	}
+	objects := append(test.Projects(test.DefaultProject, "dev", "prod"),
+		app("web", "dev", 1, false),
+		app("web", "prod", 3, false),
+		app("worker", "prod", 2, true),
+		test.Postgres("db", "prod", "nine-es34"),
+		test.MySQL("db", "dev", "nine-es34"),
+	)
+
+	newCmd := func() *quotaCmd {
+		return &quotaCmd{out: &bytes.Buffer{}}
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(objects...),
+		test.WithDefaultProject("prod"),
+		test.WithNameIndexFor(&management.Project{}),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	t.Run("current project", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+		assert.Equal(t, "PROJECT    APPS    REPLICAS    POSTGRES    MYSQL    KVS    BUCKETS    CLUSTERS    VMS\n"+
+			"prod       2       3           1           0        0      0          0           0\n",
+			cmd.out.(*bytes.Buffer).String())
+	})
+
+	t.Run("all projects", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: noHeader, AllProjects: true}))
+		assert.Equal(t, "dev      1    1    0    1    0    0    0    0\n"+
+			"prod     2    3    1    0    0    0    0    0\n"+
+			"TOTAL    3    4    1    1    0    0    0    0\n",
+			cmd.out.(*bytes.Buffer).String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: jsonOut, AllProjects: true}))
+		list := struct {
+			Items []usage `json:"items"`
+		}{}
+		require.NoError(t, json.Unmarshal(cmd.out.(*bytes.Buffer).Bytes(), &list))
+		require.Len(t, list.Items, 3)
+		assert.Equal(t, usage{Organization: test.DefaultProject, Applications: 3, Replicas: 4, Postgres: 1, MySQL: 1}, list.Items[2])
+	})
+}
@@ -0,0 +1,65 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+type registryCmd struct {
+	resourceCmd
+	out io.Writer
+}
+
+func (cmd *registryCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	cmd.out = defaultOut(cmd.out)
+
+	registryList := &storage.RegistryList{}
+	if err := get.list(ctx, client, registryList, api.MatchName(cmd.Name)); err != nil {
+		return err
+	}
+
+	if len(registryList.Items) == 0 {
+		get.printEmptyMessage(cmd.out, storage.RegistryKind, client.Project)
+		return nil
+	}
+
+	switch get.Output {
+	case full, wide:
+		return cmd.printRegistries(registryList.Items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.printRegistries(registryList.Items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(registryList.GetItems(), format.PrintOpts{Out: cmd.out, Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, registryList.GetItems(), cmd.out)
+	}
+
+	return nil
+}
+
+func (cmd *registryCmd) printRegistries(list []storage.Registry, get *Cmd, header bool) error {
+	w := format.NewTable(cmd.out)
+
+	headings := []string{"NAME", "URL"}
+	if get.Output == wide {
+		headings = append(headings, "NINE AUTH SERVER")
+	}
+	if header {
+		get.writeHeader(w, headings...)
+	}
+
+	for _, registry := range list {
+		row := []string{registry.Name, noneIfEmpty(registry.Status.AtProvider.URL)}
+		if get.Output == wide {
+			row = append(row, fmt.Sprint(registry.Spec.ForProvider.NineAuthServer))
+		}
+		get.writeTabRow(w, registry.Namespace, row...)
+	}
+
+	return w.Flush()
+}
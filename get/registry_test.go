@@ -0,0 +1,39 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := &storage.Registry{
+		ObjectMeta: metav1.ObjectMeta{Name: "images", Namespace: test.DefaultProject},
+		Spec:       storage.RegistrySpec{ForProvider: storage.RegistryParameters{NineAuthServer: true}},
+		Status:     storage.RegistryStatus{AtProvider: storage.RegistryObservation{URL: "https://images.example.org"}},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(registry),
+		test.WithNameIndexFor(&storage.Registry{}),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := registryCmd{out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Contains(t, out.String(), "https://images.example.org")
+	assert.Equal(t, 2, test.CountLines(out.String()))
+
+	out.Reset()
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: wide}))
+	assert.Contains(t, out.String(), "NINE AUTH SERVER")
+	assert.Contains(t, out.String(), "true")
+}
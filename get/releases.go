@@ -4,19 +4,17 @@ import (
 	"context"
 	"io"
 	"strconv"
-	"text/tabwriter"
-	"time"
 
 	apps "github.com/ninech/apis/apps/v1alpha1"
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/internal/format"
-	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type releasesCmd struct {
 	resourceCmd
-	ApplicationName string `short:"a" help:"Name of the Application to get releases for. If omitted all applications in the project will be listed."`
+	ApplicationName string `short:"a" aliases:"app" help:"Name of the Application to get releases for. If omitted all applications in the project will be listed."`
 	out             io.Writer
 }
 
@@ -28,36 +26,52 @@ func (cmd *releasesCmd) Run(ctx context.Context, client *api.Client, get *Cmd) e
 	if len(cmd.ApplicationName) != 0 {
 		opts = append(opts, api.MatchLabel(util.ApplicationNameLabel, cmd.ApplicationName))
 	}
-
 	if err := get.list(ctx, client, releaseList, opts...); err != nil {
 		return err
 	}
 
+	if len(cmd.ApplicationName) != 0 {
+		// the label is selected by the API, so that --limit and
+		// --continue work as expected. Releases which carry the label
+		// but are owned by another application are removed afterwards.
+		if err := filterOwnedBy(releaseList, apps.ApplicationKind, cmd.ApplicationName); err != nil {
+			return err
+		}
+	}
+
 	if len(releaseList.Items) == 0 {
 		get.printEmptyMessage(cmd.out, apps.ReleaseKind, client.Project)
 		return nil
 	}
 
-	util.OrderReleaseList(releaseList, true)
+	if get.SortBy == "" {
+		util.OrderReleaseList(releaseList, true)
+	}
 
 	switch get.Output {
-	case full:
-		return cmd.printReleases(releaseList.Items, get, true)
+	case full, wide:
+		return cmd.printReleases(releaseList.Items, trafficReleases(ctx, client, releaseList.Items), get, !get.NoHeaders)
 	case noHeader:
-		return cmd.printReleases(releaseList.Items, get, false)
+		return cmd.printReleases(releaseList.Items, trafficReleases(ctx, client, releaseList.Items), get, false)
 	case yamlOut:
-		return format.PrettyPrintObjects(releaseList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out)})
+		return format.PrettyPrintObjects(releaseList.GetItems(), format.PrintOpts{Out: defaultOut(cmd.out), Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, releaseList.GetItems(), defaultOut(cmd.out))
 	}
 
 	return nil
 }
 
-func (cmd *releasesCmd) printReleases(releases []apps.Release, get *Cmd, header bool) error {
-	w := tabwriter.NewWriter(cmd.out, 0, 0, 4, ' ', 0)
+func (cmd *releasesCmd) printReleases(releases []apps.Release, traffic map[types.NamespacedName]bool, get *Cmd, header bool) error {
+	opts := []format.TableOption{format.StatusColumns("STATUS")}
+	if get.Output != wide {
+		// the wide output is used to see the full messages.
+		opts = append(opts, format.Truncate())
+	}
+	w := format.NewTable(cmd.out, opts...)
 
 	if header {
-		get.writeHeader(
-			w,
+		headings := []string{
 			"NAME",
 			"BUILDNAME",
 			"APPLICATION",
@@ -66,8 +80,13 @@ func (cmd *releasesCmd) printReleases(releases []apps.Release, get *Cmd, header
 			"WORKERJOBS",
 			"SCHEDULEDJOBS",
 			"STATUS",
+			"TRAFFIC",
 			"AGE",
-		)
+		}
+		if get.Output == wide {
+			headings = append(headings, "MESSAGE")
+		}
+		get.writeHeader(w, headings...)
 	}
 
 	for _, r := range releases {
@@ -82,9 +101,7 @@ func (cmd *releasesCmd) printReleases(releases []apps.Release, get *Cmd, header
 		workerJobs := strconv.Itoa(len(cfg.WorkerJobs))
 		scheduledJobs := strconv.Itoa(len(cfg.ScheduledJobs))
 
-		get.writeTabRow(
-			w,
-			r.ObjectMeta.Namespace,
+		row := []string{
 			r.ObjectMeta.Name,
 			r.Spec.ForProvider.Build.Name,
 			r.ObjectMeta.Labels[util.ApplicationNameLabel],
@@ -93,9 +110,36 @@ func (cmd *releasesCmd) printReleases(releases []apps.Release, get *Cmd, header
 			workerJobs,
 			scheduledJobs,
 			string(r.Status.AtProvider.ReleaseStatus),
-			duration.HumanDuration(time.Since(r.ObjectMeta.CreationTimestamp.Time)),
-		)
+			strconv.FormatBool(traffic[api.ObjectName(&r)]),
+			format.Age(r.ObjectMeta.CreationTimestamp.Time),
+		}
+		if get.Output == wide {
+			row = append(row, noneIfEmpty(r.Annotations[util.ReleaseMessageAnnotation]))
+		}
+		get.writeTabRow(w, r.ObjectMeta.Namespace, row...)
 	}
 
 	return w.Flush()
 }
+
+// trafficReleases returns the releases which currently receive traffic,
+// which is the latest available release of each application.
+func trafficReleases(ctx context.Context, client *api.Client, releases []apps.Release) map[types.NamespacedName]bool {
+	traffic := map[types.NamespacedName]bool{}
+	seen := map[types.NamespacedName]bool{}
+	for _, r := range releases {
+		app := types.NamespacedName{Name: r.Labels[util.ApplicationNameLabel], Namespace: r.Namespace}
+		if app.Name == "" || seen[app] {
+			continue
+		}
+		seen[app] = true
+
+		release, err := util.ApplicationLatestAvailableRelease(ctx, client, app)
+		if err != nil {
+			// the application has no available release yet.
+			continue
+		}
+		traffic[api.ObjectName(release)] = true
+	}
+	return traffic
+}
@@ -127,6 +127,46 @@ func TestReleases(t *testing.T) {
 			wantLines:   2,
 		},
 
+		"releases owned by another app are removed": {
+			cmd: releasesCmd{
+				ApplicationName: "app6",
+			},
+			releases: []client.Object{
+				withOwner(newRelease(time.Second*10, 10, "owned", project, "app6", "pc", test.StatusAvailable), "app6"),
+				withOwner(newRelease(time.Second*11, 20, "foreign", project, "app6", "pc", test.StatusAvailable), "other-app"),
+				newRelease(time.Second*12, 30, "labelled", project, "app6", "pc", test.StatusSuperseded),
+			},
+			wantContain: []string{"owned", "labelled"},
+			wantLines:   3,
+		},
+
+		"release receiving traffic is marked": {
+			cmd: releasesCmd{
+				resourceCmd: resourceCmd{
+					Name: "live",
+				},
+			},
+			releases: []client.Object{
+				newRelease(time.Second*10, 10, "old", project, "app7", "pc", test.StatusSuperseded),
+				newRelease(time.Second*12, 20, "live", project, "app7", "pc", test.StatusAvailable),
+			},
+			wantContain: []string{"TRAFFIC", "live", "true"},
+			wantLines:   2,
+		},
+
+		"wide output shows the message of the release": {
+			cmd: releasesCmd{
+				ApplicationName: "app8",
+			},
+			output: wide,
+			releases: []client.Object{
+				withMessage(newRelease(time.Second*10, 10, "annotated", project, "app8", "pc", test.StatusAvailable), "fix checkout bug"),
+				newRelease(time.Second*12, 20, "plain", project, "app8", "pc", test.StatusAvailable),
+			},
+			wantContain: []string{"MESSAGE", "fix checkout bug", "<none>"},
+			wantLines:   3,
+		},
+
 		"list all releases in all projects": {
 			cmd:           releasesCmd{},
 			inAllProjects: true,
@@ -201,6 +241,20 @@ func TestReleases(t *testing.T) {
 	}
 }
 
+func withOwner(release *apps.Release, app string) *apps.Release {
+	release.OwnerReferences = append(release.OwnerReferences, metav1.OwnerReference{
+		APIVersion: apps.SchemeGroupVersion.String(),
+		Kind:       apps.ApplicationKind,
+		Name:       app,
+	})
+	return release
+}
+
+func withMessage(release *apps.Release, message string) *apps.Release {
+	release.Annotations = map[string]string{util.ReleaseMessageAnnotation: message}
+	return release
+}
+
 func newRelease(
 	creationTimeOffset time.Duration,
 	creationTimeNanoOffset int64,
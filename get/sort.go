@@ -0,0 +1,113 @@
+package get
+
+import (
+	"sort"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type sortBy string
+
+const (
+	sortByName   sortBy = "name"
+	sortByAge    sortBy = "age"
+	sortByStatus sortBy = "status"
+)
+
+// conditioned is implemented by all resources with crossplane conditions.
+type conditioned interface {
+	GetCondition(runtimev1.ConditionType) runtimev1.Condition
+}
+
+// sortList sorts the items of the list in place.
+func sortList(list runtimeclient.ObjectList, by sortBy) error {
+	if by == "" {
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	objs := make([]runtimeclient.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(runtimeclient.Object)
+		if !ok {
+			// we can't sort lists of unknown items, so we leave them
+			// as they are.
+			return nil
+		}
+		objs = append(objs, obj)
+	}
+	sortObjects(objs, by)
+
+	sorted := make([]runtime.Object, len(objs))
+	for i, obj := range objs {
+		sorted[i] = obj
+	}
+	return meta.SetList(list, sorted)
+}
+
+// sortObjects sorts by name, by age (newest first) or by the reason of the
+// ready condition. Objects which compare equal stay ordered by project and
+// name.
+func sortObjects[T runtimeclient.Object](objs []T, by sortBy) {
+	less := func(a, b runtimeclient.Object) bool {
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	}
+
+	switch by {
+	case sortByName:
+		sort.SliceStable(objs, func(i, j int) bool {
+			if objs[i].GetName() != objs[j].GetName() {
+				return objs[i].GetName() < objs[j].GetName()
+			}
+			return less(objs[i], objs[j])
+		})
+	case sortByAge:
+		sort.SliceStable(objs, func(i, j int) bool {
+			ti, tj := objs[i].GetCreationTimestamp(), objs[j].GetCreationTimestamp()
+			if !ti.Equal(&tj) {
+				return tj.Before(&ti)
+			}
+			return less(objs[i], objs[j])
+		})
+	case sortByStatus:
+		sort.SliceStable(objs, func(i, j int) bool {
+			si, sj := status(objs[i]), status(objs[j])
+			if si != sj {
+				return si < sj
+			}
+			return less(objs[i], objs[j])
+		})
+	}
+}
+
+// status returns the reason of the ready condition, e.g. Available or
+// Creating.
+func status(obj runtimeclient.Object) string {
+	if c, ok := any(obj).(conditioned); ok {
+		return string(c.GetCondition(runtimev1.TypeReady).Reason)
+	}
+
+	u, ok := any(obj).(*unstructured.Unstructured)
+	if !ok {
+		return ""
+	}
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		if cm, ok := c.(map[string]any); ok && cm["type"] == string(runtimev1.TypeReady) {
+			reason, _ := cm["reason"].(string)
+			return reason
+		}
+	}
+	return ""
+}
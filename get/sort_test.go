@@ -0,0 +1,106 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestSortList(t *testing.T) {
+	now := time.Now()
+	postgres := func(name string, age time.Duration, ready runtimev1.Condition) storage.Postgres {
+		pg := test.Postgres(name, test.DefaultProject, "nine-es34")
+		pg.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		pg.SetConditions(ready)
+		return *pg
+	}
+
+	for by, want := range map[sortBy][]string{
+		"":           {"b", "c", "a"},
+		sortByName:   {"a", "b", "c"},
+		sortByAge:    {"c", "a", "b"},
+		sortByStatus: {"a", "b", "c"},
+	} {
+		t.Run(string(by), func(t *testing.T) {
+			list := &storage.PostgresList{Items: []storage.Postgres{
+				postgres("b", time.Hour, runtimev1.Available()),
+				postgres("c", time.Minute, runtimev1.Creating()),
+				postgres("a", 2*time.Minute, runtimev1.Available()),
+			}}
+			require.NoError(t, sortList(list, by))
+
+			names := []string{}
+			for _, pg := range list.Items {
+				names = append(names, pg.Name)
+			}
+			assert.Equal(t, want, names)
+		})
+	}
+}
+
+func TestPaginationFlags(t *testing.T) {
+	assert.NoError(t, (&Cmd{Output: full, Limit: 10}).AfterApply())
+	assert.Error(t, (&Cmd{Output: full, Limit: 10, AllProjects: true}).AfterApply())
+	assert.Error(t, (&Cmd{Output: full, Continue: "token", AllProjects: true}).AfterApply())
+}
+
+func TestPaginationContinue(t *testing.T) {
+	ctx := context.Background()
+	pg := test.Postgres("db", "other", "nine-es34")
+	// the options of the lists in the other project are recorded, they
+	// must not carry the continue token of the current project.
+	otherOpts := &runtimeclient.ListOptions{}
+	apiClient, err := test.SetupClient(
+		test.WithProjects(test.DefaultProject, "other"),
+		test.WithObjects(pg),
+		test.WithNameIndexFor(&storage.Postgres{}),
+		test.WithKubeconfig(t),
+		test.WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c runtimeclient.WithWatch, list runtimeclient.ObjectList, opts ...runtimeclient.ListOption) error {
+				listOpts := &runtimeclient.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				if listOpts.Namespace == "other" {
+					otherOpts = listOpts
+				}
+				if err := c.List(ctx, list, opts...); err != nil {
+					return err
+				}
+				if listOpts.Namespace == test.DefaultProject && listOpts.Limit > 0 {
+					list.SetContinue("next")
+				}
+				return nil
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	stdErr := &bytes.Buffer{}
+	get := &Cmd{Output: full, Limit: 1, Continue: "token", stdErr: stdErr}
+	cmd := postgresCmd{resourceCmd: resourceCmd{Name: "db"}, out: &bytes.Buffer{}}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient, get), "it was found in project(s): other")
+	assert.Empty(t, otherOpts.Continue)
+	assert.Zero(t, otherOpts.Limit)
+
+	// the hint to continue the list includes the limit, as the continue
+	// token is only valid with the same one.
+	cmd = postgresCmd{out: &bytes.Buffer{}}
+	require.NoError(t, cmd.Run(ctx, apiClient, get))
+	assert.Contains(t, stdErr.String(), "continue with --limit=1 --continue=next")
+
+	for _, c := range []func(*Cmd) error{
+		func(get *Cmd) error { return (&allCmd{out: &bytes.Buffer{}}).Run(ctx, apiClient, get) },
+		func(get *Cmd) error { return (&quotaCmd{}).Run(ctx, apiClient, get) },
+	} {
+		assert.ErrorContains(t, c(&Cmd{Output: full, Limit: 1}), "not supported")
+	}
+}
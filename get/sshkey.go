@@ -0,0 +1,65 @@
+package get
+
+import (
+	"context"
+	"io"
+
+	security "github.com/ninech/apis/security/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+)
+
+type sshKeysCmd struct {
+	resourceCmd
+	out io.Writer
+}
+
+func (cmd *sshKeysCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	cmd.out = defaultOut(cmd.out)
+
+	keyList := &security.SSHKeyList{}
+	if err := get.list(ctx, client, keyList, api.MatchName(cmd.Name)); err != nil {
+		return err
+	}
+
+	if len(keyList.Items) == 0 {
+		get.printEmptyMessage(cmd.out, security.SSHKeyKind, client.Project)
+		return nil
+	}
+
+	switch get.Output {
+	case full, wide:
+		return cmd.printSSHKeys(keyList.Items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.printSSHKeys(keyList.Items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(keyList.GetItems(), format.PrintOpts{Out: cmd.out, Export: get.Export})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, keyList.GetItems(), cmd.out)
+	}
+
+	return nil
+}
+
+func (cmd *sshKeysCmd) printSSHKeys(list []security.SSHKey, get *Cmd, header bool) error {
+	w := format.NewTable(cmd.out)
+
+	headings := []string{"NAME", "FORMAT", "FINGERPRINT"}
+	if get.Output == wide {
+		headings = append(headings, "PUBLIC KEY")
+	}
+	if header {
+		get.writeHeader(w, headings...)
+	}
+
+	for _, key := range list {
+		row := []string{key.Name, key.Spec.ForProvider.Format, noneIfEmpty(util.SSHKeyFingerprint(key.Status.AtProvider.PublicKey))}
+		if get.Output == wide {
+			row = append(row, noneIfEmpty(key.Status.AtProvider.PublicKey))
+		}
+		get.writeTabRow(w, key.Namespace, row...)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,46 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	security "github.com/ninech/apis/security/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSSHKeys(t *testing.T) {
+	ctx := context.Background()
+	const publicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAICG87fZctr2IWNwq8WUAq74Rjp7w6j+MegD0pPsCQ6xe"
+	key := &security.SSHKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: test.DefaultProject},
+		Spec:       security.SSHKeySpec{ForProvider: security.SSHKeyParameters{Format: "ed25519"}},
+		Status:     security.SSHKeyStatus{AtProvider: security.SSHKeyObservation{PublicKey: publicKey}},
+	}
+	pending := &security.SSHKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: test.DefaultProject},
+		Spec:       security.SSHKeySpec{ForProvider: security.SSHKeyParameters{Format: "rsa"}},
+	}
+	apiClient, err := test.SetupClient(
+		test.WithObjects(key, pending),
+		test.WithNameIndexFor(&security.SSHKey{}),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := sshKeysCmd{out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: full}))
+	assert.Regexp(t, `NAME\s+FORMAT\s+FINGERPRINT`, out.String())
+	// the fingerprint matches the one of "ssh-keygen -l"
+	assert.Regexp(t, `deploy\s+ed25519\s+SHA256:vAZ1zOcw3MsFtmZ\+4vkzhTJmL2zV1dV5hIJJT8sG7Rs`, out.String())
+	assert.Regexp(t, `pending\s+rsa\s+<none>`, out.String())
+	assert.Equal(t, 3, test.CountLines(out.String()))
+
+	out.Reset()
+	require.NoError(t, cmd.Run(ctx, apiClient, &Cmd{Output: wide}))
+	assert.Contains(t, out.String(), publicKey)
+}
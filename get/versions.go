@@ -0,0 +1,83 @@
+package get
+
+import (
+	"context"
+	"io"
+
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	versionDefault    = "default"
+	versionAvailable  = "available"
+	versionDeprecated = "deprecated"
+)
+
+type versionsCmd struct {
+	out io.Writer
+}
+
+// version is a version of a product which can be selected when creating it.
+type version struct {
+	Product string `json:"product"`
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+func (cmd *versionsCmd) Help() string {
+	return "Lists the database versions which can be selected when creating an instance.\n" +
+		"Deprecated versions are still running but can't be used for new instances."
+}
+
+func (cmd *versionsCmd) Run(ctx context.Context, client *api.Client, get *Cmd) error {
+	if err := get.unpaginated("versions"); err != nil {
+		return err
+	}
+
+	items := availableVersions()
+
+	switch get.Output {
+	case full, wide:
+		return cmd.print(items, get, !get.NoHeaders)
+	case noHeader:
+		return cmd.print(items, get, false)
+	case yamlOut:
+		return format.PrettyPrintObjects(items, format.PrintOpts{Out: cmd.out})
+	case jsonOut, customColumns, jsonPath, goTemplate:
+		return printCustom(get, items, cmd.out)
+	}
+
+	return nil
+}
+
+func (cmd *versionsCmd) print(items []version, get *Cmd, header bool) error {
+	w := format.NewTable(defaultOut(cmd.out))
+	if header {
+		get.writeTabRow(w, "PRODUCT", "VERSION", "STATUS")
+	}
+	for _, v := range items {
+		get.writeTabRow(w, v.Product, v.Version, v.Status)
+	}
+	return w.Flush()
+}
+
+func availableVersions() []version {
+	items := []version{}
+	add := func(product, v, def string, status string) {
+		if v == def {
+			status = versionDefault
+		}
+		items = append(items, version{Product: product, Version: v, Status: status})
+	}
+
+	for _, v := range storage.PostgresVersions {
+		add("postgres", string(v), string(storage.PostgresVersionDefault), versionAvailable)
+	}
+	for _, v := range storage.PostgresVersionsDeprecated {
+		add("postgres", string(v), string(storage.PostgresVersionDefault), versionDeprecated)
+	}
+	add("mysql", string(storage.MySQLVersion8), string(storage.MySQLVersionDefault), versionAvailable)
+	return items
+}
@@ -0,0 +1,17 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersions(t *testing.T) {
+	out := &bytes.Buffer{}
+	cmd := &versionsCmd{out: out}
+	require.NoError(t, cmd.Run(context.Background(), nil, &Cmd{Output: jsonPath, outputArg: `{range .items[?(@.status=="default")]}{.product}={.version} {end}`}))
+	assert.Equal(t, "postgres=16 mysql=8 ", out.String())
+}
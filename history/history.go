@@ -0,0 +1,76 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ninech/nctl/internal/audit"
+	"github.com/ninech/nctl/internal/format"
+)
+
+type Cmd struct {
+	Limit  int  `short:"n" default:"20" help:"Amount of most recent commands to show. 0 shows all."`
+	Failed bool `help:"Only show failed commands."`
+	out    io.Writer
+	path   string
+}
+
+func (cmd *Cmd) Help() string {
+	return "Shows the local history of mutating commands (create, apply, update and delete).\n" +
+		"Commands are only recorded if the audit log is enabled with --audit-log or NCTL_AUDIT_LOG=true."
+}
+
+func (cmd *Cmd) Run() error {
+	out := cmd.out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	path := cmd.path
+	if path == "" {
+		var err error
+		if path, err = audit.DefaultPath(); err != nil {
+			return err
+		}
+	}
+
+	entries, err := audit.Read(path)
+	if err != nil {
+		return err
+	}
+	if cmd.Failed {
+		failed := []audit.Entry{}
+		for _, e := range entries {
+			if e.Result == audit.ResultError {
+				failed = append(failed, e)
+			}
+		}
+		entries = failed
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "no commands recorded in %s\n", path)
+		return nil
+	}
+	if cmd.Limit > 0 && len(entries) > cmd.Limit {
+		entries = entries[len(entries)-cmd.Limit:]
+	}
+
+	w := format.NewTable(out, format.Truncate(), format.StatusColumns("RESULT"))
+	fmt.Fprintln(w, "TIME\tPROJECT\tRESULT\tCOMMAND")
+	for _, e := range entries {
+		result := e.Result
+		if e.Error != "" {
+			result = fmt.Sprintf("%s: %s", e.Result, strings.ReplaceAll(e.Error, "\n", " "))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			e.Time.In(format.Location()).Format(time.DateTime),
+			e.Project,
+			result,
+			strings.Join(append([]string{"nctl"}, e.Args...), " "),
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,44 @@
+package history
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ninech/nctl/internal/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	for i, args := range [][]string{{"create", "app", "one"}, {"update", "app", "one"}, {"delete", "app", "one"}} {
+		var err error
+		if i == 1 {
+			err = errors.New("forbidden")
+		}
+		require.NoError(t, audit.Record(path, audit.NewEntry(args[0], args, "org", "proj", err)))
+	}
+
+	out := &bytes.Buffer{}
+	cmd := &Cmd{Limit: 2, out: out, path: path}
+	require.NoError(t, cmd.Run())
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[1], "nctl update app one")
+	assert.Contains(t, lines[1], "error: forbidden")
+	assert.Contains(t, lines[2], "nctl delete app one")
+
+	out.Reset()
+	cmd = &Cmd{Failed: true, out: out, path: path}
+	require.NoError(t, cmd.Run())
+	assert.NotContains(t, out.String(), "create")
+	assert.Contains(t, out.String(), "update")
+
+	out.Reset()
+	cmd = &Cmd{out: out, path: filepath.Join(t.TempDir(), "missing.jsonl")}
+	require.NoError(t, cmd.Run())
+	assert.Contains(t, out.String(), "no commands recorded")
+}
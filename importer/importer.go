@@ -0,0 +1,7 @@
+// Package importer converts resources of other platforms to resources of the
+// Nine API, e.g. to migrate Kubernetes workloads to deplo.io.
+package importer
+
+type Cmd struct {
+	K8s k8sCmd `cmd:"" name:"k8s" aliases:"kubernetes" help:"Generate a deplo.io Application from a Kubernetes Deployment, Service and Ingress."`
+}
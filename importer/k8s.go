@@ -0,0 +1,411 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/internal/format"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// appSizes are the sizes of applications from the smallest to the largest.
+var appSizes = []apps.ApplicationSize{apps.AppMicro, apps.AppMini, apps.AppStandard1, apps.AppStandard2}
+
+type k8sCmd struct {
+	Filenames   []string `name:"filename" short:"f" required:"" predictor:"file" help:"YAML or JSON file with the Deployment and optionally its Service and Ingress. Can be repeated, use - to read from stdin."`
+	Name        string   `help:"Name of the application. Defaults to the name of the Deployment."`
+	Deployment  string   `help:"Name of the Deployment to import if the files contain several."`
+	Container   string   `help:"Name of the container to import if the Deployment has several."`
+	GitURL      string   `name:"git-url" help:"URL of the git repository with the source of the application. deplo.io builds applications from git instead of running container images."`
+	GitRevision string   `name:"git-revision" default:"main" help:"Revision of the git repository to deploy."`
+	GitSubPath  string   `name:"git-sub-path" help:"Path in the git repository which contains the application code."`
+	out         io.Writer
+	stdErr      io.Writer
+	stdin       io.Reader
+}
+
+func (cmd *k8sCmd) Help() string {
+	return `Reads a Kubernetes Deployment together with the Service and Ingress which
+expose it and prints the equivalent deplo.io Application. The replicas,
+environment variables, port and hosts are taken over and the size is chosen
+from the resource requests of the container. Settings which deplo.io doesn't
+support, like volumes or references to secrets, are printed as warnings.
+
+Examples:
+
+  # generate an application and create it
+  nctl import k8s -f deployment.yaml -f service.yaml -f ingress.yaml \
+    --git-url https://github.com/acme/shop > shop.yaml
+  nctl apply -f shop.yaml
+
+  # import from a running cluster
+  kubectl get deploy,svc,ing -o yaml | nctl import k8s -f - --deployment shop`
+}
+
+// workload are the Kubernetes resources which are imported.
+type workload struct {
+	deployments []appsv1.Deployment
+	services    []corev1.Service
+	ingresses   []networkingv1.Ingress
+}
+
+func (cmd *k8sCmd) Run() error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.stdErr == nil {
+		cmd.stdErr = os.Stderr
+	}
+	if cmd.stdin == nil {
+		cmd.stdin = os.Stdin
+	}
+
+	w := &workload{}
+	var warnings []string
+	for _, name := range cmd.Filenames {
+		ws, err := cmd.read(name, w)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", name, err)
+		}
+		warnings = append(warnings, ws...)
+	}
+
+	app, ws, err := cmd.convert(w)
+	if err != nil {
+		return err
+	}
+	for _, warning := range append(warnings, ws...) {
+		fmt.Fprintf(cmd.stdErr, "warning: %s\n", warning)
+	}
+	return format.PrettyPrintObject(app, format.PrintOpts{Out: cmd.out, Export: true})
+}
+
+// read adds the Deployments, Services and Ingresses of the file to the
+// workload. Other kinds are skipped with a warning.
+func (cmd *k8sCmd) read(name string, w *workload) ([]string, error) {
+	var r io.Reader = cmd.stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var warnings []string
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return warnings, nil
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		items := []unstructured.Unstructured{*obj}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+			items = list.Items
+		}
+		for _, item := range items {
+			warning, err := w.add(item)
+			if err != nil {
+				return nil, err
+			}
+			if warning != "" {
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+}
+
+func (w *workload) add(obj unstructured.Unstructured) (string, error) {
+	var err error
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "Deployment.apps":
+		d := appsv1.Deployment{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &d)
+		w.deployments = append(w.deployments, d)
+	case "Service":
+		s := corev1.Service{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &s)
+		w.services = append(w.services, s)
+	case "Ingress.networking.k8s.io":
+		i := networkingv1.Ingress{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &i)
+		w.ingresses = append(w.ingresses, i)
+	default:
+		return fmt.Sprintf("%s %q is skipped, only Deployments, Services and Ingresses are imported", obj.GetKind(), obj.GetName()), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid %s %q: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return "", nil
+}
+
+// convert returns the application for the deployment of the workload and
+// warnings about the settings which can't be taken over.
+func (cmd *k8sCmd) convert(w *workload) (*apps.Application, []string, error) {
+	deployment, err := cmd.deployment(w)
+	if err != nil {
+		return nil, nil, err
+	}
+	container, err := cmd.container(deployment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := cmd.Name
+	if name == "" {
+		name = deployment.Name
+	}
+	app := &apps.Application{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       apps.ApplicationKind,
+			APIVersion: apps.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apps.ApplicationSpec{
+			ForProvider: apps.ApplicationParameters{
+				Git: apps.ApplicationGitConfig{
+					GitTarget: apps.GitTarget{
+						URL:      cmd.GitURL,
+						Revision: cmd.GitRevision,
+						SubPath:  cmd.GitSubPath,
+					},
+				},
+				Config: apps.Config{
+					Replicas: deployment.Spec.Replicas,
+				},
+				BuildEnv: apps.EnvVars{},
+			},
+		},
+	}
+
+	var warnings []string
+	warn := func(format string, a ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, a...))
+	}
+
+	if cmd.GitURL == "" {
+		warn("the image %s can't be run, deplo.io builds applications from git, set the repository with --git-url", container.Image)
+	}
+	if len(container.Command) != 0 || len(container.Args) != 0 {
+		warn("the command of container %q is not imported, the web process is started as defined in the Procfile or Dockerfile of the repository", container.Name)
+	}
+
+	// the variables are kept in the order of the container.
+	env := apps.EnvVars{}
+	for _, e := range container.Env {
+		if e.ValueFrom != nil {
+			warn("env %s is not imported, references to secrets, config maps or fields are not supported, set it with \"nctl update app %s --env\"", e.Name, name)
+			continue
+		}
+		env = append(env, apps.EnvVar{Name: e.Name, Value: e.Value})
+	}
+	app.Spec.ForProvider.Config.Env = env
+	for _, e := range container.EnvFrom {
+		source := "config map"
+		if e.SecretRef != nil {
+			source = "secret"
+		}
+		warn("env from %s is not imported, set the variables with \"nctl update app %s --env\"", source, name)
+	}
+
+	size, ok := sizeFor(container.Resources)
+	if !ok {
+		warn("the resources of container %q exceed the largest size %s", container.Name, size)
+	}
+	app.Spec.ForProvider.Config.Size = size
+
+	service := serviceFor(w.services, deployment)
+	port, ws := portFor(container, service)
+	warnings = append(warnings, ws...)
+	app.Spec.ForProvider.Config.Port = port
+
+	if service != nil {
+		hosts, ws := hostsFor(w.ingresses, service.Name)
+		warnings = append(warnings, ws...)
+		app.Spec.ForProvider.Hosts = hosts
+	}
+
+	spec := deployment.Spec.Template.Spec
+	if len(spec.Volumes) != 0 {
+		warn("volumes are not imported, applications have no persistent storage, use a database or object storage instead")
+	}
+	if len(spec.InitContainers) != 0 {
+		warn("init containers are not imported, use a deploy job (--deploy-job-command) to run tasks before a release")
+	}
+	if container.LivenessProbe != nil || container.ReadinessProbe != nil || container.StartupProbe != nil {
+		warn("the probes of container %q are not imported", container.Name)
+	}
+	return app, warnings, nil
+}
+
+// deployment returns the deployment to import, the one given by name or the
+// only one.
+func (cmd *k8sCmd) deployment(w *workload) (*appsv1.Deployment, error) {
+	names := []string{}
+	for i, d := range w.deployments {
+		if d.Name == cmd.Deployment {
+			return &w.deployments[i], nil
+		}
+		names = append(names, d.Name)
+	}
+	switch {
+	case cmd.Deployment != "":
+		return nil, fmt.Errorf("deployment %q not found", cmd.Deployment)
+	case len(w.deployments) == 0:
+		return nil, fmt.Errorf("no deployment found")
+	case len(w.deployments) > 1:
+		return nil, fmt.Errorf("found several deployments, select one with --deployment: %s", strings.Join(names, ", "))
+	}
+	return &w.deployments[0], nil
+}
+
+// container returns the container to import, the one given by name or the
+// only one.
+func (cmd *k8sCmd) container(d *appsv1.Deployment) (*corev1.Container, error) {
+	containers := d.Spec.Template.Spec.Containers
+	names := []string{}
+	for i, c := range containers {
+		if c.Name == cmd.Container {
+			return &containers[i], nil
+		}
+		names = append(names, c.Name)
+	}
+	switch {
+	case cmd.Container != "":
+		return nil, fmt.Errorf("container %q not found in deployment %q", cmd.Container, d.Name)
+	case len(containers) == 0:
+		return nil, fmt.Errorf("deployment %q has no containers", d.Name)
+	case len(containers) > 1:
+		return nil, fmt.Errorf("deployment %q has several containers, applications run a single one, select it with --container: %s", d.Name, strings.Join(names, ", "))
+	}
+	return &containers[0], nil
+}
+
+// sizeFor returns the smallest application size which fits the requests of
+// the container, or the limits if it has no requests. If no size fits, the
+// largest one is returned and ok is false.
+func sizeFor(resources corev1.ResourceRequirements) (size apps.ApplicationSize, ok bool) {
+	wanted := resources.Requests
+	if len(wanted) == 0 {
+		wanted = resources.Limits
+	}
+	if len(wanted) == 0 {
+		// the default size of the API is used.
+		return "", true
+	}
+	for _, size := range appSizes {
+		res := apps.AppResources[size]
+		if wanted.Cpu().Cmp(*res.Cpu()) <= 0 && wanted.Memory().Cmp(*res.Memory()) <= 0 {
+			return size, true
+		}
+	}
+	return appSizes[len(appSizes)-1], false
+}
+
+// serviceFor returns the first service which selects the pods of the
+// deployment.
+func serviceFor(services []corev1.Service, d *appsv1.Deployment) *corev1.Service {
+	for i, s := range services {
+		if len(s.Spec.Selector) == 0 || (s.Namespace != "" && d.Namespace != "" && s.Namespace != d.Namespace) {
+			continue
+		}
+		if labels.SelectorFromSet(s.Spec.Selector).Matches(labels.Set(d.Spec.Template.Labels)) {
+			return &services[i]
+		}
+	}
+	return nil
+}
+
+// portFor returns the port the container is listening on, the target port
+// of the service if there is one.
+func portFor(c *corev1.Container, s *corev1.Service) (*int32, []string) {
+	var warnings []string
+	if s != nil && len(s.Spec.Ports) != 0 {
+		if len(s.Spec.Ports) > 1 {
+			warnings = append(warnings, fmt.Sprintf("service %q has several ports, applications only expose one, port %s is imported", s.Name, portName(s.Spec.Ports[0])))
+		}
+		target := s.Spec.Ports[0].TargetPort
+		switch {
+		case target.Type == intstr.String:
+			for _, p := range c.Ports {
+				if p.Name == target.StrVal {
+					return &p.ContainerPort, warnings
+				}
+			}
+			warnings = append(warnings, fmt.Sprintf("port %q of service %q not found in container %q", target.StrVal, s.Name, c.Name))
+		case target.IntVal != 0:
+			return &target.IntVal, warnings
+		default:
+			// the target port defaults to the port of the service.
+			return &s.Spec.Ports[0].Port, warnings
+		}
+	}
+	if len(c.Ports) == 0 {
+		return nil, warnings
+	}
+	if len(c.Ports) > 1 {
+		warnings = append(warnings, fmt.Sprintf("container %q has several ports, applications only expose one, port %d is imported", c.Name, c.Ports[0].ContainerPort))
+	}
+	return &c.Ports[0].ContainerPort, warnings
+}
+
+func portName(p corev1.ServicePort) string {
+	if p.Name != "" {
+		return fmt.Sprintf("%q", p.Name)
+	}
+	return fmt.Sprint(p.Port)
+}
+
+// hostsFor returns the hosts of the ingress rules which route to the service.
+func hostsFor(ingresses []networkingv1.Ingress, service string) ([]string, []string) {
+	var hosts, warnings []string
+	for _, ing := range ingresses {
+		routed := false
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service == nil || path.Backend.Service.Name != service {
+					continue
+				}
+				routed = true
+				if path.Path != "" && path.Path != "/" {
+					warnings = append(warnings, fmt.Sprintf("path %s of ingress %q is not imported, applications receive all requests of their hosts", path.Path, ing.Name))
+				}
+				if rule.Host != "" && !slices.Contains(hosts, rule.Host) {
+					hosts = append(hosts, rule.Host)
+				}
+			}
+		}
+		if routed && len(ing.Spec.TLS) != 0 {
+			warnings = append(warnings, fmt.Sprintf("the TLS settings of ingress %q are not imported, deplo.io issues certificates for all hosts", ing.Name))
+		}
+	}
+	return hosts, warnings
+}
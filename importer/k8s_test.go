@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: shop
+spec:
+  replicas: 2
+  template:
+    metadata:
+      labels: {app: shop}
+    spec:
+      volumes:
+      - name: cache
+        emptyDir: {}
+      containers:
+      - name: web
+        image: ghcr.io/acme/shop:1.0
+        ports:
+        - name: http
+          containerPort: 8080
+        env:
+        - name: RAILS_ENV
+          value: production
+        - name: SECRET_KEY_BASE
+          valueFrom:
+            secretKeyRef: {name: shop, key: secret}
+        resources:
+          requests: {cpu: 300m, memory: 512Mi}
+`
+
+const serviceAndIngress = `apiVersion: v1
+kind: Service
+metadata:
+  name: shop
+spec:
+  selector: {app: shop}
+  ports:
+  - port: 80
+    targetPort: http
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: shop
+spec:
+  rules:
+  - host: shop.example.org
+    http:
+      paths:
+      - path: /api
+        pathType: Prefix
+        backend:
+          service: {name: shop, port: {number: 80}}
+  - host: other.example.org
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service: {name: other, port: {number: 80}}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shop
+`
+
+func TestK8s(t *testing.T) {
+	dir := t.TempDir()
+	deploymentFile := filepath.Join(dir, "deployment.yaml")
+	require.NoError(t, os.WriteFile(deploymentFile, []byte(deployment), 0o600))
+
+	out, stdErr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := k8sCmd{
+		Filenames:   []string{deploymentFile, "-"},
+		GitURL:      "https://github.com/acme/shop",
+		GitRevision: "main",
+		out:         out,
+		stdErr:      stdErr,
+		stdin:       strings.NewReader(serviceAndIngress),
+	}
+	require.NoError(t, cmd.Run())
+
+	app := &apps.Application{}
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), app))
+	assert.Equal(t, apps.ApplicationKind, app.Kind)
+	assert.Equal(t, "shop", app.Name)
+	assert.Equal(t, "https://github.com/acme/shop", app.Spec.ForProvider.Git.URL)
+	assert.Equal(t, apps.EnvVars{{Name: "RAILS_ENV", Value: "production"}}, app.Spec.ForProvider.Config.Env)
+	assert.Equal(t, ptr.To(int32(2)), app.Spec.ForProvider.Config.Replicas)
+	// the named target port of the service is resolved
+	assert.Equal(t, ptr.To(int32(8080)), app.Spec.ForProvider.Config.Port)
+	assert.Equal(t, apps.AppStandard1, app.Spec.ForProvider.Config.Size)
+	assert.Equal(t, []string{"shop.example.org"}, app.Spec.ForProvider.Hosts)
+
+	warnings := stdErr.String()
+	assert.Contains(t, warnings, `ConfigMap "shop" is skipped`)
+	assert.Contains(t, warnings, "env SECRET_KEY_BASE is not imported")
+	assert.Contains(t, warnings, "path /api of ingress")
+	assert.Contains(t, warnings, "volumes are not imported")
+	assert.NotContains(t, warnings, "image")
+}
+
+func TestK8sSelection(t *testing.T) {
+	w := &workload{}
+	for _, name := range []string{"web", "worker"} {
+		_, err := w.add(deploymentObj(t, name))
+		require.NoError(t, err)
+	}
+
+	_, _, err := (&k8sCmd{}).convert(w)
+	assert.ErrorContains(t, err, "--deployment: web, worker")
+
+	_, _, err = (&k8sCmd{Deployment: "cron"}).convert(w)
+	assert.ErrorContains(t, err, `deployment "cron" not found`)
+
+	app, warnings, err := (&k8sCmd{Deployment: "worker", Name: "jobs"}).convert(w)
+	require.NoError(t, err)
+	assert.Equal(t, "jobs", app.Name)
+	assert.Contains(t, strings.Join(warnings, "\n"), "--git-url")
+}
+
+func TestSizeFor(t *testing.T) {
+	size, ok := sizeFor(corev1.ResourceRequirements{})
+	assert.True(t, ok)
+	assert.Empty(t, size)
+
+	size, ok = sizeFor(corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}})
+	assert.True(t, ok)
+	assert.Equal(t, apps.AppMicro, size)
+
+	size, ok = sizeFor(corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}})
+	assert.False(t, ok)
+	assert.Equal(t, apps.AppStandard2, size)
+}
+
+func deploymentObj(t *testing.T, name string) unstructured.Unstructured {
+	t.Helper()
+	obj := unstructured.Unstructured{}
+	require.NoError(t, yaml.Unmarshal([]byte(strings.Replace(deployment, "name: shop", "name: "+name, 1)), &obj.Object))
+	return obj
+}
@@ -0,0 +1,146 @@
+// Package audit implements a local log of all mutating nctl commands. It is
+// purely local, nothing is ever sent anywhere.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// mutatingCommands are the top level commands which change resources.
+var mutatingCommands = []string{
+	"create", "apply", "update", "delete", "clone", "promote", "copy", "start", "stop", "pause", "resume",
+	"restart", "deploy", "edit", "patch", "label", "annotate", "init",
+}
+
+// sensitiveFlag reports if the values of the flag should not end up in the
+// log, like env variables or passwords.
+func sensitiveFlag(arg string) bool {
+	if !strings.HasPrefix(arg, "-") {
+		return false
+	}
+	return strings.Contains(strings.ToLower(arg), "env") || format.SensitiveName(arg)
+}
+
+// Entry is a single recorded command.
+type Entry struct {
+	Time         time.Time `json:"time"`
+	Command      string    `json:"command"`
+	Args         []string  `json:"args"`
+	Organization string    `json:"organization,omitempty"`
+	Project      string    `json:"project,omitempty"`
+	Result       string    `json:"result"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// DefaultPath returns the path of the log within the user config dir.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "nctl", "history.jsonl"), nil
+}
+
+// Mutating reports if the kong command (e.g. "create application <name>")
+// changes resources and should be recorded.
+func Mutating(command string) bool {
+	cmd, _, _ := strings.Cut(command, " ")
+	for _, m := range mutatingCommands {
+		if cmd == m {
+			return true
+		}
+	}
+	return false
+}
+
+// NewEntry returns an entry for the command with the given args and error.
+// Values of sensitive flags like env variables or passwords are redacted.
+func NewEntry(command string, args []string, organization, project string, err error) Entry {
+	e := Entry{
+		Time:         time.Now(),
+		Command:      command,
+		Args:         RedactArgs(args),
+		Organization: organization,
+		Project:      project,
+		Result:       ResultSuccess,
+	}
+	if err != nil {
+		e.Result = ResultError
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// RedactArgs replaces the values of sensitive flags.
+func RedactArgs(args []string) []string {
+	result := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if name, _, ok := strings.Cut(arg, "="); ok && sensitiveFlag(name) {
+			result = append(result, name+"="+format.Redacted)
+			continue
+		}
+		result = append(result, arg)
+		if sensitiveFlag(arg) && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			result = append(result, format.Redacted)
+			i++
+		}
+	}
+	return result
+}
+
+// Record appends the entry to the log at path.
+func Record(path string, e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(e)
+}
+
+// Read returns all entries of the log at path, oldest first. A missing log
+// is not an error.
+func Read(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		e := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("unable to parse line %d of %s: %w", line, path, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
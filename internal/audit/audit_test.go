@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutating(t *testing.T) {
+	assert.True(t, Mutating("create application <name>"))
+	assert.True(t, Mutating("delete postgres"))
+	assert.True(t, Mutating("label <kind> <name> <key=value> ..."))
+	assert.True(t, Mutating("init ci"))
+	assert.False(t, Mutating("get applications"))
+	assert.False(t, Mutating("logs app <name>"))
+}
+
+func TestRedactArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"create", "app", "--env=<redacted>", "--git-url", "https://example.org", "--basic-auth-password", "<redacted>", "--dry-run"},
+		RedactArgs([]string{"create", "app", "--env=SECRET=foo", "--git-url", "https://example.org", "--basic-auth-password", "hunter2", "--dry-run"}),
+	)
+}
+
+func TestRecordAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nctl", "history.jsonl")
+
+	entries, err := Read(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	require.NoError(t, Record(path, NewEntry("create application <name>", []string{"create", "app", "foo"}, "org", "proj", nil)))
+	require.NoError(t, Record(path, NewEntry("delete application <name>", []string{"delete", "app", "foo"}, "org", "proj", errors.New("not found"))))
+
+	entries, err = Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, ResultSuccess, entries[0].Result)
+	assert.Equal(t, []string{"create", "app", "foo"}, entries[0].Args)
+	assert.Equal(t, ResultError, entries[1].Result)
+	assert.Equal(t, "not found", entries[1].Error)
+}
@@ -0,0 +1,73 @@
+package format
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// unknownAge is printed in tables for times which are not set.
+const unknownAge = "<unknown>"
+
+var (
+	showTimestamps atomic.Bool
+	utc            atomic.Bool
+)
+
+// SetShowTimestamps makes Age and AgeDetailed print absolute RFC3339
+// timestamps instead of humanized ages, e.g. to correlate them with logs.
+func SetShowTimestamps(enabled bool) {
+	showTimestamps.Store(enabled)
+}
+
+// ShowTimestamps reports if absolute timestamps are printed instead of ages.
+func ShowTimestamps() bool {
+	return showTimestamps.Load()
+}
+
+// SetUTC makes timestamps be printed in UTC instead of the local timezone,
+// e.g. to compare them with timestamps of other systems.
+func SetUTC(enabled bool) {
+	utc.Store(enabled)
+}
+
+// Location returns the timezone in which timestamps are printed, the local
+// one unless UTC is set.
+func Location() *time.Location {
+	if utc.Load() {
+		return time.UTC
+	}
+	return time.Local
+}
+
+// Timestamp returns t in RFC3339 format in the timezone of Location.
+func Timestamp(t time.Time) string {
+	return t.In(Location()).Format(time.RFC3339)
+}
+
+// Age returns the time since t for table columns, e.g. "5m" or "3d4h". If
+// timestamps are shown, t is returned in RFC3339 format instead.
+func Age(t time.Time) string {
+	if t.IsZero() {
+		return unknownAge
+	}
+	if ShowTimestamps() {
+		return Timestamp(t)
+	}
+	return duration.HumanDuration(time.Since(t))
+}
+
+// AgeDetailed returns the time since t together with the timestamp, e.g.
+// "5m ago (2024-05-01T10:00:00Z)", or only the timestamp if timestamps are
+// shown. It returns an empty string if t is not set.
+func AgeDetailed(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if ShowTimestamps() {
+		return Timestamp(t)
+	}
+	return fmt.Sprintf("%s ago (%s)", duration.HumanDuration(time.Since(t)), Timestamp(t))
+}
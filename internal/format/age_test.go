@@ -0,0 +1,40 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAge(t *testing.T) {
+	created := time.Now().Add(-90 * time.Minute)
+	timestamp := created.Format(time.RFC3339)
+
+	assert.Equal(t, "90m", Age(created))
+	assert.Equal(t, "90m ago ("+timestamp+")", AgeDetailed(created))
+	assert.Equal(t, "<unknown>", Age(time.Time{}))
+	assert.Equal(t, "", AgeDetailed(time.Time{}))
+
+	SetShowTimestamps(true)
+	defer SetShowTimestamps(false)
+	assert.Equal(t, timestamp, Age(created))
+	assert.Equal(t, timestamp, AgeDetailed(created))
+	assert.Equal(t, "<unknown>", Age(time.Time{}))
+}
+
+func TestTimestamp(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("CEST", 2*60*60)
+	defer func() { time.Local = local }()
+
+	ts := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, "2024-05-01T12:30:00+02:00", Timestamp(ts))
+
+	SetUTC(true)
+	defer SetUTC(false)
+	assert.Equal(t, "2024-05-01T10:30:00Z", Timestamp(ts))
+	SetShowTimestamps(true)
+	defer SetShowTimestamps(false)
+	assert.Equal(t, "2024-05-01T10:30:00Z", Age(ts))
+}
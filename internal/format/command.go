@@ -14,6 +14,7 @@ const (
 	LogoutCommand         = "auth logout"
 	SetOrgCommand         = "auth set-org"
 	getApplicationCommand = "get application"
+	waitCommand           = "wait"
 )
 
 type command string
@@ -36,6 +37,27 @@ func (c command) GetApplication(extraFields ...string) string {
 	return fmt.Sprintf("%s %s %s", string(c), getApplicationCommand, strings.Join(extraFields, " "))
 }
 
+// Wait returns the command to wait for the condition of a resource, see
+// "nctl wait --help" for the format of the condition.
+func (c command) Wait(kind, name, project, condition string) string {
+	cmd := fmt.Sprintf("%s %s %s %s --for %s", string(c), waitCommand, kind, name, condition)
+	if project != "" {
+		cmd += " --project " + project
+	}
+	return cmd
+}
+
+// InterruptedError is returned if a command waiting for a resource has been
+// interrupted, e.g. with ctrl-c. The resource is not affected by this.
+type InterruptedError struct {
+	// Resume is the command to continue waiting.
+	Resume string
+}
+
+func (e InterruptedError) Error() string {
+	return fmt.Sprintf("interrupted, continue waiting with: %s", e.Resume)
+}
+
 // MissingChildren detects missing commands/args.
 // Logic taken from github.com/alecthomas/kong/context.go
 func MissingChildren(node *kong.Node) bool {
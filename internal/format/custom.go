@@ -0,0 +1,167 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const noValue = "<none>"
+
+// customColumn is a single column of a custom columns output.
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// PrintCustomColumns prints the supplied objects as a table with the columns
+// defined in spec, using the same syntax as kubectl, e.g.
+// "NAME:.metadata.name,FQDN:.status.atProvider.fqdn".
+func PrintCustomColumns[T any](objs []T, spec string, header bool, opts PrintOpts) error {
+	columns, err := parseCustomColumns(spec)
+	if err != nil {
+		return err
+	}
+
+	w := NewTable(opts.defaultOut())
+	if header {
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = c.header
+		}
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+	}
+
+	for _, obj := range objs {
+		data, err := toJSONData(obj)
+		if err != nil {
+			return err
+		}
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i], err = columnValue(c.path, data)
+			if err != nil {
+				return fmt.Errorf("unable to get value of column %s: %w", c.header, err)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// PrintJSONPath prints the supplied objects with the given JSONPath template,
+// e.g. "{.items[*].metadata.name}". Like with kubectl, the template is applied
+// to a list containing all objects.
+func PrintJSONPath[T any](objs []T, template string, opts PrintOpts) error {
+	j := jsonpath.New("output")
+	if err := j.Parse(template); err != nil {
+		return fmt.Errorf("invalid jsonpath template %q: %w", template, err)
+	}
+
+	items := make([]any, len(objs))
+	for i, obj := range objs {
+		data, err := toJSONData(obj)
+		if err != nil {
+			return err
+		}
+		items[i] = data
+	}
+
+	return j.Execute(opts.defaultOut(), map[string]any{
+		"kind":  "List",
+		"items": items,
+	})
+}
+
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	if len(spec) == 0 {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+
+	columns := []customColumn{}
+	for _, def := range strings.Split(spec, ",") {
+		header, expr, ok := strings.Cut(def, ":")
+		if !ok || len(header) == 0 || len(expr) == 0 {
+			return nil, fmt.Errorf("invalid custom column %q, expected <header>:<jsonpath>", def)
+		}
+		j := jsonpath.New(header).AllowMissingKeys(true)
+		if err := j.Parse(relaxedJSONPath(expr)); err != nil {
+			return nil, fmt.Errorf("invalid jsonpath %q of column %s: %w", expr, header, err)
+		}
+		columns = append(columns, customColumn{header: header, path: j})
+	}
+	return columns, nil
+}
+
+// relaxedJSONPath allows to omit the curly braces and the leading dot of a
+// JSONPath expression, so "metadata.name" becomes "{.metadata.name}".
+func relaxedJSONPath(expr string) string {
+	if strings.HasPrefix(expr, "{") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
+
+func columnValue(path *jsonpath.JSONPath, data any) (string, error) {
+	results, err := path.FindResults(data)
+	if err != nil {
+		return "", err
+	}
+
+	values := []string{}
+	for _, result := range results {
+		for _, r := range result {
+			buf := &bytes.Buffer{}
+			if err := path.PrintResults(buf, []reflect.Value{r}); err != nil {
+				return "", err
+			}
+			values = append(values, buf.String())
+		}
+	}
+	if len(values) == 0 {
+		return noValue, nil
+	}
+	return strings.Join(values, ","), nil
+}
+
+// toJSONData converts an object into its generic JSON representation so
+// JSONPath expressions can be evaluated using the JSON field names.
+func toJSONData(obj any) (any, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PrintJSON prints the supplied objects as an indented JSON list like
+// "kubectl get -o json" does.
+func PrintJSON[T any](objs []T, opts PrintOpts) error {
+	items := make([]any, len(objs))
+	for i, obj := range objs {
+		data, err := toJSONData(obj)
+		if err != nil {
+			return err
+		}
+		items[i] = data
+	}
+
+	enc := json.NewEncoder(opts.defaultOut())
+	enc.SetIndent("", "    ")
+	return enc.Encode(struct {
+		Kind  string `json:"kind"`
+		Items []any  `json:"items"`
+	}{Kind: "List", Items: items})
+}
@@ -0,0 +1,68 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPrintCustomColumns(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		{Object: map[string]any{
+			"metadata": map[string]any{"name": "first"},
+			"status":   map[string]any{"hosts": []any{"a.example.org", "b.example.org"}},
+		}},
+		{Object: map[string]any{
+			"metadata": map[string]any{"name": "second"},
+		}},
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, PrintCustomColumns(objs, "NAME:.metadata.name,HOST:status.hosts[0],HOSTS:{.status.hosts[*]}", true, PrintOpts{Out: out}))
+	assert.Equal(t, ""+
+		"NAME      HOST             HOSTS\n"+
+		"first     a.example.org    a.example.org,b.example.org\n"+
+		"second    <none>           <none>\n",
+		out.String(),
+	)
+
+	for _, spec := range []string{"", "NAME", "NAME:", "NAME:{.metadata.name"} {
+		assert.Error(t, PrintCustomColumns(objs, spec, true, PrintOpts{Out: out}), spec)
+	}
+}
+
+func TestPrintJSONPath(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		{Object: map[string]any{"metadata": map[string]any{"name": "first"}}},
+		{Object: map[string]any{"metadata": map[string]any{"name": "second"}}},
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, PrintJSONPath(objs, "{.items[*].metadata.name}", PrintOpts{Out: out}))
+	assert.Equal(t, "first second", out.String())
+
+	assert.Error(t, PrintJSONPath(objs, "{.items[*", PrintOpts{Out: out}))
+}
+
+func TestPrintJSON(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		{Object: map[string]any{"metadata": map[string]any{"name": "first"}}},
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, PrintJSON(objs, PrintOpts{Out: out}))
+	assert.Equal(t, `{
+    "kind": "List",
+    "items": [
+        {
+            "metadata": {
+                "name": "first"
+            }
+        }
+    ]
+}
+`, out.String())
+}
@@ -0,0 +1,275 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/fatih/color"
+)
+
+const describeIndent = "  "
+
+// Description is a human readable view of a single resource, made up of
+// top level fields followed by titled sections. Sections can contain fields,
+// nested objects and tables.
+type Description struct {
+	fields   []describeField
+	sections []*DescriptionSection
+}
+
+// DescriptionSection is a titled part of a Description.
+type DescriptionSection struct {
+	title  string
+	fields []describeField
+	tables []*DescriptionTable
+}
+
+// DescriptionTable is a table within a DescriptionSection.
+type DescriptionTable struct {
+	headers []string
+	rows    [][]string
+}
+
+type describeField struct {
+	name  string
+	value string
+	depth int
+}
+
+// NewDescription returns an empty Description.
+func NewDescription() *Description {
+	return &Description{}
+}
+
+// Field adds a top level field. Empty values are omitted.
+func (d *Description) Field(name string, value any) *Description {
+	if v := describeValue(value); len(v) != 0 {
+		d.fields = append(d.fields, describeField{name: name, value: v})
+	}
+	return d
+}
+
+// Section adds a new section with the given title.
+func (d *Description) Section(title string) *DescriptionSection {
+	s := &DescriptionSection{title: title}
+	d.sections = append(d.sections, s)
+	return s
+}
+
+// Field adds a field to the section. Empty values are omitted.
+func (s *DescriptionSection) Field(name string, value any) *DescriptionSection {
+	if v := describeValue(value); len(v) != 0 {
+		s.fields = append(s.fields, describeField{name: name, value: v})
+	}
+	return s
+}
+
+// Object adds all fields of a nested object (as found in unstructured
+// objects) to the section. Keys are converted to a readable form and sorted,
+// nested objects are indented and empty values are omitted.
+func (s *DescriptionSection) Object(obj map[string]any) *DescriptionSection {
+	s.fields = append(s.fields, objectFields(obj, 0)...)
+	return s
+}
+
+// Table adds a table with the given headers to the section.
+func (s *DescriptionSection) Table(headers ...string) *DescriptionTable {
+	t := &DescriptionTable{headers: headers}
+	s.tables = append(s.tables, t)
+	return t
+}
+
+// Row adds a row to the table.
+func (t *DescriptionTable) Row(values ...string) *DescriptionTable {
+	t.rows = append(t.rows, values)
+	return t
+}
+
+// Print writes the description to out. Stdout is used if out is nil.
+func (d *Description) Print(out io.Writer) error {
+	if out == nil {
+		out = os.Stdout
+	}
+
+	if err := printFields(out, d.fields, ""); err != nil {
+		return err
+	}
+
+	title := color.New(color.Bold).SprintFunc()
+	for _, s := range d.sections {
+		if s.empty() {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s\n", title(s.title+":"))
+		if err := printFields(out, s.fields, describeIndent); err != nil {
+			return err
+		}
+		for _, t := range s.tables {
+			if err := t.print(out); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *DescriptionSection) empty() bool {
+	if len(s.fields) != 0 {
+		return false
+	}
+	for _, t := range s.tables {
+		if len(t.rows) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *DescriptionTable) print(out io.Writer) error {
+	if len(t.rows) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "%s%s\n", describeIndent, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintf(w, "%s%s\n", describeIndent, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func printFields(out io.Writer, fields []describeField, indent string) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for i, f := range fields {
+		// values are only aligned within consecutive fields of the same
+		// nesting level.
+		if i > 0 && fields[i-1].depth != f.depth {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		prefix := indent + strings.Repeat(describeIndent, f.depth)
+		if len(f.value) == 0 {
+			// a nested object header, do not align it with values
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s%s:\n", prefix, f.name)
+			continue
+		}
+		fmt.Fprintf(w, "%s%s:\t%s\n", prefix, f.name, f.value)
+	}
+	return w.Flush()
+}
+
+func objectFields(obj map[string]any, depth int) []describeField {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := []describeField{}
+	for _, k := range keys {
+		name := Humanize(k)
+		switch v := obj[k].(type) {
+		case map[string]any:
+			nested := objectFields(v, depth+1)
+			if len(nested) == 0 {
+				continue
+			}
+			fields = append(fields, describeField{name: name, depth: depth})
+			fields = append(fields, nested...)
+		case []any:
+			if len(v) == 0 {
+				continue
+			}
+			if !containsObjects(v) {
+				fields = append(fields, describeField{name: name, value: describeValue(v), depth: depth})
+				continue
+			}
+			fields = append(fields, describeField{name: name, depth: depth})
+			for i, item := range v {
+				m, ok := item.(map[string]any)
+				if !ok {
+					fields = append(fields, describeField{name: fmt.Sprintf("[%d]", i), value: describeValue(item), depth: depth + 1})
+					continue
+				}
+				fields = append(fields, describeField{name: fmt.Sprintf("[%d]", i), depth: depth + 1})
+				fields = append(fields, objectFields(m, depth+2)...)
+			}
+		default:
+			if value := describeValue(v); len(value) != 0 {
+				fields = append(fields, describeField{name: name, value: value, depth: depth})
+			}
+		}
+	}
+	return fields
+}
+
+func containsObjects(list []any) bool {
+	for _, item := range list {
+		if _, ok := item.(map[string]any); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func describeValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, describeValue(item))
+		}
+		return strings.Join(items, ", ")
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]string, 0, len(v))
+		for _, k := range keys {
+			items = append(items, k+"="+v[k])
+		}
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// Humanize converts a camel cased field name like "machineType" or
+// "allowedCIDRs" into a readable form like "Machine Type" or "Allowed CIDRs".
+func Humanize(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if i == 0 {
+			b.WriteRune(unicode.ToUpper(r))
+			continue
+		}
+		prev := runes[i-1]
+		// start a new word at the last upper case letter of an acronym
+		// ("HTTPServer"), except for plurals of acronyms ("CIDRs").
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		plural := nextLower && runes[i+1] == 's' && (i+2 == len(runes) || unicode.IsUpper(runes[i+2]))
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextLower && !plural)) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHumanize(t *testing.T) {
+	for in, want := range map[string]string{
+		"name":             "Name",
+		"machineType":      "Machine Type",
+		"allowedCIDRs":     "Allowed CIDRs",
+		"fqdn":             "Fqdn",
+		"defaultURLs":      "Default URLs",
+		"HTTPServer":       "HTTP Server",
+		"keepDailyBackups": "Keep Daily Backups",
+	} {
+		assert.Equal(t, want, Humanize(in), in)
+	}
+}
+
+func TestDescription(t *testing.T) {
+	d := NewDescription().
+		Field("Name", "test").
+		Field("Empty", "").
+		Field("Labels", map[string]string{"b": "2", "a": "1"})
+	d.Section("Spec").Object(map[string]any{
+		"machineType":  "nine-db-s",
+		"allowedCIDRs": []any{"10.0.0.0/8", "192.168.0.0/16"},
+		"git": map[string]any{
+			"url":      "https://example.org",
+			"revision": "main",
+		},
+		"empty": map[string]any{},
+		"env": []any{
+			map[string]any{"name": "FOO", "value": "bar"},
+		},
+	})
+	d.Section("Empty Section").Table("A", "B")
+	d.Section("Conditions").Table("TYPE", "STATUS").Row("Ready", "True")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, d.Print(out))
+
+	assert.Equal(t, `Name:    test
+Labels:  a=1, b=2
+
+Spec:
+  Allowed CIDRs:  10.0.0.0/8, 192.168.0.0/16
+  Env:
+    [0]:
+      Name:   FOO
+      Value:  bar
+  Git:
+    Revision:  main
+    Url:       https://example.org
+  Machine Type:  nine-db-s
+
+Conditions:
+  TYPE    STATUS
+  Ready   True
+`, out.String())
+}
@@ -51,11 +51,17 @@ func SuccessMessage(icon, message string) string {
 
 // PrintSuccessf prints a success message.
 func PrintSuccessf(icon, format string, a ...any) {
+	if Quiet() {
+		return
+	}
 	fmt.Print(SuccessMessagef(icon, format, a...) + "\n")
 }
 
 // PrintSuccess prints a success message.
 func PrintSuccess(icon, message string) {
+	if Quiet() {
+		return
+	}
 	fmt.Print(SuccessMessage(icon, message) + "\n")
 }
 
@@ -66,20 +72,36 @@ func FailureMessagef(icon, format string, a ...any) string {
 
 // PrintFailuref prints a failure message.
 func PrintFailuref(icon, format string, a ...any) {
+	if Quiet() {
+		return
+	}
 	fmt.Print(FailureMessagef(icon, format, a...) + "\n")
 }
 
+// PrintWarningf prints a warning. In quiet mode it is printed to stderr.
 func PrintWarningf(msg string, a ...any) {
-	fmt.Printf(color.YellowString("Warning: ")+msg, a...)
+	var out io.Writer = os.Stdout
+	if Quiet() {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, color.YellowString("Warning: ")+msg, a...)
 }
 
 // Confirm prints a confirm dialog using the supplied message and then waits
 // until prompt is confirmed or denied. Only y and yes are accepted for
-// confirmation.
+// confirmation. If AssumeYes is enabled, it returns true without prompting.
 func Confirm(message string) (bool, error) {
+	if AssumeYes() {
+		return true, nil
+	}
 	var input string
 
-	fmt.Printf("%s [y|n]: ", message)
+	// in quiet mode stdout only contains the output meant for scripts.
+	var out io.Writer = os.Stdout
+	if Quiet() {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, "%s [y|n]: ", message)
 	_, err := fmt.Scanln(&input)
 	if err != nil {
 		return false, err
@@ -104,7 +126,7 @@ func NewSpinner(message, stopMessage string) (*yacspin.Spinner, error) {
 }
 
 func spinnerConfig(message, stopMessage string) yacspin.Config {
-	return yacspin.Config{
+	cfg := yacspin.Config{
 		Frequency:         spinnerFrequency,
 		CharSet:           spinnerCharset,
 		Prefix:            spinnerPrefix,
@@ -114,6 +136,10 @@ func spinnerConfig(message, stopMessage string) yacspin.Config {
 		StopCharacter:     SuccessChar,
 		StopFailCharacter: FailureChar,
 	}
+	if Quiet() {
+		cfg.Writer = io.Discard
+	}
+	return cfg
 }
 
 const escape = "\x1b"
@@ -128,6 +154,22 @@ type PrintOpts struct {
 	Out io.Writer
 	// ExcludeAdditional allows to exclude more fields of the object
 	ExcludeAdditional [][]string
+	// Export additionally removes the status and all metadata which is
+	// specific to the current instance of the object, so the output can
+	// be applied again, e.g. in a different project.
+	Export bool
+}
+
+// exportExcludes are removed from objects when exporting them.
+var exportExcludes = [][]string{
+	{"status"},
+	{"metadata", "namespace"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+	{"metadata", "ownerReferences"},
+	{"metadata", "selfLink"},
+	{"spec", "writeConnectionSecretToRef"},
 }
 
 func (p PrintOpts) defaultOut() io.Writer {
@@ -173,6 +215,12 @@ func PrettyPrintObject(obj any, opts PrintOpts) error {
 		if err != nil {
 			return err
 		}
+		if opts.Export {
+			toPrint, err = exportObj(toPrint.(resource.Object))
+			if err != nil {
+				return err
+			}
+		}
 	}
 	// if we got an unstructured object passed we want to remove the
 	// 'object' key from the yaml
@@ -281,6 +329,34 @@ func stripObj(obj resource.Object, excludeAdditional [][]string) (resource.Objec
 	return obj, nil
 }
 
+// ExportObject returns a copy of the object without the status and all
+// metadata which is specific to this instance of the object, the same as
+// printed with PrintOpts.Export.
+func ExportObject(obj resource.Object) (*unstructured.Unstructured, error) {
+	stripped, err := stripObj(obj.DeepCopyObject().(resource.Object), nil)
+	if err != nil {
+		return nil, err
+	}
+	return exportObj(stripped)
+}
+
+// exportObj returns the object as unstructured with all fields removed which
+// can't be applied elsewhere. As typed objects can't omit their status, the
+// object needs to be converted.
+func exportObj(obj resource.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, exclude := range exportExcludes {
+		unstructured.RemoveNestedField(content, exclude...)
+	}
+	if spec, ok := content["spec"].(map[string]any); ok && len(spec) == 0 {
+		delete(content, "spec")
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
 func printerProperty(p *printer.Property) printer.PrintFunc {
 	return func() *printer.Property {
 		return p
@@ -0,0 +1,66 @@
+package format
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrettyPrintObjectExport(t *testing.T) {
+	pg := &storage.Postgres{
+		TypeMeta: metav1.TypeMeta{Kind: storage.PostgresKind, APIVersion: storage.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "db",
+			Namespace:         "project",
+			UID:               "4c4f5e8e-6a36-4bd4-a4b6-0a1f0a9c8c3d",
+			CreationTimestamp: metav1.Now(),
+			Labels:            map[string]string{"team": "web"},
+		},
+		Spec: storage.PostgresSpec{
+			ResourceSpec: runtimev1.ResourceSpec{
+				WriteConnectionSecretToReference: &runtimev1.SecretReference{Name: "db", Namespace: "project"},
+			},
+			ForProvider: storage.PostgresParameters{Location: "nine-es34"},
+		},
+	}
+	pg.Status.AtProvider.FQDN = "db.example.org"
+
+	out := &bytes.Buffer{}
+	require.NoError(t, PrettyPrintObject(pg, PrintOpts{Out: out, Export: true}))
+
+	assert.Contains(t, out.String(), "kind: Postgres")
+	assert.Contains(t, out.String(), "name: db")
+	assert.Contains(t, out.String(), "team: web")
+	assert.Contains(t, out.String(), "location: nine-es34")
+	for _, field := range []string{"status:", "namespace:", "uid:", "creationTimestamp:", "writeConnectionSecretToRef:", "db.example.org"} {
+		assert.NotContains(t, out.String(), field)
+	}
+
+	// without export the status is kept
+	out.Reset()
+	require.NoError(t, PrettyPrintObject(pg, PrintOpts{Out: out}))
+	assert.Contains(t, out.String(), "db.example.org")
+	assert.Contains(t, out.String(), "namespace: project")
+}
+
+func TestConfirmAssumeYes(t *testing.T) {
+	SetAssumeYes(true)
+	defer SetAssumeYes(false)
+
+	ok, err := Confirm("delete everything?")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestQuietSpinner(t *testing.T) {
+	SetQuiet(true)
+	defer SetQuiet(false)
+
+	assert.Equal(t, io.Discard, spinnerConfig("creating", "created").Writer)
+}
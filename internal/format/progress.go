@@ -0,0 +1,129 @@
+package format
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/theckman/yacspin"
+)
+
+// progressInterval is the interval in which a plain progress line is
+// printed if the output is not a terminal.
+var progressInterval = 30 * time.Second
+
+// Progress reports the progress of a long running operation like waiting
+// for a resource to become ready. On a terminal, a spinner shows the elapsed
+// time and the latest status. If the output is not a terminal, like in CI
+// logs, a plain line with the same information is printed periodically and
+// whenever the status changes.
+type Progress struct {
+	spinner     *yacspin.Spinner
+	message     string
+	status      string
+	start       time.Time
+	interval    time.Duration
+	interactive bool
+	mu          sync.Mutex
+	done        chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewProgress returns a new Progress showing message while running and
+// stopMessage once it is stopped.
+func NewProgress(message, stopMessage string) (*Progress, error) {
+	spinner, err := NewSpinner(message, stopMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	interactive := IsInteractiveEnvironment(os.Stdout)
+	interval := time.Second
+	if !interactive {
+		interval = progressInterval
+	}
+
+	return &Progress{
+		spinner:     spinner,
+		message:     message,
+		interval:    interval,
+		interactive: interactive,
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// Start starts showing the progress.
+func (p *Progress) Start() error {
+	p.mu.Lock()
+	p.start = time.Now()
+	p.mu.Unlock()
+
+	if err := p.spinner.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if len(p.message) != 0 {
+					p.spinner.Message(p.render())
+				}
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// SetStatus sets the latest status, e.g. the message of a condition, which
+// is shown next to the progress message. If the output is not a terminal, a
+// changed status is printed right away.
+func (p *Progress) SetStatus(status string) {
+	p.mu.Lock()
+	changed := status != p.status
+	started := !p.start.IsZero()
+	p.status = status
+	p.mu.Unlock()
+
+	if changed && started && !p.interactive && len(status) != 0 && len(p.message) != 0 {
+		p.spinner.Message(p.render())
+	}
+}
+
+// Stop stops the progress and prints the stop message.
+func (p *Progress) Stop() error {
+	p.stopUpdates()
+	return p.spinner.Stop()
+}
+
+// StopFail stops the progress and prints the fail message.
+func (p *Progress) StopFail() error {
+	p.stopUpdates()
+	return p.spinner.StopFail()
+}
+
+// StopFailMessage sets the message printed by StopFail.
+func (p *Progress) StopFailMessage(message string) {
+	p.spinner.StopFailMessage(message)
+}
+
+func (p *Progress) stopUpdates() {
+	p.stopOnce.Do(func() { close(p.done) })
+}
+
+func (p *Progress) render() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	msg := fmt.Sprintf("%s (%s)", p.message, time.Since(p.start).Round(time.Second))
+	if len(p.status) != 0 {
+		msg += ": " + p.status
+	}
+	return msg
+}
@@ -0,0 +1,26 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgress(t *testing.T) {
+	p, err := NewProgress("waiting for app", "app ready")
+	require.NoError(t, err)
+
+	p.start = time.Now().Add(-90 * time.Second)
+	assert.Equal(t, "waiting for app (1m30s)", p.render())
+
+	p.SetStatus("build is running")
+	assert.Equal(t, "waiting for app (1m30s): build is running", p.render())
+
+	// stopping multiple times must not panic
+	assert.NotPanics(t, func() {
+		p.stopUpdates()
+		p.stopUpdates()
+	})
+}
@@ -0,0 +1,16 @@
+package format
+
+import "regexp"
+
+// Redacted replaces sensitive values in the output.
+const Redacted = "<redacted>"
+
+// sensitiveName matches names which likely belong to secrets.
+var sensitiveName = regexp.MustCompile(`(?i)token|password|secret|key|credential`)
+
+// SensitiveName reports if the name of a value, e.g. of an env variable, a
+// flag or a query parameter, looks like the value is a secret, like
+// API_TOKEN or --basic-auth-password.
+func SensitiveName(name string) bool {
+	return sensitiveName.MatchString(name)
+}
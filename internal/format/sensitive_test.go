@@ -0,0 +1,16 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensitiveName(t *testing.T) {
+	for _, name := range []string{"API_TOKEN", "db_password", "--basic-auth-password", "SECRET_KEY_BASE", "access_key", "AWS_CREDENTIALS"} {
+		assert.True(t, SensitiveName(name), name)
+	}
+	for _, name := range []string{"RAILS_ENV", "PORT", "--git-url", "page"} {
+		assert.False(t, SensitiveName(name), name)
+	}
+}
@@ -0,0 +1,247 @@
+package format
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+)
+
+const (
+	tablePadding = 4
+	// minColumnWidth is the width up to which columns are truncated when the
+	// table does not fit the terminal.
+	minColumnWidth = 12
+	ellipsis       = "…"
+)
+
+// statusColors maps well known status values to the color they are printed
+// in.
+var statusColors = map[string]color.Attribute{
+	"true":              color.FgGreen,
+	"ready":             color.FgGreen,
+	"available":         color.FgGreen,
+	"success":           color.FgGreen,
+	"running":           color.FgGreen,
+	"false":             color.FgRed,
+	"error":             color.FgRed,
+	"failed":            color.FgRed,
+	"failure":           color.FgRed,
+	"replicafailure":    color.FgRed,
+	"imageuploadfailed": color.FgRed,
+	"progressing":       color.FgYellow,
+	"pending":           color.FgYellow,
+	"creating":          color.FgYellow,
+	"deleting":          color.FgYellow,
+	"unknown":           color.FgYellow,
+}
+
+// Table is an io.Writer which renders tab separated lines as an aligned
+// table, like a text/tabwriter. Optionally, the table is fit into the
+// terminal width by truncating the widest columns and well known status
+// values in the status columns are colored, see the TableOptions. Nothing is
+// written until Flush is called.
+type Table struct {
+	out      io.Writer
+	buf      bytes.Buffer
+	maxWidth int
+	truncate bool
+	color    bool
+	// statusColumns are the headers of the columns whose values are
+	// colored.
+	statusColumns []string
+}
+
+// TableOption allows to customize a Table.
+type TableOption func(*Table)
+
+// Truncate fits the table into the width of the terminal by truncating the
+// widest columns. As truncated values can not be copied anymore, it should
+// only be used for list views with free text like messages.
+func Truncate() TableOption {
+	return func(t *Table) {
+		t.truncate = true
+	}
+}
+
+// MaxWidth truncates the table to the given width instead of the terminal
+// width. Zero disables truncation.
+func MaxWidth(width int) TableOption {
+	return func(t *Table) {
+		t.truncate = width > 0
+		t.maxWidth = width
+	}
+}
+
+// StatusColumns colors well known status values in the columns with one of
+// the given headers. The header has to be the first line of the table.
+func StatusColumns(headers ...string) TableOption {
+	return func(t *Table) {
+		t.statusColumns = headers
+	}
+}
+
+// Colored enables or disables colored status values.
+func Colored(enabled bool) TableOption {
+	return func(t *Table) {
+		t.color = enabled
+	}
+}
+
+// NewTable returns a new Table writing to out. Width and colors are
+// detected from out, colors are disabled with NO_COLOR or --no-color.
+func NewTable(out io.Writer, opts ...TableOption) *Table {
+	t := &Table{out: out}
+	terminal := false
+	if f, ok := out.(*os.File); ok && isatty.IsTerminal(f.Fd()) {
+		terminal = true
+		t.color = !color.NoColor
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.truncate && t.maxWidth == 0 && terminal {
+		if width, _, err := term.GetSize(int(out.(*os.File).Fd())); err == nil {
+			t.maxWidth = width
+		}
+	}
+	return t
+}
+
+func (t *Table) Write(p []byte) (int, error) {
+	return t.buf.Write(p)
+}
+
+// Flush renders all written lines to the underlying writer.
+func (t *Table) Flush() error {
+	content := strings.TrimSuffix(t.buf.String(), "\n")
+	t.buf.Reset()
+	if len(content) == 0 {
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, line := range strings.Split(content, "\n") {
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+
+	widths := columnWidths(rows)
+	if t.truncate {
+		t.fit(widths)
+	}
+	status := t.statusColumnIndexes(rows[0])
+
+	var b strings.Builder
+	for r, row := range rows {
+		for i, cell := range row {
+			last := i == len(row)-1
+			if i < len(widths) && utf8.RuneCountInString(cell) > widths[i] {
+				cell = truncate(cell, widths[i])
+			}
+			padding := 0
+			if !last {
+				padding = widths[i] - utf8.RuneCountInString(cell) + tablePadding
+			}
+			if r > 0 && status[i] {
+				cell = t.colorize(cell)
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", padding))
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(t.out, b.String())
+	return err
+}
+
+// statusColumnIndexes returns the indexes of the status columns in the
+// header.
+func (t *Table) statusColumnIndexes(header []string) map[int]bool {
+	indexes := map[int]bool{}
+	for i, cell := range header {
+		if slices.Contains(t.statusColumns, strings.TrimSpace(cell)) {
+			indexes[i] = true
+		}
+	}
+	return indexes
+}
+
+// columnWidths returns the width of all columns which are terminated by a
+// tab. Like with a tabwriter, the last cell of a row is not aligned.
+func columnWidths(rows [][]string) []int {
+	widths := []int{}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := utf8.RuneCountInString(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	return widths
+}
+
+// fit shrinks the widest columns until the table fits the max width or no
+// column can be truncated any further.
+func (t *Table) fit(widths []int) {
+	if t.maxWidth <= 0 {
+		return
+	}
+
+	total := func() int {
+		sum := 0
+		for i, w := range widths {
+			sum += w
+			if i < len(widths)-1 {
+				sum += tablePadding
+			}
+		}
+		return sum
+	}
+
+	for excess := total() - t.maxWidth; excess > 0; excess = total() - t.maxWidth {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			return
+		}
+		widths[widest] = max(minColumnWidth, widths[widest]-excess)
+	}
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + ellipsis
+}
+
+func (t *Table) colorize(cell string) string {
+	if !t.color {
+		return cell
+	}
+	attr, ok := statusColors[strings.ToLower(strings.TrimSpace(cell))]
+	if !ok {
+		return cell
+	}
+	c := color.New(attr)
+	c.EnableColor()
+	return c.Sprint(cell)
+}
@@ -0,0 +1,100 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable(t *testing.T) {
+	lines := []string{
+		"PROJECT\tNAME\tSTATUS\tMESSAGE",
+		"default\tapp\tReady\tall good",
+		"default\tsome-longer-name\tError\tsomething went wrong",
+		"\t\t\t",
+	}
+
+	t.Run("aligned like a tabwriter", func(t *testing.T) {
+		want := &bytes.Buffer{}
+		w := tabwriter.NewWriter(want, 0, 0, 4, ' ', 0)
+		got := &bytes.Buffer{}
+		table := NewTable(got)
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+			fmt.Fprintln(table, line)
+		}
+		require.NoError(t, w.Flush())
+		require.NoError(t, table.Flush())
+		assert.Equal(t, want.String(), got.String())
+	})
+
+	t.Run("truncated to max width", func(t *testing.T) {
+		got := &bytes.Buffer{}
+		table := NewTable(got, MaxWidth(50))
+		for _, line := range lines[:3] {
+			fmt.Fprintln(table, line)
+		}
+		require.NoError(t, table.Flush())
+		for _, line := range strings.Split(strings.TrimSpace(got.String()), "\n") {
+			assert.LessOrEqual(t, len([]rune(line)), 50, line)
+		}
+		assert.Contains(t, got.String(), "some-longer-…")
+	})
+
+	t.Run("not truncated by default", func(t *testing.T) {
+		got := &bytes.Buffer{}
+		table := NewTable(got)
+		fmt.Fprintln(table, "NAME\tPASSWORD")
+		fmt.Fprintf(table, "app\t%s\n", strings.Repeat("x", 200))
+		require.NoError(t, table.Flush())
+		assert.Contains(t, got.String(), strings.Repeat("x", 200))
+	})
+
+	t.Run("colored status", func(t *testing.T) {
+		got := &bytes.Buffer{}
+		table := NewTable(got, Colored(true), StatusColumns("STATUS"))
+		for _, line := range lines[:3] {
+			fmt.Fprintln(table, line)
+		}
+		require.NoError(t, table.Flush())
+
+		green := color.New(color.FgGreen)
+		green.EnableColor()
+		red := color.New(color.FgRed)
+		red.EnableColor()
+		assert.Contains(t, got.String(), green.Sprint("Ready"))
+		assert.Contains(t, got.String(), red.Sprint("Error"))
+		assert.NotContains(t, got.String(), green.Sprint("app"))
+	})
+
+	t.Run("only status columns are colored", func(t *testing.T) {
+		got := &bytes.Buffer{}
+		table := NewTable(got, Colored(true), StatusColumns("STATUS"))
+		fmt.Fprintln(table, "NAME\tSTATUS\tMESSAGE")
+		fmt.Fprintln(table, "ready\tFailed\tError")
+		require.NoError(t, table.Flush())
+
+		red := color.New(color.FgRed)
+		red.EnableColor()
+		green := color.New(color.FgGreen)
+		green.EnableColor()
+		assert.Contains(t, got.String(), red.Sprint("Failed"))
+		assert.NotContains(t, got.String(), red.Sprint("Error"))
+		assert.NotContains(t, got.String(), green.Sprint("ready"))
+		assert.NotContains(t, got.String(), green.Sprint("STATUS"))
+	})
+
+	t.Run("not colored by default", func(t *testing.T) {
+		got := &bytes.Buffer{}
+		table := NewTable(got)
+		fmt.Fprintln(table, lines[1])
+		require.NoError(t, table.Flush())
+		assert.Equal(t, "default    app    Ready    all good\n", got.String())
+	})
+}
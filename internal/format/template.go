@@ -0,0 +1,58 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+)
+
+// PrintGoTemplate prints the supplied objects with the given Go template.
+// Like with kubectl, the template is executed with a list containing all
+// objects, e.g. "{{range .items}}{{.metadata.name}}{{end}}". All sprig
+// functions are available, as well as toYaml and ago.
+func PrintGoTemplate[T any](objs []T, text string, opts PrintOpts) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid go template: %w", err)
+	}
+
+	items := make([]any, len(objs))
+	for i, obj := range objs {
+		data, err := toJSONData(obj)
+		if err != nil {
+			return err
+		}
+		items[i] = data
+	}
+
+	if err := tmpl.Execute(opts.defaultOut(), map[string]any{
+		"kind":  "List",
+		"items": items,
+	}); err != nil {
+		return fmt.Errorf("unable to execute go template: %w", err)
+	}
+	return nil
+}
+
+func templateFuncs() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	funcs["toYaml"] = func(v any) (string, error) {
+		b, err := yaml.Marshal(v)
+		return strings.TrimSuffix(string(b), "\n"), err
+	}
+	// ago returns the human readable duration since the passed RFC3339
+	// timestamp, e.g. "{{ago .metadata.creationTimestamp}}".
+	funcs["ago"] = func(timestamp string) (string, error) {
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return "", err
+		}
+		return duration.HumanDuration(time.Since(t)), nil
+	}
+	return funcs
+}
@@ -0,0 +1,39 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPrintGoTemplate(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		{Object: map[string]any{"metadata": map[string]any{
+			"name":              "first",
+			"creationTimestamp": time.Now().Add(-5 * time.Hour).Format(time.RFC3339),
+		}}},
+		{Object: map[string]any{"metadata": map[string]any{
+			"name":   "second",
+			"labels": map[string]any{"team": "web"},
+		}}},
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, PrintGoTemplate(objs, `{{range .items}}{{.metadata.name | upper}} {{.metadata.labels.team | default "none"}}{{"\n"}}{{end}}`, PrintOpts{Out: out}))
+	assert.Equal(t, "FIRST none\nSECOND web\n", out.String())
+
+	out.Reset()
+	require.NoError(t, PrintGoTemplate(objs[:1], `{{range .items}}{{ago .metadata.creationTimestamp}}{{end}}`, PrintOpts{Out: out}))
+	assert.Equal(t, "5h", out.String())
+
+	out.Reset()
+	require.NoError(t, PrintGoTemplate(objs[1:], `{{range .items}}{{toYaml .metadata.labels}}{{end}}`, PrintOpts{Out: out}))
+	assert.Equal(t, "team: web", out.String())
+
+	assert.Error(t, PrintGoTemplate(objs, `{{range .items}`, PrintOpts{Out: out}))
+	assert.Error(t, PrintGoTemplate(objs, `{{fail "nope"}}`, PrintOpts{Out: out}))
+}
@@ -0,0 +1,31 @@
+package format
+
+import "sync/atomic"
+
+var (
+	quiet     atomic.Bool
+	assumeYes atomic.Bool
+)
+
+// SetQuiet enables or disables the quiet output tier. In quiet mode, success
+// and failure messages as well as spinners are not printed and warnings are
+// printed to stderr, so that stdout only contains the output meant for
+// scripts.
+func SetQuiet(enabled bool) {
+	quiet.Store(enabled)
+}
+
+// Quiet reports if the quiet output tier is enabled.
+func Quiet() bool {
+	return quiet.Load()
+}
+
+// SetAssumeYes makes all confirm dialogs return true without prompting.
+func SetAssumeYes(enabled bool) {
+	assumeYes.Store(enabled)
+}
+
+// AssumeYes reports if confirm dialogs are answered with yes automatically.
+func AssumeYes() bool {
+	return assumeYes.Load()
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/grafana/loki/pkg/logcli/output"
 	"github.com/grafana/loki/pkg/loghttp"
+	"github.com/ninech/nctl/internal/format"
 )
 
 var appStyle = lipgloss.NewStyle().Margin(0, 2, 1, 1)
@@ -101,7 +102,7 @@ type Output struct {
 }
 
 func (f *Output) FormatAndPrintln(ts time.Time, lbls loghttp.LabelSet, maxLabelsLen int, line string) {
-	timestamp := ts.In(time.Local).Format(time.RFC3339)
+	timestamp := format.Timestamp(ts)
 	line = strings.TrimSpace(line)
 
 	// we delay the send to the terminal slightly to make the log output look
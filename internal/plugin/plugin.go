@@ -0,0 +1,89 @@
+// Package plugin allows to extend nctl with custom subcommands. Like with
+// kubectl, running "nctl foo bar" executes a binary named "nctl-foo-bar" or
+// "nctl-foo" found in the PATH, if foo is not a built-in command.
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+)
+
+// Prefix is the prefix of all plugin binaries.
+const Prefix = "nctl-"
+
+// lookPath is used to find plugin binaries, it can be replaced in tests.
+var lookPath = exec.LookPath
+
+// Find returns the path of the plugin binary for the passed command line
+// arguments and the arguments which should be passed to it. The longest
+// matching plugin name wins, so "nctl foo bar" prefers "nctl-foo-bar" over
+// "nctl-foo". No plugin is returned for built-in commands and flags.
+func Find(args []string, builtin func(name string) bool) (string, []string, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || builtin(args[0]) {
+		return "", nil, false
+	}
+
+	names := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		names = append(names, arg)
+	}
+
+	for i := len(names); i > 0; i-- {
+		path, err := lookPath(Prefix + strings.Join(names[:i], "-"))
+		if err == nil {
+			return path, args[i:], true
+		}
+	}
+	return "", nil, false
+}
+
+// Env returns the environment variables which pass the current API context
+// to a plugin. If client is nil, e.g. as the user is not logged in, only the
+// environment of nctl is passed.
+func Env(ctx context.Context, client *api.Client) []string {
+	env := os.Environ()
+	if client == nil {
+		return env
+	}
+
+	vars := map[string]string{
+		"NCTL_API_HOST":    client.Config.Host,
+		"NCTL_API_CLUSTER": client.KubeconfigContext,
+		"NCTL_KUBECONFIG":  client.KubeconfigPath,
+		"NCTL_PROJECT":     client.Project,
+		"NCTL_TOKEN":       client.Token(ctx),
+	}
+	if org, err := client.Organization(); err == nil {
+		vars["NCTL_ORGANIZATION"] = org
+	}
+	for k, v := range vars {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+// Run runs the plugin and returns its exit code.
+func Run(ctx context.Context, path string, args, env []string) (int, error) {
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return 1, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	plugins := map[string]bool{"nctl-foo": true, "nctl-foo-bar": true}
+	lookPath = func(file string) (string, error) {
+		if plugins[file] {
+			return "/bin/" + file, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	defer func() { lookPath = exec.LookPath }()
+	builtin := func(name string) bool { return name == "get" }
+
+	for _, tc := range []struct {
+		args     []string
+		wantPath string
+		wantArgs []string
+	}{
+		{args: []string{"foo", "baz", "--flag"}, wantPath: "/bin/nctl-foo", wantArgs: []string{"baz", "--flag"}},
+		{args: []string{"foo", "bar", "baz"}, wantPath: "/bin/nctl-foo-bar", wantArgs: []string{"baz"}},
+		{args: []string{"get", "apps"}},
+		{args: []string{"--help"}},
+		{args: []string{"unknown"}},
+		{args: []string{}},
+	} {
+		path, args, ok := Find(tc.args, builtin)
+		assert.Equal(t, tc.wantPath != "", ok, tc.args)
+		assert.Equal(t, tc.wantPath, path, tc.args)
+		if ok {
+			assert.Equal(t, tc.wantArgs, args, tc.args)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "nctl-exit")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n[ \"$NCTL_PROJECT\" = \"$1\" ] || exit 1\nexit 3\n"), 0o755))
+
+	code, err := Run(context.Background(), path, []string{"proj"}, append(Env(context.Background(), nil), "NCTL_PROJECT=proj"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, code)
+
+	_, err = Run(context.Background(), filepath.Join(t.TempDir(), "missing"), nil, nil)
+	assert.Error(t, err)
+}
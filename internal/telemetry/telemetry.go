@@ -0,0 +1,87 @@
+// Package telemetry exports traces of commands and API calls with
+// OpenTelemetry, e.g. to correlate CLI latency with API-side traces.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterEnv selects the trace exporter. Tracing is disabled unless it
+	// is set to ExporterOTLP.
+	ExporterEnv = "NCTL_OTEL_EXPORTER"
+	// ExporterOTLP exports traces via OTLP/HTTP. The collector is
+	// configured with the standard OTEL_EXPORTER_OTLP_* environment
+	// variables and defaults to localhost:4318.
+	ExporterOTLP = "otlp"
+
+	tracerName = "github.com/ninech/nctl"
+)
+
+// Enabled reports if traces should be exported.
+func Enabled() bool {
+	return os.Getenv(ExporterEnv) == ExporterOTLP
+}
+
+// Setup configures the global tracer provider if tracing is enabled. The
+// returned func flushes all spans and has to be called before exiting.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	switch exporter := os.Getenv(ExporterEnv); exporter {
+	case "":
+		return noop, nil
+	case ExporterOTLP:
+	default:
+		return noop, fmt.Errorf("unsupported %s %q, only %q is supported", ExporterEnv, exporter, ExporterOTLP)
+	}
+
+	exp, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("unable to create OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("nctl"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return noop, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// StartCommand starts the root span of a command. If tracing is disabled,
+// the span is a no-op.
+func StartCommand(ctx context.Context, command string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "nctl "+command,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("nctl.command", command)),
+	)
+}
+
+// EndCommand ends the root span of a command and records its error.
+func EndCommand(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+
+	t.Setenv(ExporterEnv, "")
+	shutdown, err := Setup(ctx, "1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(ctx))
+	assert.False(t, Enabled())
+
+	t.Setenv(ExporterEnv, "jaeger")
+	_, err = Setup(ctx, "1.0.0")
+	assert.ErrorContains(t, err, "unsupported")
+
+	var received atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			received.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	t.Setenv(ExporterEnv, ExporterOTLP)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	assert.True(t, Enabled())
+	shutdown, err = Setup(ctx, "1.0.0")
+	require.NoError(t, err)
+
+	spanCtx, span := StartCommand(ctx, "get apps")
+	assert.True(t, span.SpanContext().IsValid())
+	_, child := otel.Tracer(tracerName).Start(spanCtx, "request")
+	assert.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+	child.End()
+	EndCommand(span, errors.New("failed"))
+
+	require.NoError(t, shutdown(ctx))
+	assert.Equal(t, int32(1), received.Load())
+}
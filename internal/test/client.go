@@ -123,6 +123,16 @@ func WithInterceptorFuncs(f interceptor.Funcs) ClientSetupOption {
 	}
 }
 
+// Organization returns the organization a client would be set up with by
+// the given options.
+func Organization(opts ...ClientSetupOption) string {
+	setup := defaultClientSetup()
+	for _, opt := range opts {
+		opt(setup)
+	}
+	return setup.organization
+}
+
 func SetupClient(opts ...ClientSetupOption) (*api.Client, error) {
 	setup := defaultClientSetup()
 	for _, opt := range opts {
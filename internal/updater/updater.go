@@ -0,0 +1,288 @@
+// Package updater checks for new releases of nctl on GitHub and replaces
+// the running binary with a verified release binary.
+package updater
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	defaultBaseURL = "https://api.github.com"
+	repository     = "ninech/nctl"
+	binaryName     = "nctl"
+	checksumsFile  = "checksums.txt"
+)
+
+// goos is the OS Replace handles the binary for, it can be changed in tests.
+var goos = runtime.GOOS
+
+// Release is a GitHub release of nctl.
+type Release struct {
+	TagName string  `json:"tag_name"`
+	URL     string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a downloadable file of a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Version returns the version of the release without the "v" prefix, like
+// it is used in the release artifacts.
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+func (r *Release) asset(name string) (Asset, error) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return Asset{}, fmt.Errorf("release %s has no asset %s", r.TagName, name)
+}
+
+// Updater fetches releases from GitHub.
+type Updater struct {
+	client  *http.Client
+	baseURL string
+}
+
+// Option allows to customize an Updater.
+type Option func(*Updater)
+
+// BaseURL sets the URL of the GitHub API.
+func BaseURL(url string) Option {
+	return func(u *Updater) {
+		u.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// HTTPClient sets the http client which is used for all requests.
+func HTTPClient(client *http.Client) Option {
+	return func(u *Updater) {
+		u.client = client
+	}
+}
+
+// New returns a new Updater.
+func New(opts ...Option) *Updater {
+	u := &Updater{client: http.DefaultClient, baseURL: defaultBaseURL}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Latest returns the latest release.
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	body, err := u.get(ctx, fmt.Sprintf("%s/repos/%s/releases/latest", u.baseURL, repository))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get latest release: %w", err)
+	}
+
+	release := &Release{}
+	if err := json.Unmarshal(body, release); err != nil {
+		return nil, fmt.Errorf("unable to parse latest release: %w", err)
+	}
+	return release, nil
+}
+
+// IsNewer reports if the latest version is newer than the current one.
+// Versions are compared as semantic versions, with or without a "v" prefix.
+func IsNewer(current, latest string) (bool, error) {
+	c, l := canonical(current), canonical(latest)
+	if !semver.IsValid(c) {
+		return false, fmt.Errorf("current version %q is not a release version", current)
+	}
+	if !semver.IsValid(l) {
+		return false, fmt.Errorf("latest version %q is not a valid version", latest)
+	}
+	return semver.Compare(c, l) < 0, nil
+}
+
+func canonical(version string) string {
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
+// ArchiveName returns the name of the release archive for the platform, as
+// built by goreleaser.
+func ArchiveName(version, goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s.%s", binaryName, version, goos, goarch, ext)
+}
+
+// Download downloads the release archive for the platform, verifies it
+// against the published checksums and returns the contained binary.
+func (u *Updater) Download(ctx context.Context, release *Release, goos, goarch string) ([]byte, error) {
+	archiveName := ArchiveName(release.Version(), goos, goarch)
+	archiveAsset, err := release.asset(archiveName)
+	if err != nil {
+		return nil, err
+	}
+	checksumsAsset, err := release.asset(checksumsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums, err := u.get(ctx, checksumsAsset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download checksums: %w", err)
+	}
+	want, err := findChecksum(checksums, archiveName)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := u.get(ctx, archiveAsset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download %s: %w", archiveName, err)
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archiveName, want, got)
+	}
+
+	if goos == "windows" {
+		return extractZip(archive, binaryName+".exe")
+	}
+	return extractTarGz(archive, binaryName)
+}
+
+// Replace atomically replaces the binary at path with the passed content.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+binaryName+"-update-")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	if goos != "windows" {
+		return os.Rename(tmp.Name(), path)
+	}
+	// Windows does not allow to replace a running executable, but it can be
+	// renamed. The old binary is removed on the next update.
+	old := path + ".old"
+	if err := os.Remove(old); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to remove the previous binary %s: %w", old, err)
+	}
+	if err := os.Rename(path, old); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		// restore the running binary so nctl stays usable.
+		return errors.Join(err, os.Rename(old, path))
+	}
+	return nil
+}
+
+func (u *Updater) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// findChecksum returns the sha256 checksum of the file from a checksums file
+// in the format of sha256sum.
+func findChecksum(checksums []byte, file string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == file {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("no checksum found for %s", file)
+}
+
+func extractTarGz(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+	return nil, fmt.Errorf("binary %s not found in archive", name)
+}
+
+func extractZip(archive []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if filepath.Base(f.Name) != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("binary %s not found in archive", name)
+}
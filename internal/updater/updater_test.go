@@ -0,0 +1,162 @@
+package updater
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNewer(t *testing.T) {
+	for _, tc := range []struct {
+		current, latest string
+		want, wantErr   bool
+	}{
+		{current: "1.2.0", latest: "v1.3.0", want: true},
+		{current: "v1.3.0", latest: "v1.3.0"},
+		{current: "1.10.0", latest: "v1.9.1"},
+		{current: "(devel)", latest: "v1.9.1", wantErr: true},
+	} {
+		got, err := IsNewer(tc.current, tc.latest)
+		if tc.wantErr {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, "%s -> %s", tc.current, tc.latest)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	binary := []byte("new nctl binary")
+	archive := tarGz(t, map[string][]byte{"README.md": []byte("readme"), "nctl": binary})
+	archiveName := ArchiveName("1.3.0", "linux", "amd64")
+
+	checksum := func(b []byte) string {
+		sum := sha256.Sum256(b)
+		return hex.EncodeToString(sum[:])
+	}
+
+	tests := map[string]struct {
+		checksums string
+		wantErr   bool
+	}{
+		"valid checksum": {
+			checksums: fmt.Sprintf("%s  other.tar.gz\n%s  %s\n", checksum(nil), checksum(archive), archiveName),
+		},
+		"checksum mismatch": {
+			checksums: fmt.Sprintf("%s  %s\n", checksum([]byte("tampered")), archiveName),
+			wantErr:   true,
+		},
+		"missing checksum": {
+			checksums: fmt.Sprintf("%s  other.tar.gz\n", checksum(archive)),
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/ninech/nctl/releases/latest":
+					require.NoError(t, json.NewEncoder(w).Encode(Release{
+						TagName: "v1.3.0",
+						Assets: []Asset{
+							{Name: archiveName, URL: server.URL + "/download/" + archiveName},
+							{Name: checksumsFile, URL: server.URL + "/download/" + checksumsFile},
+						},
+					}))
+				case "/download/" + archiveName:
+					_, _ = w.Write(archive)
+				case "/download/" + checksumsFile:
+					_, _ = w.Write([]byte(tc.checksums))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			u := New(BaseURL(server.URL))
+			release, err := u.Latest(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "1.3.0", release.Version())
+
+			got, err := u.Download(ctx, release, "linux", "amd64")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, binary, got)
+
+			_, err = u.Download(ctx, release, "darwin", "arm64")
+			assert.Error(t, err, "missing asset")
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nctl")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o755))
+
+	require.NoError(t, Replace(path, []byte("new")))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+}
+
+func TestReplaceWindows(t *testing.T) {
+	defer func(orig string) { goos = orig }(goos)
+	goos = "windows"
+
+	path := filepath.Join(t.TempDir(), "nctl.exe")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o755))
+	require.NoError(t, os.WriteFile(path+".old", []byte("older"), 0o755))
+
+	require.NoError(t, Replace(path, []byte("new")))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	// the running binary is moved aside, replacing the one of the last update
+	content, err = os.ReadFile(path + ".old")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+}
+
+func tarGz(t *testing.T, files map[string][]byte) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o755,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
@@ -2,7 +2,12 @@ package logs
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,11 +15,14 @@ import (
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/api/log"
+	"github.com/ninech/nctl/internal/format"
 )
 
 type Cmd struct {
-	Applications applicationCmd `cmd:"" group:"deplo.io" name:"application" aliases:"app,application" help:"Get deplo.io Application logs."`
-	Builds       buildCmd       `cmd:"" group:"deplo.io" name:"build" help:"Get deplo.io Build logs."`
+	Applications applicationCmd `cmd:"" group:"deplo.io" name:"application" aliases:"app,apps" help:"Get deplo.io Application logs."`
+	Builds       buildCmd       `cmd:"" group:"deplo.io" name:"build" aliases:"builds" help:"Get deplo.io Build logs."`
+	Releases     releaseCmd     `cmd:"" group:"deplo.io" name:"release" aliases:"releases" help:"Get deplo.io Release logs."`
+	Query        queryCmd       `cmd:"" name:"query" help:"Get logs matching a raw LogQL query."`
 }
 
 type resourceCmd struct {
@@ -22,14 +30,18 @@ type resourceCmd struct {
 }
 
 type logsCmd struct {
-	Follow   bool          `help:"Follow the logs by live tailing." short:"f"`
-	Lines    int           `help:"Amount of lines to output" default:"50" short:"l"`
-	Since    time.Duration `help:"Duration how long to look back for logs" short:"s" default:"${log_retention}"`
-	From     time.Time     `help:"Ignore since flag and start looking for logs at this absolute time (RFC3339)" placeholder:"2025-01-01T14:00:00+01:00"`
-	To       time.Time     `help:"Ignore since flag and stop looking for logs at this absolute time (RFC3339)" placeholder:"2025-01-01T15:00:00+01:00"`
-	Output   string        `help:"Configures the log output format. ${enum}" short:"o" enum:"default,json" default:"default"`
-	NoLabels bool          `help:"disable labels in log output"`
-	out      log.Output
+	Follow        bool          `help:"Follow the logs by live tailing." short:"f"`
+	Lines         int           `help:"Amount of lines to output" default:"50" short:"l"`
+	Since         time.Duration `help:"Duration how long to look back for logs" short:"s" default:"${log_retention}"`
+	From          timestamp     `help:"Ignore since flag and start looking for logs at this time. Either absolute (RFC3339) or relative, e.g. \"2h ago\"." placeholder:"2025-01-01T14:00:00+01:00"`
+	To            timestamp     `help:"Ignore since flag and stop looking for logs at this time. Either absolute (RFC3339) or relative, e.g. \"1h ago\"." placeholder:"2025-01-01T15:00:00+01:00"`
+	Output        string        `help:"Configures the log output format. ${enum}" short:"o" enum:"default,json" default:"default"`
+	OutputFile    string        `help:"Write the logs to this file instead of stdout." type:"path" placeholder:"FILE"`
+	NoLabels      bool          `help:"disable labels in log output"`
+	Redact        []string      `sep:"none" help:"Replace text matching this regular expression with <redacted> before the lines are printed or written to a file, e.g. 'password=\\S+'. If it has capture groups, only the text matched by the groups is replaced, e.g. 'password=(\\S+)'. Bearer tokens and email addresses are redacted as well." placeholder:"REGEX"`
+	RedactBuiltin bool          `help:"Only redact bearer tokens and email addresses, see --redact."`
+	Stats         bool          `help:"Print the amount of lines per level and source and their distribution over time instead of the lines. The most recent ${log_stats_lines} lines, or --lines if higher, are considered."`
+	out           log.Output
 }
 
 // 30 days, we hardcode this for now as it's not possible to customize this on
@@ -37,17 +49,23 @@ type logsCmd struct {
 var logRetention = time.Duration(time.Hour * 24 * 30)
 
 func (cmd *logsCmd) Run(ctx context.Context, client *api.Client, queryString string, labels ...string) error {
+	if cmd.Stats && cmd.Follow {
+		return errors.New("--stats can not be combined with --follow")
+	}
 	now := time.Now()
 	start, end := now.Add(-cmd.Since), now
 	if !cmd.From.IsZero() {
-		start = cmd.From
+		start = cmd.From.Time
 	}
 	if !cmd.To.IsZero() {
-		end = cmd.To
+		end = cmd.To.Time
 	}
 	if now.Sub(start) > logRetention {
 		return fmt.Errorf("the logs requested exceed the retention period of %.f days", logRetention.Hours()/24)
 	}
+	if end.Before(start) {
+		return fmt.Errorf("the start time %s needs to be before the end time %s", format.Timestamp(start), format.Timestamp(end))
+	}
 
 	query := log.Query{
 		QueryString: queryString,
@@ -58,7 +76,17 @@ func (cmd *logsCmd) Run(ctx context.Context, client *api.Client, queryString str
 		Quiet:       true,
 	}
 
-	out, err := log.NewStdOut(log.Mode(cmd.Output), cmd.NoLabels, labels...)
+	var w io.Writer = os.Stdout
+	if cmd.OutputFile != "" {
+		f, err := os.Create(cmd.OutputFile)
+		if err != nil {
+			return fmt.Errorf("unable to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	out, err := log.NewOutput(w, log.Mode(cmd.Output), cmd.NoLabels, labels...)
 	if err != nil {
 		return err
 	}
@@ -67,6 +95,26 @@ func (cmd *logsCmd) Run(ctx context.Context, client *api.Client, queryString str
 		out = cmd.out
 	}
 
+	if len(cmd.Redact) != 0 || cmd.RedactBuiltin {
+		redactor, err := log.NewRedactor(append(slices.Clone(log.BuiltinRedactions), cmd.Redact...)...)
+		if err != nil {
+			return err
+		}
+		out = log.NewRedactedOutput(out, redactor)
+	}
+
+	if cmd.Stats {
+		stats := newStatsOutput(w, start, end)
+		query.Limit = max(query.Limit, statsLimit)
+		if err := client.Log.QueryRange(ctx, stats, query); err != nil {
+			return err
+		}
+		if stats.LineCount() == 0 {
+			return fmt.Errorf("no logs found between %s and %s", format.Timestamp(start), format.Timestamp(end))
+		}
+		return stats.print()
+	}
+
 	if cmd.Follow {
 		return client.Log.TailQuery(ctx, 0, out, query)
 	}
@@ -75,12 +123,54 @@ func (cmd *logsCmd) Run(ctx context.Context, client *api.Client, queryString str
 		return err
 	}
 	if out.LineCount() == 0 {
-		return fmt.Errorf("no logs found between %s and %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+		return fmt.Errorf("no logs found between %s and %s", format.Timestamp(start), format.Timestamp(end))
 	}
 
 	return nil
 }
 
+// timestamp is a point in time which can either be given as RFC3339 or
+// relative to now, e.g. "2h ago" or "3d ago".
+type timestamp struct {
+	time.Time
+}
+
+func (t *timestamp) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "now" {
+		t.Time = time.Now()
+		return nil
+	}
+	if ago, ok := strings.CutSuffix(s, " ago"); ok {
+		d, err := parseDuration(strings.TrimSpace(ago))
+		if err != nil {
+			return fmt.Errorf("invalid relative time %q: %w", s, err)
+		}
+		t.Time = time.Now().Add(-d)
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("invalid time %q, expected RFC3339 or a relative time like \"2h ago\"", s)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// parseDuration parses a duration which additionally supports days, e.g.
+// "3d".
+func parseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	return time.ParseDuration(s)
+}
+
 type queryOperator string
 
 const (
@@ -104,5 +194,6 @@ func inProject(project string) string {
 func KongVars() kong.Vars {
 	result := make(kong.Vars)
 	result["log_retention"] = logRetention.String()
+	result["log_stats_lines"] = strconv.Itoa(statsLimit)
 	return result
 }
@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -12,7 +14,10 @@ import (
 	apps "github.com/ninech/apis/apps/v1alpha1"
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/api/log"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestRun(t *testing.T) {
@@ -77,8 +82,8 @@ func TestRun(t *testing.T) {
 				Output: "default",
 				Lines:  len(lines),
 				Since:  logRetention * 2,
-				From:   time.Now().Add(-time.Hour),
-				To:     time.Now(),
+				From:   timestamp{time.Now().Add(-time.Hour)},
+				To:     timestamp{time.Now()},
 			},
 			expectedLines: len(lines),
 		},
@@ -87,7 +92,7 @@ func TestRun(t *testing.T) {
 				Output: "default",
 				Lines:  len(lines),
 				Since:  logRetention * 2,
-				From:   time.Now().Add(-time.Hour),
+				From:   timestamp{time.Now().Add(-time.Hour)},
 			},
 			expectedLines: len(lines),
 		},
@@ -140,3 +145,109 @@ func TestMatchLabels(t *testing.T) {
 		`{app="some-app",namespace="default"}`,
 	)
 }
+
+func TestReleaseCmd(t *testing.T) {
+	ctx := context.Background()
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "app-release",
+			Namespace:         test.DefaultProject,
+			Labels:            map[string]string{util.ApplicationNameLabel: "app"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(release), test.WithNameIndexFor(&apps.Release{}))
+	assert.NoError(t, err)
+	apiClient.Log = &log.Client{Client: log.NewFake(t, time.Now(), "log line")}
+
+	for name, cmd := range map[string]releaseCmd{
+		"by name":        {resourceCmd: resourceCmd{Name: "app-release"}},
+		"by application": {ApplicationName: "app"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			out, err := log.NewOutput(&bytes.Buffer{}, log.Mode("default"), true)
+			assert.NoError(t, err)
+			cmd.out = out
+			cmd.Output = "default"
+			cmd.Lines = 10
+			cmd.Since = logRetention
+
+			assert.NoError(t, cmd.Run(ctx, apiClient))
+			assert.Equal(t, 1, out.LineCount())
+			assert.Less(t, cmd.Since, 2*time.Hour)
+		})
+	}
+
+	assert.Error(t, (&releaseCmd{}).Run(ctx, apiClient))
+	assert.Equal(t, `{namespace="default",release="app-release"}`, ReleaseQuery("app-release", "default"))
+}
+
+func TestQueryCmd(t *testing.T) {
+	apiClient := &api.Client{
+		Project: "default",
+		Log:     &log.Client{Client: log.NewFake(t, time.Now(), "panic: oops")},
+	}
+	out, err := log.NewOutput(&bytes.Buffer{}, log.Mode("default"), true)
+	assert.NoError(t, err)
+
+	cmd := queryCmd{Query: `{app="myapp"} |= "panic"`, logsCmd: logsCmd{Output: "default", Lines: 10, Since: time.Hour, out: out}}
+	assert.NoError(t, cmd.Run(context.Background(), apiClient))
+	assert.Equal(t, 1, out.LineCount())
+}
+
+func TestRunOutputFile(t *testing.T) {
+	apiClient := &api.Client{
+		Project: "default",
+		Log:     &log.Client{Client: log.NewFake(t, time.Now(), "first", "second")},
+	}
+	path := filepath.Join(t.TempDir(), "app.log")
+	cmd := logsCmd{Output: "default", Lines: 10, NoLabels: true, OutputFile: path}
+	assert.NoError(t, cmd.Run(context.Background(), apiClient, ApplicationQuery("app", "default")))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "first")
+	assert.Contains(t, string(content), "second")
+}
+
+func TestRunRedact(t *testing.T) {
+	apiClient := &api.Client{
+		Project: "default",
+		Log: &log.Client{Client: log.NewFake(t, time.Now(),
+			"login user=jane@example.com password=hunter2",
+			"GET /api Authorization: Bearer eyJhbGciOi.abc-123",
+			"nothing to see here",
+		)},
+	}
+	path := filepath.Join(t.TempDir(), "app.log")
+	cmd := logsCmd{Output: "default", Lines: 10, NoLabels: true, OutputFile: path, Redact: []string{`password=(\S+)`}}
+	assert.NoError(t, cmd.Run(context.Background(), apiClient, ApplicationQuery("app", "default")))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "login user=<redacted> password=<redacted>")
+	assert.Contains(t, string(content), "Authorization: Bearer <redacted>")
+	assert.Contains(t, string(content), "nothing to see here")
+	assert.NotContains(t, string(content), "hunter2")
+
+	cmd.Redact = []string{"("}
+	assert.ErrorContains(t, cmd.Run(context.Background(), apiClient, ApplicationQuery("app", "default")), "invalid redaction pattern")
+}
+
+func TestTimestamp(t *testing.T) {
+	ts := timestamp{}
+	assert.NoError(t, ts.UnmarshalText([]byte("2025-01-01T14:00:00+01:00")))
+	assert.Equal(t, time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC), ts.UTC())
+
+	assert.NoError(t, ts.UnmarshalText([]byte("2h ago")))
+	assert.WithinDuration(t, time.Now().Add(-2*time.Hour), ts.Time, time.Second)
+
+	assert.NoError(t, ts.UnmarshalText([]byte("3d ago")))
+	assert.WithinDuration(t, time.Now().Add(-72*time.Hour), ts.Time, time.Second)
+
+	assert.NoError(t, ts.UnmarshalText([]byte("now")))
+	assert.WithinDuration(t, time.Now(), ts.Time, time.Second)
+
+	assert.Error(t, ts.UnmarshalText([]byte("yesterday")))
+	assert.Error(t, ts.UnmarshalText([]byte("2x ago")))
+}
@@ -0,0 +1,30 @@
+package logs
+
+import (
+	"context"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+)
+
+type queryCmd struct {
+	Query string `arg:"" help:"LogQL log query which is passed to the logging API as is, e.g. '{app=\"myapp\"} |= \"panic\"'. Metric queries are not supported."`
+	logsCmd
+}
+
+func (cmd *queryCmd) Help() string {
+	return `Queries the logs with a raw LogQL expression. See
+https://grafana.com/docs/loki/latest/query/log_queries/ for the syntax.
+The available labels are namespace, app, build, release, replica,
+worker_job, deploy_job and scheduled_job.
+
+  nctl logs query '{app="myapp"} |= "panic"' --since 6h
+`
+}
+
+func (cmd *queryCmd) Run(ctx context.Context, client *api.Client) error {
+	return cmd.logsCmd.Run(ctx, client, cmd.Query,
+		apps.LogLabelApplication, apps.LogLabelBuild, apps.LogLabelRelease, apps.LogLabelReplica,
+		apps.LogLabelWorkerJob, apps.LogLabelDeployJob, apps.LogLabelScheduledJob,
+	)
+}
@@ -0,0 +1,52 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+)
+
+type releaseCmd struct {
+	resourceCmd
+	logsCmd
+	ApplicationName string `short:"a" help:"Name of the application to get the logs of its latest release for."`
+}
+
+func (cmd *releaseCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.Name == "" && cmd.ApplicationName == "" {
+		return errors.New("please specify a release name or an application name to see release logs from")
+	}
+
+	release := &apps.Release{}
+	if cmd.Name != "" {
+		if err := client.GetObject(ctx, cmd.Name, release); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		release, err = util.ApplicationLatestRelease(ctx, client, client.Name(cmd.ApplicationName))
+		if err != nil {
+			return err
+		}
+	}
+
+	// the replicas of a release can't have logged anything before it was
+	// created, so there is no need to look back further.
+	if age := time.Since(release.CreationTimestamp.Time); age < cmd.Since {
+		cmd.Since = age
+	}
+
+	return cmd.logsCmd.Run(ctx, client, ReleaseQuery(release.Name, client.Project),
+		apps.LogLabelReplica, apps.LogLabelWorkerJob, apps.LogLabelDeployJob, apps.LogLabelScheduledJob,
+	)
+}
+
+// ReleaseQuery returns the query for the logs of the replicas and jobs of a
+// release.
+func ReleaseQuery(name, project string) string {
+	return buildQuery(inProject(project), queryExpr(opEquals, apps.LogLabelRelease, name))
+}
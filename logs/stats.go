@@ -0,0 +1,187 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/grafana/loki/pkg/logcli/output"
+	"github.com/grafana/loki/pkg/loghttp"
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	// statsLimit is the minimum amount of lines the stats are computed from.
+	statsLimit = 5000
+	// sparklineBuckets is the amount of time buckets in the sparkline.
+	sparklineBuckets = 40
+	unknown          = "unknown"
+)
+
+var (
+	sparklineChars = []rune("▁▂▃▄▅▆▇█")
+	levelRegex     = regexp.MustCompile(`(?i)\b(fatal|panic|critical|error|err|warning|warn|info|debug|trace)\b`)
+	// levelAliases normalizes the different spellings of log levels.
+	levelAliases = map[string]string{
+		"err":      "error",
+		"critical": "fatal",
+		"panic":    "fatal",
+		"warning":  "warn",
+	}
+	// sourceLabels are the labels which identify where a log line comes
+	// from, in order of precedence.
+	sourceLabels = []string{
+		apps.LogLabelReplica,
+		apps.LogLabelWorkerJob,
+		apps.LogLabelDeployJob,
+		apps.LogLabelScheduledJob,
+		apps.LogLabelBuild,
+	}
+)
+
+// statsOutput collects log entries and prints a summary of them instead of
+// the lines themselves.
+type statsOutput struct {
+	w          io.Writer
+	start, end time.Time
+	times      []time.Time
+	levels     map[string]int
+	sources    map[string]int
+}
+
+func newStatsOutput(w io.Writer, start, end time.Time) *statsOutput {
+	return &statsOutput{w: w, start: start, end: end, levels: map[string]int{}, sources: map[string]int{}}
+}
+
+func (o *statsOutput) FormatAndPrintln(ts time.Time, lbls loghttp.LabelSet, _ int, line string) {
+	o.times = append(o.times, ts)
+	o.levels[logLevel(line)]++
+	o.sources[source(lbls)]++
+}
+
+func (o *statsOutput) WithWriter(w io.Writer) output.LogOutput {
+	o.w = w
+	return o
+}
+
+func (o *statsOutput) LineCount() int {
+	return len(o.times)
+}
+
+// print writes the summary of all collected entries.
+func (o *statsOutput) print() error {
+	w := tabwriter.NewWriter(o.w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "%d lines between %s and %s\n\n", o.LineCount(), format.Timestamp(o.start), format.Timestamp(o.end))
+
+	fmt.Fprintln(w, "LEVEL\tLINES")
+	for _, c := range sortedCounts(o.levels) {
+		fmt.Fprintf(w, "%s\t%d\n", c.key, c.count)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "SOURCE\tLINES")
+	for _, c := range sortedCounts(o.sources) {
+		fmt.Fprintf(w, "%s\t%d\n", c.key, c.count)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%s %s %s\n", o.start.Format(time.TimeOnly), sparkline(o.times, o.start, o.end, sparklineBuckets), o.end.Format(time.TimeOnly))
+	return w.Flush()
+}
+
+// logLevel guesses the level of a log line. Structured lines are checked for
+// a level field, all others for the first level keyword.
+func logLevel(line string) string {
+	if strings.HasPrefix(strings.TrimSpace(line), "{") {
+		fields := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &fields); err == nil {
+			for _, key := range []string{"level", "lvl", "severity"} {
+				if level, ok := fields[key].(string); ok {
+					return normalizeLevel(level)
+				}
+			}
+		}
+	}
+	if match := levelRegex.FindString(line); match != "" {
+		return normalizeLevel(match)
+	}
+	return unknown
+}
+
+func normalizeLevel(level string) string {
+	level = strings.ToLower(level)
+	if alias, ok := levelAliases[level]; ok {
+		return alias
+	}
+	return level
+}
+
+func source(lbls loghttp.LabelSet) string {
+	for _, label := range sourceLabels {
+		if v := lbls[label]; v != "" {
+			return v
+		}
+	}
+	return unknown
+}
+
+type count struct {
+	key   string
+	count int
+}
+
+// sortedCounts returns the counts sorted descending by count and then by key.
+func sortedCounts(m map[string]int) []count {
+	counts := make([]count, 0, len(m))
+	for k, v := range m {
+		counts = append(counts, count{key: k, count: v})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].count != counts[j].count {
+			return counts[i].count > counts[j].count
+		}
+		return counts[i].key < counts[j].key
+	})
+	return counts
+}
+
+// sparkline returns the distribution of the times between start and end in
+// the given amount of buckets.
+func sparkline(times []time.Time, start, end time.Time, buckets int) string {
+	counts := make([]int, buckets)
+	width := end.Sub(start) / time.Duration(buckets)
+	highest := 0
+	for _, t := range times {
+		i := buckets - 1
+		if width > 0 {
+			i = int(t.Sub(start) / width)
+		}
+		if i < 0 || i >= buckets {
+			// the end is inclusive
+			if !t.Equal(end) {
+				continue
+			}
+			i = buckets - 1
+		}
+		counts[i]++
+		if counts[i] > highest {
+			highest = counts[i]
+		}
+	}
+
+	var b strings.Builder
+	for _, c := range counts {
+		if c == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(sparklineChars[c*(len(sparklineChars)-1)/highest])
+	}
+	return b.String()
+}
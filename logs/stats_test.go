@@ -0,0 +1,56 @@
+package logs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevel(t *testing.T) {
+	for line, want := range map[string]string{
+		`{"level":"WARN","msg":"slow request"}`:     "warn",
+		`{"severity":"error","msg":"failed"}`:       "error",
+		"2025-01-01 ERROR payment failed":           "error",
+		"panic: runtime error: nil pointer":         "fatal",
+		"I, [2025-01-01] INFO -- : Started GET /":   "info",
+		"listening on :8080":                        unknown,
+		`{"msg":"no level","detail":"debug build"}`: "debug",
+	} {
+		assert.Equal(t, want, logLevel(line), line)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	start := time.Now()
+	end := start.Add(4 * time.Minute)
+	times := []time.Time{
+		start,
+		start.Add(time.Minute), start.Add(time.Minute),
+		end,
+	}
+	assert.Equal(t, "▄█ ▄", sparkline(times, start, end, 4))
+	assert.Equal(t, "    ", sparkline(nil, start, end, 4))
+}
+
+func TestStats(t *testing.T) {
+	apiClient := &api.Client{
+		Project: "default",
+		Log:     &log.Client{Client: log.NewFake(t, time.Now(), "ERROR boom", "INFO ok", "INFO ok")},
+	}
+	path := filepath.Join(t.TempDir(), "stats")
+	cmd := logsCmd{Output: "default", Lines: 10, Since: time.Hour, Stats: true, OutputFile: path}
+	assert.NoError(t, cmd.Run(context.Background(), apiClient, ApplicationQuery("app", "default")))
+
+	out, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "3 lines between")
+	assert.Regexp(t, `info\s+2`, string(out))
+	assert.Regexp(t, `error\s+1`, string(out))
+	assert.Regexp(t, `SOURCE\s+LINES`, string(out))
+}
@@ -2,55 +2,143 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"syscall"
 
 	"github.com/alecthomas/kong"
+	"github.com/fatih/color"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
-	completion "github.com/jotaen/kong-completion"
+	kongcompletion "github.com/jotaen/kong-completion"
 	"github.com/ninech/nctl/api"
+	apilog "github.com/ninech/nctl/api/log"
 	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/apiresources"
 	"github.com/ninech/nctl/apply"
 	"github.com/ninech/nctl/auth"
+	"github.com/ninech/nctl/clone"
+	"github.com/ninech/nctl/completion"
+	"github.com/ninech/nctl/cost"
+	"github.com/ninech/nctl/cp"
 	"github.com/ninech/nctl/create"
 	"github.com/ninech/nctl/delete"
+	"github.com/ninech/nctl/deploy"
+	"github.com/ninech/nctl/deprecations"
+	"github.com/ninech/nctl/describe"
+	"github.com/ninech/nctl/diff"
+	"github.com/ninech/nctl/docs"
+	"github.com/ninech/nctl/doctor"
+	"github.com/ninech/nctl/edit"
+	"github.com/ninech/nctl/events"
 	"github.com/ninech/nctl/exec"
+	"github.com/ninech/nctl/export"
 	"github.com/ninech/nctl/get"
+	"github.com/ninech/nctl/history"
+	"github.com/ninech/nctl/importer"
+	"github.com/ninech/nctl/internal/audit"
 	"github.com/ninech/nctl/internal/format"
+	"github.com/ninech/nctl/internal/plugin"
+	"github.com/ninech/nctl/internal/telemetry"
 	"github.com/ninech/nctl/logs"
+	"github.com/ninech/nctl/metadata"
+	"github.com/ninech/nctl/patch"
+	"github.com/ninech/nctl/power"
 	"github.com/ninech/nctl/predictor"
+	"github.com/ninech/nctl/promote"
+	"github.com/ninech/nctl/raw"
+	"github.com/ninech/nctl/scaffold"
+	"github.com/ninech/nctl/search"
+	"github.com/ninech/nctl/selftest"
+	"github.com/ninech/nctl/selfupdate"
+	"github.com/ninech/nctl/ssh"
 	"github.com/ninech/nctl/update"
+	"github.com/ninech/nctl/wait"
 	"github.com/posener/complete"
 )
 
 type flags struct {
-	Project        string           `predictor:"resource_name" help:"Limit commands to a specific project." short:"p"`
-	APICluster     string           `help:"Context name of the API cluster." default:"${api_cluster}" env:"NCTL_API_CLUSTER" hidden:""`
-	LogAPIAddress  string           `help:"Address of the deplo.io logging API server." default:"https://logs.deplo.io" env:"NCTL_LOG_ADDR" hidden:""`
-	LogAPIInsecure bool             `help:"Don't verify TLS connection to the logging API server." hidden:"" default:"false" env:"NCTL_LOG_INSECURE"`
-	Verbose        bool             `help:"Show verbose messages."`
-	Version        kong.VersionFlag `name:"version" help:"Print version information and quit."`
+	Project               string           `predictor:"resource_name" help:"Limit commands to a specific project." short:"p"`
+	APICluster            string           `name:"api-context" aliases:"api-cluster" help:"Name of the kubeconfig context of the API to use, e.g. to work with several organizations in one script without switching contexts." default:"${api_cluster}" env:"NCTL_API_CONTEXT,NCTL_API_CLUSTER" placeholder:"CONTEXT"`
+	APIAddress            string           `help:"Address of the API server, overrides the server of the kubeconfig context." env:"NCTL_API_URL" placeholder:"URL"`
+	IssuerAddress         string           `help:"Address of the OIDC issuer, overrides the issuer of the kubeconfig context." env:"NCTL_ISSUER_URL" placeholder:"URL"`
+	LogAPIAddress         string           `help:"Address of the deplo.io logging API server." default:"https://logs.deplo.io" env:"NCTL_LOG_ADDR,NCTL_LOG_URL" placeholder:"URL"`
+	LogAPIInsecure        bool             `help:"Don't verify TLS connection to the logging API server." hidden:"" default:"false" env:"NCTL_LOG_INSECURE"`
+	Quiet                 bool             `short:"q" xor:"verbosity" help:"Only print the names of the resources changed by mutating commands, e.g. application/myapp. Messages and spinners are not shown, warnings are printed to stderr." env:"NCTL_QUIET"`
+	Verbose               bool             `xor:"verbosity" help:"Show verbose messages, e.g. the full errors returned by the API."`
+	Yes                   bool             `short:"y" help:"Answer all confirmations with yes, e.g. to run commands non-interactively." env:"NCTL_YES"`
+	Debug                 bool             `short:"v" help:"Log the requests to the API with their status and latency to stderr. Secrets are redacted." env:"NCTL_DEBUG"`
+	ShowTimestamps        bool             `help:"Print absolute RFC3339 timestamps instead of ages like 5m in tables and descriptions, e.g. to correlate them with logs." env:"NCTL_SHOW_TIMESTAMPS"`
+	UTC                   bool             `name:"utc" help:"Print timestamps in UTC instead of the local timezone, in tables, descriptions and logs. Can be set as \"utc\" in the config file." env:"NCTL_UTC"`
+	ReadOnly              bool             `help:"Reject all changes to resources, e.g. for shared automation accounts or demos. Server side dry runs are still possible." env:"NCTL_READONLY"`
+	AuditLog              bool             `help:"Record mutating commands in a local history file, see \"nctl history\"." env:"NCTL_AUDIT_LOG"`
+	QPS                   float32          `name:"qps" help:"Maximum requests per second to the API." default:"25" env:"NCTL_QPS"`
+	Burst                 int              `help:"Maximum burst of requests to the API." default:"50" env:"NCTL_BURST"`
+	Proxy                 string           `help:"URL of the proxy to connect to the API and the logging API. Defaults to the HTTPS_PROXY environment variable." env:"NCTL_PROXY" placeholder:"URL"`
+	CAFile                string           `help:"CA bundle to verify the API and logging API server certificates with, e.g. when connecting through a TLS intercepting proxy." env:"NCTL_CA_FILE" type:"existingfile" predictor:"file" placeholder:"PATH"`
+	InsecureSkipTLSVerify bool             `help:"Don't verify the certificates of the API and the logging API server. Only use this for debugging." env:"NCTL_INSECURE_SKIP_TLS_VERIFY"`
+	Record                string           `help:"Record the requests to the API and their responses to a file, see --replay. Recordings are appended to the file and contain all responses in plain text, including secrets." env:"NCTL_RECORD" xor:"replay" placeholder:"FILE"`
+	Replay                string           `help:"Replay the responses recorded with --record instead of connecting to the API, e.g. for testing in CI. A kubeconfig with the API context is still needed, but no login." env:"NCTL_REPLAY" xor:"replay" type:"existingfile" placeholder:"FILE"`
+	NoKeychain            bool             `help:"Store the refresh tokens in plain text files in ~/.kube/cache/oidc-login instead of the keychain of the OS." env:"NCTL_NO_KEYCHAIN"`
+	PriceTable            string           `help:"File or URL of the price table used to estimate costs, see \"nctl cost\"." env:"NCTL_PRICE_TABLE" placeholder:"FILE|URL"`
+	NoColor               bool             `help:"Disable colored output. Colors are also disabled if the NO_COLOR environment variable is set."`
+	Version               kong.VersionFlag `name:"version" help:"Print version information and quit."`
 }
 
 type rootCommand struct {
 	flags
-	Get         get.Cmd               `cmd:"" help:"Get resource."`
-	Auth        auth.Cmd              `cmd:"" help:"Authenticate with resource."`
-	Completions completion.Completion `cmd:"" help:"Print shell completions."`
-	Create      create.Cmd            `cmd:"" help:"Create resource."`
-	Apply       apply.Cmd             `cmd:"" help:"Apply resource."`
-	Delete      delete.Cmd            `cmd:"" help:"Delete resource."`
-	Logs        logs.Cmd              `cmd:"" help:"Get logs of resource."`
-	Update      update.Cmd            `cmd:"" help:"Update resource."`
-	Exec        exec.Cmd              `cmd:"" help:"Execute a command."`
+	Get          get.Cmd               `cmd:"" aliases:"ls" help:"Get resource."`
+	Auth         auth.Cmd              `cmd:"" help:"Authenticate with resource."`
+	Completions  completion.Cmd        `cmd:"" aliases:"completion" help:"Print or install shell completions."`
+	Create       create.Cmd            `cmd:"" help:"Create resource."`
+	Apply        apply.Cmd             `cmd:"" help:"Apply resource."`
+	Delete       delete.Cmd            `cmd:"" aliases:"rm" help:"Delete resource."`
+	Logs         logs.Cmd              `cmd:"" help:"Get logs of resource."`
+	Update       update.Cmd            `cmd:"" help:"Update resource."`
+	Edit         edit.Cmd              `cmd:"" help:"Edit a resource in your editor."`
+	Patch        patch.Cmd             `cmd:"" help:"Patch a resource with a JSON merge patch or JSON patch."`
+	Exec         exec.Cmd              `cmd:"" help:"Execute a command."`
+	Attach       exec.AttachCmd        `cmd:"" help:"Attach to the output of a running process."`
+	Describe     describe.Cmd          `cmd:"" help:"Show details of a resource."`
+	Diff         diff.Cmd              `cmd:"" help:"Show differences between resources."`
+	Search       search.Cmd            `cmd:"" help:"Search resources by name, hostname or git URL across all projects."`
+	Events       events.Cmd            `cmd:"" help:"Show status conditions and events of a resource in chronological order."`
+	Wait         wait.Cmd              `cmd:"" help:"Wait for a condition on a resource."`
+	Label        metadata.LabelCmd     `cmd:"" help:"Add, change or remove labels of a resource."`
+	Annotate     metadata.AnnotateCmd  `cmd:"" help:"Add, change or remove annotations of a resource."`
+	SelfTest     selftest.Cmd          `cmd:"" name:"selftest" help:"Run an end-to-end smoke test against the platform account."`
+	VersionInfo  selfupdate.VersionCmd `cmd:"" name:"version" help:"Print version information and check for updates."`
+	SelfUpdate   selfupdate.Cmd        `cmd:"" name:"self-update" help:"Update nctl to the latest release."`
+	History      history.Cmd           `cmd:"" help:"Show the local history of mutating commands."`
+	API          raw.Cmd               `cmd:"" name:"api" help:"Access the Nine API directly."`
+	Clone        clone.Cmd             `cmd:"" help:"Clone resources."`
+	Promote      promote.Cmd           `cmd:"" help:"Promote resources to another project."`
+	Copy         cp.Cmd                `cmd:"" name:"copy" aliases:"cp" help:"Copy settings between resources."`
+	Cost         cost.Cmd              `cmd:"" help:"Estimate the monthly cost of resources."`
+	Deprecations deprecations.Cmd      `cmd:"" help:"List resources which use deprecated versions or settings."`
+	Export       export.Cmd            `cmd:"" help:"Export resources to other tools."`
+	Import       importer.Cmd          `cmd:"" help:"Import resources from other platforms."`
+	Start        power.StartCmd        `cmd:"" help:"Start resource."`
+	Stop         power.StopCmd         `cmd:"" help:"Stop resource."`
+	Pause        power.PauseCmd        `cmd:"" help:"Pause resource."`
+	Resume       power.ResumeCmd       `cmd:"" help:"Resume resource."`
+	Restart      power.RestartCmd      `cmd:"" help:"Restart resource."`
+	Deploy       deploy.Cmd            `cmd:"" help:"Deploy an application described in an app config file (nctl.yaml)."`
+	Init         scaffold.Cmd          `cmd:"" help:"Set up new applications and their CI pipelines."`
+	Doctor       doctor.Cmd            `cmd:"" help:"Diagnose problems with the local environment."`
+	SSH          ssh.Cmd               `cmd:"" name:"ssh" help:"Connect to resource via SSH."`
+	Docs         docs.Cmd              `cmd:"" help:"Generate man pages or a JSON description of all commands."`
+	APIResources apiresources.Cmd      `cmd:"" name:"api-resources" help:"List the resource kinds with their short names and supported commands."`
 }
 
 const (
@@ -81,17 +169,14 @@ func main() {
 		kong.PostBuild(format.InterpolateFlagPlaceholders(kongVars)),
 		kongVars,
 		kong.BindTo(ctx, (*context.Context)(nil)),
+		configuration(),
 	)
 
 	resourceNamePredictor := predictor.NewResourceName(func() (*api.Client, error) {
 		// the client for the predictor requires a static token in the client config
 		// since dynamic exec config seems to break with some shells during completion.
 		// The exact reason for that is unknown.
-		apiCluster := defaultAPICluster
-		if v, ok := os.LookupEnv("NCTL_API_CLUSTER"); ok {
-			apiCluster = v
-		}
-		c, err := api.New(ctx, apiCluster, "", api.StaticToken(ctx))
+		c, err := api.New(ctx, apiContextFromEnv(), "", api.StaticToken(ctx))
 		if err != nil {
 			return nil, err
 		}
@@ -100,12 +185,16 @@ func main() {
 	})
 
 	// completion handling
-	completion.Register(
+	kongcompletion.Register(
 		parser,
-		completion.WithPredictor("file", complete.PredictFiles("*")),
-		completion.WithPredictor("resource_name", resourceNamePredictor),
+		kongcompletion.WithPredictor("file", complete.PredictFiles("*")),
+		kongcompletion.WithPredictor("resource_name", resourceNamePredictor),
 	)
 
+	if path, args, ok := plugin.Find(os.Args[1:], isBuiltinCommand(parser)); ok {
+		os.Exit(runPlugin(ctx, path, args))
+	}
+
 	kongCtx, err := parser.Parse(os.Args[1:])
 	if err != nil {
 		var parseErr *kong.ParseError
@@ -126,6 +215,19 @@ func main() {
 		parser.FatalIfErrorf(err)
 	}
 
+	if nctl.NoColor {
+		color.NoColor = true
+	}
+	format.SetQuiet(nctl.Quiet)
+	format.SetAssumeYes(nctl.Yes)
+	format.SetShowTimestamps(nctl.ShowTimestamps)
+	format.SetUTC(nctl.UTC)
+	if nctl.NoKeychain {
+		api.Keychain = false
+		// the exec credential plugin runs in a sub process.
+		os.Setenv("NCTL_NO_KEYCHAIN", "true")
+	}
+
 	// handle the login/oidc cmds separately as we should not try to get the
 	// API client if we're not logged in.
 	command, err := os.Executable()
@@ -145,19 +247,107 @@ func main() {
 		return
 	}
 
+	// version, self-update, cost and doctor don't need an API client.
+	switch kongCtx.Command() {
+	case "version":
+		kongCtx.FatalIfErrorf(nctl.VersionInfo.Run(ctx, version, versionOutput(version, commit, date)))
+		return
+	case "self-update":
+		kongCtx.FatalIfErrorf(nctl.SelfUpdate.Run(ctx, version))
+		return
+	case "history":
+		kongCtx.FatalIfErrorf(nctl.History.Run())
+		return
+	case completion.CommandName, completion.CommandName + " <shell>":
+		kongCtx.FatalIfErrorf(nctl.Completions.Run())
+		return
+	case "docs man":
+		kongCtx.FatalIfErrorf(nctl.Docs.Man.Run(parser.Model, version))
+		return
+	case "docs json":
+		kongCtx.FatalIfErrorf(nctl.Docs.JSON.Run(parser.Model))
+		return
+	case "api-resources":
+		kongCtx.FatalIfErrorf(nctl.APIResources.Run(parser.Model))
+		return
+	case "import k8s":
+		kongCtx.FatalIfErrorf(nctl.Import.K8s.Run())
+		return
+	case "cost app", "cost postgres", "cost mysql":
+		kongCtx.FatalIfErrorf(kongCtx.Run(ctx, nctl.priceSource()))
+		return
+	case "get locations", "get machinetypes", "get versions":
+		// the capabilities are defined by the API types, no client is
+		// needed to list them.
+		kongCtx.FatalIfErrorf(kongCtx.Run(ctx, (*api.Client)(nil)))
+		return
+	case "doctor":
+		kongCtx.FatalIfErrorf(nctl.Doctor.Run(ctx, nctl.APICluster, nctl.endpoints(), nctl.LogAPIAddress, version, nctl.transport()))
+		return
+	}
+
 	if strings.HasPrefix(kongCtx.Command(), auth.OIDCCmdName) {
 		kongCtx.FatalIfErrorf(nctl.Auth.OIDC.Run(ctx, os.Stdout))
 		return
 	}
 
-	client, err := api.New(ctx, nctl.APICluster, nctl.Project, api.LogClient(ctx, nctl.LogAPIAddress, nctl.LogAPIInsecure))
+	shutdownTracing, err := telemetry.Setup(ctx, version)
+	if err != nil {
+		format.PrintWarningf("%s\n", err)
+	}
+	ctx, span := telemetry.StartCommand(ctx, kongCtx.Command())
+	kongCtx.BindTo(ctx, (*context.Context)(nil))
+	// finishTracing ends the command span and flushes all spans, it has to
+	// be called before exiting.
+	finishTracing := func(err error) {
+		telemetry.EndCommand(span, err)
+		_ = shutdownTracing(context.Background())
+	}
+
+	logTransport := nctl.transport()
+	logTransport.Insecure = logTransport.Insecure || nctl.LogAPIInsecure
+	clientOpts := []api.ClientOpt{
+		api.OverrideEndpoints(nctl.endpoints()),
+		api.LogClient(ctx, nctl.LogAPIAddress, logTransport),
+		api.Transport(api.TransportConfig{
+			QPS:      nctl.QPS,
+			Burst:    nctl.Burst,
+			Proxy:    nctl.Proxy,
+			CAFile:   nctl.CAFile,
+			Insecure: nctl.InsecureSkipTLSVerify,
+		}),
+		api.PriceTableSource(nctl.priceSource()),
+	}
+	switch {
+	case nctl.Record != "":
+		clientOpts = append(clientOpts, api.Record(nctl.Record))
+	case nctl.Replay != "":
+		clientOpts = append(clientOpts, api.Replay(nctl.Replay))
+	}
+	if nctl.Debug {
+		clientOpts = append(clientOpts, api.Debug(os.Stderr))
+	}
+	if telemetry.Enabled() {
+		clientOpts = append(clientOpts, api.Tracing())
+	}
+	if nctl.ReadOnly {
+		clientOpts = append(clientOpts, api.ReadOnly())
+	}
+	client, err := api.New(ctx, nctl.APICluster, nctl.Project, clientOpts...)
 	if err != nil {
+		finishTracing(err)
 		fmt.Println(err)
 		fmt.Printf("\nUnable to get API client, are you logged in?\n\nUse `%s` to login.\n", format.Command().Login())
 		os.Exit(1)
 	}
 
-	err = kongCtx.Run(ctx, client)
+	kongCtx.Bind(nctl.transport())
+	err = runCommand(ctx, kongCtx, client, nctl.Quiet && audit.Mutating(kongCtx.Command()))
+	finishTracing(err)
+	printAPIWarnings(client)
+	if nctl.AuditLog && audit.Mutating(kongCtx.Command()) {
+		recordAudit(kongCtx.Command(), client, err)
+	}
 	if err != nil {
 		if k8serrors.IsForbidden(err) && !nctl.Verbose {
 			err = errors.New("permission denied: are you part of the organization?")
@@ -167,6 +357,151 @@ func main() {
 
 }
 
+// runCommand runs the selected command. If quiet is set, the regular output
+// of the command is discarded and only the resources it changed are printed,
+// one per line, so that the output can be passed on to other commands.
+func runCommand(ctx context.Context, kongCtx *kong.Context, client *api.Client, quiet bool) error {
+	if !quiet {
+		return kongCtx.Run(ctx, client)
+	}
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	os.Stdout = devNull
+	err = kongCtx.Run(ctx, client)
+	os.Stdout = stdout
+	devNull.Close()
+	for _, name := range client.Affected() {
+		fmt.Fprintln(os.Stdout, name)
+	}
+	return err
+}
+
+// printAPIWarnings prints the warnings returned by the API, e.g. about
+// deprecated API versions, after the output of the command. They are printed
+// to stderr so they don't end up in any parsed output.
+func printAPIWarnings(client *api.Client) {
+	for _, w := range client.Warnings() {
+		fmt.Fprintf(os.Stderr, "%s%s\n", color.YellowString("Warning: "), w)
+	}
+}
+
+// isBuiltinCommand returns a func which reports if the name is a top level
+// command or an alias of one.
+func isBuiltinCommand(parser *kong.Kong) func(string) bool {
+	return func(name string) bool {
+		for _, child := range parser.Model.Children {
+			if child.Name == name || slices.Contains(child.Aliases, name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// runPlugin runs the plugin binary and returns its exit code. The API
+// context is passed to the plugin if the user is logged in.
+func runPlugin(ctx context.Context, path string, args []string) int {
+	// if we can't get a client, the user is not logged in and the plugin
+	// is run without an API context.
+	client, _ := api.New(ctx, apiContextFromEnv(), "")
+
+	code, err := plugin.Run(ctx, path, args, plugin.Env(ctx, client))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to run plugin %s: %s\n", path, err)
+	}
+	return code
+}
+
+// apiContextFromEnv returns the API context set in the environment or the
+// default one. It is used where the flags have not been parsed yet.
+func apiContextFromEnv() string {
+	for _, env := range []string{"NCTL_API_CONTEXT", "NCTL_API_CLUSTER"} {
+		if v, ok := os.LookupEnv(env); ok && v != "" {
+			return v
+		}
+	}
+	return defaultAPICluster
+}
+
+// configuration reads the defaults of flags from the config file of nctl,
+// e.g. {"context-name-template": "{{.Org}}-{{.Name}}"}. The
+// keys are the names of the flags, values given on the command line or with
+// env variables take precedence.
+func configuration() kong.Option {
+	path, err := configFile()
+	if err != nil {
+		return kong.OptionFunc(func(*kong.Kong) error { return nil })
+	}
+	return kong.Configuration(configLoader, path)
+}
+
+// configLoader reads the JSON config file. Unlike kong.JSON, the keys are
+// the names of the flags as they are written on the command line.
+func configLoader(r io.Reader) (kong.Resolver, error) {
+	values := map[string]any{}
+	if err := json.NewDecoder(r).Decode(&values); err != nil {
+		return nil, err
+	}
+	return kong.ResolverFunc(func(_ *kong.Context, _ *kong.Path, flag *kong.Flag) (any, error) {
+		// kong resolves the config after the env variables, which
+		// would overwrite them otherwise.
+		for _, env := range flag.Envs {
+			if _, ok := os.LookupEnv(env); ok {
+				return nil, nil
+			}
+		}
+		return values[flag.Name], nil
+	}), nil
+}
+
+// configFile returns the path of the config file within the user config
+// dir.
+func configFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "nctl", "config.json"), nil
+}
+
+// endpoints returns the endpoints which override the ones of the
+// kubeconfig.
+func (f flags) endpoints() api.Endpoints {
+	return api.Endpoints{API: f.APIAddress, Issuer: f.IssuerAddress}
+}
+
+// priceSource returns the price table source, which is fetched with the
+// proxy and TLS settings of the flags.
+func (f flags) priceSource() api.PriceSource {
+	return api.PriceSource{Source: f.PriceTable, Transport: f.transport()}
+}
+
+// transport returns the proxy and TLS settings for the HTTP endpoints apart
+// from the API, e.g. the log API.
+func (f flags) transport() apilog.TransportConfig {
+	return apilog.TransportConfig{
+		Proxy:    f.Proxy,
+		CAFile:   f.CAFile,
+		Insecure: f.InsecureSkipTLSVerify,
+	}
+}
+
+// recordAudit records the command in the local audit log. Failing to do so
+// only results in a warning as the command itself has already been run.
+func recordAudit(command string, client *api.Client, cmdErr error) {
+	path, err := audit.DefaultPath()
+	if err == nil {
+		org, _ := client.Organization()
+		err = audit.Record(path, audit.NewEntry(command, os.Args[1:], org, client.Project, cmdErr))
+	}
+	if err != nil {
+		format.PrintWarningf("unable to record command in audit log: %s\n", err)
+	}
+}
+
 func setupSignalHandler(ctx context.Context, cancel context.CancelFunc) {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
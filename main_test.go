@@ -1,8 +1,12 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/alecthomas/kong"
+	"github.com/ninech/nctl/api"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,3 +17,109 @@ func TestKongVars(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, vars)
 }
+
+// TestCommands makes sure that all commands can be built, e.g. that no flags
+// of a command conflict with the global flags.
+func TestCommands(t *testing.T) {
+	vars, err := kongVariables()
+	require.NoError(t, err)
+	_, err = kong.New(&rootCommand{}, vars)
+	require.NoError(t, err)
+}
+
+func TestAPIContextFromEnv(t *testing.T) {
+	t.Setenv("NCTL_API_CONTEXT", "")
+	t.Setenv("NCTL_API_CLUSTER", "")
+	require.Equal(t, defaultAPICluster, apiContextFromEnv())
+
+	t.Setenv("NCTL_API_CLUSTER", "legacy")
+	require.Equal(t, "legacy", apiContextFromEnv())
+
+	t.Setenv("NCTL_API_CONTEXT", "acme")
+	require.Equal(t, "acme", apiContextFromEnv())
+}
+
+// TestResourceAliases makes sure that all names and aliases of the resource
+// commands resolve to the kind of the command, so the short names can be
+// used the same way in all commands.
+func TestResourceAliases(t *testing.T) {
+	vars, err := kongVariables()
+	require.NoError(t, err)
+	parser, err := kong.New(&rootCommand{}, vars)
+	require.NoError(t, err)
+	scheme, err := api.NewScheme()
+	require.NoError(t, err)
+
+	for _, verb := range parser.Model.Children {
+		for _, n := range verb.Children {
+			if n.Group == nil {
+				continue
+			}
+			kind, err := api.LookupKind(scheme, n.Name)
+			require.NoError(t, err, "%s %s", verb.Name, n.Name)
+			for _, alias := range n.Aliases {
+				aliasKind, err := api.LookupKind(scheme, alias)
+				require.NoError(t, err, "%s %s", verb.Name, alias)
+				require.Equal(t, kind, aliasKind, "%s %s", verb.Name, alias)
+			}
+		}
+	}
+}
+
+// TestDeleteFromFileFlags makes sure that the flags of the default delete
+// command can be used.
+func TestDeleteFromFileFlags(t *testing.T) {
+	vars, err := kongVariables()
+	require.NoError(t, err)
+	nctl := &rootCommand{}
+	parser, err := kong.New(nctl, vars)
+	require.NoError(t, err)
+	_, err = parser.Parse([]string{"delete", "-f", "app.yaml", "--force-protected", "--dry-run=server"})
+	require.NoError(t, err)
+	require.True(t, nctl.Delete.FromFile.ForceProtected)
+	require.Equal(t, "server", nctl.Delete.FromFile.DryRun)
+
+	_, err = parser.Parse([]string{"create", "-f", "app.yaml", "--dry-run=server"})
+	require.NoError(t, err)
+	require.Equal(t, "server", nctl.Create.FromFile.DryRun)
+}
+
+func TestConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	for _, env := range []string{"NCTL_CONTEXT_NAME_TEMPLATE", "NCTL_UTC"} {
+		// restored after the test
+		t.Setenv(env, "")
+		require.NoError(t, os.Unsetenv(env))
+	}
+	path, err := configFile()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(`{"context-name-template": "{{.Org}}-{{.Name}}", "utc": true}`), 0o600))
+
+	parse := func(args ...string) *rootCommand {
+		vars, err := kongVariables()
+		require.NoError(t, err)
+		nctl := &rootCommand{}
+		parser, err := kong.New(nctl, vars, configuration())
+		require.NoError(t, err)
+		_, err = parser.Parse(args)
+		require.NoError(t, err)
+		return nctl
+	}
+
+	nctl := parse("auth", "cluster", "mycluster")
+	require.Equal(t, "{{.Org}}-{{.Name}}", nctl.Auth.Cluster.ContextNameTemplate)
+	require.True(t, nctl.UTC)
+
+	// flags and env variables take precedence
+	nctl = parse("auth", "login", "--context-name-template={{.Name}}")
+	require.Equal(t, "{{.Name}}", nctl.Auth.Login.ContextNameTemplate)
+	t.Setenv("NCTL_CONTEXT_NAME_TEMPLATE", "{{.Project}}")
+	nctl = parse("auth", "cluster", "mycluster")
+	require.Equal(t, "{{.Project}}", nctl.Auth.Cluster.ContextNameTemplate)
+	t.Setenv("NCTL_UTC", "false")
+	nctl = parse("get", "apps")
+	require.False(t, nctl.UTC)
+}
@@ -0,0 +1,179 @@
+// Package metadata contains the commands to edit the labels and annotations
+// of resources of any kind.
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type resourceCmd struct {
+	Kind      string   `arg:"" help:"Kind of the resource, e.g. application, postgres or project."`
+	Name      string   `arg:"" predictor:"resource_name" help:"Name of the resource."`
+	Pairs     []string `arg:"" name:"key=value" help:"Pairs to set in the form key=value. Use key- to remove a key."`
+	Overwrite bool     `help:"Allow changing the value of keys which are already set."`
+	out       io.Writer
+}
+
+type LabelCmd struct {
+	resourceCmd
+}
+
+type AnnotateCmd struct {
+	resourceCmd
+}
+
+func (cmd *LabelCmd) Help() string {
+	return "Labels can be used to select resources, e.g. with \"nctl delete app -l team=payments\".\n\n" +
+		"Examples:\n\n" +
+		"  nctl label app myapp team=payments\n" +
+		"  nctl label app myapp team=checkout --overwrite\n" +
+		"  nctl label app myapp team-"
+}
+
+func (cmd *LabelCmd) Run(ctx context.Context, client *api.Client) error {
+	return cmd.run(ctx, client, "labeled", validateLabel,
+		(*unstructured.Unstructured).GetLabels, (*unstructured.Unstructured).SetLabels)
+}
+
+func (cmd *AnnotateCmd) Help() string {
+	return "Examples:\n\n" +
+		"  nctl annotate app myapp description=\"checkout frontend\"\n" +
+		"  nctl annotate app myapp description-"
+}
+
+func (cmd *AnnotateCmd) Run(ctx context.Context, client *api.Client) error {
+	return cmd.run(ctx, client, "annotated", validateAnnotation,
+		(*unstructured.Unstructured).GetAnnotations, (*unstructured.Unstructured).SetAnnotations)
+}
+
+// change is a single key to set or, if remove is true, remove.
+type change struct {
+	key    string
+	value  string
+	remove bool
+}
+
+func (cmd *resourceCmd) run(
+	ctx context.Context,
+	client *api.Client,
+	verb string,
+	validate func(key, value string) error,
+	get func(*unstructured.Unstructured) map[string]string,
+	set func(*unstructured.Unstructured, map[string]string),
+) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	changes, err := parseChanges(cmd.Pairs, validate)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := api.LookupKind(client.Scheme(), cmd.Kind)
+	if err != nil {
+		return err
+	}
+	name, err := client.NamespacedNameFor(gvk, cmd.Name)
+	if err != nil {
+		return err
+	}
+	kind := strings.ToLower(gvk.Kind)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := client.Get(ctx, name, obj); err != nil {
+		return fmt.Errorf("unable to get %s %q: %w", kind, cmd.Name, err)
+	}
+	orig := obj.DeepCopy()
+
+	values, err := apply(get(obj), changes, cmd.Overwrite)
+	if err != nil {
+		return err
+	}
+	set(obj, values)
+
+	if err := client.Patch(ctx, obj, runtimeclient.MergeFrom(orig)); err != nil {
+		return fmt.Errorf("unable to update %s %q: %w", kind, cmd.Name, err)
+	}
+
+	fmt.Fprintln(cmd.out, format.SuccessMessagef("🏷", "%s %s %q", verb, kind, cmd.Name))
+	return nil
+}
+
+// parseChanges parses pairs like "key=value" and "key-".
+func parseChanges(pairs []string, validate func(key, value string) error) ([]change, error) {
+	changes := []change{}
+	for _, pair := range pairs {
+		if key, ok := strings.CutSuffix(pair, "-"); ok && !strings.Contains(pair, "=") {
+			if err := validate(key, ""); err != nil {
+				return nil, err
+			}
+			changes = append(changes, change{key: key, remove: true})
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid pair %q, expected key=value or key-", pair)
+		}
+		if err := validate(key, value); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change{key: key, value: value})
+	}
+	return changes, nil
+}
+
+// apply returns the values with the changes applied. Changing the value of an
+// existing key is only allowed with overwrite.
+func apply(values map[string]string, changes []change, overwrite bool) (map[string]string, error) {
+	result := map[string]string{}
+	for k, v := range values {
+		result[k] = v
+	}
+	conflicts := []string{}
+	for _, c := range changes {
+		if c.remove {
+			delete(result, c.key)
+			continue
+		}
+		if current, ok := result[c.key]; ok && current != c.value && !overwrite {
+			conflicts = append(conflicts, fmt.Sprintf("%q is already set to %q", c.key, current))
+			continue
+		}
+		result[c.key] = c.value
+	}
+	if len(conflicts) != 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("%s, use --overwrite to change it", strings.Join(conflicts, ", "))
+	}
+	return result, nil
+}
+
+func validateLabel(key, value string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+		return fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func validateAnnotation(key, _ string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return fmt.Errorf("invalid annotation key %q: %s", key, strings.Join(errs, "; "))
+	}
+	return nil
+}
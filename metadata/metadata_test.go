@@ -0,0 +1,114 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLabel(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		pairs      []string
+		overwrite  bool
+		wantLabels map[string]string
+		wantErr    string
+	}{
+		{
+			name:       "add",
+			pairs:      []string{"env=prod"},
+			wantLabels: map[string]string{"team": "payments", "env": "prod"},
+		},
+		{
+			name:       "same value",
+			pairs:      []string{"team=payments"},
+			wantLabels: map[string]string{"team": "payments"},
+		},
+		{
+			name:    "change without overwrite",
+			pairs:   []string{"team=checkout"},
+			wantErr: "--overwrite",
+		},
+		{
+			name:       "change with overwrite",
+			pairs:      []string{"team=checkout"},
+			overwrite:  true,
+			wantLabels: map[string]string{"team": "checkout"},
+		},
+		{
+			name:       "remove",
+			pairs:      []string{"team-", "env=prod"},
+			wantLabels: map[string]string{"env": "prod"},
+		},
+		{
+			name:    "invalid key",
+			pairs:   []string{"not valid=foo"},
+			wantErr: "invalid label key",
+		},
+		{
+			name:    "invalid value",
+			pairs:   []string{"team=not valid"},
+			wantErr: "invalid label value",
+		},
+		{
+			name:    "invalid pair",
+			pairs:   []string{"team"},
+			wantErr: "invalid pair",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &apps.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "myapp",
+					Namespace: test.DefaultProject,
+					Labels:    map[string]string{"team": "payments"},
+				},
+			}
+			apiClient, err := test.SetupClient(test.WithObjects(app))
+			require.NoError(t, err)
+
+			cmd := &LabelCmd{resourceCmd{Kind: "app", Name: app.Name, Pairs: tt.pairs, Overwrite: tt.overwrite, out: &bytes.Buffer{}}}
+			err = cmd.Run(ctx, apiClient)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NoError(t, apiClient.Get(ctx, api.ObjectName(app), app))
+			assert.Equal(t, tt.wantLabels, app.Labels)
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	ctx := context.Background()
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "myapp",
+			Namespace:   test.DefaultProject,
+			Annotations: map[string]string{"old": "value"},
+		},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(app))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cmd := &AnnotateCmd{resourceCmd{Kind: "application", Name: app.Name, Pairs: []string{"description=checkout frontend", "old-"}, out: out}}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Contains(t, out.String(), `annotated application "myapp"`)
+
+	require.NoError(t, apiClient.Get(ctx, api.ObjectName(app), app))
+	assert.Equal(t, map[string]string{"description": "checkout frontend"}, app.Annotations)
+}
@@ -0,0 +1,128 @@
+package patch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/apply"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	typeMerge = "merge"
+	typeJSON  = "json"
+)
+
+type Cmd struct {
+	Kind      string `arg:"" help:"Kind of the resource to patch, e.g. application, postgres or project."`
+	Name      string `arg:"" predictor:"resource_name" help:"Name of the resource to patch."`
+	Type      string `enum:"merge,json" default:"merge" help:"Type of the patch: a JSON merge patch (RFC 7386) or a JSON patch (RFC 6902). ${enum}"`
+	Patch     string `xor:"patch" help:"The patch in JSON or YAML."`
+	PatchFile string `xor:"patch" predictor:"file" help:"File containing the patch in JSON or YAML. Use - to read from stdin."`
+	DryRun    string `help:"Must be \"none\" or \"server\". If server, the patch is validated by the API (including webhooks) without being persisted. ${enum}" enum:"none,server" default:"none"`
+	out       io.Writer
+}
+
+func (cmd *Cmd) Help() string {
+	return "Strategic merge patches are not supported as the resources of the Nine API are\n" +
+		"custom resources.\n\n" +
+		"Examples:\n\n" +
+		"  nctl patch app myapp --patch '{\"spec\":{\"forProvider\":{\"config\":{\"size\":\"standard-1\"}}}}'\n" +
+		"  nctl patch app myapp --type json --patch '[{\"op\":\"remove\",\"path\":\"/spec/forProvider/hosts/0\"}]'"
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	data, err := cmd.patchData()
+	if err != nil {
+		return err
+	}
+
+	gvk, err := api.LookupKind(client.Scheme(), cmd.Kind)
+	if err != nil {
+		return err
+	}
+	name, err := client.NamespacedNameFor(gvk, cmd.Name)
+	if err != nil {
+		return err
+	}
+	kind := strings.ToLower(gvk.Kind)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name.Name)
+	obj.SetNamespace(name.Namespace)
+
+	patchType := types.MergePatchType
+	if cmd.Type == typeJSON {
+		patchType = types.JSONPatchType
+	}
+
+	opts := []runtimeclient.PatchOption{}
+	suffix := ""
+	if cmd.DryRun == apply.DryRunServer {
+		opts = append(opts, runtimeclient.DryRunAll)
+		suffix = " (server dry run)"
+	}
+	if err := client.Patch(ctx, obj, runtimeclient.RawPatch(patchType, data), opts...); err != nil {
+		return fmt.Errorf("unable to patch %s %q: %w", kind, cmd.Name, err)
+	}
+
+	fmt.Fprintln(cmd.out, format.SuccessMessagef("🩹", "patched %s %q%s", kind, cmd.Name, suffix))
+	return nil
+}
+
+// patchData returns the patch as JSON. Patches in YAML are converted.
+func (cmd *Cmd) patchData() ([]byte, error) {
+	var data []byte
+	switch {
+	case cmd.Patch != "":
+		data = []byte(cmd.Patch)
+	case cmd.PatchFile == "-":
+		d, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		data = d
+	case cmd.PatchFile != "":
+		d, err := os.ReadFile(cmd.PatchFile)
+		if err != nil {
+			return nil, err
+		}
+		data = d
+	default:
+		return nil, fmt.Errorf("either --patch or --patch-file is required")
+	}
+
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	// validate the structure of the patch before sending it
+	switch cmd.Type {
+	case typeJSON:
+		ops := []map[string]any{}
+		if err := json.Unmarshal(data, &ops); err != nil {
+			return nil, fmt.Errorf("a json patch needs to be a list of operations: %w", err)
+		}
+	default:
+		patch := map[string]any{}
+		if err := json.Unmarshal(data, &patch); err != nil {
+			return nil, fmt.Errorf("a merge patch needs to be an object: %w", err)
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,107 @@
+package patch
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPatch(t *testing.T) {
+	ctx := context.Background()
+
+	patchFile := filepath.Join(t.TempDir(), "patch.yaml")
+	require.NoError(t, os.WriteFile(patchFile, []byte("spec:\n  forProvider:\n    config:\n      size: standard-2\n"), 0o600))
+
+	tests := []struct {
+		name      string
+		cmd       Cmd
+		wantSize  apps.ApplicationSize
+		wantHosts []string
+		wantErr   string
+	}{
+		{
+			name:      "merge",
+			cmd:       Cmd{Type: typeMerge, Patch: `{"spec":{"forProvider":{"config":{"size":"standard-1"}}}}`},
+			wantSize:  "standard-1",
+			wantHosts: []string{"one.example.org", "two.example.org"},
+		},
+		{
+			name:      "merge from yaml file",
+			cmd:       Cmd{Type: typeMerge, PatchFile: patchFile},
+			wantSize:  "standard-2",
+			wantHosts: []string{"one.example.org", "two.example.org"},
+		},
+		{
+			name:      "json",
+			cmd:       Cmd{Type: typeJSON, Patch: `[{"op":"remove","path":"/spec/forProvider/hosts/0"}]`},
+			wantSize:  "micro",
+			wantHosts: []string{"two.example.org"},
+		},
+		{
+			name:      "dry run",
+			cmd:       Cmd{Type: typeMerge, Patch: `{"spec":{"forProvider":{"config":{"size":"standard-1"}}}}`, DryRun: "server"},
+			wantSize:  "micro",
+			wantHosts: []string{"one.example.org", "two.example.org"},
+		},
+		{
+			name:    "json patch which is not a list",
+			cmd:     Cmd{Type: typeJSON, Patch: `{"spec":{}}`},
+			wantErr: "list of operations",
+		},
+		{
+			name:    "merge patch which is not an object",
+			cmd:     Cmd{Type: typeMerge, Patch: `[]`},
+			wantErr: "needs to be an object",
+		},
+		{
+			name:    "missing patch",
+			cmd:     Cmd{Type: typeMerge},
+			wantErr: "--patch",
+		},
+		{
+			name:    "not found",
+			cmd:     Cmd{Name: "doesnotexist", Type: typeMerge, Patch: `{}`},
+			wantErr: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &apps.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "myapp",
+					Namespace: test.DefaultProject,
+				},
+			}
+			app.Spec.ForProvider.Config.Size = "micro"
+			app.Spec.ForProvider.Hosts = []string{"one.example.org", "two.example.org"}
+			apiClient, err := test.SetupClient(test.WithObjects(app))
+			require.NoError(t, err)
+
+			tt.cmd.Kind = "app"
+			if tt.cmd.Name == "" {
+				tt.cmd.Name = app.Name
+			}
+			tt.cmd.out = &bytes.Buffer{}
+			err = tt.cmd.Run(ctx, apiClient)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NoError(t, apiClient.Get(ctx, api.ObjectName(app), app))
+			assert.Equal(t, tt.wantSize, app.Spec.ForProvider.Config.Size)
+			assert.Equal(t, tt.wantHosts, app.Spec.ForProvider.Hosts)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Package power contains the commands to start and stop resources like
+// CloudVMs and to pause and resume applications.
+package power
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	powerStateOn       infrastructure.VirtualMachinePowerState = "on"
+	powerStateOff      infrastructure.VirtualMachinePowerState = "off"
+	powerStateShutdown infrastructure.VirtualMachinePowerState = "shutdown"
+)
+
+type StartCmd struct {
+	CloudVirtualMachine startVMCmd `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm,vm" help:"Start a CloudVM."`
+}
+
+type StopCmd struct {
+	CloudVirtualMachine stopVMCmd `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm,vm" help:"Stop a CloudVM."`
+}
+
+type PauseCmd struct {
+	Application pauseAppCmd `cmd:"" group:"deplo.io" name:"application" aliases:"app,apps" help:"Pause a deplo.io Application. All replicas and jobs are stopped and no costs accrue while paused."`
+}
+
+type ResumeCmd struct {
+	Application resumeAppCmd `cmd:"" group:"deplo.io" name:"application" aliases:"app,apps" help:"Resume a paused deplo.io Application."`
+}
+
+type resourceCmd struct {
+	Name        string        `arg:"" predictor:"resource_name" help:"Name of the resource."`
+	Wait        bool          `default:"true" help:"Wait until the new power state is reached."`
+	WaitTimeout time.Duration `default:"5m" help:"Duration to wait for the new power state. Only relevant if --wait is set."`
+}
+
+type startVMCmd struct {
+	resourceCmd
+}
+
+type stopVMCmd struct {
+	resourceCmd
+	Force bool `help:"Turn the CloudVM off immediately instead of shutting it down via ACPI."`
+}
+
+type appCmd struct {
+	Name string `arg:"" predictor:"resource_name" help:"Name of the application."`
+}
+
+type pauseAppCmd struct {
+	appCmd
+}
+
+type resumeAppCmd struct {
+	appCmd
+}
+
+// pollInterval is the interval in which the power state is checked while
+// waiting if changes to the CloudVM can't be watched.
+var pollInterval = 2 * time.Second
+
+func (cmd *startVMCmd) Run(ctx context.Context, client *api.Client) error {
+	return cmd.setPowerState(ctx, client, powerStateOn, powerStateOn, "started")
+}
+
+func (cmd *stopVMCmd) Run(ctx context.Context, client *api.Client) error {
+	state := powerStateShutdown
+	if cmd.Force {
+		state = powerStateOff
+	}
+	return cmd.setPowerState(ctx, client, state, powerStateOff, "stopped")
+}
+
+// setPowerState sets the desired power state of the VM and waits until the
+// observed power state matches the expected one.
+func (cmd *resourceCmd) setPowerState(ctx context.Context, client *api.Client, desired, expected infrastructure.VirtualMachinePowerState, done string) error {
+	vm := &infrastructure.CloudVirtualMachine{}
+	if err := client.Get(ctx, client.Name(cmd.Name), vm); err != nil {
+		return err
+	}
+
+	if vm.Spec.ForProvider.PowerState != desired {
+		vm.Spec.ForProvider.PowerState = desired
+		if err := client.Update(ctx, vm); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Wait {
+		format.PrintSuccessf("⚡", "set power state of cloudvm %q to %s", cmd.Name, desired)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.WaitTimeout)
+	defer cancel()
+
+	progress, err := format.NewProgress(
+		format.ProgressMessagef("⏳", "waiting for cloudvm %q to be %s", cmd.Name, done),
+		format.ProgressMessagef("⚡", "cloudvm %q %s", cmd.Name, done),
+	)
+	if err != nil {
+		return err
+	}
+	_ = progress.Start()
+
+	err = client.WaitUntil(ctx, pollInterval, func() (bool, error) {
+		if err := client.Get(ctx, client.Name(cmd.Name), vm); err != nil {
+			return false, err
+		}
+		progress.SetStatus(string(vm.Status.AtProvider.PowerState))
+		return vm.Status.AtProvider.PowerState == expected, nil
+	}, api.WatchObject(vm))
+	if err != nil {
+		_ = progress.StopFail()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timeout waiting for cloudvm %q to be %s, current power state: %q", cmd.Name, done, vm.Status.AtProvider.PowerState)
+		}
+		return err
+	}
+	return progress.Stop()
+}
+
+func (cmd *pauseAppCmd) Run(ctx context.Context, client *api.Client) error {
+	return cmd.setPaused(ctx, client, true)
+}
+
+func (cmd *resumeAppCmd) Run(ctx context.Context, client *api.Client) error {
+	return cmd.setPaused(ctx, client, false)
+}
+
+// setPaused pauses or resumes the application. The configuration of the app
+// is kept as is, so resuming brings back the same replicas and jobs.
+func (cmd *appCmd) setPaused(ctx context.Context, client *api.Client, paused bool) error {
+	app := &apps.Application{}
+	if err := client.Get(ctx, client.Name(cmd.Name), app); err != nil {
+		return err
+	}
+
+	state := "resumed"
+	if paused {
+		state = "paused"
+	}
+	if app.Spec.ForProvider.Paused == paused {
+		format.PrintWarningf("application %q is already %s\n", cmd.Name, state)
+		return nil
+	}
+
+	app.Spec.ForProvider.Paused = paused
+	if err := client.Update(ctx, app); err != nil {
+		return err
+	}
+
+	format.PrintSuccessf("⏯", "%s application %q", state, cmd.Name)
+	return nil
+}
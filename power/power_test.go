@@ -0,0 +1,108 @@
+package power
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPower(t *testing.T) {
+	ctx := context.Background()
+	pollInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name string
+		cmd  interface {
+			Run(context.Context, *api.Client) error
+		}
+		current   infrastructure.VirtualMachinePowerState
+		observed  infrastructure.VirtualMachinePowerState
+		wantState infrastructure.VirtualMachinePowerState
+		wantErr   bool
+	}{
+		{
+			name:      "start",
+			cmd:       &startVMCmd{resourceCmd{Name: "vm", Wait: true, WaitTimeout: time.Second}},
+			current:   powerStateOff,
+			observed:  powerStateOn,
+			wantState: powerStateOn,
+		},
+		{
+			name:      "stop",
+			cmd:       &stopVMCmd{resourceCmd: resourceCmd{Name: "vm"}},
+			current:   powerStateOn,
+			observed:  powerStateOn,
+			wantState: powerStateShutdown,
+		},
+		{
+			name:      "stop forced",
+			cmd:       &stopVMCmd{resourceCmd: resourceCmd{Name: "vm", Wait: true, WaitTimeout: time.Second}, Force: true},
+			current:   powerStateOn,
+			observed:  powerStateOff,
+			wantState: powerStateOff,
+		},
+		{
+			name:      "wait timeout",
+			cmd:       &stopVMCmd{resourceCmd: resourceCmd{Name: "vm", Wait: true, WaitTimeout: 50 * time.Millisecond}},
+			current:   powerStateOn,
+			observed:  powerStateOn,
+			wantState: powerStateShutdown,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := test.CloudVirtualMachine("vm", test.DefaultProject, "nine-es34", tt.current)
+			vm.Status.AtProvider.PowerState = tt.observed
+			apiClient, err := test.SetupClient(test.WithObjects(vm))
+			require.NoError(t, err)
+
+			err = tt.cmd.Run(ctx, apiClient)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			updated := &infrastructure.CloudVirtualMachine{}
+			require.NoError(t, apiClient.Get(ctx, apiClient.Name("vm"), updated))
+			assert.Equal(t, tt.wantState, updated.Spec.ForProvider.PowerState)
+		})
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	ctx := context.Background()
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: test.DefaultProject},
+		Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+			Config: apps.Config{Replicas: ptr.To(int32(3))},
+		}},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(app))
+	require.NoError(t, err)
+
+	updated := &apps.Application{}
+	require.NoError(t, (&pauseAppCmd{appCmd{Name: "app"}}).Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("app"), updated))
+	assert.True(t, updated.Spec.ForProvider.Paused)
+	assert.Equal(t, int32(3), *updated.Spec.ForProvider.Config.Replicas)
+
+	// pausing twice is a no-op
+	require.NoError(t, (&pauseAppCmd{appCmd{Name: "app"}}).Run(ctx, apiClient))
+
+	require.NoError(t, (&resumeAppCmd{appCmd{Name: "app"}}).Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("app"), updated))
+	assert.False(t, updated.Spec.ForProvider.Paused)
+
+	assert.Error(t, (&pauseAppCmd{appCmd{Name: "missing"}}).Run(ctx, apiClient))
+}
@@ -0,0 +1,114 @@
+package power
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type RestartCmd struct {
+	Application restartAppCmd `cmd:"" group:"deplo.io" name:"application" aliases:"app,apps" help:"Restart the replicas of a deplo.io Application."`
+}
+
+type restartAppCmd struct {
+	appCmd
+	Replica string `help:"Name of the replica to restart, see \"nctl get app <name> --replicas\". All replicas of the web process and the workers are restarted if not given."`
+	Force   bool   `help:"Do not ask for confirmation before restarting all replicas."`
+	// runtimeClient returns the client of the deplo.io cluster the replicas
+	// run on, defaults to the runtime client of the API client.
+	runtimeClient func(ctx context.Context, client *api.Client) (runtimeclient.Client, error)
+	// confirm defaults to format.Confirmf.
+	confirm func(format string, a ...any) (bool, error)
+}
+
+func (cmd *restartAppCmd) Help() string {
+	return `Restarts replicas of an application by deleting them on the deplo.io
+cluster, they are replaced with new replicas of the same release right away.
+
+All replicas are restarted at the same time, which leads to a short downtime.
+To restart them one after another without downtime, roll out the release
+again with "nctl update app <name> --retry-release".
+
+Examples:
+
+  # restart a single stuck replica
+  nctl restart app myapp --replica myapp-7d9c8b5f6-x2x4k
+
+  # restart all replicas of the application
+  nctl restart app myapp`
+}
+
+func (cmd *restartAppCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.runtimeClient == nil {
+		cmd.runtimeClient = deploioRuntimeClient
+	}
+	if cmd.confirm == nil {
+		cmd.confirm = format.Confirmf
+	}
+
+	release, err := util.ApplicationLatestAvailableRelease(ctx, client, client.Name(cmd.Name))
+	if err != nil {
+		return err
+	}
+	replicas := []string{}
+	for _, r := range util.ReleaseReplicas(release, false) {
+		if cmd.Replica == "" || r.ReplicaName == cmd.Replica {
+			replicas = append(replicas, r.ReplicaName)
+		}
+	}
+	if len(replicas) == 0 {
+		if cmd.Replica != "" {
+			return fmt.Errorf("replica %q of application %q not found, list the replicas with: nctl get app %s --replicas", cmd.Replica, cmd.Name, cmd.Name)
+		}
+		return fmt.Errorf("application %q has no running replicas", cmd.Name)
+	}
+
+	if cmd.Replica == "" && !cmd.Force {
+		ok, err := cmd.confirm("restart all %d replicas of application %q at the same time?", len(replicas), cmd.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	runtimeClient, err := cmd.runtimeClient(ctx, client)
+	if err != nil {
+		return fmt.Errorf("can not connect to the deplo.io cluster: %w", err)
+	}
+	for _, name := range replicas {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: client.Project}}
+		// replicas which are already gone are replaced anyway.
+		if err := runtimeClient.Delete(ctx, pod); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("unable to restart replica %q: %w", name, err)
+		}
+	}
+
+	format.PrintSuccessf("🔁", "restarted %s of application %q", replicaList(replicas), cmd.Name)
+	return nil
+}
+
+func replicaList(replicas []string) string {
+	if len(replicas) == 1 {
+		return fmt.Sprintf("replica %s", replicas[0])
+	}
+	return fmt.Sprintf("replicas %s", strings.Join(replicas, ", "))
+}
+
+func deploioRuntimeClient(ctx context.Context, client *api.Client) (runtimeclient.Client, error) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.DeploioRuntimeClient(ctx, scheme)
+}
@@ -0,0 +1,72 @@
+package power
+
+import (
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRestart(t *testing.T) {
+	ctx := context.Background()
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shop-release",
+			Namespace: test.DefaultProject,
+			Labels:    map[string]string{util.ApplicationNameLabel: "shop"},
+		},
+	}
+	release.Status.AtProvider.ReleaseStatus = apps.ReleaseProcessStatusAvailable
+	release.Status.AtProvider.ReplicaObservation = []apps.ReplicaObservation{{ReplicaName: "web-1"}, {ReplicaName: "web-2"}}
+	release.Status.AtProvider.WorkerJobStatus = []apps.WorkerJobStatus{{Name: "queue", ReplicaObservation: []apps.ReplicaObservation{{ReplicaName: "queue-1"}}}}
+	release.Status.AtProvider.ScheduledJobStatus = []apps.ScheduledJobStatus{{Name: "cleanup", ReplicaObservation: []apps.ReplicaObservation{{ReplicaName: "cleanup-1"}}}}
+	apiClient, err := test.SetupClient(test.WithObjects(release))
+	require.NoError(t, err)
+
+	pods := []runtimeclient.Object{}
+	for _, name := range []string{"web-1", "web-2", "queue-1", "cleanup-1"} {
+		pods = append(pods, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: test.DefaultProject}})
+	}
+	runtimeClient := fake.NewClientBuilder().WithObjects(pods...).Build()
+	newCmd := func(replica string, confirmed bool) *restartAppCmd {
+		return &restartAppCmd{
+			appCmd:        appCmd{Name: "shop"},
+			Replica:       replica,
+			runtimeClient: func(context.Context, *api.Client) (runtimeclient.Client, error) { return runtimeClient, nil },
+			confirm:       func(string, ...any) (bool, error) { return confirmed, nil },
+		}
+	}
+	exists := func(name string) bool {
+		err := runtimeClient.Get(ctx, api.NamespacedName(name, test.DefaultProject), &corev1.Pod{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	require.NoError(t, newCmd("web-2", false).Run(ctx, apiClient))
+	assert.False(t, exists("web-2"))
+	assert.True(t, exists("web-1"))
+
+	assert.ErrorContains(t, newCmd("unknown", true).Run(ctx, apiClient), "not found")
+
+	// all replicas are only restarted if confirmed
+	require.NoError(t, newCmd("", false).Run(ctx, apiClient))
+	assert.True(t, exists("web-1"))
+
+	require.NoError(t, newCmd("", true).Run(ctx, apiClient))
+	assert.False(t, exists("web-1"))
+	assert.False(t, exists("queue-1"))
+	// replicas of scheduled jobs end on their own
+	assert.True(t, exists("cleanup-1"))
+}
@@ -94,6 +94,12 @@ func (r *Resource) findKind(arg string) schema.GroupVersionKind {
 		}
 	}
 
+	// short names like "app" or "pg" are resolved like kinds given by the
+	// user.
+	if gvk, err := api.LookupKind(r.client.Scheme(), arg); err == nil {
+		return gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	}
+
 	return schema.GroupVersionKind{}
 }
 
@@ -0,0 +1,275 @@
+package promote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/format"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	secretsPrompt = "prompt"
+	secretsCopy   = "copy"
+	secretsSkip   = "skip"
+
+	envPrompt      = "prompt"
+	envSource      = "source"
+	envDestination = "destination"
+)
+
+type Cmd struct {
+	App appCmd `cmd:"" aliases:"application,apps" help:"Promote an application to another project."`
+}
+
+type appCmd struct {
+	Name      string `arg:"" predictor:"resource_name" help:"Name of the application to promote."`
+	ToProject string `required:"" placeholder:"PROJECT" help:"Name of the project the application is promoted to. The project needs to exist already."`
+	Pin       bool   `help:"Pin the git revision of the build which is running in the current release, instead of following the revision of the source application."`
+	Env       string `enum:"prompt,source,destination" default:"prompt" help:"How to handle env variables which are set to different values in both projects: ask for each variable, use the value of the source or keep the value of the destination. ${enum}"`
+	Secrets   string `enum:"prompt,copy,skip" default:"prompt" help:"How to handle the git credentials of the application if they don't exist in the destination yet: ask, copy or skip. ${enum}"`
+	DryRun    bool   `help:"Only print the changes which would be made."`
+	out       io.Writer
+	// confirm asks about secrets and differing env variables, defaults to
+	// format.Confirmf.
+	confirm func(format string, a ...any) (bool, error)
+}
+
+func (cmd *appCmd) Help() string {
+	return "Copies the spec of an application in the current project to another project,\n" +
+		"e.g. to promote what has been tested in staging to production. If the application\n" +
+		"exists in the destination already, it is updated. Hosts and the paused state of\n" +
+		"the destination are never changed and env variables which only exist in the\n" +
+		"destination are kept.\n\n" +
+		"Example:\n\n" +
+		"  nctl promote app myapp --project staging --to-project prod --pin"
+}
+
+func (cmd *appCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.confirm == nil {
+		cmd.confirm = format.Confirmf
+	}
+	if client.Project == cmd.ToProject {
+		return fmt.Errorf("source and destination project need to be different")
+	}
+	if err := cmd.checkDestination(ctx, client); err != nil {
+		return err
+	}
+
+	source := &apps.Application{}
+	if err := client.Get(ctx, api.NamespacedName(cmd.Name, client.Project), source); err != nil {
+		return err
+	}
+	params := source.Spec.ForProvider.DeepCopy()
+	if cmd.Pin {
+		revision, err := cmd.releasedRevision(ctx, client, source)
+		if err != nil {
+			return err
+		}
+		params.Git.Revision = revision
+	}
+
+	dest := &apps.Application{}
+	err := client.Get(ctx, api.NamespacedName(cmd.Name, cmd.ToProject), dest)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if !exists {
+		dest = &apps.Application{ObjectMeta: metav1.ObjectMeta{Name: cmd.Name, Namespace: cmd.ToProject}}
+	}
+
+	if err := cmd.promote(params, &dest.Spec.ForProvider, exists); err != nil {
+		return err
+	}
+
+	if cmd.DryRun {
+		action := "create"
+		if exists {
+			action = "update"
+		}
+		fmt.Fprintf(cmd.out, "would %s application %q in project %s with git revision %s\n",
+			action, dest.Name, cmd.ToProject, dest.Spec.ForProvider.Git.Revision)
+		return nil
+	}
+
+	if err := cmd.copyGitAuth(ctx, client, source); err != nil {
+		return err
+	}
+
+	if exists {
+		if err := client.Update(ctx, dest); err != nil {
+			return fmt.Errorf("unable to update application %q: %w", dest.Name, err)
+		}
+	} else {
+		if err := client.Create(ctx, dest); err != nil {
+			return fmt.Errorf("unable to create application %q: %w", dest.Name, err)
+		}
+	}
+
+	fmt.Fprintln(cmd.out, format.SuccessMessagef("🚀", "promoted application %q from project %s to %s (revision %s)",
+		dest.Name, client.Project, cmd.ToProject, dest.Spec.ForProvider.Git.Revision))
+	return nil
+}
+
+// checkDestination ensures the destination project exists as we can't
+// create the application otherwise.
+func (cmd *appCmd) checkDestination(ctx context.Context, client *api.Client) error {
+	projects, err := client.Projects(ctx, cmd.ToProject)
+	if err != nil {
+		return err
+	}
+	if len(projects) != 0 {
+		return nil
+	}
+	org, err := client.Organization()
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf(
+		"project %q does not exist in organization %s, create it first with \"nctl create project %s\"",
+		cmd.ToProject, org, cmd.ToProject,
+	)
+}
+
+// releasedRevision returns the git revision of the build which is used by
+// the latest available release of the app.
+func (cmd *appCmd) releasedRevision(ctx context.Context, client *api.Client, app *apps.Application) (string, error) {
+	release, err := util.ApplicationLatestAvailableRelease(ctx, client, api.ObjectName(app))
+	if err != nil {
+		return "", err
+	}
+	build := &apps.Build{}
+	if err := client.Get(ctx, api.NamespacedName(release.Spec.ForProvider.Build.Name, app.Namespace), build); err != nil {
+		return "", fmt.Errorf("unable to get build of release %s: %w", release.Name, err)
+	}
+	revision := build.Spec.ForProvider.SourceConfig.Git.Revision
+	if revision == "" {
+		return "", fmt.Errorf("build %s of release %s has no git revision", build.Name, release.Name)
+	}
+	return revision, nil
+}
+
+// promote copies the source parameters to dest. If the application exists
+// in the destination, differing env variables are resolved according to the
+// env mode and destination specific fields are kept.
+func (cmd *appCmd) promote(source, dest *apps.ApplicationParameters, exists bool) error {
+	if !exists {
+		*dest = *source
+		// hosts are specific to the project and can't be used twice.
+		dest.Hosts = nil
+		dest.BasicAuthPasswordChange = nil
+		return nil
+	}
+
+	env, err := cmd.mergeEnv("env variable", source.Config.Env, dest.Config.Env)
+	if err != nil {
+		return err
+	}
+	buildEnv, err := cmd.mergeEnv("build env variable", source.BuildEnv, dest.BuildEnv)
+	if err != nil {
+		return err
+	}
+
+	hosts, paused, passwordChange := dest.Hosts, dest.Paused, dest.BasicAuthPasswordChange
+	*dest = *source
+	dest.Hosts, dest.Paused, dest.BasicAuthPasswordChange = hosts, paused, passwordChange
+	dest.Config.Env = env
+	dest.BuildEnv = buildEnv
+	return nil
+}
+
+// mergeEnv returns the env variables of the destination with the variables
+// of the source added. Variables which exist in both with different values
+// are resolved according to the env mode.
+func (cmd *appCmd) mergeEnv(kind string, source, dest apps.EnvVars) (apps.EnvVars, error) {
+	merged := make(apps.EnvVars, len(dest))
+	copy(merged, dest)
+	for _, env := range source {
+		i := slices.IndexFunc(merged, func(e apps.EnvVar) bool { return e.Name == env.Name })
+		if i == -1 {
+			merged = append(merged, env)
+			continue
+		}
+		if merged[i].Value == env.Value {
+			continue
+		}
+		if cmd.DryRun {
+			fmt.Fprintf(cmd.out, "%s %s differs (destination: %q, source: %q)\n", kind, env.Name, merged[i].Value, env.Value)
+			continue
+		}
+		useSource := cmd.Env == envSource
+		if cmd.Env == envPrompt {
+			ok, err := cmd.confirm("%s %s differs (destination: %q, source: %q), use the value of the source?",
+				kind, env.Name, merged[i].Value, env.Value)
+			if err != nil {
+				return nil, err
+			}
+			useSource = ok
+		}
+		if useSource {
+			merged[i].Value = env.Value
+		}
+	}
+	return merged, nil
+}
+
+// copyGitAuth copies the git credentials of the app to the destination
+// project if they don't exist there yet, depending on the secrets mode after
+// asking for confirmation.
+func (cmd *appCmd) copyGitAuth(ctx context.Context, client *api.Client, app *apps.Application) error {
+	auth := app.Spec.ForProvider.Git.Auth
+	if auth == nil || auth.FromSecret == nil || cmd.Secrets == secretsSkip {
+		return nil
+	}
+	name := auth.FromSecret.Name
+
+	err := client.Get(ctx, api.NamespacedName(name, cmd.ToProject), &corev1.Secret{})
+	if err == nil {
+		return nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	if cmd.Secrets == secretsPrompt {
+		ok, err := cmd.confirm("application %q uses git credentials, copy them to project %s?", app.Name, cmd.ToProject)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			format.PrintWarningf("git credentials of application %q not copied, the build will fail until they are added\n", app.Name)
+			return nil
+		}
+	}
+
+	source := &corev1.Secret{}
+	if err := client.Get(ctx, api.NamespacedName(name, app.Namespace), source); err != nil {
+		return fmt.Errorf("unable to get git credentials of application %q: %w", app.Name, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   cmd.ToProject,
+			Labels:      source.Labels,
+			Annotations: map[string]string{util.ManagedByAnnotation: util.NctlName},
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+	if err := client.Create(ctx, secret); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("unable to copy git credentials of application %q: %w", app.Name, err)
+	}
+	return nil
+}
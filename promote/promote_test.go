@@ -0,0 +1,152 @@
+package promote
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	management "github.com/ninech/apis/management/v1alpha1"
+	meta "github.com/ninech/apis/meta/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPromoteApp(t *testing.T) {
+	ctx := context.Background()
+
+	source := func() *apps.Application {
+		return &apps.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "staging"},
+			Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+				Git: apps.ApplicationGitConfig{
+					GitTarget: apps.GitTarget{URL: "https://git.example.org/web.git", Revision: "main"},
+					Auth:      &apps.GitAuth{FromSecret: &meta.LocalReference{Name: "web"}},
+				},
+				Hosts: []string{"staging.example.org"},
+				Config: apps.Config{
+					Size: apps.ApplicationSize("mini"),
+					Env:  apps.EnvVars{{Name: "DEBUG", Value: "true"}, {Name: "FEATURE", Value: "on"}},
+				},
+			}},
+		}
+	}
+	build := &apps.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-build", Namespace: "staging"},
+		Spec: apps.BuildSpec{ForProvider: apps.BuildParameters{
+			SourceConfig: apps.SourceConfig{Git: apps.GitTarget{Revision: "0a1b2c3"}},
+		}},
+	}
+	release := &apps.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web-release", Namespace: "staging",
+			Labels: map[string]string{util.ApplicationNameLabel: "web"},
+		},
+		Spec:   apps.ReleaseSpec{ForProvider: apps.ReleaseParameters{Build: meta.LocalReference{Name: build.Name}}},
+		Status: apps.ReleaseStatus{AtProvider: apps.ReleaseObservation{ReleaseStatus: apps.ReleaseProcessStatusAvailable}},
+	}
+	gitAuth := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "staging"},
+		Data:       map[string][]byte{util.PasswordSecretKey: []byte("secret")},
+	}
+
+	setup := func(t *testing.T, objects ...client.Object) *api.Client {
+		apiClient, err := test.SetupClient(
+			test.WithProjects("staging", "prod"),
+			test.WithDefaultProject("staging"),
+			test.WithObjects(append([]client.Object{source(), build, release, gitAuth}, objects...)...),
+			test.WithNameIndexFor(&management.Project{}),
+			test.WithKubeconfig(t),
+		)
+		require.NoError(t, err)
+		return apiClient
+	}
+
+	t.Run("create in destination", func(t *testing.T) {
+		apiClient := setup(t)
+		cmd := &appCmd{
+			Name: "web", ToProject: "prod", Env: envPrompt, Secrets: secretsCopy, out: &bytes.Buffer{},
+			confirm: func(string, ...any) (bool, error) { t.Fatal("unexpected prompt"); return false, nil },
+		}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		app := &apps.Application{}
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("web", "prod"), app))
+		assert.Equal(t, "main", app.Spec.ForProvider.Git.Revision)
+		assert.Equal(t, apps.ApplicationSize("mini"), app.Spec.ForProvider.Config.Size)
+		assert.Empty(t, app.Spec.ForProvider.Hosts)
+		secret := &corev1.Secret{}
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("web", "prod"), secret))
+		assert.Equal(t, "secret", string(secret.Data[util.PasswordSecretKey]))
+	})
+
+	t.Run("pin and merge env", func(t *testing.T) {
+		prod := source()
+		prod.Namespace = "prod"
+		prod.Spec.ForProvider.Hosts = []string{"www.example.org"}
+		prod.Spec.ForProvider.Config.Size = apps.ApplicationSize("micro")
+		prod.Spec.ForProvider.Config.Env = apps.EnvVars{{Name: "DEBUG", Value: "false"}, {Name: "SECRET_KEY", Value: "prod"}}
+		apiClient := setup(t, prod)
+
+		asked := 0
+		cmd := &appCmd{
+			Name: "web", ToProject: "prod", Pin: true, Env: envPrompt, Secrets: secretsPrompt, out: &bytes.Buffer{},
+			confirm: func(string, ...any) (bool, error) { asked++; return false, nil },
+		}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+		// one prompt for DEBUG and one for the git credentials.
+		assert.Equal(t, 2, asked)
+
+		app := &apps.Application{}
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("web", "prod"), app))
+		assert.Equal(t, "0a1b2c3", app.Spec.ForProvider.Git.Revision)
+		assert.Equal(t, apps.ApplicationSize("mini"), app.Spec.ForProvider.Config.Size)
+		assert.Equal(t, []string{"www.example.org"}, app.Spec.ForProvider.Hosts)
+		assert.Equal(t, apps.EnvVars{
+			{Name: "DEBUG", Value: "false"},
+			{Name: "SECRET_KEY", Value: "prod"},
+			{Name: "FEATURE", Value: "on"},
+		}, app.Spec.ForProvider.Config.Env)
+	})
+
+	t.Run("use source env", func(t *testing.T) {
+		prod := source()
+		prod.Namespace = "prod"
+		prod.Spec.ForProvider.Config.Env = apps.EnvVars{{Name: "DEBUG", Value: "false"}}
+		apiClient := setup(t, prod)
+
+		cmd := &appCmd{Name: "web", ToProject: "prod", Env: envSource, Secrets: secretsSkip, out: &bytes.Buffer{}}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		app := &apps.Application{}
+		require.NoError(t, apiClient.Get(ctx, api.NamespacedName("web", "prod"), app))
+		assert.Equal(t, "true", util.EnvVarByName(app.Spec.ForProvider.Config.Env, "DEBUG").Value)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		apiClient := setup(t)
+		out := &bytes.Buffer{}
+		cmd := &appCmd{Name: "web", ToProject: "prod", Env: envPrompt, Secrets: secretsPrompt, DryRun: true, out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+		assert.Contains(t, out.String(), `would create application "web" in project prod`)
+		assert.Error(t, apiClient.Get(ctx, api.NamespacedName("web", "prod"), &apps.Application{}))
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		apiClient := setup(t)
+		cmd := &appCmd{Name: "web", ToProject: "qa", out: &bytes.Buffer{}}
+		assert.ErrorContains(t, cmd.Run(ctx, apiClient), `project "qa" does not exist`)
+	})
+
+	t.Run("same project", func(t *testing.T) {
+		apiClient := setup(t)
+		cmd := &appCmd{Name: "web", ToProject: "staging", out: &bytes.Buffer{}}
+		assert.Error(t, cmd.Run(ctx, apiClient))
+	})
+}
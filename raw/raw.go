@@ -0,0 +1,94 @@
+package raw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ninech/nctl/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+type Cmd struct {
+	Get getCmd `cmd:"" help:"Get any resource of the Nine API, including the ones nctl has no dedicated commands for."`
+}
+
+type getCmd struct {
+	Kind        string `arg:"" help:"Kind of the resource, e.g. \"postgres\", \"Bucket\" or \"bucket.storage.nine.ch\"."`
+	Name        string `arg:"" optional:"" predictor:"resource_name" help:"Name of the resource. If omitted, all resources of the kind are listed."`
+	Output      string `short:"o" enum:"yaml,json" default:"yaml" help:"Output format. ${enum}"`
+	AllProjects bool   `short:"A" help:"List the resources of all projects."`
+	out         io.Writer
+}
+
+func (cmd *getCmd) Help() string {
+	return "Prints the unmodified resources as returned by the API. Use this for resources which\n" +
+		"nctl doesn't have dedicated commands for yet."
+}
+
+func (cmd *getCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	gvk, err := api.LookupKind(client.Scheme(), cmd.Kind)
+	if err != nil {
+		return err
+	}
+	name, err := client.NamespacedNameFor(gvk, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Name != "" {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := client.Get(ctx, name, obj); err != nil {
+			return fmt.Errorf("unable to get %s %q: %w", strings.ToLower(gvk.Kind), cmd.Name, err)
+		}
+		return cmd.print(obj.Object)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if cmd.AllProjects {
+		err = client.ListObjects(ctx, list, api.AllProjects())
+	} else {
+		err = client.List(ctx, list, runtimeclient.InNamespace(name.Namespace))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to list %s: %w", strings.ToLower(gvk.Kind), err)
+	}
+
+	items := make([]any, len(list.Items))
+	for i := range list.Items {
+		items[i] = list.Items[i].Object
+	}
+	return cmd.print(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	})
+}
+
+func (cmd *getCmd) print(obj map[string]any) error {
+	var b []byte
+	var err error
+	if cmd.Output == "json" {
+		b, err = json.MarshalIndent(obj, "", "  ")
+		b = append(b, '\n')
+	} else {
+		b, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.out.Write(b)
+	return err
+}
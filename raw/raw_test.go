@@ -0,0 +1,58 @@
+package raw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	apiClient, err := test.SetupClient(test.WithObjects(
+		test.Postgres("first", test.DefaultProject, "nine-es34"),
+		test.Postgres("second", test.DefaultProject, "nine-es34"),
+		test.Postgres("other", "other-project", "nine-es34"),
+	))
+	require.NoError(t, err)
+
+	t.Run("single as yaml", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &getCmd{Kind: "postgres.storage.nine.ch", Name: "first", Output: "yaml", out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		obj := map[string]any{}
+		require.NoError(t, yaml.Unmarshal(out.Bytes(), &obj))
+		assert.Equal(t, "Postgres", obj["kind"])
+		assert.Equal(t, "first", obj["metadata"].(map[string]any)["name"])
+	})
+
+	t.Run("list as json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := &getCmd{Kind: "postgres", Output: "json", out: out}
+		require.NoError(t, cmd.Run(ctx, apiClient))
+
+		list := struct {
+			Kind  string           `json:"kind"`
+			Items []map[string]any `json:"items"`
+		}{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &list))
+		assert.Equal(t, "List", list.Kind)
+		assert.Len(t, list.Items, 2)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cmd := &getCmd{Kind: "postgres", Name: "missing", Output: "yaml", out: &bytes.Buffer{}}
+		assert.Error(t, cmd.Run(ctx, apiClient))
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		cmd := &getCmd{Kind: "doesnotexist", Output: "yaml", out: &bytes.Buffer{}}
+		assert.Error(t, cmd.Run(ctx, apiClient))
+	})
+}
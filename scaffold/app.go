@@ -0,0 +1,247 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/create"
+	"github.com/ninech/nctl/deploy"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// autoDetect is the language answer to let deplo.io detect the language.
+const autoDetect = "auto"
+
+var (
+	languages = []string{autoDetect, "ruby", "php", "python", "golang", "nodejs", "static"}
+	sizes     = []string{
+		string(apps.AppMicro),
+		string(apps.AppMini),
+		string(apps.AppStandard1),
+		string(apps.AppStandard2),
+	}
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+type appCmd struct {
+	File                     string        `short:"f" default:"nctl.yaml" predictor:"file" help:"App config file the answers are written to if requested."`
+	SkipRepoAccessCheck      bool          `help:"Skip the git repository access check when creating the application." default:"false"`
+	GitInformationServiceURL string        `help:"URL of the git information service." default:"https://git-info.deplo.io" env:"GIT_INFORMATION_SERVICE_URL" hidden:""`
+	Wait                     bool          `default:"true" help:"Wait until the created application is available."`
+	WaitTimeout              time.Duration `default:"30m" help:"Duration to wait for the application getting available. Only relevant if wait is set."`
+	in                       io.Reader
+	out                      io.Writer
+	// gitRemote returns the URL of the git remote of the current directory,
+	// defaults to the origin remote.
+	gitRemote func() string
+}
+
+func (cmd *appCmd) Help() string {
+	return `Asks for the name, git repository, language, size and environment of a new
+application. Afterwards, the application can be created right away and the
+answers can be written to an app config file (nctl.yaml), to deploy later
+changes with "nctl deploy".
+
+The git repository of the current directory is suggested as source. Like
+"nctl create app", the access to the repository is checked before creating
+the application, credentials of a private repository are asked for and
+stored in a secret, and the release is waited for.`
+}
+
+func (cmd *appCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.in == nil {
+		if !format.IsInteractiveEnvironment(os.Stdin) {
+			return errors.New("nctl init app asks questions and needs a terminal, use \"nctl create app\" or \"nctl deploy\" in scripts")
+		}
+		cmd.in = os.Stdin
+	}
+	if cmd.gitRemote == nil {
+		cmd.gitRemote = originRemote
+	}
+	// the app config is never overwritten, which is checked before
+	// asking all the questions.
+	if _, err := os.Stat(cmd.File); err == nil {
+		return fmt.Errorf("%s already exists, deploy changes with \"nctl deploy -f %s\" or choose another file with --file", cmd.File, cmd.File)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	p := newPrompter(cmd.in, cmd.out)
+	config, err := cmd.ask(p)
+	if err != nil {
+		return err
+	}
+	return cmd.finish(ctx, client, p, config, client.Project)
+}
+
+// ask asks for the settings of the application.
+func (cmd *appCmd) ask(p *prompter) (*deploy.Config, error) {
+	remote := cmd.gitRemote()
+	config := &deploy.Config{}
+	var err error
+	if config.Git.URL, err = p.ask("Git repository URL", remote, required); err != nil {
+		return nil, err
+	}
+	if config.Name, err = p.ask("Name of the application", defaultName(config.Git.URL), validName); err != nil {
+		return nil, err
+	}
+	if config.Git.SubPath, err = p.ask("Directory of the application in the repository (empty for the root)", "", nil); err != nil {
+		return nil, err
+	}
+	if config.Git.Revision, err = p.ask("Branch, tag or commit to deploy", "main", required); err != nil {
+		return nil, err
+	}
+	language, err := p.choose("Language, detected by deplo.io if auto", languages, autoDetect)
+	if err != nil {
+		return nil, err
+	}
+	if language != autoDetect {
+		config.Language = language
+	}
+	if config.Size, err = p.choose("Size", sizes, string(apps.DefaultConfig.Size)); err != nil {
+		return nil, err
+	}
+	if config.Env, err = p.keyValues("Environment variables"); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// finish creates the application and writes the app config as requested.
+func (cmd *appCmd) finish(ctx context.Context, client *api.Client, p *prompter, config *deploy.Config, project string) error {
+	write, err := p.confirm(fmt.Sprintf("Write the settings to %s to deploy changes with \"nctl deploy\"?", cmd.File), true)
+	if err != nil {
+		return err
+	}
+	if write {
+		if len(config.Env) != 0 {
+			fmt.Fprintf(cmd.out, "  the environment variables are written to %s as well, don't commit secrets\n", cmd.File)
+		}
+		if err := deploy.WriteConfig(cmd.File, config); err != nil {
+			return err
+		}
+		format.PrintSuccessf("📝", "wrote the app config of application %q to %s", config.Name, cmd.File)
+	}
+
+	createApp, err := p.confirm(fmt.Sprintf("Create application %q in project %s now?", config.Name, project), true)
+	if err != nil {
+		return err
+	}
+	if !createApp {
+		if write {
+			fmt.Fprintf(cmd.out, "create the application later with: nctl deploy -f %s\n", cmd.File)
+		}
+		return nil
+	}
+	opts, err := cmd.credentials(p, config.Git.URL)
+	if err != nil {
+		return err
+	}
+	return create.Application(ctx, client, config.NewApplication(project), opts)
+}
+
+// credentials asks for the credentials of the git repository, which are
+// needed if it is private.
+func (cmd *appCmd) credentials(p *prompter, gitURL string) (create.ApplicationOptions, error) {
+	opts := create.ApplicationOptions{
+		SkipRepoAccessCheck:      cmd.SkipRepoAccessCheck,
+		GitInformationServiceURL: cmd.GitInformationServiceURL,
+		Wait:                     cmd.Wait,
+		WaitTimeout:              cmd.WaitTimeout,
+	}
+	if isSSHURL(gitURL) {
+		key, err := p.ask("Path to the SSH private key of the repository (empty if not needed)", "", fileExists)
+		if err != nil || key == "" {
+			return opts, err
+		}
+		opts.GitSSHPrivateKeyFromFile = &key
+		return opts, nil
+	}
+	username, err := p.ask("Username of the repository (empty if it is public)", "", nil)
+	if err != nil || username == "" {
+		return opts, err
+	}
+	password, err := p.secret("Password or access token of the repository")
+	if err != nil {
+		return opts, err
+	}
+	opts.GitUsername, opts.GitPassword = &username, &password
+	return opts, nil
+}
+
+// isSSHURL returns true if the git repository is accessed with SSH, either
+// with an ssh:// URL or the scp-like syntax "git@github.com:acme/app.git".
+func isSSHURL(gitURL string) bool {
+	if scheme, _, ok := strings.Cut(gitURL, "://"); ok {
+		return scheme == "ssh"
+	}
+	return strings.Contains(gitURL, "@")
+}
+
+func fileExists(s string) error {
+	if s == "" {
+		return nil
+	}
+	_, err := os.Stat(s)
+	return err
+}
+
+func required(s string) error {
+	if s == "" {
+		return errors.New("a value is required")
+	}
+	return nil
+}
+
+func validName(s string) error {
+	if errs := validation.IsDNS1123Label(s); len(errs) != 0 {
+		return fmt.Errorf("invalid name: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// defaultName suggests the name of the repository or of the current
+// directory as name of the application.
+func defaultName(gitURL string) string {
+	name := strings.TrimSuffix(path.Base(strings.TrimRight(gitURL, "/")), ".git")
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		name = name[i+1:]
+	}
+	if gitURL == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		name = filepath.Base(wd)
+	}
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if validName(name) != nil {
+		return ""
+	}
+	return name
+}
+
+// originRemote returns the URL of the origin remote of the git repository in
+// the current directory or an empty string.
+func originRemote() string {
+	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
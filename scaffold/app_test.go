@@ -0,0 +1,116 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api/util"
+	"github.com/ninech/nctl/deploy"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestInitApp(t *testing.T) {
+	ctx := context.Background()
+	apiClient, err := test.SetupClient()
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), deploy.DefaultFile)
+
+	gitInfoService := test.NewGitInformationService()
+	gitInfoService.Start()
+	defer gitInfoService.Close()
+	gitInfoService.SetResponse(test.GitInformationServiceResponse{
+		Code: 200,
+		Content: apps.GitExploreResponse{RepositoryInfo: &apps.RepositoryInfo{
+			URL:              "git@github.com:acme/Payments_API.git",
+			RevisionResponse: &apps.RevisionResponse{RevisionRequested: "production", Found: true},
+		}},
+	})
+
+	answers := []string{
+		"",               // git URL of the remote
+		"Invalid_Name",   // rejected
+		"",               // name from the git URL
+		"",               // no sub path
+		"production",     // revision
+		"cobol",          // rejected
+		"ruby",           // language
+		"",               // default size
+		"RAILS_ENV=prod", // env
+		"nope",           // rejected
+		"",               // end of env
+		"y",              // write config
+		"",               // create
+		"",               // no SSH key
+	}
+	out := &bytes.Buffer{}
+	cmd := &appCmd{
+		File:                     file,
+		GitInformationServiceURL: gitInfoService.URL(),
+		in:                       strings.NewReader(strings.Join(answers, "\n") + "\n"),
+		out:                      out,
+		gitRemote:                func() string { return "git@github.com:acme/Payments_API.git" },
+	}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	request, err := gitInfoService.Request()
+	require.NoError(t, err)
+	assert.Equal(t, "production", request.Request.Revision, "the repository access is checked")
+	assert.Contains(t, out.String(), "invalid name")
+	assert.Contains(t, out.String(), `"cobol" is not one of`)
+	assert.Contains(t, out.String(), `"nope" is not in the format KEY=VALUE`)
+
+	app := &apps.Application{}
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("payments-api"), app))
+	assert.Equal(t, "git@github.com:acme/Payments_API.git", app.Spec.ForProvider.Git.URL)
+	assert.Equal(t, "production", app.Spec.ForProvider.Git.Revision)
+	assert.Equal(t, apps.Language("ruby"), app.Spec.ForProvider.Language)
+	assert.Equal(t, apps.DefaultConfig.Size, app.Spec.ForProvider.Config.Size)
+	assert.Equal(t, "prod", app.Spec.ForProvider.Config.Env[0].Value)
+
+	config, err := deploy.ReadConfig(file)
+	require.NoError(t, err)
+	assert.Equal(t, "payments-api", config.Name)
+	assert.Equal(t, map[string]string{"RAILS_ENV": "prod"}, config.Env)
+
+	// nothing is created or written if declined
+	cmd = &appCmd{
+		File:      filepath.Join(t.TempDir(), deploy.DefaultFile),
+		in:        strings.NewReader("https://example.org/other.git\n\n\n\n\n\n\nn\nn\n"),
+		out:       &bytes.Buffer{},
+		gitRemote: func() string { return "" },
+	}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.NoFileExists(t, cmd.File)
+	assert.Error(t, apiClient.Get(ctx, apiClient.Name("other"), app))
+
+	// the credentials of a private repository are stored in a secret
+	cmd = &appCmd{
+		File:                filepath.Join(t.TempDir(), deploy.DefaultFile),
+		SkipRepoAccessCheck: true,
+		in:                  strings.NewReader("https://example.org/private.git\n\n\n\n\n\n\nn\n\nbot\n\ns3cret\n"),
+		out:                 &bytes.Buffer{},
+		gitRemote:           func() string { return "" },
+	}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name("private"), app))
+	require.NotNil(t, app.Spec.ForProvider.Git.Auth)
+	secret := &corev1.Secret{}
+	require.NoError(t, apiClient.Get(ctx, apiClient.Name(app.Spec.ForProvider.Git.Auth.FromSecret.Name), secret))
+	assert.Equal(t, "s3cret", string(secret.Data[util.PasswordSecretKey]))
+
+	// an existing app config fails before asking anything
+	cmd = &appCmd{File: file, in: strings.NewReader(""), out: &bytes.Buffer{}, gitRemote: func() string { return "" }}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "already exists")
+}
+
+func TestDefaultName(t *testing.T) {
+	assert.Equal(t, "payments-api", defaultName("https://github.com/acme/payments-api.git"))
+	assert.Equal(t, "shop", defaultName("git@gitlab.com:shop.git"))
+	assert.Equal(t, "scaffold", defaultName(""))
+}
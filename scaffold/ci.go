@@ -0,0 +1,346 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/deploy"
+	"github.com/ninech/nctl/internal/format"
+)
+
+const (
+	providerGitHub = "github"
+	providerGitLab = "gitlab"
+
+	// serviceAccountName is the name suggested for the service account the
+	// pipeline logs in with.
+	serviceAccountName = "ci"
+)
+
+// defaultPipelineFiles are the files the pipelines are written to if no
+// output is given.
+var defaultPipelineFiles = map[string]string{
+	providerGitHub: filepath.Join(".github", "workflows", "deploy.yml"),
+	providerGitLab: ".gitlab-ci.yml",
+}
+
+var (
+	safeShellWord = regexp.MustCompile(`^[a-zA-Z0-9._/-]+$`)
+	// commitSHA and versionTag match revisions which are most likely not
+	// a branch.
+	commitSHA  = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+	versionTag = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)+`)
+)
+
+type ciCmd struct {
+	Provider string `default:"github" enum:"github,gitlab" help:"CI provider to write the pipeline for. Possible values: ${enum}"`
+	File     string `short:"f" default:"nctl.yaml" predictor:"file" help:"App config file of the application to deploy."`
+	App      string `name:"application" predictor:"resource_name" help:"Name of an existing application to deploy. Its settings are written to the app config file if the file does not exist yet."`
+	Output   string `short:"o" placeholder:"PATH" help:"File to write the pipeline to, \"-\" prints it instead. Defaults to .github/workflows/deploy.yml for GitHub and .gitlab-ci.yml for GitLab."`
+	Branch   string `placeholder:"BRANCH" help:"Branch whose pushes are deployed. Defaults to the git revision of the app config, which has to be a branch then, or main if the revision is empty."`
+	Force    bool   `help:"Overwrite an existing pipeline file."`
+	out      io.Writer
+}
+
+// pipeline are the values the pipeline templates are rendered with.
+type pipeline struct {
+	App          string
+	Project      string
+	Organization string
+	Branch       string
+	// Deploy is the nctl deploy command without the revision flag.
+	Deploy string
+}
+
+func (cmd *ciCmd) Help() string {
+	return `Writes a CI pipeline which deploys the application on every push to the
+branch of the app config with "nctl deploy" and waits until the release is
+available. The pushed commit is deployed, so the application is only changed
+by the pipeline afterwards. If the app config points to a tag or a commit,
+the branch has to be given with --branch.
+
+The pipeline logs in with the API token of a service account, which has to be
+stored as the secret NCTL_API_TOKEN of the repository, see the output of the
+command for the steps.
+
+Examples:
+
+  # pipeline for the app config nctl.yaml
+  nctl init ci
+
+  # write nctl.yaml from an existing application as well
+  nctl init ci --application myapp --provider gitlab
+
+  # deploy pushes to main, the app config points to a tag
+  nctl init ci --branch main
+`
+}
+
+func (cmd *ciCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	config, err := cmd.config(ctx, client)
+	if err != nil {
+		return err
+	}
+	org, err := client.Organization()
+	if err != nil {
+		return err
+	}
+
+	branch, err := cmd.branch(config.Git.Revision)
+	if err != nil {
+		return err
+	}
+
+	project := config.Project
+	if project == "" {
+		project = client.Project
+	}
+	p := pipeline{
+		App:          config.Name,
+		Project:      project,
+		Organization: org,
+		Branch:       branch,
+		Deploy:       cmd.deployCommand(project),
+	}
+
+	content, err := p.render(cmd.Provider)
+	if err != nil {
+		return err
+	}
+	if cmd.Output == "-" {
+		_, err := cmd.out.Write(content)
+		return err
+	}
+
+	path := cmd.Output
+	if path == "" {
+		path = defaultPipelineFiles[cmd.Provider]
+	}
+	if err := writeFile(path, content, cmd.Force); err != nil {
+		return err
+	}
+	format.PrintSuccessf("📝", "wrote the pipeline deploying application %q to %s", p.App, path)
+	cmd.printNextSteps(p)
+	return nil
+}
+
+// config returns the app config of the application. It is written from the
+// live application if an application is given and the file does not exist.
+func (cmd *ciCmd) config(ctx context.Context, client *api.Client) (*deploy.Config, error) {
+	_, err := os.Stat(cmd.File)
+	exists := err == nil
+	if cmd.App == "" || exists {
+		config, err := deploy.ReadConfig(cmd.File)
+		if err != nil {
+			if !exists {
+				return nil, fmt.Errorf("%w, use --application to write it from an existing application", err)
+			}
+			return nil, err
+		}
+		if cmd.App != "" && cmd.App != config.Name {
+			return nil, fmt.Errorf("the app config %s describes application %q, not %q", cmd.File, config.Name, cmd.App)
+		}
+		return config, nil
+	}
+
+	app := &apps.Application{}
+	if err := client.Get(ctx, client.Name(cmd.App), app); err != nil {
+		return nil, err
+	}
+	config := deploy.ConfigFromApplication(app)
+	if err := deploy.WriteConfig(cmd.File, config); err != nil {
+		return nil, err
+	}
+	format.PrintSuccessf("📝", "wrote the app config of application %q to %s", app.Name, cmd.File)
+	return config, nil
+}
+
+// branch returns the branch whose pushes trigger the pipeline. Without
+// --branch it is the git revision of the app config, which is rejected if
+// it looks like a tag or a commit.
+func (cmd *ciCmd) branch(revision string) (string, error) {
+	if cmd.Branch != "" {
+		return strings.TrimPrefix(cmd.Branch, "refs/heads/"), nil
+	}
+	if revision == "" {
+		return "main", nil
+	}
+	if branch, ok := strings.CutPrefix(revision, "refs/heads/"); ok {
+		return branch, nil
+	}
+	if strings.HasPrefix(revision, "refs/") || commitSHA.MatchString(revision) || versionTag.MatchString(revision) {
+		return "", fmt.Errorf("the git revision %q of the app config is not a branch, use --branch to set the branch which is deployed", revision)
+	}
+	return revision, nil
+}
+
+// deployCommand returns the command deploying the application in the
+// pipeline. The revision is added by the template, as it depends on the
+// provider.
+func (cmd *ciCmd) deployCommand(project string) string {
+	args := []string{"nctl", "deploy", "--project", shellQuote(project)}
+	if cmd.File != deploy.DefaultFile {
+		args = append(args, "-f", shellQuote(filepath.ToSlash(cmd.File)))
+	}
+	args = append(args, "--force", "--wait")
+	if cmd.Provider == providerGitHub {
+		args = append(args, "--github-status")
+	}
+	return strings.Join(args, " ")
+}
+
+func (cmd *ciCmd) printNextSteps(p pipeline) {
+	where := "as secret NCTL_API_TOKEN of the repository (Settings > Secrets and variables > Actions)"
+	if cmd.Provider == providerGitLab {
+		where = "as masked CI/CD variable NCTL_API_TOKEN of the project (Settings > CI/CD > Variables)"
+	}
+	fmt.Fprintf(cmd.out, "\nCreate a service account for the pipeline and store its token %s:\n\n", where)
+	fmt.Fprintf(cmd.out, "  nctl create apiserviceaccount %s --project %s\n", serviceAccountName, p.Project)
+	fmt.Fprintf(cmd.out, "  nctl get apiserviceaccount %s --project %s --print-token\n\n", serviceAccountName, p.Project)
+	fmt.Fprintf(cmd.out, "Then commit the pipeline and push it to branch %s.\n", p.Branch)
+}
+
+func (p pipeline) render(provider string) ([]byte, error) {
+	tmpl, ok := pipelineTemplates[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown CI provider %q", provider)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, p); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFile writes the content to path and creates the directory if needed.
+// An existing file is only overwritten if force is set.
+func writeFile(path string, content []byte, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", path)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+// shellQuote quotes s for a POSIX shell if needed.
+func shellQuote(s string) string {
+	if safeShellWord.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// yamlQuote returns s as a double quoted YAML string.
+func yamlQuote(s string) string {
+	// a JSON string is a valid double quoted YAML string.
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// the templates use other delimiters, as GitHub expressions look like Go
+// template actions.
+var pipelineTemplates = map[string]*template.Template{
+	providerGitHub: newPipelineTemplate(providerGitHub, githubTemplate),
+	providerGitLab: newPipelineTemplate(providerGitLab, gitlabTemplate),
+}
+
+func newPipelineTemplate(name, text string) *template.Template {
+	return template.Must(template.New(name).
+		Delims("[[", "]]").
+		Funcs(template.FuncMap{
+			"quote": yamlQuote,
+			"branchRule": func(branch string) string {
+				return yamlQuote(fmt.Sprintf("$CI_COMMIT_BRANCH == %s", yamlQuote(branch)))
+			},
+		}).
+		Parse(text))
+}
+
+const githubTemplate = `# Deploys the application [[ .App ]] of project [[ .Project ]] to deplo.io on
+# every push to [[ .Branch ]]. Generated by "nctl init ci".
+#
+# The API token of a service account has to be stored as secret NCTL_API_TOKEN
+# of the repository.
+name: deploy [[ .App ]]
+
+on:
+  push:
+    branches:
+      - [[ quote .Branch ]]
+
+permissions:
+  contents: read
+  statuses: write
+
+concurrency:
+  group: [[ quote (printf "deploy-%s-%s" .Project .App) ]]
+  cancel-in-progress: false
+
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Install nctl
+        run: |
+          echo "deb [trusted=yes] https://repo.nine.ch/deb/ /" | sudo tee /etc/apt/sources.list.d/repo.nine.ch.list
+          sudo apt-get update
+          sudo apt-get install -y nctl
+      - name: Log in
+        run: nctl auth login
+        env:
+          NCTL_API_TOKEN: ${{ secrets.NCTL_API_TOKEN }}
+          NCTL_ORGANIZATION: [[ quote .Organization ]]
+      - name: Deploy
+        run: |
+          [[ .Deploy ]] --revision "$GITHUB_SHA"
+        env:
+          GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}
+`
+
+const gitlabTemplate = `# Deploys the application [[ .App ]] of project [[ .Project ]] to deplo.io on
+# every push to [[ .Branch ]]. Generated by "nctl init ci".
+#
+# The API token of a service account has to be stored as masked CI/CD
+# variable NCTL_API_TOKEN of the project.
+stages:
+  - deploy
+
+deploy:
+  stage: deploy
+  image: ubuntu:24.04
+  resource_group: [[ quote (printf "deploy-%s-%s" .Project .App) ]]
+  rules:
+    - if: [[ branchRule .Branch ]]
+  variables:
+    NCTL_ORGANIZATION: [[ quote .Organization ]]
+  before_script:
+    - apt-get update && apt-get install -y ca-certificates
+    - echo "deb [trusted=yes] https://repo.nine.ch/deb/ /" > /etc/apt/sources.list.d/repo.nine.ch.list
+    - apt-get update && apt-get install -y nctl
+    - nctl auth login
+  script:
+    - |
+      [[ .Deploy ]] --revision "$CI_COMMIT_SHA"
+`
@@ -0,0 +1,104 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/deploy"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCI(t *testing.T) {
+	ctx := context.Background()
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: test.DefaultProject},
+		Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+			Git: apps.ApplicationGitConfig{GitTarget: apps.GitTarget{URL: "https://github.com/acme/shop", Revision: "production"}},
+		}},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(app), test.WithKubeconfig(t))
+	require.NoError(t, err)
+	org, err := apiClient.Organization()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "my config.yaml")
+	output := filepath.Join(dir, ".github", "workflows", "deploy.yml")
+
+	// the app config does not exist yet
+	cmd := &ciCmd{Provider: providerGitHub, File: configFile, Output: output, out: &bytes.Buffer{}}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "--application")
+
+	out := &bytes.Buffer{}
+	cmd = &ciCmd{Provider: providerGitHub, File: configFile, App: "shop", Output: output, out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	config, err := deploy.ReadConfig(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/shop", config.Git.URL)
+	assert.Contains(t, out.String(), "nctl get apiserviceaccount ci --project default --print-token")
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+	workflow := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(content, &workflow))
+	assert.Contains(t, workflow["jobs"], "deploy")
+	assert.Contains(t, string(content), "branches:\n      - \"production\"")
+	assert.Contains(t, string(content), "NCTL_ORGANIZATION: \""+org+"\"")
+	assert.Contains(t, string(content), "nctl deploy --project default -f '"+filepath.ToSlash(configFile)+"' --force --wait --github-status --revision \"$GITHUB_SHA\"")
+	assert.Contains(t, string(content), "${{ secrets.NCTL_API_TOKEN }}")
+
+	// existing pipelines are only overwritten with --force
+	cmd = &ciCmd{Provider: providerGitHub, File: configFile, Output: output, out: &bytes.Buffer{}}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), "already exists")
+	cmd.Force = true
+	assert.NoError(t, cmd.Run(ctx, apiClient))
+
+	// the app config has to match the application
+	cmd = &ciCmd{Provider: providerGitHub, File: configFile, App: "other", Output: "-", out: &bytes.Buffer{}}
+	assert.ErrorContains(t, cmd.Run(ctx, apiClient), `describes application "shop"`)
+
+	out.Reset()
+	cmd = &ciCmd{Provider: providerGitLab, File: configFile, Output: "-", out: out}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	pipeline := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), &pipeline))
+	job := pipeline["deploy"].(map[string]any)
+	assert.Equal(t, `$CI_COMMIT_BRANCH == "production"`, job["rules"].([]any)[0].(map[string]any)["if"])
+	assert.NotContains(t, out.String(), "--github-status")
+	assert.Contains(t, out.String(), `--revision "$CI_COMMIT_SHA"`)
+}
+
+func TestCIBranch(t *testing.T) {
+	for name, tc := range map[string]struct {
+		flag     string
+		revision string
+		want     string
+		wantErr  bool
+	}{
+		"branch revision":      {revision: "production", want: "production"},
+		"empty revision":       {want: "main"},
+		"full branch ref":      {revision: "refs/heads/feature/x", want: "feature/x"},
+		"tag ref":              {revision: "refs/tags/v1", wantErr: true},
+		"version tag":          {revision: "v1.2.3", wantErr: true},
+		"commit":               {revision: "3f2a9c1", wantErr: true},
+		"flag overrides a tag": {flag: "main", revision: "v1.2.3", want: "main"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			branch, err := (&ciCmd{Branch: tc.flag}).branch(tc.revision)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "--branch")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, branch)
+		})
+	}
+}
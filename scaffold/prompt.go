@@ -0,0 +1,155 @@
+package scaffold
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// prompter asks questions on the terminal. Empty answers select the
+// default.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	// terminal is set if the input is a terminal, secrets are then read
+	// without echoing them.
+	terminal *os.File
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	p := &prompter{in: bufio.NewReader(in), out: out}
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		p.terminal = f
+	}
+	return p
+}
+
+// readLine returns the next line of input without surrounding whitespace.
+// The last line does not need to be terminated.
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// ask asks the question until validate accepts the answer. validate may be
+// nil.
+func (p *prompter) ask(question, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+		if validate == nil {
+			return answer, nil
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(p.out, "  %s\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// choose asks to pick one of the options.
+func (p *prompter) choose(question string, options []string, def string) (string, error) {
+	return p.ask(fmt.Sprintf("%s (%s)", question, strings.Join(options, ", ")), def, func(s string) error {
+		if !slices.Contains(options, s) {
+			return fmt.Errorf("%q is not one of %s", s, strings.Join(options, ", "))
+		}
+		return nil
+	})
+}
+
+// confirm asks a yes or no question.
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y|N"
+	if def {
+		hint = "Y|n"
+	}
+	for {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, hint)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// secret asks for a value which is required and not shown while typing it
+// on a terminal.
+func (p *prompter) secret(question string) (string, error) {
+	for {
+		fmt.Fprintf(p.out, "%s: ", question)
+		var answer string
+		if p.terminal != nil {
+			b, err := term.ReadPassword(int(p.terminal.Fd()))
+			fmt.Fprintln(p.out)
+			if err != nil {
+				return "", err
+			}
+			answer = strings.TrimSpace(string(b))
+		} else {
+			line, err := p.readLine()
+			if err != nil {
+				return "", err
+			}
+			answer = line
+		}
+		if err := required(answer); err != nil {
+			fmt.Fprintf(p.out, "  %s\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// keyValues asks for KEY=VALUE pairs until an empty line is entered.
+func (p *prompter) keyValues(question string) (map[string]string, error) {
+	fmt.Fprintf(p.out, "%s, one KEY=VALUE per line, finish with an empty line:\n", question)
+	values := map[string]string{}
+	for {
+		fmt.Fprint(p.out, "  ")
+		line, err := p.readLine()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if line == "" {
+			break
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok || k == "" {
+			fmt.Fprintf(p.out, "  %q is not in the format KEY=VALUE\n", line)
+			continue
+		}
+		values[k] = v
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
+}
@@ -0,0 +1,8 @@
+// Package scaffold contains the commands to set up new applications and the
+// pipelines deploying them.
+package scaffold
+
+type Cmd struct {
+	App appCmd `cmd:"" name:"application" aliases:"app" help:"Set up a new deplo.io application step by step."`
+	CI  ciCmd  `cmd:"" name:"ci" help:"Write a CI pipeline which deploys the application with nctl."`
+}
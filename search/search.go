@@ -0,0 +1,194 @@
+// Package search contains the command to search resources across all
+// projects of the organization.
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	management "github.com/ninech/apis/management/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type Cmd struct {
+	Term string   `arg:"" help:"Text to search for, the case is ignored."`
+	Kind []string `help:"Only search resources of these kinds, e.g. application or postgres."`
+	out  io.Writer
+}
+
+// field is a searched field of a resource. The paths point to string values,
+// a segment ending with "[]" iterates over the items of a list.
+type field struct {
+	name  string
+	paths []string
+}
+
+// fields are the searched fields in the order they are reported.
+var fields = []field{
+	{name: "name", paths: []string{"metadata.name"}},
+	{name: "host", paths: []string{
+		"spec.forProvider.hosts[]",
+		"status.atProvider.hosts[].name",
+		"status.atProvider.defaultURLs[]",
+		"status.atProvider.cnameTarget",
+		"status.atProvider.fqdn",
+		"status.atProvider.url",
+	}},
+	{name: "git", paths: []string{
+		"spec.forProvider.git.url",
+		"spec.forProvider.sourceConfig.git.url",
+	}},
+}
+
+// match is a field of a resource which contains the search term.
+type match struct {
+	project string
+	kind    string
+	name    string
+	field   string
+	value   string
+}
+
+func (cmd *Cmd) Help() string {
+	return "Searches the names, hostnames and git URLs of the resources in all projects\n" +
+		"of the organization. All kinds are listed in parallel, resources of kinds\n" +
+		"which can't be listed are skipped with a warning.\n\n" +
+		"Example:\n\n" +
+		"  nctl search payments-api"
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	term := strings.ToLower(cmd.Term)
+	if strings.TrimSpace(term) == "" {
+		return fmt.Errorf("the search term can not be empty")
+	}
+
+	// kinds are resolved, so that short names like "app" can be used.
+	kinds := make([]string, len(cmd.Kind))
+	for i, k := range cmd.Kind {
+		gvk, err := api.LookupKind(client.Scheme(), k)
+		if err != nil {
+			return err
+		}
+		kinds[i] = gvk.Kind
+	}
+
+	projects, err := client.Projects(ctx, "")
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(projects))
+	found := []match{}
+	for i, p := range projects {
+		names[i] = p.Name
+		if includesKind(kinds, management.ProjectKind) && strings.Contains(strings.ToLower(p.Name), term) {
+			found = append(found, match{
+				project: p.Namespace,
+				kind:    strings.ToLower(management.ProjectKind),
+				name:    p.Name,
+				field:   "name",
+				value:   p.Name,
+			})
+		}
+	}
+
+	items, warnings, err := client.ProjectResources(ctx, names, kinds, false)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		format.PrintWarningf("%s\n", w)
+	}
+	for _, item := range items {
+		found = append(found, matches(item, term)...)
+	}
+
+	if len(found) == 0 {
+		fmt.Fprintf(cmd.out, "no resources matching %q found\n", cmd.Term)
+		return nil
+	}
+
+	w := format.NewTable(cmd.out, format.Truncate())
+	fmt.Fprintln(w, "PROJECT\tKIND\tNAME\tMATCH")
+	for _, m := range found {
+		value := fmt.Sprintf("%s: %s", m.field, m.value)
+		if m.field == "name" {
+			value = m.field
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.project, m.kind, m.name, value)
+	}
+	return w.Flush()
+}
+
+// includesKind reports if resources of kind are searched.
+func includesKind(kinds []string, kind string) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns all fields of the item which contain the lower case term.
+// Every value is only reported once per field.
+func matches(item *unstructured.Unstructured, term string) []match {
+	result := []match{}
+	for _, f := range fields {
+		seen := map[string]bool{}
+		for _, path := range f.paths {
+			for _, v := range values(item.Object, strings.Split(path, ".")) {
+				if seen[v] || !strings.Contains(strings.ToLower(v), term) {
+					continue
+				}
+				seen[v] = true
+				result = append(result, match{
+					project: item.GetNamespace(),
+					kind:    strings.ToLower(item.GetKind()),
+					name:    item.GetName(),
+					field:   f.name,
+					value:   v,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// values returns the string values at the path within obj.
+func values(obj any, path []string) []string {
+	if len(path) == 0 {
+		if s, ok := obj.(string); ok && s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	m, ok := obj.(map[string]any)
+	if !ok {
+		return nil
+	}
+	key, isList := strings.CutSuffix(path[0], "[]")
+	if !isList {
+		return values(m[key], path[1:])
+	}
+	list, ok := m[key].([]any)
+	if !ok {
+		return nil
+	}
+	result := []string{}
+	for _, item := range list {
+		result = append(result, values(item, path[1:])...)
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	management "github.com/ninech/apis/management/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "prod"},
+		Spec: apps.ApplicationSpec{ForProvider: apps.ApplicationParameters{
+			Git:   apps.ApplicationGitConfig{GitTarget: apps.GitTarget{URL: "https://github.com/acme/payments-api"}},
+			Hosts: []string{"pay.example.org"},
+		}},
+	}
+	app.Status.AtProvider.Hosts = []apps.VerificationStatus{{Name: "pay.example.org"}}
+	db := test.Postgres("payments-api-db", "dev", "nine-es34")
+	other := test.Postgres("other", "dev", "nine-es34")
+
+	apiClient, err := test.SetupClient(
+		test.WithObjects(append(test.Projects(test.DefaultProject, "dev", "prod"), app, db, other)...),
+		test.WithNameIndexFor(&management.Project{}),
+		test.WithKubeconfig(t),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, (&Cmd{Term: "Payments-API", out: out}).Run(ctx, apiClient))
+	assert.Contains(t, out.String(), "PROJECT")
+	assert.Regexp(t, `dev\s+postgres\s+payments-api-db\s+name`, out.String())
+	assert.Regexp(t, `prod\s+application\s+shop\s+git: https://github.com/acme/payments-api`, out.String())
+	assert.NotContains(t, out.String(), "other")
+
+	out.Reset()
+	require.NoError(t, (&Cmd{Term: "pay.example", out: out}).Run(ctx, apiClient))
+	assert.Regexp(t, `prod\s+application\s+shop\s+host: pay.example.org\n$`, out.String())
+
+	out.Reset()
+	require.NoError(t, (&Cmd{Term: "payments", Kind: []string{"app"}, out: out}).Run(ctx, apiClient))
+	assert.NotContains(t, out.String(), "postgres")
+	assert.Contains(t, out.String(), "shop")
+
+	out.Reset()
+	require.NoError(t, (&Cmd{Term: "prod", Kind: []string{"project"}, out: out}).Run(ctx, apiClient))
+	assert.Regexp(t, `project\s+prod\s+name`, out.String())
+
+	out.Reset()
+	require.NoError(t, (&Cmd{Term: "nothing", out: out}).Run(ctx, apiClient))
+	assert.Equal(t, "no resources matching \"nothing\" found\n", out.String())
+
+	assert.Error(t, (&Cmd{Term: " "}).Run(ctx, apiClient))
+}
@@ -0,0 +1,161 @@
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+)
+
+type Cmd struct {
+	Sandbox         string        `predictor:"resource_name" help:"Name of an existing project to run the self-test in. If omitted, a throwaway project is created and deleted afterwards."`
+	GitURL          string        `default:"https://github.com/ninech/deploio-examples" help:"URL of the git repository used for the test application."`
+	GitSubPath      string        `default:"nodejs/express" help:"Path in the git repository which contains the test application."`
+	GitRevision     string        `default:"main" help:"Revision of the git repository used for the test application."`
+	SkipApplication bool          `help:"Do not provision a test application."`
+	SkipDatabase    bool          `help:"Do not provision a test database."`
+	Timeout         time.Duration `default:"30m" help:"Duration to wait for the self-test to complete. Cleanup is always attempted, even after the timeout."`
+	CleanupTimeout  time.Duration `default:"10m" help:"Duration to wait for the test resources to be deleted."`
+
+	out io.Writer
+}
+
+type result string
+
+const (
+	resultPass result = "PASS"
+	resultFail result = "FAIL"
+	resultSkip result = "SKIP"
+)
+
+// step is a single check of the self-test.
+type step struct {
+	name string
+	run  func(ctx context.Context) error
+	// cleanup steps are run even if a previous step failed.
+	cleanup bool
+	// skip allows to skip a step, e.g. when a resource which should be
+	// cleaned up has never been created.
+	skip func() bool
+}
+
+type stepResult struct {
+	name     string
+	result   result
+	duration time.Duration
+	err      error
+}
+
+// runner runs steps in order and records their results. Once a step fails,
+// all following steps except cleanups are skipped as they usually depend on
+// the previous ones.
+type runner struct {
+	results []stepResult
+	failed  bool
+}
+
+func (r *runner) run(ctx context.Context, s step) {
+	if (r.failed && !s.cleanup) || (s.skip != nil && s.skip()) {
+		r.results = append(r.results, stepResult{name: s.name, result: resultSkip})
+		return
+	}
+
+	spinner, err := format.NewSpinner(
+		format.ProgressMessage("⏳", s.name),
+		format.ProgressMessage("✅", s.name),
+	)
+	if err == nil {
+		_ = spinner.Start()
+	}
+
+	start := time.Now()
+	err = s.run(ctx)
+	res := stepResult{name: s.name, result: resultPass, duration: time.Since(start), err: err}
+	if err != nil {
+		res.result = resultFail
+		r.failed = true
+	}
+	r.results = append(r.results, res)
+
+	if spinner == nil {
+		return
+	}
+	if err != nil {
+		spinner.StopFailMessage(format.ProgressMessagef("", "%s: %s", s.name, err))
+		_ = spinner.StopFail()
+		return
+	}
+	_ = spinner.Stop()
+}
+
+// failures returns the number of failed steps.
+func (r *runner) failures() int {
+	failures := 0
+	for _, res := range r.results {
+		if res.result == resultFail {
+			failures++
+		}
+	}
+	return failures
+}
+
+func (r *runner) printReport(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "STEP\tRESULT\tDURATION\tMESSAGE")
+	for _, res := range r.results {
+		msg := ""
+		if res.err != nil {
+			msg = res.err.Error()
+		}
+		duration := ""
+		if res.result != resultSkip {
+			duration = res.duration.Round(time.Second).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", res.name, res.result, duration, msg)
+	}
+	return w.Flush()
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	st, err := newSelfTest(client, cmd)
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, cmd.Timeout)
+	defer cancel()
+
+	r := &runner{}
+	for _, s := range st.steps() {
+		stepCtx := runCtx
+		if s.cleanup {
+			// cleanups should still be attempted after the timeout of the
+			// test run has been reached.
+			var cancelCleanup context.CancelFunc
+			stepCtx, cancelCleanup = context.WithTimeout(context.WithoutCancel(ctx), cmd.CleanupTimeout)
+			defer cancelCleanup()
+		}
+		r.run(stepCtx, s)
+	}
+
+	fmt.Fprintln(cmd.out)
+	if err := r.printReport(cmd.out); err != nil {
+		return err
+	}
+
+	if failures := r.failures(); failures > 0 {
+		return fmt.Errorf("self-test failed: %d of %d steps failed", failures, len(r.results))
+	}
+
+	format.PrintSuccess("🎉", "self-test passed")
+	return nil
+}
@@ -0,0 +1,128 @@
+package selftest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+	ran := []string{}
+	record := func(name string, err error) func(context.Context) error {
+		return func(context.Context) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+
+	r := &runner{}
+	for _, s := range []step{
+		{name: "first", run: record("first", nil)},
+		{name: "second", run: record("second", errors.New("boom"))},
+		{name: "third", run: record("third", nil)},
+		{name: "cleanup", run: record("cleanup", nil), cleanup: true},
+		{name: "skipped-cleanup", run: record("skipped-cleanup", nil), cleanup: true, skip: func() bool { return true }},
+	} {
+		r.run(ctx, s)
+	}
+
+	assert.Equal(t, []string{"first", "second", "cleanup"}, ran)
+	assert.Equal(t, 1, r.failures())
+
+	results := []result{}
+	for _, res := range r.results {
+		results = append(results, res.result)
+	}
+	assert.Equal(t, []result{resultPass, resultFail, resultSkip, resultPass, resultSkip}, results)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, r.printReport(out))
+	assert.Contains(t, out.String(), "boom")
+}
+
+func TestSteps(t *testing.T) {
+	st := &selfTest{cmd: &Cmd{}, organization: "org", project: "selftest"}
+
+	names := []string{}
+	for _, s := range st.steps() {
+		names = append(names, s.name)
+	}
+
+	assert.Equal(t, []string{
+		"create project",
+		"create application",
+		"wait for application release",
+		"check application connectivity",
+		"check application logs",
+		"create database",
+		"wait for database",
+		"check database connectivity",
+		"delete database",
+		"delete application",
+		"delete project",
+	}, names)
+}
+
+func TestDatabaseInSandbox(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	apiClient, err := test.SetupClient(test.WithNameIndexFor(&storage.Postgres{}))
+	require.NoError(t, err)
+
+	// simulate a controller which makes the database available
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				list := &storage.PostgresList{}
+				if err := apiClient.List(ctx, list); err != nil || len(list.Items) == 0 {
+					continue
+				}
+				db := &list.Items[0]
+				db.SetConditions(runtimev1.Available())
+				db.Status.AtProvider.FQDN = "localhost"
+				_ = apiClient.Update(ctx, db)
+
+				ref := db.GetWriteConnectionSecretToReference()
+				_ = apiClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace},
+					Data:       map[string][]byte{"password": []byte("secret")},
+				})
+				return
+			}
+		}
+	}()
+
+	out := &bytes.Buffer{}
+	cmd := &Cmd{
+		Sandbox:         apiClient.Project,
+		SkipApplication: true,
+		Timeout:         5 * time.Second,
+		CleanupTimeout:  5 * time.Second,
+		out:             out,
+	}
+	require.NoError(t, cmd.Run(ctx, apiClient))
+	assert.Contains(t, out.String(), "check database connectivity")
+
+	list := &storage.PostgresList{}
+	require.NoError(t, apiClient.List(ctx, list, runtimeclient.InNamespace(apiClient.Project)))
+	assert.Empty(t, list.Items)
+}
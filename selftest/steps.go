@@ -0,0 +1,352 @@
+package selftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/grafana/loki/pkg/loghttp"
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/lucasepe/codename"
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	management "github.com/ninech/apis/management/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/log"
+	"github.com/ninech/nctl/logs"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+)
+
+// pollInterval is the interval in which the state of the test resources is
+// checked.
+var pollInterval = 5 * time.Second
+
+type selfTest struct {
+	client *api.Client
+	cmd    *Cmd
+	// organization is only set if a throwaway project is created.
+	organization string
+	project      string
+
+	projectCreated bool
+	app            *apps.Application
+	database       *storage.Postgres
+}
+
+func newSelfTest(client *api.Client, cmd *Cmd) (*selfTest, error) {
+	st := &selfTest{client: client, cmd: cmd, project: cmd.Sandbox}
+	if len(st.project) != 0 {
+		return st, nil
+	}
+
+	org, err := client.Organization()
+	if err != nil {
+		return nil, err
+	}
+	st.organization = org
+	st.project = testName()
+
+	return st, nil
+}
+
+// steps returns all steps of the self-test in the order they should be run.
+func (st *selfTest) steps() []step {
+	steps := []step{}
+	cleanups := []step{}
+
+	if len(st.organization) != 0 {
+		steps = append(steps, step{name: "create project", run: st.createProject})
+		cleanups = append(cleanups, step{
+			name: "delete project", run: st.deleteProject, cleanup: true,
+			skip: func() bool { return !st.projectCreated },
+		})
+	}
+
+	if !st.cmd.SkipApplication {
+		steps = append(steps,
+			step{name: "create application", run: st.createApplication},
+			step{name: "wait for application release", run: st.waitForRelease},
+			step{name: "check application connectivity", run: st.checkApplicationConnectivity},
+			step{name: "check application logs", run: st.checkApplicationLogs},
+		)
+		cleanups = append(cleanups, step{
+			name: "delete application", run: st.deleteApplication, cleanup: true,
+			skip: func() bool { return st.app == nil },
+		})
+	}
+
+	if !st.cmd.SkipDatabase {
+		steps = append(steps,
+			step{name: "create database", run: st.createDatabase},
+			step{name: "wait for database", run: st.waitForDatabase},
+			step{name: "check database connectivity", run: st.checkDatabaseConnectivity},
+		)
+		cleanups = append(cleanups, step{
+			name: "delete database", run: st.deleteDatabase, cleanup: true,
+			skip: func() bool { return st.database == nil },
+		})
+	}
+
+	// resources are cleaned up in reverse order of creation, the project
+	// needs to be last.
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		steps = append(steps, cleanups[i])
+	}
+
+	return steps
+}
+
+func (st *selfTest) createProject(ctx context.Context) error {
+	project := &management.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      st.project,
+			Namespace: st.organization,
+		},
+		Spec: management.ProjectSpec{
+			DisplayName: "nctl self-test",
+		},
+	}
+	if err := st.client.Create(ctx, project); err != nil {
+		return fmt.Errorf("unable to create project %q: %w", st.project, err)
+	}
+	st.projectCreated = true
+
+	return st.waitForAvailable(ctx, project)
+}
+
+func (st *selfTest) deleteProject(ctx context.Context) error {
+	return st.deleteAndWait(ctx, &management.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: st.project, Namespace: st.organization},
+	})
+}
+
+func (st *selfTest) createApplication(ctx context.Context) error {
+	app := &apps.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName(),
+			Namespace: st.project,
+		},
+		Spec: apps.ApplicationSpec{
+			ForProvider: apps.ApplicationParameters{
+				Git: apps.ApplicationGitConfig{
+					GitTarget: apps.GitTarget{
+						URL:      st.cmd.GitURL,
+						SubPath:  st.cmd.GitSubPath,
+						Revision: st.cmd.GitRevision,
+					},
+				},
+				Config: apps.Config{
+					Size:     apps.AppMicro,
+					Replicas: ptr.To(int32(1)),
+				},
+			},
+		},
+	}
+	if err := st.client.Create(ctx, app); err != nil {
+		return fmt.Errorf("unable to create application: %w", err)
+	}
+	st.app = app
+
+	return nil
+}
+
+func (st *selfTest) waitForRelease(ctx context.Context) error {
+	return st.poll(ctx, func(ctx context.Context) (bool, error) {
+		if err := st.client.Get(ctx, api.ObjectName(st.app), st.app); err != nil {
+			return false, err
+		}
+
+		if name := st.app.Status.AtProvider.LatestBuild; len(name) != 0 {
+			build := &apps.Build{}
+			if err := st.client.Get(ctx, api.NamespacedName(name, st.project), build); err != nil {
+				return false, err
+			}
+			switch build.Status.AtProvider.BuildStatus {
+			case apps.BuildProcessStatusError, apps.BuildProcessStatusImageUploadFailed:
+				return false, fmt.Errorf("build %q failed with status %q", name, build.Status.AtProvider.BuildStatus)
+			}
+		}
+
+		name := st.app.Status.AtProvider.LatestRelease
+		if len(name) == 0 {
+			return false, nil
+		}
+		release := &apps.Release{}
+		if err := st.client.Get(ctx, api.NamespacedName(name, st.project), release); err != nil {
+			return false, err
+		}
+
+		switch release.Status.AtProvider.ReleaseStatus {
+		case apps.ReleaseProcessStatusAvailable:
+			return true, nil
+		case apps.ReleaseProcessStatusFailure, apps.ReleaseProcessStatusReplicaFailure:
+			return false, fmt.Errorf("release %q failed with status %q", name, release.Status.AtProvider.ReleaseStatus)
+		}
+		return false, nil
+	})
+}
+
+func (st *selfTest) checkApplicationConnectivity(ctx context.Context) error {
+	if len(st.app.Status.AtProvider.DefaultURLs) == 0 {
+		return errors.New("application has no default URL")
+	}
+	url := st.app.Status.AtProvider.DefaultURLs[0]
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	var lastErr error
+	if err := st.poll(ctx, func(ctx context.Context) (bool, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return false, err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			lastErr = fmt.Errorf("%s returned status %s", url, resp.Status)
+			return false, nil
+		}
+		return true, nil
+	}); err != nil {
+		if lastErr != nil {
+			return lastErr
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (st *selfTest) checkApplicationLogs(ctx context.Context) error {
+	if st.client.Log == nil {
+		return errors.New("no log client configured")
+	}
+
+	start := st.app.CreationTimestamp.Add(-time.Minute)
+	return st.poll(ctx, func(ctx context.Context) (bool, error) {
+		resp, err := st.client.Log.QueryRangeResponse(ctx, log.Query{
+			QueryString: logs.BuildsOfAppQuery(st.app.Name, st.project),
+			Start:       start,
+			End:         time.Now(),
+			Limit:       10,
+			Direction:   logproto.BACKWARD,
+			Quiet:       true,
+		})
+		if err != nil {
+			return false, fmt.Errorf("unable to query logs: %w", err)
+		}
+		streams, ok := resp.Data.Result.(loghttp.Streams)
+		return ok && len(streams) != 0, nil
+	})
+}
+
+func (st *selfTest) deleteApplication(ctx context.Context) error {
+	return st.deleteAndWait(ctx, st.app)
+}
+
+func (st *selfTest) createDatabase(ctx context.Context) error {
+	name := testName()
+	db := &storage.Postgres{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: st.project,
+		},
+		Spec: storage.PostgresSpec{
+			ResourceSpec: runtimev1.ResourceSpec{
+				WriteConnectionSecretToReference: &runtimev1.SecretReference{
+					Name:      "postgres-" + name,
+					Namespace: st.project,
+				},
+			},
+			ForProvider: storage.PostgresParameters{
+				Location:    storage.PostgresLocationDefault,
+				MachineType: storage.PostgresMachineTypeDefault,
+			},
+		},
+	}
+	if err := st.client.Create(ctx, db); err != nil {
+		return fmt.Errorf("unable to create database: %w", err)
+	}
+	st.database = db
+
+	return nil
+}
+
+func (st *selfTest) waitForDatabase(ctx context.Context) error {
+	return st.waitForAvailable(ctx, st.database)
+}
+
+// checkDatabaseConnectivity verifies that the database endpoint resolves and
+// that credentials have been issued. The database is not reachable from the
+// outside as no allowed CIDRs are configured.
+func (st *selfTest) checkDatabaseConnectivity(ctx context.Context) error {
+	fqdn := st.database.Status.AtProvider.FQDN
+	if len(fqdn) == 0 {
+		return errors.New("database has no FQDN")
+	}
+	if _, err := net.DefaultResolver.LookupHost(ctx, fqdn); err != nil {
+		return fmt.Errorf("unable to resolve database endpoint: %w", err)
+	}
+
+	secret := &corev1.Secret{}
+	ref := st.database.GetWriteConnectionSecretToReference()
+	if err := st.client.Get(ctx, api.NamespacedName(ref.Name, ref.Namespace), secret); err != nil {
+		return fmt.Errorf("unable to get database credentials: %w", err)
+	}
+	if len(secret.Data) == 0 {
+		return errors.New("database credentials are empty")
+	}
+
+	return nil
+}
+
+func (st *selfTest) deleteDatabase(ctx context.Context) error {
+	return st.deleteAndWait(ctx, st.database)
+}
+
+func (st *selfTest) waitForAvailable(ctx context.Context, mg resource.Managed) error {
+	return st.poll(ctx, func(ctx context.Context) (bool, error) {
+		if err := st.client.Get(ctx, api.ObjectName(mg), mg); err != nil {
+			return false, err
+		}
+		ready := mg.GetCondition(runtimev1.TypeReady)
+		return ready.Reason == runtimev1.ReasonAvailable && ready.Status == corev1.ConditionTrue, nil
+	})
+}
+
+func (st *selfTest) deleteAndWait(ctx context.Context, mg resource.Managed) error {
+	if err := st.client.Delete(ctx, mg); err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	return st.poll(ctx, func(ctx context.Context) (bool, error) {
+		if err := st.client.Get(ctx, api.ObjectName(mg), mg); err != nil {
+			if kerrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	})
+}
+
+func (st *selfTest) poll(ctx context.Context, condition wait.ConditionWithContextFunc) error {
+	return wait.PollUntilContextCancel(ctx, pollInterval, true, condition)
+}
+
+func testName() string {
+	return "selftest-" + codename.Generate(rand.New(rand.NewSource(time.Now().UnixNano())), 0)
+}
@@ -0,0 +1,125 @@
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ninech/nctl/internal/format"
+	"github.com/ninech/nctl/internal/updater"
+)
+
+// VersionCmd prints the version and optionally checks for a newer release.
+type VersionCmd struct {
+	Check bool `help:"Check if a newer release is available."`
+	out   io.Writer
+}
+
+// Cmd replaces the running binary with the latest release.
+type Cmd struct {
+	Force bool `help:"Do not ask for confirmation and update even if nctl seems to be managed by a package manager or is already up to date."`
+	out   io.Writer
+	opts  []updater.Option
+}
+
+func (cmd *VersionCmd) Run(ctx context.Context, current, details string) error {
+	out := defaultOut(cmd.out)
+	fmt.Fprintln(out, details)
+	if !cmd.Check {
+		return nil
+	}
+
+	latest, err := updater.New().Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	newer, err := updater.IsNewer(current, latest.TagName)
+	if err != nil {
+		return err
+	}
+	if !newer {
+		fmt.Fprintln(out, "nctl is up to date")
+		return nil
+	}
+
+	fmt.Fprintf(out, "a new version %s is available: %s\n", latest.TagName, latest.URL)
+	fmt.Fprintf(out, "update with %q or your package manager, e.g. %q\n", "nctl self-update", "brew upgrade nctl")
+	return nil
+}
+
+func (cmd *Cmd) Run(ctx context.Context, current string) error {
+	out := defaultOut(cmd.out)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to identify executable path: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+	if managedByPackageManager(executable) && !cmd.Force {
+		return fmt.Errorf("nctl at %s seems to be installed by a package manager, please update it with the package manager (e.g. \"brew upgrade nctl\") or use --force", executable)
+	}
+
+	u := updater.New(cmd.opts...)
+	latest, err := u.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	newer, err := updater.IsNewer(current, latest.TagName)
+	if err != nil && !cmd.Force {
+		return fmt.Errorf("%w, use --force to update anyway", err)
+	}
+	if !newer && !cmd.Force {
+		fmt.Fprintf(out, "nctl %s is already the latest version\n", current)
+		return nil
+	}
+
+	if !cmd.Force {
+		ok, err := format.Confirmf("do you want to update nctl from %s to %s", current, latest.TagName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			format.PrintFailuref("🛑", "update aborted")
+			return nil
+		}
+	}
+
+	binary, err := u.Download(ctx, latest, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	if err := updater.Replace(executable, binary); err != nil {
+		return fmt.Errorf("unable to replace %s: %w", executable, err)
+	}
+
+	format.PrintSuccessf("🚀", "updated nctl to %s (checksum verified)", latest.TagName)
+	return nil
+}
+
+// managedByPackageManager reports if the binary at path is likely managed by
+// a package manager, which should be used for updating instead.
+func managedByPackageManager(path string) bool {
+	// Windows paths are matched with forward slashes as well.
+	path = strings.ReplaceAll(path, `\`, "/")
+	for _, dir := range []string{"/Cellar/", "/homebrew/", "/linuxbrew/", "/nix/store/", "/scoop/apps/", "/WinGet/Packages/", "/chocolatey/"} {
+		if strings.Contains(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultOut(out io.Writer) io.Writer {
+	if out == nil {
+		return os.Stdout
+	}
+	return out
+}
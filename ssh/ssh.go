@@ -0,0 +1,108 @@
+// Package ssh contains the commands to connect to resources via SSH.
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"strings"
+
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/api"
+)
+
+// defaultUser is the user which is allowed to connect with the public keys
+// configured on a CloudVM.
+const defaultUser = "root"
+
+// lookPath allows to replace the lookup of the ssh binary in tests.
+var lookPath = osexec.LookPath
+
+type Cmd struct {
+	CloudVirtualMachine cloudVMCmd `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm,vm" help:"Connect to a CloudVM via SSH."`
+}
+
+type cloudVMCmd struct {
+	Name         string   `arg:"" predictor:"resource_name" help:"Name of the CloudVM to connect to."`
+	User         string   `short:"l" default:"root" help:"User to log in as."`
+	IdentityFile string   `short:"i" predictor:"file" help:"Private key file to authenticate with."`
+	Print        bool     `help:"Only print the ssh command instead of executing it."`
+	Command      []string `arg:"" optional:"" help:"Command to execute on the CloudVM instead of opening a shell."`
+	out          io.Writer
+}
+
+func (cmd *cloudVMCmd) Help() string {
+	return `Examples:
+  # Open a shell on a CloudVM
+  nctl ssh vm myvm
+
+  # Run a command on a CloudVM
+  nctl ssh vm myvm -- uptime
+
+  # Print the ssh command, e.g. to use it in scripts
+  nctl ssh vm myvm --print
+  `
+}
+
+func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	vm := &infrastructure.CloudVirtualMachine{}
+	if err := client.Get(ctx, client.Name(cmd.Name), vm); err != nil {
+		return err
+	}
+
+	args, err := cmd.sshArgs(vm)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Print {
+		fmt.Fprintln(cmd.out, "ssh "+strings.Join(args, " "))
+		return nil
+	}
+
+	path, err := lookPath("ssh")
+	if err != nil {
+		return fmt.Errorf("ssh client not found, install OpenSSH or use --print to show the command: %w", err)
+	}
+	ssh := osexec.CommandContext(ctx, path, args...)
+	ssh.Stdin, ssh.Stdout, ssh.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return ssh.Run()
+}
+
+// sshArgs returns the arguments to pass to ssh to connect to the VM. The IP
+// address is preferred over the FQDN as it is available right after the
+// VM got created, while the DNS record might still be propagating.
+func (cmd *cloudVMCmd) sshArgs(vm *infrastructure.CloudVirtualMachine) ([]string, error) {
+	host := vm.Status.AtProvider.IPAddress
+	if host == "" {
+		host = vm.Status.AtProvider.FQDN
+	}
+	if host == "" {
+		return nil, fmt.Errorf("cloudvm %q has no IP address yet, check its status with \"nctl get vm %s\"", vm.Name, vm.Name)
+	}
+	if vm.Status.AtProvider.PowerState != "" && vm.Status.AtProvider.PowerState != "on" {
+		return nil, fmt.Errorf("cloudvm %q is not running (power state %q), start it with \"nctl start vm %s\"", vm.Name, vm.Status.AtProvider.PowerState, vm.Name)
+	}
+
+	user := cmd.User
+	if user == "" {
+		user = defaultUser
+	}
+
+	args := []string{}
+	if cmd.IdentityFile != "" {
+		args = append(args, "-i", cmd.IdentityFile)
+	}
+	args = append(args, fmt.Sprintf("%s@%s", user, host))
+	if len(cmd.Command) != 0 {
+		args = append(args, "--")
+		args = append(args, cmd.Command...)
+	}
+	return args, nil
+}
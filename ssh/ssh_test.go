@@ -0,0 +1,81 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudVM(t *testing.T) {
+	ctx := context.Background()
+
+	running := test.CloudVirtualMachine("running", test.DefaultProject, "nine-es34", "on")
+	running.Status.AtProvider.PowerState = "on"
+	running.Status.AtProvider.IPAddress = "203.0.113.10"
+	running.Status.AtProvider.FQDN = "running.example.org"
+	stopped := test.CloudVirtualMachine("stopped", test.DefaultProject, "nine-es34", "off")
+	stopped.Status.AtProvider.PowerState = "off"
+	stopped.Status.AtProvider.IPAddress = "203.0.113.11"
+	pending := test.CloudVirtualMachine("pending", test.DefaultProject, "nine-es34", "on")
+
+	apiClient, err := test.SetupClient(test.WithObjects(running, stopped, pending))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		cmd     cloudVMCmd
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "print",
+			cmd:  cloudVMCmd{Name: "running", User: "root"},
+			want: "ssh root@203.0.113.10\n",
+		},
+		{
+			name: "identity file and command",
+			cmd:  cloudVMCmd{Name: "running", User: "admin", IdentityFile: "~/.ssh/vm", Command: []string{"uptime", "-p"}},
+			want: "ssh -i ~/.ssh/vm admin@203.0.113.10 -- uptime -p\n",
+		},
+		{
+			name:    "stopped",
+			cmd:     cloudVMCmd{Name: "stopped", User: "root"},
+			wantErr: true,
+		},
+		{
+			name:    "no address",
+			cmd:     cloudVMCmd{Name: "pending", User: "root"},
+			wantErr: true,
+		},
+		{
+			name:    "not found",
+			cmd:     cloudVMCmd{Name: "missing", User: "root"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			tt.cmd.out = out
+			tt.cmd.Print = true
+			err := tt.cmd.Run(ctx, apiClient)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+
+	t.Run("missing ssh client", func(t *testing.T) {
+		lookPath = func(string) (string, error) { return "", errors.New("not found") }
+		cmd := cloudVMCmd{Name: "running", User: "root", out: &bytes.Buffer{}}
+		assert.ErrorContains(t, cmd.Run(ctx, apiClient), "ssh client not found")
+	})
+}
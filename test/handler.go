@@ -0,0 +1,514 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	infrastructure "github.com/ninech/apis/infrastructure/v1alpha1"
+	"github.com/ninech/nctl/api"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// coreKinds are the kinds of the core API group which are served.
+var coreKinds = []string{"Namespace", "Secret", "ConfigMap"}
+
+// clusterScoped are the served kinds which are not namespaced.
+var clusterScoped = map[schema.GroupKind]bool{
+	{Kind: "Namespace"}: true,
+	infrastructure.SchemeGroupVersion.WithKind("ClusterData").GroupKind(): true,
+}
+
+type resourceInfo struct {
+	gvk        schema.GroupVersionKind
+	namespaced bool
+}
+
+// handler serves the objects of a client with the Kubernetes API
+// conventions. It only implements as much of the API as nctl needs.
+type handler struct {
+	client    *api.Client
+	resources map[schema.GroupVersionResource]resourceInfo
+}
+
+func newHandler(c *api.Client) *handler {
+	h := &handler{client: c, resources: map[schema.GroupVersionResource]resourceInfo{}}
+	scheme := c.Scheme()
+	for gvk := range scheme.AllKnownTypes() {
+		if !served(scheme, gvk) {
+			continue
+		}
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		h.resources[gvr] = resourceInfo{gvk: gvk, namespaced: !clusterScoped[gvk.GroupKind()]}
+	}
+	return h
+}
+
+// served returns if the kind is served by the fake server. These are all
+// kinds of the Nine API and some of the core group.
+func served(scheme *runtime.Scheme, gvk schema.GroupVersionKind) bool {
+	if gvk.Version == runtime.APIVersionInternal || strings.HasSuffix(gvk.Kind, "List") {
+		return false
+	}
+	if gvk.Group == "" {
+		if gvk.Version != "v1" {
+			return false
+		}
+		for _, k := range coreKinds {
+			if k == gvk.Kind {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.HasSuffix(gvk.Group, ".nine.ch") && scheme.Recognizes(gvk.GroupVersion().WithKind(gvk.Kind+"List"))
+}
+
+// request is a parsed API request path.
+type request struct {
+	gv          schema.GroupVersion
+	namespace   string
+	resource    string
+	name        string
+	subresource string
+}
+
+func parsePath(path string) (request, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	var r request
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		r.gv = schema.GroupVersion{Version: parts[1]}
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		r.gv = schema.GroupVersion{Group: parts[1], Version: parts[2]}
+		parts = parts[3:]
+	default:
+		return r, false
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		r.namespace = parts[1]
+		parts = parts[2:]
+	}
+	if len(parts) > 3 {
+		return r, false
+	}
+	for i, p := range []*string{&r.resource, &r.name, &r.subresource} {
+		if i < len(parts) {
+			*p = parts[i]
+		}
+	}
+	return r, true
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch strings.TrimSuffix(req.URL.Path, "/") {
+	case "/api":
+		writeJSON(w, http.StatusOK, &metav1.APIVersions{
+			TypeMeta: metav1.TypeMeta{Kind: "APIVersions"},
+			Versions: []string{"v1"},
+		})
+		return
+	case "/apis":
+		writeJSON(w, http.StatusOK, h.groups())
+		return
+	}
+
+	r, ok := parsePath(req.URL.Path)
+	if !ok {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, req.URL.Path))
+		return
+	}
+	if r.resource == "" {
+		writeJSON(w, http.StatusOK, h.resourceList(r.gv))
+		return
+	}
+
+	gvr := r.gv.WithResource(r.resource)
+	info, ok := h.resources[gvr]
+	if !ok {
+		writeError(w, apierrors.NewNotFound(gvr.GroupResource(), r.name))
+		return
+	}
+	if r.subresource != "" && r.subresource != "status" {
+		writeError(w, apierrors.NewMethodNotSupported(gvr.GroupResource(), r.subresource))
+		return
+	}
+
+	var err error
+	switch {
+	case req.Method == http.MethodGet && r.name == "" && req.URL.Query().Get("watch") == "true":
+		err = h.watch(w, req, info, r)
+	case req.Method == http.MethodGet && r.name == "":
+		err = h.list(w, req, info, r)
+	case req.Method == http.MethodGet:
+		err = h.get(w, req, info, r)
+	case req.Method == http.MethodPost && r.name == "":
+		err = h.create(w, req, info, r)
+	case req.Method == http.MethodPut && r.name != "":
+		err = h.update(w, req, info, r)
+	case req.Method == http.MethodPatch && r.name != "":
+		err = h.patch(w, req, info, r)
+	case req.Method == http.MethodDelete && r.name != "":
+		err = h.delete(w, req, info, r)
+	default:
+		err = apierrors.NewMethodNotSupported(gvr.GroupResource(), req.Method)
+	}
+	if err != nil {
+		writeError(w, err)
+	}
+}
+
+func (h *handler) groups() *metav1.APIGroupList {
+	versions := map[string][]string{}
+	for gvr := range h.resources {
+		if gvr.Group == "" {
+			continue
+		}
+		found := false
+		for _, v := range versions[gvr.Group] {
+			found = found || v == gvr.Version
+		}
+		if !found {
+			versions[gvr.Group] = append(versions[gvr.Group], gvr.Version)
+		}
+	}
+
+	list := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}}
+	for group, vs := range versions {
+		sort.Strings(vs)
+		g := metav1.APIGroup{Name: group}
+		for _, v := range vs {
+			g.Versions = append(g.Versions, metav1.GroupVersionForDiscovery{GroupVersion: group + "/" + v, Version: v})
+		}
+		g.PreferredVersion = g.Versions[0]
+		list.Groups = append(list.Groups, g)
+	}
+	sort.Slice(list.Groups, func(i, j int) bool { return list.Groups[i].Name < list.Groups[j].Name })
+	return list
+}
+
+func (h *handler) resourceList(gv schema.GroupVersion) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: gv.String(),
+	}
+	for gvr, info := range h.resources {
+		if gvr.GroupVersion() != gv {
+			continue
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:         gvr.Resource,
+			SingularName: strings.ToLower(info.gvk.Kind),
+			Kind:         info.gvk.Kind,
+			Namespaced:   info.namespaced,
+			Verbs:        metav1.Verbs{"create", "delete", "get", "list", "patch", "update", "watch"},
+		})
+	}
+	sort.Slice(list.APIResources, func(i, j int) bool { return list.APIResources[i].Name < list.APIResources[j].Name })
+	return list
+}
+
+func (h *handler) get(w http.ResponseWriter, req *http.Request, info resourceInfo, r request) error {
+	obj, err := h.newObject(info.gvk)
+	if err != nil {
+		return err
+	}
+	if err := h.client.Get(req.Context(), types.NamespacedName{Name: r.name, Namespace: r.namespace}, obj); err != nil {
+		return err
+	}
+	return h.writeObject(w, http.StatusOK, info.gvk, obj)
+}
+
+func (h *handler) list(w http.ResponseWriter, req *http.Request, info resourceInfo, r request) error {
+	list, err := h.newList(info.gvk)
+	if err != nil {
+		return err
+	}
+	if err := h.client.List(req.Context(), list, client.InNamespace(r.namespace)); err != nil {
+		return err
+	}
+
+	match, err := matcher(req)
+	if err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	filtered := []runtime.Object{}
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok && match(obj) {
+			obj.GetObjectKind().SetGroupVersionKind(info.gvk)
+			filtered = append(filtered, obj)
+		}
+	}
+	if err := meta.SetList(list, filtered); err != nil {
+		return err
+	}
+
+	list.GetObjectKind().SetGroupVersionKind(info.gvk.GroupVersion().WithKind(info.gvk.Kind + "List"))
+	writeJSON(w, http.StatusOK, list)
+	return nil
+}
+
+// watch streams watch events of the resource. Like the real API, the
+// existing objects are sent as added events first.
+func (h *handler) watch(w http.ResponseWriter, req *http.Request, info resourceInfo, r request) error {
+	list, err := h.newList(info.gvk)
+	if err != nil {
+		return err
+	}
+	wa, err := h.client.Watch(req.Context(), list, client.InNamespace(r.namespace))
+	if err != nil {
+		return err
+	}
+	defer wa.Stop()
+
+	match, err := matcher(req)
+	if err != nil {
+		return err
+	}
+	if err := h.client.List(req.Context(), list, client.InNamespace(r.namespace)); err != nil {
+		return err
+	}
+	existing, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("streaming is not supported")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	send := func(t watch.EventType, o runtime.Object) error {
+		obj, ok := o.(client.Object)
+		if !ok || !match(obj) {
+			return nil
+		}
+		obj.GetObjectKind().SetGroupVersionKind(info.gvk)
+		if err := enc.Encode(&metav1.WatchEvent{Type: string(t), Object: runtime.RawExtension{Object: obj}}); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	for _, o := range existing {
+		if err := send(watch.Added, o); err != nil {
+			return nil
+		}
+	}
+	for {
+		select {
+		case <-req.Context().Done():
+			return nil
+		case ev, ok := <-wa.ResultChan():
+			if !ok {
+				return nil
+			}
+			if err := send(ev.Type, ev.Object); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func (h *handler) create(w http.ResponseWriter, req *http.Request, info resourceInfo, r request) error {
+	obj, err := h.decode(req, info.gvk)
+	if err != nil {
+		return err
+	}
+	if info.namespaced && obj.GetNamespace() == "" {
+		obj.SetNamespace(r.namespace)
+	}
+	// the fake client does not set the creation timestamp like the API.
+	obj.SetCreationTimestamp(metav1.Now())
+	opts := []client.CreateOption{}
+	if isDryRun(req) {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := h.client.Create(req.Context(), obj, opts...); err != nil {
+		return err
+	}
+	return h.writeObject(w, http.StatusCreated, info.gvk, obj)
+}
+
+func (h *handler) update(w http.ResponseWriter, req *http.Request, info resourceInfo, r request) error {
+	obj, err := h.decode(req, info.gvk)
+	if err != nil {
+		return err
+	}
+	obj.SetName(r.name)
+	obj.SetNamespace(r.namespace)
+	opts := []client.UpdateOption{}
+	if isDryRun(req) {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := h.client.Update(req.Context(), obj, opts...); err != nil {
+		return err
+	}
+	return h.writeObject(w, http.StatusOK, info.gvk, obj)
+}
+
+func (h *handler) patch(w http.ResponseWriter, req *http.Request, info resourceInfo, r request) error {
+	contentType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return unsupportedMediaType(req.Header.Get("Content-Type"))
+	}
+	switch types.PatchType(contentType) {
+	case types.JSONPatchType, types.MergePatchType, types.StrategicMergePatchType:
+	default:
+		return unsupportedMediaType(contentType)
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+
+	obj, err := h.newObject(info.gvk)
+	if err != nil {
+		return err
+	}
+	obj.SetName(r.name)
+	obj.SetNamespace(r.namespace)
+	opts := []client.PatchOption{}
+	if isDryRun(req) {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := h.client.Patch(req.Context(), obj, client.RawPatch(types.PatchType(contentType), data), opts...); err != nil {
+		return err
+	}
+	return h.writeObject(w, http.StatusOK, info.gvk, obj)
+}
+
+func (h *handler) delete(w http.ResponseWriter, req *http.Request, info resourceInfo, r request) error {
+	obj, err := h.newObject(info.gvk)
+	if err != nil {
+		return err
+	}
+	obj.SetName(r.name)
+	obj.SetNamespace(r.namespace)
+	opts := []client.DeleteOption{}
+	if isDryRun(req) {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := h.client.Delete(req.Context(), obj, opts...); err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusSuccess,
+	})
+	return nil
+}
+
+func (h *handler) newObject(gvk schema.GroupVersionKind) (client.Object, error) {
+	o, err := h.client.Scheme().New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := o.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", gvk)
+	}
+	return obj, nil
+}
+
+func (h *handler) newList(gvk schema.GroupVersionKind) (client.ObjectList, error) {
+	o, err := h.client.Scheme().New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err != nil {
+		return nil, err
+	}
+	list, ok := o.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", gvk)
+	}
+	return list, nil
+}
+
+func (h *handler) decode(req *http.Request, gvk schema.GroupVersionKind) (client.Object, error) {
+	obj, err := h.newObject(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(req.Body).Decode(obj); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unable to decode body: %s", err))
+	}
+	return obj, nil
+}
+
+func (h *handler) writeObject(w http.ResponseWriter, code int, gvk schema.GroupVersionKind, obj client.Object) error {
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	writeJSON(w, code, obj)
+	return nil
+}
+
+// matcher returns a function matching the objects against the label and
+// field selectors of the request. The field selectors are evaluated here as
+// the fake client only supports indexed fields.
+func matcher(req *http.Request) (func(client.Object) bool, error) {
+	labelSelector, err := labels.Parse(req.URL.Query().Get("labelSelector"))
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	fieldSelector, err := fields.ParseSelector(req.URL.Query().Get("fieldSelector"))
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	return func(obj client.Object) bool {
+		return labelSelector.Matches(labels.Set(obj.GetLabels())) &&
+			fieldSelector.Matches(fields.Set{
+				"metadata.name":      obj.GetName(),
+				"metadata.namespace": obj.GetNamespace(),
+			})
+	}, nil
+}
+
+func unsupportedMediaType(contentType string) error {
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusUnsupportedMediaType,
+		Reason:  metav1.StatusReasonUnsupportedMediaType,
+		Message: fmt.Sprintf("the content type %q is not supported", contentType),
+	}}
+}
+
+func isDryRun(req *http.Request) bool {
+	return len(req.URL.Query()["dryRun"]) != 0
+}
+
+func writeJSON(w http.ResponseWriter, code int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		apiStatus = apierrors.NewInternalError(err)
+	}
+	status := apiStatus.Status()
+	status.TypeMeta = metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}
+	writeJSON(w, int(status.Code), &status)
+}
@@ -0,0 +1,55 @@
+package test
+
+import (
+	"sort"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	management "github.com/ninech/apis/management/v1alpha1"
+	"github.com/ninech/nctl/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExampleName is the name of the resources returned by Resources.
+const ExampleName = "example"
+
+// Resources returns a resource named "example" of every kind of the Nine API
+// which is supported by nctl, located in the given project. Managed resources
+// are marked as available. Projects are not included, they can be set up with
+// WithProjects.
+func Resources(project string) ([]client.Object, error) {
+	scheme, err := api.NewScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []client.Object{}
+	for gvk := range scheme.AllKnownTypes() {
+		if gvk.Group == "" || !served(scheme, gvk) || clusterScoped[gvk.GroupKind()] ||
+			gvk.GroupKind() == management.SchemeGroupVersion.WithKind(management.ProjectKind).GroupKind() {
+			continue
+		}
+		o, err := scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := o.(client.Object)
+		if !ok {
+			continue
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		obj.SetName(ExampleName)
+		obj.SetNamespace(project)
+		obj.SetCreationTimestamp(metav1.Now())
+		if mg, ok := obj.(resource.Managed); ok {
+			mg.SetConditions(runtimev1.Available(), runtimev1.ReconcileSuccess())
+		}
+		objects = append(objects, obj)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].GetObjectKind().GroupVersionKind().String() < objects[j].GetObjectKind().GroupVersionKind().String()
+	})
+	return objects, nil
+}
@@ -0,0 +1,150 @@
+// Package test provides a fake Nine API server which can be used to test
+// scripts or plugins that shell out to nctl without access to the real API.
+//
+//	resources, err := test.Resources(test.DefaultProject)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	srv, err := test.NewServer(test.WithObjects(resources...))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer srv.Close()
+//
+//	cmd := exec.Command("nctl", "get", "apps")
+//	cmd.Env = append(os.Environ(), srv.Env()...)
+package test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/api/config"
+	"github.com/ninech/nctl/api/util"
+	internaltest "github.com/ninech/nctl/internal/test"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultProject is the project and organization the server is set up
+	// with if not configured otherwise.
+	DefaultProject = internaltest.DefaultProject
+	// ContextName is the name of the kubeconfig context which points to
+	// the server. It is the default API cluster context of nctl.
+	ContextName = "nineapis.ch"
+)
+
+// Server is a fake Nine API server. It serves the resources of a fake client
+// over HTTP, so it can be used by nctl like the real API.
+type Server struct {
+	// URL of the server.
+	URL string
+	// Kubeconfig is the path of a kubeconfig which points to the server.
+	Kubeconfig string
+	// Client is the fake client backing the server. It can be used to
+	// check or change the state of the server during a test, e.g. to set
+	// the status of a resource which has been created with nctl.
+	Client *api.Client
+
+	srv *httptest.Server
+	dir string
+}
+
+// Option configures the fake server.
+type Option = internaltest.ClientSetupOption
+
+// WithObjects sets the objects which are served by the server.
+func WithObjects(objects ...client.Object) Option {
+	return internaltest.WithObjects(objects...)
+}
+
+// WithOrganization sets the organization the kubeconfig is logged in to.
+func WithOrganization(org string) Option {
+	return internaltest.WithOrganization(org)
+}
+
+// WithProjects sets the projects which exist in the organization.
+func WithProjects(projects ...string) Option {
+	return internaltest.WithProjects(projects...)
+}
+
+// WithDefaultProject sets the project of the kubeconfig context.
+func WithDefaultProject(project string) Option {
+	return internaltest.WithDefaultProject(project)
+}
+
+// NewServer starts a fake API server and writes a kubeconfig pointing to it.
+// Unless configured otherwise with WithProjects, the default project exists.
+// The server needs to be closed with Close after use.
+func NewServer(opts ...Option) (*Server, error) {
+	opts = append([]Option{WithProjects(DefaultProject)}, opts...)
+	c, err := internaltest.SetupClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{Client: c, srv: httptest.NewServer(newHandler(c))}
+	s.URL = s.srv.URL
+
+	s.dir, err = os.MkdirTemp("", "nctl-test-")
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.Kubeconfig = filepath.Join(s.dir, "kubeconfig")
+	if err := writeKubeconfig(s.Kubeconfig, s.URL, internaltest.Organization(opts...), c.Project); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("unable to write kubeconfig: %w", err)
+	}
+
+	return s, nil
+}
+
+// Env returns the environment variables which need to be set for nctl to
+// use the server.
+func (s *Server) Env() []string {
+	return []string{
+		"KUBECONFIG=" + s.Kubeconfig,
+		"NCTL_API_CLUSTER=" + ContextName,
+	}
+}
+
+// Close shuts down the server and removes the kubeconfig.
+func (s *Server) Close() {
+	s.srv.Close()
+	if s.dir != "" {
+		os.RemoveAll(s.dir)
+	}
+}
+
+func writeKubeconfig(path, server, org, project string) error {
+	ext, err := config.NewExtension(org).ToObject()
+	if err != nil {
+		return err
+	}
+
+	kubeconfig := clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			ContextName: {Server: server},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			ContextName: {Token: internaltest.FakeJWTToken},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			ContextName: {
+				Cluster:    ContextName,
+				AuthInfo:   ContextName,
+				Namespace:  project,
+				Extensions: map[string]runtime.Object{util.NctlName: ext},
+			},
+		},
+		CurrentContext: ContextName,
+	}
+
+	return clientcmd.WriteToFile(kubeconfig, path)
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	storage "github.com/ninech/apis/storage/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resources, err := Resources(DefaultProject)
+	require.NoError(t, err)
+	srv, err := NewServer(WithObjects(resources...))
+	require.NoError(t, err)
+	defer srv.Close()
+
+	for _, env := range srv.Env() {
+		k, v, _ := strings.Cut(env, "=")
+		t.Setenv(k, v)
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	c, err := api.New(ctx, ContextName, "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProject, c.Project)
+	org, err := c.Organization()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProject, org)
+
+	app := &apps.Application{}
+	require.NoError(t, c.Get(ctx, c.Name(ExampleName), app))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, c.Name("missing"), app)))
+
+	pgs := &storage.PostgresList{}
+	require.NoError(t, c.List(ctx, pgs, client.InNamespace(DefaultProject)))
+	assert.Len(t, pgs.Items, 1)
+	require.NoError(t, c.List(ctx, pgs, client.MatchingFields{"metadata.name": "other"}))
+	assert.Empty(t, pgs.Items)
+
+	kvs := &storage.KeyValueStore{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: DefaultProject}}
+	require.NoError(t, c.Create(ctx, kvs))
+	assert.True(t, apierrors.IsAlreadyExists(c.Create(ctx, &storage.KeyValueStore{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: DefaultProject}})))
+
+	kvs.Labels = map[string]string{"team": "a"}
+	require.NoError(t, c.Update(ctx, kvs))
+	list := &storage.KeyValueStoreList{}
+	require.NoError(t, c.List(ctx, list, client.MatchingLabels{"team": "a"}))
+	assert.Len(t, list.Items, 1)
+
+	require.NoError(t, c.Patch(ctx, kvs, client.RawPatch("application/merge-patch+json", []byte(`{"metadata":{"labels":{"team":"b"}}}`))))
+	require.NoError(t, srv.Client.Get(ctx, api.ObjectName(kvs), kvs))
+	assert.Equal(t, "b", kvs.Labels["team"])
+
+	wa, err := c.Watch(ctx, &storage.KeyValueStoreList{}, client.InNamespace(DefaultProject), client.MatchingFields{"metadata.name": "new"})
+	require.NoError(t, err)
+	defer wa.Stop()
+	ev := <-wa.ResultChan()
+	assert.Equal(t, watch.Added, ev.Type)
+
+	require.NoError(t, c.Delete(ctx, kvs))
+	ev = <-wa.ResultChan()
+	assert.Equal(t, watch.Deleted, ev.Type)
+	assert.True(t, apierrors.IsNotFound(srv.Client.Get(ctx, api.ObjectName(kvs), kvs)))
+}
@@ -11,10 +11,12 @@ import (
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/api/util"
 	"github.com/ninech/nctl/api/validation"
+	"github.com/ninech/nctl/cost"
 	"github.com/ninech/nctl/internal/format"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ReleaseTrigger is used to request a new release for the application.
@@ -38,6 +40,8 @@ type applicationCmd struct {
 	DeleteEnv               *[]string         `help:"Runtime environment variables names which are to be deleted."`
 	BuildEnv                map[string]string `help:"Environment variables names which are passed to the app build process."`
 	DeleteBuildEnv          *[]string         `help:"Build environment variables which are to be deleted."`
+	EnvFromFile             *string           `help:"Path to a YAML or JSON file with the runtime and build environment variables of the app, as exported by \"nctl get app --env -o yaml\". If the file contains the env of multiple apps, the one of this app is used. The variables are added to the existing ones unless --replace is given." predictor:"file"`
+	Replace                 bool              `help:"Replace all runtime environment variables with the ones of --env-from-file. The build environment variables are replaced too if the file contains any."`
 	// DeployJob, ScheduledJob and WorkerJob are embedded pointers to
 	// structs. Due to the usage of kong these pointers will never be `nil`.
 	// So checking for `nil` values can not be used to find out if some of
@@ -45,6 +49,7 @@ type applicationCmd struct {
 	DeployJob                *deployJob      `embed:"" prefix:"deploy-job-"`
 	WorkerJob                *workerJob      `embed:"" prefix:"worker-job-"`
 	ScheduledJob             *scheduledJob   `embed:"" prefix:"scheduled-job-"`
+	Workers                  []string        `name:"worker" sep:"none" placeholder:"name=NAME[,command=COMMAND][,size=SIZE]" help:"Add a worker process or change the command or size of an existing one, e.g. name=queue,command=\"bundle exec sidekiq\". Can be repeated."`
 	DeleteWorkerJob          *string         `help:"Delete a worker job by name"`
 	DeleteScheduledJob       *string         `help:"Delete a scheduled job by name"`
 	RetryRelease             *bool           `help:"Retries release for the application." placeholder:"false"`
@@ -52,9 +57,14 @@ type applicationCmd struct {
 	Pause                    *bool           `help:"Pauses the application if set to true. Stops all costs." placeholder:"false"`
 	GitInformationServiceURL string          `help:"URL of the git information service." default:"https://git-info.deplo.io" env:"GIT_INFORMATION_SERVICE_URL" hidden:""`
 	SkipRepoAccessCheck      bool            `help:"Skip the git repository access check" default:"false"`
-	Debug                    bool            `help:"Enable debug messages" default:"false"`
 	Language                 *string         `help:"${app_language_help} Possible values: ${enum}" enum:"ruby,php,python,golang,nodejs,static,"`
 	DockerfileBuild          dockerfileBuild `embed:""`
+	ShowCost                 bool            `help:"Print the estimated monthly cost of the updated app, see \"nctl cost\"."`
+	Protect                  *bool           `help:"Protects the application against deletion with nctl unless --force-protected is given. Use --protect=false to remove the protection." placeholder:"false"`
+	// workers are parsed from Workers.
+	workers []apps.WorkerJob
+	// envFile is read from EnvFromFile.
+	envFile *util.AppEnv
 }
 
 type gitConfig struct {
@@ -124,6 +134,28 @@ type dockerfileBuild struct {
 	BuildContext *string `name:"dockerfile-build-context" help:"${app_dockerfile_build_context_help}" placeholder:"."`
 }
 
+// AfterApply parses the workers given with --worker.
+func (cmd *applicationCmd) AfterApply() error {
+	for _, w := range cmd.Workers {
+		job, err := util.ParseWorkerJob(w)
+		if err != nil {
+			return err
+		}
+		cmd.workers = append(cmd.workers, job)
+	}
+	if cmd.Replace && cmd.EnvFromFile == nil {
+		return fmt.Errorf("--replace can only be used together with --env-from-file")
+	}
+	if cmd.EnvFromFile != nil {
+		env, err := util.ReadAppEnv(*cmd.EnvFromFile, cmd.Name)
+		if err != nil {
+			return err
+		}
+		cmd.envFile = env
+	}
+	return nil
+}
+
 func (cmd *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 	app := &apps.Application{
 		ObjectMeta: metav1.ObjectMeta{
@@ -137,7 +169,14 @@ func (cmd *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 		if !ok {
 			return fmt.Errorf("resource is of type %T, expected %T", current, apps.Application{})
 		}
-		cmd.applyUpdates(app)
+		if err := cmd.applyUpdates(app); err != nil {
+			return err
+		}
+		if cmd.ShowCost {
+			if err := cost.PrintApplication(ctx, client, nil, app.Spec.ForProvider.Config); err != nil {
+				return err
+			}
+		}
 
 		// if there was no change in the git config, we don't have
 		// anything to do anymore
@@ -158,7 +197,7 @@ func (cmd *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 			validator := &validation.RepositoryValidator{
 				GitInformationServiceURL: cmd.GitInformationServiceURL,
 				Token:                    client.Token(ctx),
-				Debug:                    cmd.Debug,
+				Debug:                    client.Debug,
 			}
 
 			if !auth.Enabled() {
@@ -178,11 +217,17 @@ func (cmd *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 		}
 
 		if auth.Enabled() {
+			createOpts, updateOpts := []runtimeclient.CreateOption{}, []runtimeclient.UpdateOption{}
+			if cmd.serverDryRun() {
+				createOpts = append(createOpts, runtimeclient.DryRunAll)
+				updateOpts = append(updateOpts, runtimeclient.DryRunAll)
+			}
+
 			secret := auth.Secret(app)
 			if err := client.Get(ctx, client.Name(secret.Name), secret); err != nil {
 				if errors.IsNotFound(err) {
 					auth.UpdateSecret(secret)
-					if err := client.Create(ctx, secret); err != nil {
+					if err := client.Create(ctx, secret, createOpts...); err != nil {
 						return err
 					}
 
@@ -193,7 +238,7 @@ func (cmd *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 			}
 
 			auth.UpdateSecret(secret)
-			if err := client.Update(ctx, secret); err != nil {
+			if err := client.Update(ctx, secret, updateOpts...); err != nil {
 				return err
 			}
 		}
@@ -208,12 +253,28 @@ func (cmd *applicationCmd) Run(ctx context.Context, client *api.Client) error {
 		}
 
 		return nil
-	})
+	}, dryRun(cmd.serverDryRun()))
 
 	return upd.Update(ctx)
 }
 
-func (cmd *applicationCmd) applyUpdates(app *apps.Application) {
+// applyEnvFile adds the variables of the env file to the app or replaces the
+// existing ones with them if --replace is given. The build env is left
+// untouched if the file has none.
+func (cmd *applicationCmd) applyEnvFile(app *apps.Application) {
+	env := app.Spec.ForProvider.Config.Env
+	buildEnv := app.Spec.ForProvider.BuildEnv
+	if cmd.Replace {
+		env = nil
+		if cmd.envFile.BuildEnv != nil {
+			buildEnv = nil
+		}
+	}
+	app.Spec.ForProvider.Config.Env = util.UpdateEnvVars(env, cmd.envFile.Env, nil)
+	app.Spec.ForProvider.BuildEnv = util.UpdateEnvVars(buildEnv, cmd.envFile.BuildEnv, nil)
+}
+
+func (cmd *applicationCmd) applyUpdates(app *apps.Application) error {
 	if cmd.Git != nil {
 		if cmd.Git.URL != nil {
 			app.Spec.ForProvider.Git.URL = *cmd.Git.URL
@@ -248,7 +309,14 @@ func (cmd *applicationCmd) applyUpdates(app *apps.Application) {
 		cmd.DeployJob.applyUpdates(&app.Spec.ForProvider.Config)
 	}
 	if cmd.WorkerJob != nil && cmd.WorkerJob.changesGiven() {
-		cmd.WorkerJob.applyUpdates(&app.Spec.ForProvider.Config)
+		if err := cmd.WorkerJob.applyUpdates(&app.Spec.ForProvider.Config); err != nil {
+			return err
+		}
+	}
+	for _, job := range cmd.workers {
+		if err := util.SetWorkerJob(&app.Spec.ForProvider.Config, job); err != nil {
+			return err
+		}
 	}
 	if cmd.DeleteWorkerJob != nil {
 		deleteWorkerJob(*cmd.DeleteWorkerJob, &app.Spec.ForProvider.Config)
@@ -263,6 +331,12 @@ func (cmd *applicationCmd) applyUpdates(app *apps.Application) {
 		app.Spec.ForProvider.Language = apps.Language(*cmd.Language)
 	}
 
+	// the env file is applied first, so single variables can still be
+	// changed with --env and --delete-env in the same update.
+	if cmd.envFile != nil {
+		cmd.applyEnvFile(app)
+	}
+
 	runtimeEnv := make(map[string]string)
 	if cmd.Env != nil {
 		runtimeEnv = cmd.Env
@@ -293,6 +367,10 @@ func (cmd *applicationCmd) applyUpdates(app *apps.Application) {
 		app.Spec.ForProvider.Paused = *cmd.Pause
 	}
 
+	if cmd.Protect != nil {
+		util.SetProtected(app, *cmd.Protect)
+	}
+
 	if cmd.DockerfileBuild.Path != nil {
 		app.Spec.ForProvider.DockerfileBuild.DockerfilePath = *cmd.DockerfileBuild.Path
 		warnIfDockerfileNotEnabled(app, "path")
@@ -302,6 +380,7 @@ func (cmd *applicationCmd) applyUpdates(app *apps.Application) {
 		app.Spec.ForProvider.DockerfileBuild.BuildContext = *cmd.DockerfileBuild.BuildContext
 		warnIfDockerfileNotEnabled(app, "build context")
 	}
+	return nil
 }
 
 func triggerTimestamp() string {
@@ -337,23 +416,11 @@ func ensureDeployJob(cfg *apps.Config) *apps.Config {
 	return cfg
 }
 
-func (job workerJob) applyUpdates(cfg *apps.Config) {
+func (job workerJob) applyUpdates(cfg *apps.Config) error {
 	if job.Name == nil {
 		format.PrintWarningf("you need to pass a job name to update the command or size\n")
-		return
-	}
-	for i := range cfg.WorkerJobs {
-		if cfg.WorkerJobs[i].Name == *job.Name {
-			if job.Command != nil {
-				cfg.WorkerJobs[i].Command = *job.Command
-			}
-			if job.Size != nil {
-				cfg.WorkerJobs[i].Size = ptr.To(apps.ApplicationSize(*job.Size))
-			}
-			return
-		}
+		return nil
 	}
-
 	newJob := apps.WorkerJob{Job: apps.Job{Name: *job.Name}}
 	if job.Command != nil {
 		newJob.Command = *job.Command
@@ -361,7 +428,7 @@ func (job workerJob) applyUpdates(cfg *apps.Config) {
 	if job.Size != nil {
 		newJob.Size = ptr.To(apps.ApplicationSize(*job.Size))
 	}
-	cfg.WorkerJobs = append(cfg.WorkerJobs, newJob)
+	return util.SetWorkerJob(cfg, newJob)
 }
 
 func deleteWorkerJob(name string, cfg *apps.Config) {
@@ -2,6 +2,8 @@ package update
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -408,6 +410,34 @@ func TestApplication(t *testing.T) {
 				assert.Nil(t, util.EnvVarByName(updated.Spec.ForProvider.BuildEnv, BuildTrigger))
 			},
 		},
+		"protect": {
+			orig: existingApp,
+			cmd: applicationCmd{
+				resourceCmd: resourceCmd{
+					Name: existingApp.Name,
+				},
+				Protect: ptr.To(true),
+			},
+			checkApp: func(t *testing.T, cmd applicationCmd, orig, updated *apps.Application) {
+				assert.True(t, util.IsProtected(updated))
+			},
+		},
+		"unprotect": {
+			orig: func() *apps.Application {
+				app := existingApp.DeepCopy()
+				util.SetProtected(app, true)
+				return app
+			}(),
+			cmd: applicationCmd{
+				resourceCmd: resourceCmd{
+					Name: existingApp.Name,
+				},
+				Protect: ptr.To(false),
+			},
+			checkApp: func(t *testing.T, cmd applicationCmd, orig, updated *apps.Application) {
+				assert.False(t, util.IsProtected(updated))
+			},
+		},
 		"disabling the git repo check works": {
 			orig: existingApp,
 			cmd: applicationCmd{
@@ -573,3 +603,73 @@ func TestApplicationFlags(t *testing.T) {
 	assert.NotNil(t, emptyFlags.Env)
 	assert.NotNil(t, emptyFlags.BuildEnv)
 }
+
+func TestApplicationWorkers(t *testing.T) {
+	vars, err := create.ApplicationKongVars()
+	require.NoError(t, err)
+
+	cmd := &applicationCmd{}
+	_, err = kong.Must(cmd, vars).Parse([]string{
+		"testname",
+		`--worker=name=queue,command="bundle exec sidekiq -q a,b"`,
+		"--worker=name=mailer,size=mini",
+	})
+	require.NoError(t, err)
+
+	app := &apps.Application{}
+	app.Spec.ForProvider.Config.WorkerJobs = []apps.WorkerJob{{Job: apps.Job{Name: "mailer", Command: "bin/mailer"}}}
+	require.NoError(t, cmd.applyUpdates(app))
+	assert.Equal(t, []apps.WorkerJob{
+		{Job: apps.Job{Name: "mailer", Command: "bin/mailer"}, Size: ptr.To(apps.ApplicationSize("mini"))},
+		{Job: apps.Job{Name: "queue", Command: "bundle exec sidekiq -q a,b"}},
+	}, app.Spec.ForProvider.Config.WorkerJobs)
+
+	_, err = kong.Must(&applicationCmd{}, vars).Parse([]string{"testname", "--worker=name=queue,replicas=2"})
+	assert.ErrorContains(t, err, "replicas")
+
+	// a new worker needs a command
+	cmd = &applicationCmd{}
+	_, err = kong.Must(cmd, vars).Parse([]string{"testname", "--worker=name=newname"})
+	require.NoError(t, err)
+	assert.ErrorContains(t, cmd.applyUpdates(app), `worker "newname" does not exist`)
+}
+
+func TestApplicationEnvFromFile(t *testing.T) {
+	vars, err := create.ApplicationKongVars()
+	require.NoError(t, err)
+
+	envFile := filepath.Join(t.TempDir(), "env.yaml")
+	require.NoError(t, os.WriteFile(envFile, []byte(`
+application: testname
+env:
+  FOO: file
+  NEW: value
+`), 0o600))
+
+	existing := func() *apps.Application {
+		app := &apps.Application{}
+		app.Spec.ForProvider.Config.Env = util.EnvVarsFromMap(map[string]string{"FOO": "old", "OLD": "value"})
+		app.Spec.ForProvider.BuildEnv = util.EnvVarsFromMap(map[string]string{"BUILD": "value"})
+		return app
+	}
+
+	cmd := &applicationCmd{}
+	_, err = kong.Must(cmd, vars).Parse([]string{"testname", "--env-from-file", envFile, "--env=NEW=flag"})
+	require.NoError(t, err)
+	app := existing()
+	require.NoError(t, cmd.applyUpdates(app))
+	assert.Equal(t, map[string]string{"FOO": "file", "NEW": "flag", "OLD": "value"}, util.NewAppEnv(app).Env)
+	assert.Equal(t, map[string]string{"BUILD": "value"}, util.NewAppEnv(app).BuildEnv)
+
+	cmd = &applicationCmd{}
+	_, err = kong.Must(cmd, vars).Parse([]string{"testname", "--env-from-file", envFile, "--replace"})
+	require.NoError(t, err)
+	app = existing()
+	require.NoError(t, cmd.applyUpdates(app))
+	assert.Equal(t, map[string]string{"FOO": "file", "NEW": "value"}, util.NewAppEnv(app).Env)
+	// the build env is kept as the file contains none.
+	assert.Equal(t, map[string]string{"BUILD": "value"}, util.NewAppEnv(app).BuildEnv)
+
+	_, err = kong.Must(&applicationCmd{}, vars).Parse([]string{"testname", "--replace"})
+	assert.ErrorContains(t, err, "--env-from-file")
+}
@@ -15,7 +15,8 @@ import (
 
 type cloudVMCmd struct {
 	resourceCmd
-	MachineType               string            `placeholder:"nine-standard-1" help:"The machine type defines the sizing for a particular CloudVM."`
+	MachineType               string            `placeholder:"nine-standard-1" aliases:"resize" help:"The machine type defines the sizing for a particular CloudVM. Changing it requires the CloudVM to be stopped, see --allow-stopping."`
+	AllowStopping             *bool             `help:"Allow the CloudVM to be stopped automatically if an update requires it, e.g. when changing the machine type."`
 	Hostname                  string            `placeholder:"" help:"Hostname allows to set the hostname explicitly. If unset, the name of the resource will be used as the hostname. This does not affect the DNS name."`
 	OS                        string            `placeholder:"ubuntu22.04" help:"OS which should be used to boot the VM."`
 	BootDiskSize              string            `placeholder:"20Gi" help:"Configures the size of the boot disk."`
@@ -43,11 +44,11 @@ func (cmd *cloudVMCmd) Run(ctx context.Context, client *api.Client) error {
 		}
 
 		return cmd.applyUpdates(cloudvm)
-	}).Update(ctx); err != nil {
+	}, dryRun(cmd.serverDryRun())).Update(ctx); err != nil {
 		return err
 	}
 
-	if cmd.BootRescue != nil && *cmd.BootRescue {
+	if cmd.BootRescue != nil && *cmd.BootRescue && !cmd.serverDryRun() {
 		fmt.Println("Booting CloudVM into rescue mode. It can take a few minutes for the VM to be reachable.")
 	}
 
@@ -59,6 +60,10 @@ func (cmd *cloudVMCmd) applyUpdates(cloudVM *infrastructure.CloudVirtualMachine)
 		cloudVM.Spec.ForProvider.MachineType = infrastructure.NewMachineType(cmd.MachineType)
 	}
 
+	if cmd.AllowStopping != nil {
+		cloudVM.Spec.ForProvider.AllowStoppingForUpdate = *cmd.AllowStopping
+	}
+
 	if cmd.Hostname != "" {
 		cloudVM.Spec.ForProvider.Hostname = cmd.Hostname
 	}
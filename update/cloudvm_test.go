@@ -40,6 +40,14 @@ func TestCloudVM(t *testing.T) {
 				PowerState: infrastructure.VirtualMachinePowerState("on"),
 			},
 		},
+		{
+			name:   "resize",
+			update: cloudVMCmd{MachineType: "nine-standard-2", AllowStopping: ptr.To(true)},
+			want: infrastructure.CloudVirtualMachineParameters{
+				MachineType:            infrastructure.NewMachineType("nine-standard-2"),
+				AllowStoppingForUpdate: true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -34,7 +34,7 @@ func (cmd *keyValueStoreCmd) Run(ctx context.Context, client *api.Client) error
 		}
 
 		return cmd.applyUpdates(keyValueStore)
-	}).Update(ctx)
+	}, dryRun(cmd.serverDryRun())).Update(ctx)
 }
 
 func (cmd *keyValueStoreCmd) applyUpdates(keyValueStore *storage.KeyValueStore) error {
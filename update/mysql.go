@@ -50,7 +50,7 @@ func (cmd *mySQLCmd) Run(ctx context.Context, client *api.Client) error {
 
 		cmd.applyUpdates(mysql)
 		return nil
-	})
+	}, dryRun(cmd.serverDryRun()))
 
 	return upd.Update(ctx)
 }
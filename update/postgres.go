@@ -44,7 +44,7 @@ func (cmd *postgresCmd) Run(ctx context.Context, client *api.Client) error {
 
 		cmd.applyUpdates(postgres)
 		return nil
-	})
+	}, dryRun(cmd.serverDryRun()))
 
 	return upd.Update(ctx)
 }
@@ -66,6 +66,14 @@ func TestPostgres(t *testing.T) {
 			update: postgresCmd{AllowedCidrs: &[]meta.IPv4CIDR{meta.IPv4CIDR("0.0.0.0/0")}},
 			want:   storage.PostgresParameters{AllowedCIDRs: []meta.IPv4CIDR{meta.IPv4CIDR("0.0.0.0/0")}},
 		},
+		{
+			name: "dry-run",
+			update: postgresCmd{
+				resourceCmd: resourceCmd{DryRun: dryRunServer},
+				MachineType: ptr.To(infra.MachineTypeNineDBS),
+			},
+			want: storage.PostgresParameters{},
+		},
 		{
 			name:   "multi-update",
 			create: storage.PostgresParameters{AllowedCIDRs: []meta.IPv4CIDR{"0.0.0.0/0"}},
@@ -37,7 +37,7 @@ func (cmd *projectCmd) Run(ctx context.Context, client *api.Client) error {
 		cmd.applyUpdates(project)
 
 		return nil
-	})
+	}, dryRun(cmd.serverDryRun()))
 
 	return upd.Update(ctx)
 }
@@ -20,6 +20,7 @@ type configCmd struct {
 	Env       map[string]string `help:"Environment variables which are passed to the app at runtime."`
 	BasicAuth *bool             `help:"Enable/Disable basic authentication for applications."`
 	DeployJob *deployJob        `embed:"" prefix:"deploy-job-"`
+	DryRun    string            `help:"Must be \"none\" or \"server\". If server, the update is validated by the API (including webhooks) and the resulting resource is printed without being persisted. ${enum}" enum:"none,server" default:"none"`
 }
 
 func (cmd *configCmd) Run(ctx context.Context, client *api.Client) error {
@@ -39,7 +40,7 @@ func (cmd *configCmd) Run(ctx context.Context, client *api.Client) error {
 		cmd.applyUpdates(cfg)
 
 		return nil
-	})
+	}, dryRun(cmd.DryRun == dryRunServer))
 
 	return upd.Update(ctx)
 }
@@ -6,20 +6,30 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/ninech/nctl/api"
 	"github.com/ninech/nctl/internal/format"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type Cmd struct {
-	Application         applicationCmd   `cmd:"" group:"deplo.io" name:"application" aliases:"app,application" help:"Update an existing deplo.io Application."`
+	Application         applicationCmd   `cmd:"" group:"deplo.io" name:"application" aliases:"app,apps" help:"Update an existing deplo.io Application."`
 	Config              configCmd        `cmd:"" group:"deplo.io" name:"config"  help:"Update an existing deplo.io Project Configuration."`
-	Project             projectCmd       `cmd:"" group:"management.nine.ch" name:"project"  help:"Update an existing Project"`
+	Project             projectCmd       `cmd:"" group:"management.nine.ch" name:"project" aliases:"proj" help:"Update an existing Project"`
 	MySQL               mySQLCmd         `cmd:"" group:"storage.nine.ch" name:"mysql" help:"Update an existing MySQL instance."`
-	Postgres            postgresCmd      `cmd:"" group:"storage.nine.ch" name:"postgres" help:"Update an existing PostgreSQL instance."`
+	Postgres            postgresCmd      `cmd:"" group:"storage.nine.ch" name:"postgres" aliases:"pg" help:"Update an existing PostgreSQL instance."`
 	KeyValueStore       keyValueStoreCmd `cmd:"" group:"storage.nine.ch" name:"keyvaluestore" aliases:"kvs" help:"Update an existing KeyValueStore instance"`
-	CloudVirtualMachine cloudVMCmd       `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm" help:"Update a CloudVM."`
+	CloudVirtualMachine cloudVMCmd       `cmd:"" group:"infrastructure.nine.ch" name:"cloudvirtualmachine" aliases:"cloudvm,vm" help:"Update a CloudVM."`
 }
 
 type resourceCmd struct {
-	Name string `arg:"" predictor:"resource_name" help:"Name of the resource to update."`
+	Name   string `arg:"" predictor:"resource_name" help:"Name of the resource to update."`
+	DryRun string `help:"Must be \"none\" or \"server\". If server, the update is validated by the API (including webhooks) and the resulting resource is printed without being persisted. ${enum}" enum:"none,server" default:"none"`
+}
+
+const dryRunServer = "server"
+
+// serverDryRun returns true if the update should only be submitted as a
+// server-side dry-run.
+func (cmd resourceCmd) serverDryRun() bool {
+	return cmd.DryRun == dryRunServer
 }
 
 type updater struct {
@@ -27,12 +37,28 @@ type updater struct {
 	client     *api.Client
 	kind       string
 	updateFunc updateFunc
+	dryRun     bool
 }
 
 type updateFunc func(current resource.Managed) error
 
-func newUpdater(client *api.Client, mg resource.Managed, kind string, f updateFunc) *updater {
-	return &updater{client: client, mg: mg, kind: kind, updateFunc: f}
+// updaterOption allows to set options for the update
+type updaterOption func(*updater)
+
+// dryRun configures the updater to only submit a server-side dry-run.
+func dryRun(enabled bool) updaterOption {
+	return func(u *updater) {
+		u.dryRun = enabled
+	}
+}
+
+func newUpdater(client *api.Client, mg resource.Managed, kind string, f updateFunc, opts ...updaterOption) *updater {
+	u := &updater{client: client, mg: mg, kind: kind, updateFunc: f}
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
 }
 
 func (u *updater) Update(ctx context.Context) error {
@@ -44,6 +70,15 @@ func (u *updater) Update(ctx context.Context) error {
 		return err
 	}
 
+	if u.dryRun {
+		if err := u.client.Update(ctx, u.mg, runtimeclient.DryRunAll); err != nil {
+			return err
+		}
+
+		format.PrintSuccessf("🧪", "updated %s %q (server dry run)", u.kind, u.mg.GetName())
+		return format.PrettyPrintObject(u.mg, format.PrintOpts{})
+	}
+
 	if err := u.client.Update(ctx, u.mg); err != nil {
 		return err
 	}
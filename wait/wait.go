@@ -0,0 +1,239 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+type Cmd struct {
+	Kind     string        `arg:"" help:"Kind of the resource to wait for, e.g. application, postgres or build."`
+	Name     string        `arg:"" predictor:"resource_name" help:"Name of the resource to wait for."`
+	For      string        `required:"" help:"The condition to wait for. One of \"condition=<type>[=<status>]\" (e.g. condition=Ready), \"jsonpath={<path>}=<value>\" (e.g. jsonpath={.status.atProvider.buildStatus}=success) or \"delete\"."`
+	Timeout  time.Duration `default:"5m" help:"Duration to wait until giving up."`
+	Interval time.Duration `default:"2s" help:"Interval in which the resource is checked if changes to it can't be watched."`
+}
+
+// condition reports if the awaited state of a resource has been reached. The
+// object is nil if the resource does not exist.
+type condition struct {
+	// description is shown while waiting, done once the condition is met.
+	description string
+	done        string
+	met         func(obj *unstructured.Unstructured) (bool, error)
+}
+
+func (cmd *Cmd) Run(ctx context.Context, client *api.Client) error {
+	cond, err := parseCondition(cmd.For)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := api.LookupKind(client.Scheme(), cmd.Kind)
+	if err != nil {
+		return err
+	}
+
+	name, err := client.NamespacedNameFor(gvk, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.Timeout)
+	defer cancel()
+
+	kind := strings.ToLower(gvk.Kind)
+	spinner, err := format.NewProgress(
+		format.ProgressMessagef("⏳", "waiting for %s %q to %s", kind, cmd.Name, cond.description),
+		format.ProgressMessagef("✅", "%s %q %s", kind, cmd.Name, cond.done),
+	)
+	if err != nil {
+		return err
+	}
+	_ = spinner.Start()
+	defer func() { _ = spinner.Stop() }()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name.Name)
+	obj.SetNamespace(name.Namespace)
+	err = client.WaitUntil(ctx, cmd.Interval, func() (bool, error) {
+		current, done, err := check(ctx, client, gvk, name.Name, name.Namespace, cond)
+		spinner.SetStatus(readyStatus(current))
+		return done, err
+	}, api.WatchObject(obj))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(ctx.Err(), context.Canceled):
+		_ = spinner.StopFail()
+		return cmd.interrupted(kind, client.Project)
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		msg := "timeout waiting for %s %q to %s"
+		spinner.StopFailMessage(format.ProgressMessagef("", msg, kind, cmd.Name, cond.description))
+		_ = spinner.StopFail()
+		return fmt.Errorf(msg, kind, cmd.Name, cond.description)
+	default:
+		_ = spinner.StopFail()
+		return err
+	}
+}
+
+// interrupted returns the error for an interrupted wait with the command to
+// continue waiting.
+func (cmd *Cmd) interrupted(kind, project string) error {
+	return format.InterruptedError{Resume: format.Command().Wait(kind, cmd.Name, project, quote(cmd.For))}
+}
+
+// check gets the object and reports if the condition is met. The returned
+// object is nil if it does not exist.
+func check(ctx context.Context, client *api.Client, gvk schema.GroupVersionKind, name, namespace string, cond condition) (*unstructured.Unstructured, bool, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := client.Get(ctx, api.NamespacedName(name, namespace), obj); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, false, fmt.Errorf("unable to get %s %q: %w", strings.ToLower(gvk.Kind), name, err)
+		}
+		obj = nil
+	}
+
+	met, err := cond.met(obj)
+	return obj, met, err
+}
+
+// readyStatus returns the message or reason of the ready condition of obj.
+func readyStatus(obj *unstructured.Unstructured) string {
+	if obj == nil {
+		return ""
+	}
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]any)
+		if !ok || cm["type"] != "Ready" {
+			continue
+		}
+		if msg, _ := cm["message"].(string); len(msg) != 0 {
+			return strings.ReplaceAll(msg, "\n", " ")
+		}
+		reason, _ := cm["reason"].(string)
+		return reason
+	}
+	return ""
+}
+
+func parseCondition(s string) (condition, error) {
+	if s == "delete" {
+		return condition{
+			description: "be deleted",
+			done:        "deleted",
+			met: func(obj *unstructured.Unstructured) (bool, error) {
+				return obj == nil, nil
+			},
+		}, nil
+	}
+
+	mode, expr, ok := strings.Cut(s, "=")
+	if !ok {
+		return condition{}, fmt.Errorf("invalid condition %q", s)
+	}
+
+	switch mode {
+	case "condition":
+		return statusCondition(expr)
+	case "jsonpath":
+		return jsonPathCondition(expr)
+	}
+
+	return condition{}, fmt.Errorf("invalid condition %q, must start with \"condition=\" or \"jsonpath=\" or be \"delete\"", s)
+}
+
+// statusCondition waits for a crossplane condition like Ready or Synced to
+// have the given status, which defaults to True.
+func statusCondition(expr string) (condition, error) {
+	condType, status, ok := strings.Cut(expr, "=")
+	if !ok {
+		status = "True"
+	}
+	if len(condType) == 0 {
+		return condition{}, errors.New("condition type must not be empty")
+	}
+
+	return condition{
+		description: fmt.Sprintf("have condition %s=%s", condType, status),
+		done:        fmt.Sprintf("has condition %s=%s", condType, status),
+		met: func(obj *unstructured.Unstructured) (bool, error) {
+			if obj == nil {
+				return false, nil
+			}
+			conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+			if err != nil {
+				return false, err
+			}
+			for _, c := range conditions {
+				cm, ok := c.(map[string]any)
+				if !ok {
+					continue
+				}
+				if strings.EqualFold(fmt.Sprint(cm["type"]), condType) {
+					return strings.EqualFold(fmt.Sprint(cm["status"]), status), nil
+				}
+			}
+			return false, nil
+		},
+	}, nil
+}
+
+// jsonPathCondition waits for the result of a JSONPath expression to equal
+// a value, e.g. {.status.atProvider.buildStatus}=success.
+func jsonPathCondition(expr string) (condition, error) {
+	i := strings.LastIndex(expr, "}=")
+	if i < 0 {
+		return condition{}, fmt.Errorf("invalid jsonpath condition %q, expected {<path>}=<value>", expr)
+	}
+	path, value := expr[:i+1], expr[i+2:]
+
+	j := jsonpath.New("for")
+	if err := j.Parse(path); err != nil {
+		return condition{}, fmt.Errorf("invalid jsonpath %q: %w", path, err)
+	}
+
+	return condition{
+		description: fmt.Sprintf("have %s=%s", path, value),
+		done:        fmt.Sprintf("has %s=%s", path, value),
+		met: func(obj *unstructured.Unstructured) (bool, error) {
+			if obj == nil {
+				return false, nil
+			}
+			results, err := j.FindResults(obj.Object)
+			if err != nil {
+				// the field might not have been set yet
+				return false, nil
+			}
+			for _, r := range results {
+				for _, v := range r {
+					if fmt.Sprint(v.Interface()) == value {
+						return true, nil
+					}
+				}
+			}
+			return false, nil
+		},
+	}, nil
+}
+
+// quote quotes s for a shell if needed, e.g. a jsonpath condition.
+func quote(s string) string {
+	if strings.ContainsAny(s, " {}'\"$*?[]|&;<>()") {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+	return s
+}
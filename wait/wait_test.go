@@ -0,0 +1,148 @@
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	runtimev1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	apps "github.com/ninech/apis/apps/v1alpha1"
+	"github.com/ninech/nctl/api"
+	"github.com/ninech/nctl/internal/format"
+	"github.com/ninech/nctl/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWait(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		kind    string
+		waitFor string
+		update  func(b *apps.Build)
+		delete  bool
+		wantErr bool
+	}{
+		{
+			name:    "condition-ready",
+			kind:    "build",
+			waitFor: "condition=Ready",
+			update: func(b *apps.Build) {
+				b.SetConditions(runtimev1.Available())
+			},
+		},
+		{
+			name:    "condition-ready-false",
+			kind:    "builds",
+			waitFor: "condition=Ready=False",
+			update: func(b *apps.Build) {
+				b.SetConditions(runtimev1.Unavailable())
+			},
+		},
+		{
+			name:    "jsonpath",
+			kind:    "build",
+			waitFor: "jsonpath={.status.atProvider.buildStatus}=success",
+			update: func(b *apps.Build) {
+				b.Status.AtProvider.BuildStatus = apps.BuildProcessStatusSuccess
+			},
+		},
+		{
+			name:    "delete",
+			kind:    "build",
+			waitFor: "delete",
+			delete:  true,
+		},
+		{
+			name:    "timeout",
+			kind:    "build",
+			waitFor: "condition=Ready",
+			wantErr: true,
+		},
+		{
+			name:    "invalid-condition",
+			kind:    "build",
+			waitFor: "ready",
+			wantErr: true,
+		},
+		{
+			name:    "unknown-kind",
+			kind:    "doesnotexist",
+			waitFor: "delete",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			build := &apps.Build{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: test.DefaultProject,
+				},
+			}
+			apiClient, err := test.SetupClient(test.WithObjects(build))
+			require.NoError(t, err)
+
+			// simulate a controller changing the resource after a while
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				if tt.delete {
+					_ = apiClient.Delete(ctx, build)
+					return
+				}
+				if tt.update == nil {
+					return
+				}
+				if err := apiClient.Get(ctx, api.ObjectName(build), build); err != nil {
+					return
+				}
+				tt.update(build)
+				_ = apiClient.Update(ctx, build)
+			}()
+
+			cmd := &Cmd{
+				Kind:     tt.kind,
+				Name:     build.Name,
+				For:      tt.waitFor,
+				Timeout:  time.Second,
+				Interval: 10 * time.Millisecond,
+			}
+			err = cmd.Run(ctx, apiClient)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWaitInterrupted(t *testing.T) {
+	build := &apps.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: test.DefaultProject,
+		},
+	}
+	apiClient, err := test.SetupClient(test.WithObjects(build))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	cmd := &Cmd{
+		Kind:     "build",
+		Name:     build.Name,
+		For:      "jsonpath={.status.atProvider.buildStatus}=success",
+		Timeout:  time.Second,
+		Interval: 10 * time.Millisecond,
+	}
+	err = cmd.Run(ctx, apiClient)
+	interrupted := format.InterruptedError{}
+	require.ErrorAs(t, err, &interrupted)
+	assert.Contains(t, interrupted.Resume,
+		"wait build test --for 'jsonpath={.status.atProvider.buildStatus}=success' --project "+test.DefaultProject)
+}